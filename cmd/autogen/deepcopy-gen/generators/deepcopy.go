@@ -43,31 +43,62 @@ const (
 	tagEnabledName              = "gengo:deepcopy"
 	interfacesTagName           = tagEnabledName + ":interfaces"
 	interfacesNonPointerTagName = tagEnabledName + ":nonpointer-interfaces" // attach the DeepCopy<Interface> methods to the
+	interfaceStrategyTagName    = tagEnabledName + ":interface"             // how a field copies the values held by its interfaces
+	graphTagName                = tagEnabledName + ":graph"                 // copy the pointers reached more than once only once
+	chanFuncTagName             = tagEnabledName + ":chan-func"             // how a field or a package copies its channels and functions
+)
+
+// Known values for the interface strategy tag of a field.
+const (
+	// The copy shares the value held by the interface.
+	interfaceShare = "share"
+	// The value is copied through reflection by deepcopy.Reflect.
+	interfaceReflect = "reflect"
+	// The value is copied by the function registered for its type, through
+	// deepcopy.DeepCopyAny.
+	interfaceRegistry = "registry"
+)
+
+// Known values for the chan-func tag of a field or a package.
+const (
+	// The copy shares the channel or the function.
+	chanFuncShare = "share"
+	// The copy holds a nil channel or function.
+	chanFuncZero = "zero"
+	// A problem naming the value is reported and the package is not written.
+	// This is the default.
+	chanFuncFail = "fail"
 )
 
 // Known values for the comment tag.
 const tagValuePackage = "package"
 
+// runtimePackage is imported by the generated code for its runtime support.
+const runtimePackage = "github.com/zhaolion/gengo/deepcopy"
+
 // enabledTagValue holds parameters from a tagName tag.
 type enabledTagValue struct {
-	value    string
-	register bool
+	value      string
+	register   bool
+	unexported bool
 }
 
 func extractEnabledTypeTag(t *types.Type) *enabledTagValue {
 	comments := append(append([]string{}, t.SecondClosestCommentLines...), t.CommentLines...)
-	return extractEnabledTag(comments)
+	return extractEnabledTag(comments, typeDecl(t))
 }
 
-func extractEnabledTag(comments []string) *enabledTagValue {
+// extractEnabledTag returns the deep-copy tag in comments, nil if they have
+// none. owner is the type or the package the problems are reported against.
+func extractEnabledTag(comments []string, owner decl) *enabledTagValue {
 	tagVals := types.ExtractCommentTags("+", comments)[tagEnabledName]
 	if tagVals == nil {
 		// No match for the tag.
 		return nil
 	}
-	// If there are multiple values, abort.
+	// If there are multiple values, use the first one.
 	if len(tagVals) > 1 {
-		klog.Fatalf("Found %d %s tags: %q", len(tagVals), tagEnabledName, tagVals)
+		reportf(owner, "found %d %s tags: %q", len(tagVals), tagEnabledName, tagVals)
 	}
 
 	// If we got here we are returning something.
@@ -93,8 +124,12 @@ func extractEnabledTag(comments []string) *enabledTagValue {
 			if v != "false" {
 				tag.register = true
 			}
+		case "unexported":
+			if v != "false" {
+				tag.unexported = true
+			}
 		default:
-			klog.Fatalf("Unsupported %s param: %q", tagEnabledName, parts[i])
+			reportf(owner, "unsupported %s param: %q", tagEnabledName, parts[i])
 		}
 	}
 	return tag
@@ -132,6 +167,8 @@ func Packages(context *generator.Context, arguments *args.GeneratorArgs) generat
 	packages := generator.Packages{}
 	header := append([]byte(fmt.Sprintf("// +build !%s\n\n", arguments.GeneratedBuildTag)), boilerplate...)
 
+	problems = &problemList{universe: context.Universe, buildTag: arguments.GeneratedBuildTag}
+
 	boundingDirs := []string{}
 	if customArgs, ok := arguments.CustomArgs.(*CustomArgs); ok {
 		if customArgs.BoundingDirs == nil {
@@ -144,7 +181,8 @@ func Packages(context *generator.Context, arguments *args.GeneratorArgs) generat
 		}
 	}
 
-	for i := range inputs {
+	// The packages are generated in order, so that the problems are too.
+	for _, i := range inputs.List() {
 		klog.V(5).Infof("Considering pkg %q", i)
 		pkg := context.Universe[i]
 		if pkg == nil {
@@ -152,22 +190,38 @@ func Packages(context *generator.Context, arguments *args.GeneratorArgs) generat
 			continue
 		}
 
-		ptag := extractEnabledTag(pkg.Comments)
+		owner := decl{pkg: i}
+		ptag := extractEnabledTag(pkg.Comments, owner)
 		ptagValue := ""
 		ptagRegister := false
+		ptagUnexported := false
 		if ptag != nil {
 			ptagValue = ptag.value
 			if ptagValue != tagValuePackage {
-				klog.Fatalf("Package %v: unsupported %s value: %q", i, tagEnabledName, ptagValue)
+				reportf(owner, "unsupported %s value: %q", tagEnabledName, ptagValue)
+				ptagValue = ""
 			}
 			ptagRegister = ptag.register
-			klog.V(5).Infof("  tag.value: %q, tag.register: %t", ptagValue, ptagRegister)
+			ptagUnexported = ptag.unexported
+			klog.V(5).Infof("  tag.value: %q, tag.register: %t, tag.unexported: %t", ptagValue, ptagRegister, ptagUnexported)
 		} else {
 			klog.V(5).Infof("  no tag")
 		}
 
+		eqtagValue := extractDeepEqualTag(pkg.Comments, owner)
+		if eqtagValue != "" && eqtagValue != tagValuePackage {
+			reportf(owner, "unsupported %s value: %q", tagDeepEqualName, eqtagValue)
+			eqtagValue = ""
+		}
+		difftagValue := extractDiffTag(pkg.Comments, owner)
+		if difftagValue != "" && difftagValue != tagValuePackage {
+			reportf(owner, "unsupported %s value: %q", tagDiffName, difftagValue)
+			difftagValue = ""
+		}
+		pkgNilIsEmpty := extractNilIsEmptyTag(pkg.Comments, owner)
+
 		// If the pkg-scoped tag says to generate, we can skip scanning types.
-		pkgNeedsGeneration := (ptagValue == tagValuePackage)
+		pkgNeedsGeneration := (ptagValue == tagValuePackage || eqtagValue == tagValuePackage || difftagValue == tagValuePackage)
 		if !pkgNeedsGeneration {
 			// If the pkg-scoped tag did not exist, scan all types for one that
 			// explicitly wants generation.
@@ -176,12 +230,23 @@ func Packages(context *generator.Context, arguments *args.GeneratorArgs) generat
 				ttag := extractEnabledTypeTag(t)
 				if ttag != nil && ttag.value == "true" {
 					klog.V(5).Infof("    tag=true")
-					if !copyableType(t) {
-						klog.Fatalf("Type %v requests deepcopy generation but is not copyable", t)
+					if !copyableType(t, ptagUnexported) {
+						reportf(typeDecl(t), "requests deepcopy generation but is not copyable")
+						continue
 					}
 					pkgNeedsGeneration = true
 					break
 				}
+				if extractDeepEqualTypeTag(t) == "true" {
+					klog.V(5).Infof("    %s=true", tagDeepEqualName)
+					pkgNeedsGeneration = true
+					break
+				}
+				if extractDiffTypeTag(t) == "true" {
+					klog.V(5).Infof("    %s=true", tagDiffName)
+					pkgNeedsGeneration = true
+					break
+				}
 			}
 		}
 
@@ -201,6 +266,7 @@ func Packages(context *generator.Context, arguments *args.GeneratorArgs) generat
 					path = expandedPath
 				}
 			}
+			pkgChanFunc := extractChanFuncTag(pkg.Comments, owner)
 			packages = append(packages,
 				&generator.DefaultPackage{
 					PackageName: strings.Split(filepath.Base(pkg.Path), ".")[0],
@@ -208,7 +274,10 @@ func Packages(context *generator.Context, arguments *args.GeneratorArgs) generat
 					HeaderText:  header,
 					GeneratorFunc: func(c *generator.Context) (generators []generator.Generator) {
 						return []generator.Generator{
-							NewGenDeepCopy(arguments.OutputFileBaseName, pkg.Path, boundingDirs, (ptagValue == tagValuePackage), ptagRegister),
+							NewGenDeepCopy(arguments.OutputFileBaseName, pkg.Path, boundingDirs, (ptagValue == tagValuePackage), ptagRegister, ptagUnexported, pkgChanFunc),
+							NewGenDeepEqual(arguments.OutputFileBaseName, pkg.Path, (eqtagValue == tagValuePackage), pkgNilIsEmpty),
+							NewGenDiff(arguments.OutputFileBaseName, pkg.Path, (difftagValue == tagValuePackage), pkgNilIsEmpty),
+							&problemCheck{generator.DefaultGen{OptionalName: arguments.OutputFileBaseName}},
 						}
 					},
 					FilterFunc: func(c *generator.Context, t *types.Type) bool {
@@ -217,6 +286,7 @@ func Packages(context *generator.Context, arguments *args.GeneratorArgs) generat
 				})
 		}
 	}
+	findProblems(context, packages)
 	return packages
 }
 
@@ -227,11 +297,23 @@ type genDeepCopy struct {
 	boundingDirs  []string
 	allTypes      bool
 	registerTypes bool
+	unexported    bool   // all the unexported types too, not only those tagged with gengo:deepcopy=true
+	chanFunc      string // the chan-func policy of the package, "" if untagged
 	imports       namer.ImportTracker
 	typesForInit  []*types.Type
+
+	// member is the field of the struct being copied, while generating the
+	// code copying its value.
+	member *types.Member
+	// path names the value being copied, e.g. T.Field, in the errors about
+	// it.
+	path string
+	// graph is true while generating the DeepCopyIntoGraph method of a type
+	// tagged with graphTagName.
+	graph bool
 }
 
-func NewGenDeepCopy(sanitizedName, targetPackage string, boundingDirs []string, allTypes, registerTypes bool) generator.Generator {
+func NewGenDeepCopy(sanitizedName, targetPackage string, boundingDirs []string, allTypes, registerTypes, unexported bool, chanFunc string) generator.Generator {
 	return &genDeepCopy{
 		DefaultGen: generator.DefaultGen{
 			OptionalName: sanitizedName,
@@ -240,6 +322,8 @@ func NewGenDeepCopy(sanitizedName, targetPackage string, boundingDirs []string,
 		boundingDirs:  boundingDirs,
 		allTypes:      allTypes,
 		registerTypes: registerTypes,
+		unexported:    unexported,
+		chanFunc:      chanFunc,
 		imports:       generator.NewImportTracker(),
 		typesForInit:  make([]*types.Type, 0),
 	}
@@ -248,10 +332,20 @@ func NewGenDeepCopy(sanitizedName, targetPackage string, boundingDirs []string,
 func (g *genDeepCopy) Namers(c *generator.Context) namer.NameSystems {
 	// Have the raw namer for this file track what it imports.
 	return namer.NameSystems{
-		"raw": namer.NewRawNamer(g.targetPackage, g.imports),
+		"raw": literalNamer{g},
 	}
 }
 
+// literalNamer is the raw namer of the generated file, which also names the
+// types the raw namer of gengo cannot name.
+type literalNamer struct {
+	g *genDeepCopy
+}
+
+func (n literalNamer) Name(t *types.Type) string {
+	return n.g.typeLiteral(t)
+}
+
 func (g *genDeepCopy) Filter(c *generator.Context, t *types.Type) bool {
 	// Filter out types not being processed or not copyable within the package.
 	enabled := g.allTypes
@@ -264,7 +358,7 @@ func (g *genDeepCopy) Filter(c *generator.Context, t *types.Type) bool {
 	if !enabled {
 		return false
 	}
-	if !copyableType(t) {
+	if !copyableType(t, g.unexported) {
 		klog.V(2).Infof("Type %v is not copyable", t)
 		return false
 	}
@@ -274,7 +368,7 @@ func (g *genDeepCopy) Filter(c *generator.Context, t *types.Type) bool {
 }
 
 func (g *genDeepCopy) copyableAndInBounds(t *types.Type) bool {
-	if !copyableType(t) {
+	if !copyableType(t, g.unexported) {
 		return false
 	}
 	// Only packages within the restricted range can be processed.
@@ -323,12 +417,12 @@ func deepCopyMethod(t *types.Type) (*types.Signature, error) {
 	return f.Signature, nil
 }
 
-// deepCopyMethodOrDie returns the signatrue of a DeepCopy method, nil or calls klog.Fatalf
-// if the type does not match.
+// deepCopyMethodOrDie returns the signatrue of a DeepCopy method, or nil and
+// reports a problem if the type does not match.
 func deepCopyMethodOrDie(t *types.Type) *types.Signature {
 	ret, err := deepCopyMethod(t)
 	if err != nil {
-		klog.Fatal(err)
+		reportf(typeDecl(t), "%v", err)
 	}
 	return ret
 }
@@ -369,12 +463,12 @@ func deepCopyIntoMethod(t *types.Type) (*types.Signature, error) {
 	return f.Signature, nil
 }
 
-// deepCopyIntoMethodOrDie returns the signature of a DeepCopyInto() method, nil or calls klog.Fatalf
-// if the type is wrong.
+// deepCopyIntoMethodOrDie returns the signature of a DeepCopyInto() method, or nil
+// and reports a problem if the type is wrong.
 func deepCopyIntoMethodOrDie(t *types.Type) *types.Signature {
 	ret, err := deepCopyIntoMethod(t)
 	if err != nil {
-		klog.Fatal(err)
+		reportf(typeDecl(t), "%v", err)
 	}
 	return ret
 }
@@ -391,15 +485,18 @@ func isRootedUnder(pkg string, roots []string) bool {
 	return false
 }
 
-func copyableType(t *types.Type) bool {
+// copyableType returns true if deep-copy functions can be generated for t. The
+// unexported types are only copyable if they opt in with a tag, or if
+// unexported is true.
+func copyableType(t *types.Type, unexported bool) bool {
 	// If the type opts out of copy-generation, stop.
 	ttag := extractEnabledTypeTag(t)
 	if ttag != nil && ttag.value == "false" {
 		return false
 	}
 
-	// Filter out private types.
-	if namer.IsPrivateGoName(t.Name.Name) {
+	// Filter out private types, unless they opt in.
+	if namer.IsPrivateGoName(t.Name.Name) && !unexported && (ttag == nil || ttag.value != "true") {
 		return false
 	}
 
@@ -409,11 +506,12 @@ func copyableType(t *types.Type) bool {
 		if deepCopyMethodOrDie(t) != nil || deepCopyIntoMethodOrDie(t) != nil {
 			return true
 		} else {
-			return t.Underlying.Kind != types.Builtin || copyableType(t.Underlying)
+			return t.Underlying.Kind != types.Builtin || copyableType(t.Underlying, unexported)
 		}
 	}
 
-	if t.Kind != types.Struct {
+	// The parser gives named arrays the kind of their underlying type.
+	if t.Kind != types.Struct && t.Kind != types.Array {
 		return false
 	}
 
@@ -457,8 +555,54 @@ func argsFromType(ts ...*types.Type) generator.Args {
 	return a
 }
 
+// Init writes, if the package tag asks to register the types, the
+// RegisterDeepCopies function recording the deep-copy function of each type
+// in a registry keyed by reflect.Type, and an init function calling it with
+// the default registry.
 func (g *genDeepCopy) Init(c *generator.Context, w io.Writer) error {
-	return nil
+	if !g.registerTypes {
+		return nil
+	}
+	reflectType := &types.Type{Name: types.Name{Package: "reflect", Name: "Type"}}
+	registryType := &types.Type{Name: types.Name{Package: runtimePackage, Name: "Registry"}}
+	g.imports.AddType(reflectType)
+	g.imports.AddType(registryType)
+
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+	args := generator.Args{
+		"reflect":  g.imports.LocalNameOf("reflect"),
+		"registry": registryType,
+		"runtime":  g.imports.LocalNameOf(runtimePackage),
+	}
+	sw.Do("func init() {\n", nil)
+	sw.Do("RegisterDeepCopies($.runtime$.DefaultRegistry)\n", args)
+	sw.Do("}\n\n", nil)
+
+	sw.Do("// RegisterDeepCopies records the deep-copy functions of the types of this\n", nil)
+	sw.Do("// package in r, keyed by their reflect.Type.\n", nil)
+	sw.Do("func RegisterDeepCopies(r *$.registry|raw$) {\n", args)
+	for _, t := range g.typesForInit {
+		args["type"] = t
+		sw.Do("r.Register($.reflect$.TypeOf((*$.type|raw$)(nil)).Elem(), func(obj interface{}) interface{} {\n", args)
+		sw.Do("in := obj.($.type|raw$)\n", args)
+		if deepCopyResultIsPointer(t) {
+			sw.Do("return *in.DeepCopy()\n", nil)
+		} else {
+			sw.Do("return in.DeepCopy()\n", nil)
+		}
+		sw.Do("})\n", nil)
+	}
+	sw.Do("}\n\n", nil)
+	return sw.Error()
+}
+
+// deepCopyResultIsPointer returns true if the DeepCopy method of t, written
+// by hand or generated, returns a pointer.
+func deepCopyResultIsPointer(t *types.Type) bool {
+	if dc := deepCopyMethodOrDie(t); dc != nil {
+		return dc.Results[0].Kind == types.Pointer
+	}
+	return !isReference(t)
 }
 
 func (g *genDeepCopy) needsGeneration(t *types.Type) bool {
@@ -467,7 +611,8 @@ func (g *genDeepCopy) needsGeneration(t *types.Type) bool {
 	if tag != nil {
 		tv = tag.value
 		if tv != "true" && tv != "false" {
-			klog.Fatalf("Type %v: unsupported %s value: %q", t, tagEnabledName, tag.value)
+			reportf(typeDecl(t), "unsupported %s value: %q", tagEnabledName, tag.value)
+			return false
 		}
 	}
 	if g.allTypes && tv == "false" {
@@ -584,8 +729,38 @@ func (g *genDeepCopy) GenerateType(c *generator.Context, t *types.Type, w io.Wri
 
 	sw := generator.NewSnippetWriter(w, c, "$", "$")
 	args := argsFromType(t)
+	g.path = t.Name.Name
 
-	if deepCopyIntoMethodOrDie(t) == nil {
+	if g.isGraphType(t) {
+		if t.Kind != types.Struct {
+			reportf(typeDecl(t), "%s is only supported for structs", graphTagName)
+			return nil
+		}
+		if deepCopyMethodOrDie(t) != nil || deepCopyIntoMethodOrDie(t) != nil {
+			reportf(typeDecl(t), "%s is not supported for types with a DeepCopy or DeepCopyInto method", graphTagName)
+			return nil
+		}
+		graphType := &types.Type{Name: types.Name{Package: runtimePackage, Name: "Graph"}}
+		g.imports.AddType(graphType)
+		args["graph"] = graphType
+		args["runtime"] = g.imports.LocalNameOf(runtimePackage)
+		sw.Do("// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.\n", args)
+		sw.Do("// The pointers reached more than once are copied once, so the copy has the same shared pointers and cycles.\n", args)
+		sw.Do("func (in *$.type|raw$) DeepCopyInto(out *$.type|raw$) {\n", args)
+		sw.Do("graph := $.runtime$.NewGraph()\n", args)
+		sw.Do("graph.Add(in, out)\n", nil)
+		sw.Do("in.DeepCopyIntoGraph(out, graph)\n", nil)
+		sw.Do("}\n\n", nil)
+
+		sw.Do("// DeepCopyIntoGraph is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.\n", args)
+		sw.Do("// The pointers copied are recorded in graph, and the pointers already recorded there are not copied again.\n", args)
+		sw.Do("func (in *$.type|raw$) DeepCopyIntoGraph(out *$.type|raw$, graph *$.graph|raw$) {\n", args)
+		g.graph = true
+		g.generateFor(t, sw)
+		g.graph = false
+		sw.Do("return\n", nil)
+		sw.Do("}\n\n", nil)
+	} else if deepCopyIntoMethodOrDie(t) == nil {
 		sw.Do("// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.\n", args)
 		if isReference(t) {
 			sw.Do("func (in $.type|raw$) DeepCopyInto(out *$.type|raw$) {\n", args)
@@ -652,6 +827,36 @@ func (g *genDeepCopy) GenerateType(c *generator.Context, t *types.Type, w io.Wri
 	return sw.Error()
 }
 
+// isGraphType returns true if t is a type of the package being generated
+// tagged with graphTagName, which has a generated DeepCopyIntoGraph method.
+func (g *genDeepCopy) isGraphType(t *types.Type) bool {
+	return isGraphTagged(t, g.targetPackage)
+}
+
+// isGraphTagged returns true if t is a type of the package targetPackage
+// tagged with graphTagName.
+func isGraphTagged(t *types.Type, targetPackage string) bool {
+	if t.Name.Package != targetPackage {
+		return false
+	}
+	comments := append(append([]string{}, t.SecondClosestCommentLines...), t.CommentLines...)
+	values := types.ExtractCommentTags("+", comments)[graphTagName]
+	if len(values) == 0 {
+		return false
+	}
+	if len(values) > 1 {
+		reportf(typeDecl(t), "found %d %s tags: %q", len(values), graphTagName, values)
+	}
+	switch values[0] {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	reportf(typeDecl(t), "unsupported %s value: %q", graphTagName, values[0])
+	return false
+}
+
 // isReference return true for pointer, maps, slices and aliases of those.
 func isReference(t *types.Type) bool {
 	if t.Kind == types.Pointer || t.Kind == types.Map || t.Kind == types.Slice {
@@ -680,14 +885,23 @@ func (g *genDeepCopy) generateFor(t *types.Type, sw *generator.SnippetWriter) {
 		f = g.doStruct
 	case types.Pointer:
 		f = g.doPointer
+	case types.Array:
+		f = g.doArray
+	case types.Chan, types.Func:
+		f = func(t *types.Type, sw *generator.SnippetWriter) {
+			g.doChanFunc(t, "*in", "*out", sw)
+		}
 	case types.Interface:
 		// interfaces are handled in-line in the other cases
-		klog.Fatalf("Hit an interface type %v. This should never happen.", t)
+		reportf(g.owner(), "hit an interface type %v. This should never happen.", t)
+		return
 	case types.Alias:
 		// can never happen because we branch on the underlying type which is never an alias
-		klog.Fatalf("Hit an alias type %v. This should never happen.", t)
+		reportf(g.owner(), "hit an alias type %v. This should never happen.", t)
+		return
 	default:
-		klog.Fatalf("Hit an unsupported type %v.", t)
+		reportf(g.owner(), "hit an unsupported type %v.", t)
+		return
 	}
 	f(t, sw)
 }
@@ -715,10 +929,17 @@ func (g *genDeepCopy) doMap(t *types.Type, sw *generator.SnippetWriter) {
 	}
 
 	if !ut.Key.IsAssignable() {
-		klog.Fatalf("Hit an unsupported type %v for: %v", uet, t)
+		reportf(g.owner(), "hit an unsupported type %v for: %v", uet, t)
+		return
 	}
 
 	sw.Do("*out = make($.|raw$, len(*in))\n", t)
+	if isChanFunc(ut.Elem) && g.chanFuncPolicy() == chanFuncZero {
+		sw.Do("for key := range *in {\n", nil)
+		sw.Do("(*out)[key] = nil\n", nil)
+		sw.Do("}\n", nil)
+		return
+	}
 	sw.Do("for key, val := range *in {\n", nil)
 	dc, dci := deepCopyMethodOrDie(ut.Elem), deepCopyIntoMethodOrDie(ut.Elem)
 	switch {
@@ -739,18 +960,13 @@ func (g *genDeepCopy) doMap(t *types.Type, sw *generator.SnippetWriter) {
 		}
 	case ut.Elem.IsAnonymousStruct(): // not uet here because it needs type cast
 		sw.Do("(*out)[key] = val\n", nil)
-	case uet.IsAssignable():
+	case isShallow(ut.Elem):
 		sw.Do("(*out)[key] = val\n", nil)
+	case uet.Kind == types.Chan || uet.Kind == types.Func:
+		g.doChanFunc(ut.Elem, "val", "(*out)[key]", sw)
 	case uet.Kind == types.Interface:
-		// Note: do not generate code that won't compile as `DeepCopyinterface{}()` is not a valid function
-		if uet.Name.Name == "interface{}" {
-			klog.Fatalf("DeepCopy of %q is unsupported. Instead, use named interfaces with DeepCopy<named-interface> as one of the methods.", uet.Name.Name)
-		}
 		sw.Do("if val == nil {(*out)[key]=nil} else {\n", nil)
-		// Note: if t.Elem has been an alias "J" of an interface "I" in Go, we will see it
-		// as kind Interface of name "J" here, i.e. generate val.DeepCopyJ(). The golang
-		// parser does not give us the underlying interface name. So we cannot do any better.
-		sw.Do(fmt.Sprintf("(*out)[key] = val.DeepCopy%s()\n", uet.Name.Name), nil)
+		g.doInterface(ut.Elem, "val", "(*out)[key]", sw)
 		sw.Do("}\n", nil)
 	case uet.Kind == types.Slice || uet.Kind == types.Map || uet.Kind == types.Pointer:
 		sw.Do("var outVal $.|raw$\n", uet)
@@ -759,10 +975,23 @@ func (g *genDeepCopy) doMap(t *types.Type, sw *generator.SnippetWriter) {
 		g.generateFor(ut.Elem, sw)
 		sw.Do("}\n", nil)
 		sw.Do("(*out)[key] = outVal\n", nil)
+	case uet.Kind == types.Array:
+		sw.Do("var outVal $.|raw$\n", ut.Elem)
+		sw.Do("{\n", nil)
+		sw.Do("in, out := &val, &outVal\n", nil)
+		g.generateFor(ut.Elem, sw)
+		sw.Do("}\n", nil)
+		sw.Do("(*out)[key] = outVal\n", nil)
 	case uet.Kind == types.Struct:
-		sw.Do("(*out)[key] = *val.DeepCopy()\n", uet)
+		if g.graph && g.isGraphType(ut.Elem) {
+			sw.Do("outVal := new($.|raw$)\n", ut.Elem)
+			sw.Do("val.DeepCopyIntoGraph(outVal, graph)\n", nil)
+			sw.Do("(*out)[key] = *outVal\n", nil)
+		} else if g.hasDeepCopyInto(ut.Elem) {
+			sw.Do("(*out)[key] = *val.DeepCopy()\n", uet)
+		}
 	default:
-		klog.Fatalf("Hit an unsupported type %v for %v", uet, t)
+		reportf(g.owner(), "hit an unsupported type %v for %v", uet, t)
 	}
 	sw.Do("}\n", nil)
 }
@@ -771,7 +1000,6 @@ func (g *genDeepCopy) doMap(t *types.Type, sw *generator.SnippetWriter) {
 // is the same for both cases, i.e. it's the code for the underlying type.
 func (g *genDeepCopy) doSlice(t *types.Type, sw *generator.SnippetWriter) {
 	ut := underlyingType(t)
-	uet := underlyingType(ut.Elem)
 
 	if deepCopyMethodOrDie(t) != nil || deepCopyIntoMethodOrDie(t) != nil {
 		sw.Do("*out = in.DeepCopy()\n", nil)
@@ -779,37 +1007,82 @@ func (g *genDeepCopy) doSlice(t *types.Type, sw *generator.SnippetWriter) {
 	}
 
 	sw.Do("*out = make($.|raw$, len(*in))\n", t)
-	if deepCopyMethodOrDie(ut.Elem) != nil || deepCopyIntoMethodOrDie(ut.Elem) != nil {
+	if g.copiesElements(ut.Elem) {
+		sw.Do("copy(*out, *in)\n", nil)
+	} else if isChanFunc(ut.Elem) && g.chanFuncPolicy() == chanFuncZero {
+		// the elements of the new slice are nil
+	} else {
 		sw.Do("for i := range *in {\n", nil)
+		g.doElement(t, sw)
+		sw.Do("}\n", nil)
+	}
+}
+
+// doArray generates code for an array or an alias to an array. The generated code is
+// is the same for both cases, i.e. it's the code for the underlying type.
+func (g *genDeepCopy) doArray(t *types.Type, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+
+	if deepCopyMethodOrDie(t) != nil || deepCopyIntoMethodOrDie(t) != nil {
+		// Note: a DeepCopyInto exists because it is added if DeepCopy is manually defined
+		sw.Do("in.DeepCopyInto(out)\n", nil)
+		return
+	}
+
+	// Arrays are values: a simple copy covers the shallow elements.
+	sw.Do("*out = *in\n", nil)
+	if !g.copiesElements(ut.Elem) {
+		sw.Do("for i := range *in {\n", nil)
+		g.doElement(t, sw)
+		sw.Do("}\n", nil)
+	}
+}
+
+// copiesElements returns true if the elements of type t of a slice or an array
+// are deep-copied by copying the slice or the array.
+func (g *genDeepCopy) copiesElements(t *types.Type) bool {
+	if deepCopyMethodOrDie(t) != nil || deepCopyIntoMethodOrDie(t) != nil {
+		return false
+	}
+	ut := underlyingType(t)
+	return ut.Kind == types.Builtin || isShallow(t) || g.sharesInterfaces(ut) ||
+		isChanFunc(t) && g.chanFuncPolicy() == chanFuncShare
+}
+
+// doElement generates code for the element at index i of a slice, an array or
+// an alias to those, copying (*in)[i] into (*out)[i].
+func (g *genDeepCopy) doElement(t *types.Type, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	uet := underlyingType(ut.Elem)
+
+	switch {
+	case deepCopyMethodOrDie(ut.Elem) != nil || deepCopyIntoMethodOrDie(ut.Elem) != nil:
 		// Note: a DeepCopyInto exists because it is added if DeepCopy is manually defined
 		sw.Do("(*in)[i].DeepCopyInto(&(*out)[i])\n", nil)
+	case uet.Kind == types.Slice || uet.Kind == types.Map || uet.Kind == types.Pointer:
+		sw.Do("if (*in)[i] != nil {\n", nil)
+		sw.Do("in, out := &(*in)[i], &(*out)[i]\n", nil)
+		g.generateFor(ut.Elem, sw)
 		sw.Do("}\n", nil)
-	} else if uet.Kind == types.Builtin || uet.IsAssignable() {
-		sw.Do("copy(*out, *in)\n", nil)
-	} else {
-		sw.Do("for i := range *in {\n", nil)
-		if uet.Kind == types.Slice || uet.Kind == types.Map || uet.Kind == types.Pointer || deepCopyMethodOrDie(ut.Elem) != nil || deepCopyIntoMethodOrDie(ut.Elem) != nil {
-			sw.Do("if (*in)[i] != nil {\n", nil)
-			sw.Do("in, out := &(*in)[i], &(*out)[i]\n", nil)
-			g.generateFor(ut.Elem, sw)
-			sw.Do("}\n", nil)
-		} else if uet.Kind == types.Interface {
-			// Note: do not generate code that won't compile as `DeepCopyinterface{}()` is not a valid function
-			if uet.Name.Name == "interface{}" {
-				klog.Fatalf("DeepCopy of %q is unsupported. Instead, use named interfaces with DeepCopy<named-interface> as one of the methods.", uet.Name.Name)
-			}
-			sw.Do("if (*in)[i] != nil {\n", nil)
-			// Note: if t.Elem has been an alias "J" of an interface "I" in Go, we will see it
-			// as kind Interface of name "J" here, i.e. generate val.DeepCopyJ(). The golang
-			// parser does not give us the underlying interface name. So we cannot do any better.
-			sw.Do(fmt.Sprintf("(*out)[i] = (*in)[i].DeepCopy%s()\n", uet.Name.Name), nil)
-			sw.Do("}\n", nil)
-		} else if uet.Kind == types.Struct {
+	case uet.Kind == types.Array:
+		sw.Do("{\n", nil)
+		sw.Do("in, out := &(*in)[i], &(*out)[i]\n", nil)
+		g.generateFor(ut.Elem, sw)
+		sw.Do("}\n", nil)
+	case uet.Kind == types.Chan || uet.Kind == types.Func:
+		g.doChanFunc(ut.Elem, "(*in)[i]", "(*out)[i]", sw)
+	case uet.Kind == types.Interface:
+		sw.Do("if (*in)[i] != nil {\n", nil)
+		g.doInterface(ut.Elem, "(*in)[i]", "(*out)[i]", sw)
+		sw.Do("}\n", nil)
+	case uet.Kind == types.Struct:
+		if g.graph && g.isGraphType(ut.Elem) {
+			sw.Do("(*in)[i].DeepCopyIntoGraph(&(*out)[i], graph)\n", nil)
+		} else if g.hasDeepCopyInto(ut.Elem) {
 			sw.Do("(*in)[i].DeepCopyInto(&(*out)[i])\n", nil)
-		} else {
-			klog.Fatalf("Hit an unsupported type %v for %v", uet, t)
 		}
-		sw.Do("}\n", nil)
+	default:
+		reportf(g.owner(), "hit an unsupported type %v for %v", uet, t)
 	}
 }
 
@@ -827,7 +1100,10 @@ func (g *genDeepCopy) doStruct(t *types.Type, sw *generator.SnippetWriter) {
 	sw.Do("*out = *in\n", nil)
 
 	// Now fix-up fields as needed.
-	for _, m := range ut.Members {
+	for i := range ut.Members {
+		m := &ut.Members[i]
+		g.member = m
+		g.path = t.Name.Name + "." + m.Name
 		ft := m.Type
 		uft := underlyingType(ft)
 
@@ -853,7 +1129,7 @@ func (g *genDeepCopy) doStruct(t *types.Type, sw *generator.SnippetWriter) {
 			} else {
 				sw.Do("in.$.name$.DeepCopyInto(&out.$.name$)\n", args)
 			}
-		case uft.Kind == types.Builtin:
+		case uft.Kind == types.Builtin, isShallow(ft):
 			// the initial *out = *in was enough
 		case uft.Kind == types.Map, uft.Kind == types.Slice, uft.Kind == types.Pointer:
 			// Fixup non-nil reference-semantic types.
@@ -861,32 +1137,260 @@ func (g *genDeepCopy) doStruct(t *types.Type, sw *generator.SnippetWriter) {
 			sw.Do("in, out := &in.$.name$, &out.$.name$\n", args)
 			g.generateFor(ft, sw)
 			sw.Do("}\n", nil)
+		case uft.Kind == types.Array:
+			sw.Do("{\n", nil)
+			sw.Do("in, out := &in.$.name$, &out.$.name$\n", args)
+			g.generateFor(ft, sw)
+			sw.Do("}\n", nil)
 		case uft.Kind == types.Struct:
 			if ft.IsAssignable() {
 				sw.Do("out.$.name$ = in.$.name$\n", args)
-			} else {
+			} else if g.graph && g.isGraphType(ft) {
+				sw.Do("in.$.name$.DeepCopyIntoGraph(&out.$.name$, graph)\n", args)
+			} else if g.hasDeepCopyInto(ft) {
 				sw.Do("in.$.name$.DeepCopyInto(&out.$.name$)\n", args)
 			}
 		case uft.Kind == types.Interface:
-			// Note: do not generate code that won't compile as `DeepCopyinterface{}()` is not a valid function
-			if uft.Name.Name == "interface{}" {
-				klog.Fatalf("DeepCopy of %q is unsupported. Instead, use named interfaces with DeepCopy<named-interface> as one of the methods.", uft.Name.Name)
+			if interfaceStrategy(m, g.owner()) == interfaceShare {
+				// the initial *out = *in was enough
+				break
 			}
 			sw.Do("if in.$.name$ != nil {\n", args)
-			// Note: if t.Elem has been an alias "J" of an interface "I" in Go, we will see it
-			// as kind Interface of name "J" here, i.e. generate val.DeepCopyJ(). The golang
-			// parser does not give us the underlying interface name. So we cannot do any better.
-			sw.Do(fmt.Sprintf("out.$.name$ = in.$.name$.DeepCopy%s()\n", uft.Name.Name), args)
+			g.doInterface(ft, "in."+m.Name, "out."+m.Name, sw)
 			sw.Do("}\n", nil)
+		case uft.Kind == types.Chan, uft.Kind == types.Func:
+			if g.chanFuncPolicy() == chanFuncShare {
+				// the initial *out = *in was enough
+				break
+			}
+			g.doChanFunc(ft, "in."+m.Name, "out."+m.Name, sw)
 		default:
-			klog.Fatalf("Hit an unsupported type %v for %v, from %v", uft, ft, t)
+			reportf(g.owner(), "hit an unsupported type %v for %v, from %v", uft, ft, t)
+		}
+	}
+	g.member = nil
+	g.path = t.Name.Name
+}
+
+// interfaceStrategy returns the value of the interface strategy tag of m, ""
+// if m has none. owner is the field the problems are reported against.
+func interfaceStrategy(m *types.Member, owner decl) string {
+	values := types.ExtractCommentTags("+", m.CommentLines)[interfaceStrategyTagName]
+	if len(values) == 0 {
+		return ""
+	}
+	if len(values) > 1 {
+		reportf(owner, "found %d %s tags: %q", len(values), interfaceStrategyTagName, values)
+	}
+	switch values[0] {
+	case interfaceShare, interfaceReflect, interfaceRegistry:
+		return values[0]
+	}
+	reportf(owner, "unsupported %s value: %q", interfaceStrategyTagName, values[0])
+	return ""
+}
+
+// sharesInterfaces returns true if t is an interface type whose values the
+// field being copied shares.
+func (g *genDeepCopy) sharesInterfaces(t *types.Type) bool {
+	return t.Kind == types.Interface && g.member != nil && interfaceStrategy(g.member, g.owner()) == interfaceShare
+}
+
+// typeLiteral returns the name of t in the generated code. The raw namer
+// does not write the length of arrays, the methods of unnamed interfaces nor
+// the name of error, so the literal of those, and of the unnamed types
+// containing them, e.g. functions, is written here.
+func (g *genDeepCopy) typeLiteral(t *types.Type) string {
+	// Named functions and channels are aliases: these are unnamed.
+	switch t.Kind {
+	case types.Func:
+		return "func" + g.signatureLiteral(t.Signature)
+	case types.Chan:
+		dir, elem := chanDir(t), g.typeLiteral(t.Elem)
+		if dir == "chan" && strings.HasPrefix(elem, "<-") {
+			// chan <-chan T would be a chan<- chan T.
+			elem = "(" + elem + ")"
+		}
+		return dir + " " + elem
+	}
+	if t.Name.Package != "" && isNamedInterface(t) {
+		return namer.NewRawNamer(g.targetPackage, g.imports).Name(t)
+	}
+	switch t.Kind {
+	case types.Array:
+		return "[" + g.arrayLen(t) + "]" + g.typeLiteral(t.Elem)
+	case types.Slice:
+		return "[]" + g.typeLiteral(t.Elem)
+	case types.Pointer:
+		return "*" + g.typeLiteral(t.Elem)
+	case types.Map:
+		return "map[" + g.typeLiteral(t.Key) + "]" + g.typeLiteral(t.Elem)
+	case types.Interface:
+		if isNamedInterface(t) {
+			// error
+			return t.Name.Name
+		}
+	default:
+		return namer.NewRawNamer(g.targetPackage, g.imports).Name(t)
+	}
+	names := make([]string, 0, len(t.Methods))
+	for name := range t.Methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	methods := make([]string, 0, len(names))
+	for _, name := range names {
+		methods = append(methods, name+g.signatureLiteral(t.Methods[name].Signature))
+	}
+	if len(methods) == 0 {
+		return "interface{}"
+	}
+	return "interface{ " + strings.Join(methods, "; ") + " }"
+}
+
+// signatureLiteral returns the literal of sig following the name of a method
+// or the func keyword, e.g. "(string, ...int) error".
+func (g *genDeepCopy) signatureLiteral(sig *types.Signature) string {
+	params := make([]string, 0, len(sig.Parameters))
+	for i, p := range sig.Parameters {
+		if sig.Variadic && i == len(sig.Parameters)-1 {
+			params = append(params, "..."+g.typeLiteral(p.Elem))
+		} else {
+			params = append(params, g.typeLiteral(p))
+		}
+	}
+	results := make([]string, 0, len(sig.Results))
+	for _, r := range sig.Results {
+		results = append(results, g.typeLiteral(r))
+	}
+	literal := "(" + strings.Join(params, ", ") + ")"
+	switch len(results) {
+	case 0:
+	case 1:
+		literal += " " + results[0]
+	default:
+		literal += " (" + strings.Join(results, ", ") + ")"
+	}
+	return literal
+}
+
+// arrayLen returns the length of the unnamed array type t. The parser does not
+// record it, but it starts the name of the type, e.g. "[16]uint8".
+func (g *genDeepCopy) arrayLen(t *types.Type) string {
+	end := strings.Index(t.Name.Name, "]")
+	if !strings.HasPrefix(t.Name.Name, "[") || end < 0 {
+		reportf(g.owner(), "cannot find the length of the array type %v", t)
+		return "0"
+	}
+	return t.Name.Name[1:end]
+}
+
+// chanDir returns the keyword of the unnamed channel type t with its
+// direction, e.g. "<-chan". The parser does not record the direction, but it
+// starts the name of the type, e.g. "<-chan int".
+func chanDir(t *types.Type) string {
+	for _, dir := range []string{"<-chan", "chan<-"} {
+		if strings.HasPrefix(t.Name.Name, dir) {
+			return dir
+		}
+	}
+	return "chan"
+}
+
+// isShallow returns true if copying a value of type t by assignment is a
+// deep copy.
+func isShallow(t *types.Type) bool {
+	ut := underlyingType(t)
+	return t.IsAssignable() || ut.IsAssignable() || ut.Kind == types.Array && isShallow(ut.Elem)
+}
+
+// hasDeepCopyInto returns true if the struct t, which has no DeepCopy nor
+// DeepCopyInto method written by hand, has generated ones: it is a named type
+// in the bounding dirs, enabled if it belongs to the package being generated.
+// Otherwise a problem is reported, since the generated code would call
+// methods t does not have.
+func (g *genDeepCopy) hasDeepCopyInto(t *types.Type) bool {
+	switch {
+	case strings.HasPrefix(t.Name.Name, "struct{"):
+		reportf(g.owner(), "an anonymous struct holding references cannot be deep-copied: declare it as a named type")
+		return false
+	case !g.copyableAndInBounds(t):
+		reportf(g.owner(), "%v has no DeepCopyInto method: it is not generated, so it must be written by hand", t)
+		return false
+	case t.Name.Package == g.targetPackage && !g.allTypes:
+		if ttag := extractEnabledTypeTag(t); ttag == nil || ttag.value != "true" {
+			reportf(g.owner(), "%v has no DeepCopyInto method: tag it with +%s=true", t, tagEnabledName)
+			return false
 		}
 	}
+	return true
+}
+
+// isNamedInterface returns true if t is a named interface type, which
+// declares a DeepCopy<name> method copying its values.
+func isNamedInterface(t *types.Type) bool {
+	// The name of an unnamed interface is its literal, which the parser
+	// splits at its last dot, e.g. in "...": check the whole name.
+	return !strings.HasPrefix(t.Name.String(), "interface{")
+}
+
+// doInterface generates code copying in, a non-nil value of the interface
+// type t, into out. The values held by interface{} and unnamed interfaces are
+// copied with the strategy tagged on the field being copied, which the fields
+// of named interface types can use too instead of their DeepCopy<name> method.
+func (g *genDeepCopy) doInterface(t *types.Type, in, out string, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	strategy := ""
+	if g.member != nil {
+		strategy = interfaceStrategy(g.member, g.owner())
+	}
+	args := generator.Args{"type": t, "in": in, "out": out}
+	switch strategy {
+	case interfaceShare:
+		sw.Do("$.out$ = $.in$\n", args)
+		return
+	case interfaceReflect, interfaceRegistry:
+		runtimeFunc := &types.Type{Name: types.Name{Package: runtimePackage, Name: "Reflect"}}
+		g.imports.AddType(runtimeFunc)
+		args["runtime"] = g.imports.LocalNameOf(runtimePackage)
+		args["copy"] = "Reflect"
+		if strategy == interfaceRegistry {
+			args["copy"] = "DeepCopyAny"
+		}
+		if ut.Name.Name == "interface{}" {
+			sw.Do("$.out$ = $.runtime$.$.copy$($.in$)\n", args)
+		} else {
+			args["literal"] = g.typeLiteral(t)
+			sw.Do("$.out$ = $.runtime$.$.copy$($.in$).($.literal$)\n", args)
+		}
+		return
+	}
+
+	// Note: do not generate code that won't compile as `DeepCopyinterface{}()` is not a valid function
+	if !isNamedInterface(ut) {
+		reportf(g.owner(), "DeepCopy of %q is unsupported. Instead, tag the field with +%s=%s|%s|%s, or use named interfaces with DeepCopy<named-interface> as one of the methods.",
+			ut.Name.Name, interfaceStrategyTagName, interfaceShare, interfaceReflect, interfaceRegistry)
+		return
+	}
+	// Note: if t.Elem has been an alias "J" of an interface "I" in Go, we will see it
+	// as kind Interface of name "J" here, i.e. generate val.DeepCopyJ(). The golang
+	// parser does not give us the underlying interface name. So we cannot do any better.
+	method := "DeepCopy" + ut.Name.Name
+	if _, found := ut.Methods[method]; !found {
+		reportf(g.owner(), "DeepCopy of %q is unsupported: it has no %s method. Instead, tag the field with +%s=%s|%s|%s, or add the method to the interface.",
+			ut.Name.String(), method, interfaceStrategyTagName, interfaceShare, interfaceReflect, interfaceRegistry)
+		return
+	}
+	sw.Do(fmt.Sprintf("$.out$ = $.in$.%s()\n", method), args)
 }
 
 // doPointer generates code for a pointer or an alias to a pointer. The generated code is
 // is the same for both cases, i.e. it's the code for the underlying type.
 func (g *genDeepCopy) doPointer(t *types.Type, sw *generator.SnippetWriter) {
+	if g.graph {
+		g.doGraphPointer(t, sw)
+		return
+	}
 	ut := underlyingType(t)
 	uet := underlyingType(ut.Elem)
 
@@ -903,7 +1407,7 @@ func (g *genDeepCopy) doPointer(t *types.Type, sw *generator.SnippetWriter) {
 			sw.Do("x := (*in).DeepCopy()\n", nil)
 			sw.Do("*out = &x\n", nil)
 		}
-	case uet.IsAssignable():
+	case isShallow(ut.Elem):
 		sw.Do("*out = new($.Elem|raw$)\n", ut)
 		sw.Do("**out = **in", nil)
 	case uet.Kind == types.Map, uet.Kind == types.Slice, uet.Kind == types.Pointer:
@@ -912,10 +1416,135 @@ func (g *genDeepCopy) doPointer(t *types.Type, sw *generator.SnippetWriter) {
 		sw.Do("in, out := *in, *out\n", nil)
 		g.generateFor(uet, sw)
 		sw.Do("}\n", nil)
+	case uet.Kind == types.Array:
+		sw.Do("*out = new($.Elem|raw$)\n", ut)
+		sw.Do("{\n", nil)
+		sw.Do("in, out := *in, *out\n", nil)
+		g.generateFor(ut.Elem, sw)
+		sw.Do("}\n", nil)
 	case uet.Kind == types.Struct:
+		if g.hasDeepCopyInto(ut.Elem) {
+			sw.Do("*out = new($.Elem|raw$)\n", ut)
+			sw.Do("(*in).DeepCopyInto(*out)\n", nil)
+		}
+	case uet.Kind == types.Chan || uet.Kind == types.Func:
 		sw.Do("*out = new($.Elem|raw$)\n", ut)
+		g.doChanFunc(ut.Elem, "**in", "**out", sw)
+	default:
+		reportf(g.owner(), "hit an unsupported type %v for %v", uet, t)
+	}
+}
+
+// doGraphPointer generates code for a pointer or an alias to a pointer reached
+// while copying a graph: the pointer is only copied if graph has no copy of it
+// yet, and its copy is recorded before copying the value it points to.
+func (g *genDeepCopy) doGraphPointer(t *types.Type, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	uet := underlyingType(ut.Elem)
+
+	sw.Do("if c, found := graph.Lookup(*in); found {\n", nil)
+	sw.Do("*out = c.($.|raw$)\n", t)
+	sw.Do("} else {\n", nil)
+	sw.Do("*out = new($.Elem|raw$)\n", ut)
+	sw.Do("graph.Add(*in, *out)\n", nil)
+	dc, dci := deepCopyMethodOrDie(ut.Elem), deepCopyIntoMethodOrDie(ut.Elem)
+	switch {
+	case g.isGraphType(ut.Elem):
+		sw.Do("(*in).DeepCopyIntoGraph(*out, graph)\n", nil)
+	case dci != nil:
 		sw.Do("(*in).DeepCopyInto(*out)\n", nil)
+	case dc != nil:
+		if dc.Results[0].Kind == types.Pointer {
+			sw.Do("**out = *(*in).DeepCopy()\n", nil)
+		} else {
+			sw.Do("**out = (*in).DeepCopy()\n", nil)
+		}
+	case isShallow(ut.Elem):
+		sw.Do("**out = **in\n", nil)
+	case uet.Kind == types.Map, uet.Kind == types.Slice, uet.Kind == types.Pointer:
+		sw.Do("if **in != nil {\n", nil)
+		sw.Do("in, out := *in, *out\n", nil)
+		g.generateFor(ut.Elem, sw)
+		sw.Do("}\n", nil)
+	case uet.Kind == types.Array:
+		sw.Do("in, out := *in, *out\n", nil)
+		g.generateFor(ut.Elem, sw)
+	case uet.Kind == types.Struct:
+		if g.hasDeepCopyInto(ut.Elem) {
+			sw.Do("(*in).DeepCopyInto(*out)\n", nil)
+		}
+	case uet.Kind == types.Chan || uet.Kind == types.Func:
+		g.doChanFunc(ut.Elem, "**in", "**out", sw)
 	default:
-		klog.Fatalf("Hit an unsupported type %v for %v", uet, t)
+		reportf(g.owner(), "hit an unsupported type %v for %v", uet, t)
+	}
+	sw.Do("}\n", nil)
+}
+
+// isChanFunc returns true if t is a channel, a function or an alias to those.
+func isChanFunc(t *types.Type) bool {
+	ut := underlyingType(t)
+	return ut.Kind == types.Chan || ut.Kind == types.Func
+}
+
+// extractChanFuncTag returns the value of the chan-func tag in comments, ""
+// if they have none. owner is the field or the package the problems are
+// reported against.
+func extractChanFuncTag(comments []string, owner decl) string {
+	values := types.ExtractCommentTags("+", comments)[chanFuncTagName]
+	if len(values) == 0 {
+		return ""
+	}
+	if len(values) > 1 {
+		reportf(owner, "found %d %s tags: %q", len(values), chanFuncTagName, values)
+	}
+	switch values[0] {
+	case chanFuncShare, chanFuncZero, chanFuncFail:
+		return values[0]
+	}
+	reportf(owner, "unsupported %s value: %q", chanFuncTagName, values[0])
+	return ""
+}
+
+// owner returns the type or the field being copied, which the problems found
+// while generating its code are reported against.
+func (g *genDeepCopy) owner() decl {
+	return decl{pkg: g.targetPackage, path: g.path}
+}
+
+// chanFuncPolicy returns how the channels and functions of the value being
+// copied are copied: with the chan-func tag of the field being copied, else
+// of the package, else chanFuncFail.
+func (g *genDeepCopy) chanFuncPolicy() string {
+	if g.member != nil {
+		if policy := extractChanFuncTag(g.member.CommentLines, g.owner()); policy != "" {
+			return policy
+		}
+	}
+	if g.chanFunc != "" {
+		return g.chanFunc
+	}
+	return chanFuncFail
+}
+
+// doChanFunc generates code copying in, a channel or a function of type t,
+// into out with the policy of chanFuncPolicy. Channels and functions cannot be
+// deep-copied, so by default a problem is reported against the value.
+func (g *genDeepCopy) doChanFunc(t *types.Type, in, out string, sw *generator.SnippetWriter) {
+	args := generator.Args{
+		"in":  in,
+		"out": out,
+	}
+	switch g.chanFuncPolicy() {
+	case chanFuncShare:
+		sw.Do("$.out$ = $.in$\n", args)
+	case chanFuncZero:
+		sw.Do("$.out$ = nil\n", args)
+	default:
+		kind := "chan"
+		if underlyingType(t).Kind == types.Func {
+			kind = "func"
+		}
+		reportf(g.owner(), "a %s cannot be deep-copied: tag it with +%s=%s|%s", kind, chanFuncTagName, chanFuncShare, chanFuncZero)
 	}
 }
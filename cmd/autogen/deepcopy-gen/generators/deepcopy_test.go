@@ -17,9 +17,11 @@ limitations under the License.
 package generators
 
 import (
+	"bytes"
 	"reflect"
 	"testing"
 
+	"k8s.io/gengo/generator"
 	"k8s.io/gengo/types"
 )
 
@@ -596,7 +598,7 @@ func Test_extractTagParams(t *testing.T) {
 		{
 			comments: []string{
 				"Human comment",
-				"+k8s:deepcopy-gen",
+				"+gengo:deepcopy",
 			},
 			expect: &enabledTagValue{
 				value:    "",
@@ -606,7 +608,7 @@ func Test_extractTagParams(t *testing.T) {
 		{
 			comments: []string{
 				"Human comment",
-				"+k8s:deepcopy-gen=package",
+				"+gengo:deepcopy=package",
 			},
 			expect: &enabledTagValue{
 				value:    "package",
@@ -616,7 +618,7 @@ func Test_extractTagParams(t *testing.T) {
 		{
 			comments: []string{
 				"Human comment",
-				"+k8s:deepcopy-gen=package,register",
+				"+gengo:deepcopy=package,register",
 			},
 			expect: &enabledTagValue{
 				value:    "package",
@@ -626,7 +628,7 @@ func Test_extractTagParams(t *testing.T) {
 		{
 			comments: []string{
 				"Human comment",
-				"+k8s:deepcopy-gen=package,register=true",
+				"+gengo:deepcopy=package,register=true",
 			},
 			expect: &enabledTagValue{
 				value:    "package",
@@ -636,17 +638,28 @@ func Test_extractTagParams(t *testing.T) {
 		{
 			comments: []string{
 				"Human comment",
-				"+k8s:deepcopy-gen=package,register=false",
+				"+gengo:deepcopy=package,register=false",
 			},
 			expect: &enabledTagValue{
 				value:    "package",
 				register: false,
 			},
 		},
+		{
+			comments: []string{
+				"Human comment",
+				"+gengo:deepcopy=package,register,unexported",
+			},
+			expect: &enabledTagValue{
+				value:      "package",
+				register:   true,
+				unexported: true,
+			},
+		},
 	}
 
 	for i, tc := range testCases {
-		r := extractEnabledTag(tc.comments)
+		r := extractEnabledTag(tc.comments, decl{pkg: "target"})
 		if r == nil && tc.expect != nil {
 			t.Errorf("case[%d]: expected non-nil", i)
 		}
@@ -659,6 +672,56 @@ func Test_extractTagParams(t *testing.T) {
 	}
 }
 
+func Test_copyableType(t *testing.T) {
+	testCases := []struct {
+		name       string
+		comments   []string
+		unexported bool
+		expect     bool
+	}{
+		{
+			name:   "Public",
+			expect: true,
+		},
+		{
+			name:     "Public",
+			comments: []string{"+gengo:deepcopy=false"},
+			expect:   false,
+		},
+		{
+			name:   "private",
+			expect: false,
+		},
+		{
+			name:     "private",
+			comments: []string{"+gengo:deepcopy=true"},
+			expect:   true,
+		},
+		{
+			name:       "private",
+			unexported: true,
+			expect:     true,
+		},
+		{
+			name:       "private",
+			comments:   []string{"+gengo:deepcopy=false"},
+			unexported: true,
+			expect:     false,
+		},
+	}
+
+	for i, tc := range testCases {
+		typ := &types.Type{
+			Name:         types.Name{Package: "target", Name: tc.name},
+			Kind:         types.Struct,
+			CommentLines: tc.comments,
+		}
+		if r := copyableType(typ, tc.unexported); r != tc.expect {
+			t.Errorf("case[%d]: expected %t, got %t", i, tc.expect, r)
+		}
+	}
+}
+
 func Test_extractInterfacesTag(t *testing.T) {
 	testCases := []struct {
 		comments, secondComments []string
@@ -670,7 +733,7 @@ func Test_extractInterfacesTag(t *testing.T) {
 		},
 		{
 			comments: []string{
-				"+k8s:deepcopy-gen:interfaces=k8s.io/kubernetes/runtime.Object",
+				"+gengo:deepcopy:interfaces=k8s.io/kubernetes/runtime.Object",
 			},
 			expect: []string{
 				"k8s.io/kubernetes/runtime.Object",
@@ -678,8 +741,8 @@ func Test_extractInterfacesTag(t *testing.T) {
 		},
 		{
 			comments: []string{
-				"+k8s:deepcopy-gen:interfaces=k8s.io/kubernetes/runtime.Object",
-				"+k8s:deepcopy-gen:interfaces=k8s.io/kubernetes/runtime.List",
+				"+gengo:deepcopy:interfaces=k8s.io/kubernetes/runtime.Object",
+				"+gengo:deepcopy:interfaces=k8s.io/kubernetes/runtime.List",
 			},
 			expect: []string{
 				"k8s.io/kubernetes/runtime.Object",
@@ -688,8 +751,8 @@ func Test_extractInterfacesTag(t *testing.T) {
 		},
 		{
 			comments: []string{
-				"+k8s:deepcopy-gen:interfaces=k8s.io/kubernetes/runtime.Object",
-				"+k8s:deepcopy-gen:interfaces=k8s.io/kubernetes/runtime.Object",
+				"+gengo:deepcopy:interfaces=k8s.io/kubernetes/runtime.Object",
+				"+gengo:deepcopy:interfaces=k8s.io/kubernetes/runtime.Object",
 			},
 			expect: []string{
 				"k8s.io/kubernetes/runtime.Object",
@@ -698,7 +761,7 @@ func Test_extractInterfacesTag(t *testing.T) {
 		},
 		{
 			secondComments: []string{
-				"+k8s:deepcopy-gen:interfaces=k8s.io/kubernetes/runtime.Object",
+				"+gengo:deepcopy:interfaces=k8s.io/kubernetes/runtime.Object",
 			},
 			expect: []string{
 				"k8s.io/kubernetes/runtime.Object",
@@ -706,10 +769,10 @@ func Test_extractInterfacesTag(t *testing.T) {
 		},
 		{
 			comments: []string{
-				"+k8s:deepcopy-gen:interfaces=k8s.io/kubernetes/runtime.Object",
+				"+gengo:deepcopy:interfaces=k8s.io/kubernetes/runtime.Object",
 			},
 			secondComments: []string{
-				"+k8s:deepcopy-gen:interfaces=k8s.io/kubernetes/runtime.List",
+				"+gengo:deepcopy:interfaces=k8s.io/kubernetes/runtime.List",
 			},
 			expect: []string{
 				"k8s.io/kubernetes/runtime.List",
@@ -718,10 +781,10 @@ func Test_extractInterfacesTag(t *testing.T) {
 		},
 		{
 			comments: []string{
-				"+k8s:deepcopy-gen:interfaces=k8s.io/kubernetes/runtime.Object",
+				"+gengo:deepcopy:interfaces=k8s.io/kubernetes/runtime.Object",
 			},
 			secondComments: []string{
-				"+k8s:deepcopy-gen:interfaces=k8s.io/kubernetes/runtime.Object",
+				"+gengo:deepcopy:interfaces=k8s.io/kubernetes/runtime.Object",
 			},
 			expect: []string{
 				"k8s.io/kubernetes/runtime.Object",
@@ -747,3 +810,437 @@ func Test_extractInterfacesTag(t *testing.T) {
 		}
 	}
 }
+
+func Test_interfaceStrategy(t *testing.T) {
+	testCases := []struct {
+		comments []string
+		expect   string
+	}{
+		{
+			comments: []string{},
+			expect:   "",
+		},
+		{
+			comments: []string{
+				"Human comment",
+			},
+			expect: "",
+		},
+		{
+			comments: []string{
+				"Human comment",
+				"+gengo:deepcopy:interface=share",
+			},
+			expect: interfaceShare,
+		},
+		{
+			comments: []string{
+				"+gengo:deepcopy:interface=reflect",
+			},
+			expect: interfaceReflect,
+		},
+		{
+			comments: []string{
+				"+gengo:deepcopy:interface=registry",
+			},
+			expect: interfaceRegistry,
+		},
+	}
+
+	for i, tc := range testCases {
+		r := interfaceStrategy(&types.Member{Name: "Field", CommentLines: tc.comments}, decl{pkg: "target", path: "T.Field"})
+		if r != tc.expect {
+			t.Errorf("case[%d]: expected %q, got %q", i, tc.expect, r)
+		}
+	}
+}
+
+func Test_chanFuncPolicy(t *testing.T) {
+	testCases := []struct {
+		pkg      string
+		comments []string
+		expect   string
+	}{
+		{
+			comments: []string{"Human comment"},
+			expect:   chanFuncFail,
+		},
+		{
+			pkg:    chanFuncZero,
+			expect: chanFuncZero,
+		},
+		{
+			comments: []string{"+gengo:deepcopy:chan-func=share"},
+			expect:   chanFuncShare,
+		},
+		{
+			// The tag of the field overrides the tag of the package.
+			pkg:      chanFuncShare,
+			comments: []string{"+gengo:deepcopy:chan-func=fail"},
+			expect:   chanFuncFail,
+		},
+	}
+
+	for i, tc := range testCases {
+		g := NewGenDeepCopy("", "target", nil, true, false, false, tc.pkg).(*genDeepCopy)
+		g.member = &types.Member{Name: "Field", CommentLines: tc.comments}
+		if r := g.chanFuncPolicy(); r != tc.expect {
+			t.Errorf("case[%d]: expected %q, got %q", i, tc.expect, r)
+		}
+	}
+}
+
+func Test_doChanFunc(t *testing.T) {
+	fn := &types.Type{Name: types.Name{Name: "func()"}, Kind: types.Func, Signature: &types.Signature{}}
+	testCases := []struct {
+		policy  string
+		expect  string
+		problem string
+	}{
+		{
+			policy: chanFuncShare,
+			expect: "out.Hook = in.Hook\n",
+		},
+		{
+			policy: chanFuncZero,
+			expect: "out.Hook = nil\n",
+		},
+		{
+			policy:  chanFuncFail,
+			problem: "target.T.Hook: a func cannot be deep-copied: tag it with +gengo:deepcopy:chan-func=share|zero",
+		},
+		{
+			// Untagged.
+			policy:  "",
+			problem: "target.T.Hook: a func cannot be deep-copied: tag it with +gengo:deepcopy:chan-func=share|zero",
+		},
+	}
+
+	defer func(saved *problemList) { problems = saved }(problems)
+	for i, tc := range testCases {
+		problems = &problemList{}
+		g := NewGenDeepCopy("", "target", nil, true, false, false, tc.policy).(*genDeepCopy)
+		g.path = "T.Hook"
+		var buf bytes.Buffer
+		sw := generator.NewSnippetWriter(&buf, &generator.Context{}, "$", "$")
+		g.doChanFunc(fn, "in.Hook", "out.Hook", sw)
+		if err := sw.Error(); err != nil {
+			t.Fatalf("case[%d]: %v", i, err)
+		}
+		if r := buf.String(); r != tc.expect {
+			t.Errorf("case[%d]: expected %q, got %q", i, tc.expect, r)
+		}
+		var problem string
+		if r := Problems(); len(r) > 0 {
+			problem = r[0].Error()
+		}
+		if problem != tc.problem {
+			t.Errorf("case[%d]: expected problem %q, got %q", i, tc.problem, problem)
+		}
+	}
+}
+
+func Test_typeLiteral(t *testing.T) {
+	intType := types.Int
+	errType := &types.Type{Name: types.Name{Name: "error"}, Kind: types.Interface}
+	testCases := []struct {
+		t      *types.Type
+		expect string
+	}{
+		{
+			t:      &types.Type{Name: types.Name{Name: "interface{}"}, Kind: types.Interface},
+			expect: "interface{}",
+		},
+		{
+			t:      &types.Type{Name: types.Name{Package: "pkg", Name: "Object"}, Kind: types.Interface},
+			expect: "pkg.Object",
+		},
+		{
+			t: &types.Type{
+				Name: types.Name{Name: "interface{Len() int; Write(...int) (int, error)}"},
+				Kind: types.Interface,
+				Methods: map[string]*types.Type{
+					"Write": {Kind: types.Func, Signature: &types.Signature{
+						Parameters: []*types.Type{{Kind: types.Slice, Elem: intType}},
+						Results:    []*types.Type{intType, errType},
+						Variadic:   true,
+					}},
+					"Len": {Kind: types.Func, Signature: &types.Signature{
+						Results: []*types.Type{intType},
+					}},
+				},
+			},
+			expect: "interface{ Len() int; Write(...int) (int, error) }",
+		},
+		{
+			t: &types.Type{
+				Name: types.Name{Name: "[4]*target.Node"},
+				Kind: types.Array,
+				Elem: &types.Type{
+					Name: types.Name{Name: "*target.Node"},
+					Kind: types.Pointer,
+					Elem: &types.Type{Name: types.Name{Package: "target", Name: "Node"}, Kind: types.Struct},
+				},
+			},
+			expect: "[4]*Node",
+		},
+		{
+			t: &types.Type{
+				Name: types.Name{Name: "map[string][2][16]uint8"},
+				Kind: types.Map,
+				Key:  types.String,
+				Elem: &types.Type{
+					Name: types.Name{Name: "[2][16]uint8"},
+					Kind: types.Array,
+					Elem: &types.Type{
+						Name: types.Name{Name: "[16]uint8"},
+						Kind: types.Array,
+						Elem: types.Byte,
+					},
+				},
+			},
+			expect: "map[string][2][16]byte",
+		},
+		{
+			t:      &types.Type{Name: types.Name{Package: "pkg", Name: "ID"}, Kind: types.Array, Elem: types.Byte},
+			expect: "pkg.ID",
+		},
+		{
+			t: &types.Type{
+				Name: types.Name{Name: "map[string]func(string, ...int) error"},
+				Kind: types.Map,
+				Key:  types.String,
+				Elem: &types.Type{
+					Name: types.Name{Name: "func(string, ...int) error"},
+					Kind: types.Func,
+					Signature: &types.Signature{
+						Parameters: []*types.Type{types.String, {Name: types.Name{Name: "[]int"}, Kind: types.Slice, Elem: types.Int}},
+						Variadic:   true,
+						Results:    []*types.Type{{Name: types.Name{Name: "error"}, Kind: types.Interface}},
+					},
+				},
+			},
+			expect: "map[string]func(string, ...int) error",
+		},
+		{
+			t:      &types.Type{Name: types.Name{Name: "chan []int"}, Kind: types.Chan, Elem: &types.Type{Name: types.Name{Name: "[]int"}, Kind: types.Slice, Elem: types.Int}},
+			expect: "chan []int",
+		},
+		{
+			t:      &types.Type{Name: types.Name{Name: "<-chan int"}, Kind: types.Chan, Elem: types.Int},
+			expect: "<-chan int",
+		},
+		{
+			t:      &types.Type{Name: types.Name{Name: "chan<- int"}, Kind: types.Chan, Elem: types.Int},
+			expect: "chan<- int",
+		},
+		{
+			t: &types.Type{
+				Name: types.Name{Name: "chan (<-chan int)"},
+				Kind: types.Chan,
+				Elem: &types.Type{Name: types.Name{Name: "<-chan int"}, Kind: types.Chan, Elem: types.Int},
+			},
+			expect: "chan (<-chan int)",
+		},
+	}
+
+	g := NewGenDeepCopy("", "target", nil, true, false, false, "").(*genDeepCopy)
+	for i, tc := range testCases {
+		if r := g.typeLiteral(tc.t); r != tc.expect {
+			t.Errorf("case[%d]: expected %q, got %q", i, tc.expect, r)
+		}
+	}
+}
+
+func Test_isGraphType(t *testing.T) {
+	testCases := []struct {
+		pkg      string
+		comments []string
+		expect   bool
+	}{
+		{
+			pkg:      "target",
+			comments: []string{},
+			expect:   false,
+		},
+		{
+			pkg:      "target",
+			comments: []string{"+gengo:deepcopy:graph=true"},
+			expect:   true,
+		},
+		{
+			pkg:      "target",
+			comments: []string{"+gengo:deepcopy:graph=false"},
+			expect:   false,
+		},
+		{
+			// Only the types of the package being generated have a
+			// DeepCopyIntoGraph method.
+			pkg:      "other",
+			comments: []string{"+gengo:deepcopy:graph=true"},
+			expect:   false,
+		},
+	}
+
+	g := NewGenDeepCopy("", "target", nil, true, false, false, "").(*genDeepCopy)
+	for i, tc := range testCases {
+		typ := &types.Type{
+			Name:         types.Name{Package: tc.pkg, Name: "Node"},
+			Kind:         types.Struct,
+			CommentLines: tc.comments,
+		}
+		if r := g.isGraphType(typ); r != tc.expect {
+			t.Errorf("case[%d]: expected %t, got %t", i, tc.expect, r)
+		}
+	}
+}
+
+func Test_isShallow(t *testing.T) {
+	node := &types.Type{Name: types.Name{Package: "target", Name: "Node"}, Kind: types.Struct,
+		Members: []types.Member{{Name: "Next", Type: &types.Type{Kind: types.Pointer}}}}
+	point := &types.Type{Name: types.Name{Package: "target", Name: "Point"}, Kind: types.Struct,
+		Members: []types.Member{{Name: "X", Type: types.Int}}}
+	testCases := []struct {
+		t      *types.Type
+		expect bool
+	}{
+		{
+			t:      types.Int,
+			expect: true,
+		},
+		{
+			t:      point,
+			expect: true,
+		},
+		{
+			t:      node,
+			expect: false,
+		},
+		{
+			t:      &types.Type{Name: types.Name{Name: "[16]uint8"}, Kind: types.Array, Elem: types.Byte},
+			expect: true,
+		},
+		{
+			t:      &types.Type{Name: types.Name{Name: "[2]target.Point"}, Kind: types.Array, Elem: point},
+			expect: true,
+		},
+		{
+			t: &types.Type{Name: types.Name{Name: "[2][3]*int"}, Kind: types.Array, Elem: &types.Type{
+				Name: types.Name{Name: "[3]*int"}, Kind: types.Array, Elem: &types.Type{Kind: types.Pointer, Elem: types.Int},
+			}},
+			expect: false,
+		},
+		{
+			t:      &types.Type{Name: types.Name{Name: "[2]target.Node"}, Kind: types.Array, Elem: node},
+			expect: false,
+		},
+		{
+			t:      &types.Type{Name: types.Name{Name: "[]int"}, Kind: types.Slice, Elem: types.Int},
+			expect: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		if r := isShallow(tc.t); r != tc.expect {
+			t.Errorf("case[%d]: expected %t, got %t", i, tc.expect, r)
+		}
+	}
+}
+
+func Test_doStructUnsupported(t *testing.T) {
+	ptr := &types.Type{Name: types.Name{Name: "*int"}, Kind: types.Pointer, Elem: types.Int}
+	shape := &types.Type{
+		Name: types.Name{Package: "target", Name: "Shape"},
+		Kind: types.Interface,
+		Methods: map[string]*types.Type{
+			"DeepCopyShape": {Kind: types.Func, Signature: &types.Signature{}},
+		},
+	}
+	errorType := &types.Type{
+		Name: types.Name{Name: "error"},
+		Kind: types.Interface,
+		Methods: map[string]*types.Type{
+			"Error": {Kind: types.Func, Signature: &types.Signature{Results: []*types.Type{types.String}}},
+		},
+	}
+	inner := &types.Type{
+		Name:    types.Name{Package: "target", Name: "Inner"},
+		Kind:    types.Struct,
+		Members: []types.Member{{Name: "P", Type: ptr}},
+	}
+	untagged := &types.Type{
+		Name:    types.Name{Package: "target", Name: "Untagged"},
+		Kind:    types.Struct,
+		Members: []types.Member{{Name: "P", Type: ptr}},
+	}
+	foreign := &types.Type{
+		Name:    types.Name{Package: "time", Name: "Time"},
+		Kind:    types.Struct,
+		Members: []types.Member{{Name: "loc", Type: ptr}},
+	}
+	anonymous := &types.Type{
+		Name:    types.Name{Name: "struct{P *int}"},
+		Kind:    types.Struct,
+		Members: []types.Member{{Name: "P", Type: ptr}},
+	}
+	testCases := []struct {
+		member   types.Member
+		allTypes bool
+		expect   string
+		problem  string
+	}{
+		{
+			member: types.Member{Name: "Shape", Type: shape},
+			expect: "if in.Shape != nil {\nout.Shape = in.Shape.DeepCopyShape()\n}\n",
+		},
+		{
+			member:   types.Member{Name: "Inner", Type: inner},
+			allTypes: true,
+			expect:   "in.Inner.DeepCopyInto(&out.Inner)\n",
+		},
+		{
+			member:  types.Member{Name: "Err", Type: errorType},
+			expect:  "if in.Err != nil {\n}\n",
+			problem: `target.T.Err: DeepCopy of "error" is unsupported: it has no DeepCopyerror method. Instead, tag the field with +gengo:deepcopy:interface=share|reflect|registry, or add the method to the interface.`,
+		},
+		{
+			member:  types.Member{Name: "When", Type: foreign},
+			problem: "target.T.When: time.Time has no DeepCopyInto method: it is not generated, so it must be written by hand",
+		},
+		{
+			member:  types.Member{Name: "Anon", Type: anonymous},
+			problem: "target.T.Anon: an anonymous struct holding references cannot be deep-copied: declare it as a named type",
+		},
+		{
+			member:  types.Member{Name: "Untagged", Type: untagged},
+			problem: "target.T.Untagged: target.Untagged has no DeepCopyInto method: tag it with +gengo:deepcopy=true",
+		},
+	}
+
+	defer func(saved *problemList) { problems = saved }(problems)
+	for i, tc := range testCases {
+		problems = &problemList{}
+		g := NewGenDeepCopy("", "target", []string{"target"}, tc.allTypes, false, false, "").(*genDeepCopy)
+		typ := &types.Type{
+			Name:    types.Name{Package: "target", Name: "T"},
+			Kind:    types.Struct,
+			Members: []types.Member{tc.member},
+		}
+		var buf bytes.Buffer
+		sw := generator.NewSnippetWriter(&buf, &generator.Context{}, "$", "$")
+		g.doStruct(typ, sw)
+		if err := sw.Error(); err != nil {
+			t.Fatalf("case[%d]: %v", i, err)
+		}
+		if r := buf.String(); r != "*out = *in\n"+tc.expect {
+			t.Errorf("case[%d]: expected %q, got %q", i, "*out = *in\n"+tc.expect, r)
+		}
+		var problem string
+		if r := Problems(); len(r) > 0 {
+			problem = r[0].Error()
+		}
+		if problem != tc.problem {
+			t.Errorf("case[%d]: expected problem %q, got %q", i, tc.problem, problem)
+		}
+	}
+}
@@ -0,0 +1,421 @@
+package generators
+
+import (
+	"fmt"
+	"io"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+
+	"k8s.io/klog"
+)
+
+// This is the comment tag that carries parameters for deep-equal generation.
+const (
+	tagDeepEqualName  = "gengo:deepequal"
+	nilIsEmptyTagName = tagDeepEqualName + ":nil-is-empty" // nil and empty slices and maps are equal
+)
+
+// extractDeepEqualTypeTag returns the value of the deep-equal tag of t, "" if
+// it has none.
+func extractDeepEqualTypeTag(t *types.Type) string {
+	comments := append(append([]string{}, t.SecondClosestCommentLines...), t.CommentLines...)
+	value := extractDeepEqualTag(comments, typeDecl(t))
+	if value != "" && value != "true" && value != "false" {
+		reportf(typeDecl(t), "unsupported %s value: %q", tagDeepEqualName, value)
+		return ""
+	}
+	return value
+}
+
+// extractDeepEqualTag returns the value of the deep-equal tag in comments, ""
+// if they have none. owner is the type or the package the problems are
+// reported against.
+func extractDeepEqualTag(comments []string, owner decl) string {
+	values := types.ExtractCommentTags("+", comments)[tagDeepEqualName]
+	if len(values) == 0 {
+		return ""
+	}
+	if len(values) > 1 {
+		reportf(owner, "found %d %s tags: %q", len(values), tagDeepEqualName, values)
+	}
+	return values[0]
+}
+
+// extractNilIsEmptyTag returns the value of the nil-is-empty tag in comments,
+// "" if they have none. owner is the field, the type or the package the
+// problems are reported against.
+func extractNilIsEmptyTag(comments []string, owner decl) string {
+	values := types.ExtractCommentTags("+", comments)[nilIsEmptyTagName]
+	if len(values) == 0 {
+		return ""
+	}
+	if len(values) > 1 {
+		reportf(owner, "found %d %s tags: %q", len(values), nilIsEmptyTagName, values)
+	}
+	switch values[0] {
+	case "true", "false":
+		return values[0]
+	}
+	reportf(owner, "unsupported %s value: %q", nilIsEmptyTagName, values[0])
+	return ""
+}
+
+// deepEqualMethod returns the signature of a DeepEqual() method, nil or an
+// error if the type does not match. The correct signature for a type T is:
+//
+//	func (t T) DeepEqual(other T) bool
+//
+// or:
+//
+//	func (t *T) DeepEqual(other *T) bool
+func deepEqualMethod(t *types.Type) (*types.Signature, error) {
+	f, found := t.Methods["DeepEqual"]
+	if !found {
+		return nil, nil
+	}
+	if len(f.Signature.Parameters) != 1 {
+		return nil, fmt.Errorf("type %v: invalid DeepEqual signature, expected exactly one parameter", t)
+	}
+	if len(f.Signature.Results) != 1 || f.Signature.Results[0].Name != types.Bool.Name {
+		return nil, fmt.Errorf("type %v: invalid DeepEqual signature, expected a bool result", t)
+	}
+
+	param := f.Signature.Parameters[0]
+	ptrParam := param.Kind == types.Pointer && param.Elem.Name == t.Name
+	nonPtrParam := param.Name == t.Name
+
+	ptrRcvr := f.Signature.Receiver != nil && f.Signature.Receiver.Kind == types.Pointer && f.Signature.Receiver.Elem.Name == t.Name
+	nonPtrRcvr := f.Signature.Receiver != nil && f.Signature.Receiver.Name == t.Name
+
+	if ptrRcvr && !ptrParam {
+		return nil, fmt.Errorf("type %v: invalid DeepEqual signature, expected a *%s parameter for a *%s receiver", t, t.Name.Name, t.Name.Name)
+	}
+	if nonPtrRcvr && !nonPtrParam {
+		return nil, fmt.Errorf("type %v: invalid DeepEqual signature, expected a %s parameter for a %s receiver", t, t.Name.Name, t.Name.Name)
+	}
+
+	return f.Signature, nil
+}
+
+// deepEqualMethodOrDie returns the signature of a DeepEqual method, or nil and
+// reports a problem if the type does not match.
+func deepEqualMethodOrDie(t *types.Type) *types.Signature {
+	ret, err := deepEqualMethod(t)
+	if err != nil {
+		reportf(typeDecl(t), "%v", err)
+	}
+	return ret
+}
+
+// genDeepEqual produces autogenerated deep-equal functions, in the file of
+// the deep-copy functions.
+type genDeepEqual struct {
+	generator.DefaultGen
+	targetPackage string
+	allTypes      bool
+	pkgNilIsEmpty string // the nil-is-empty tag of the package, "" if untagged
+	imports       namer.ImportTracker
+
+	// typeNilIsEmpty is the nil-is-empty tag of the type being generated.
+	typeNilIsEmpty string
+	// member is the field of the struct being compared, while generating the
+	// code comparing its value.
+	member *types.Member
+	// path names the value being compared, e.g. T.Field, in the problems
+	// about it.
+	path string
+	// graph is true while generating the DeepEqualGraph method of a type
+	// tagged with graphTagName.
+	graph bool
+}
+
+func NewGenDeepEqual(sanitizedName, targetPackage string, allTypes bool, nilIsEmpty string) generator.Generator {
+	return &genDeepEqual{
+		DefaultGen: generator.DefaultGen{
+			OptionalName: sanitizedName,
+		},
+		targetPackage: targetPackage,
+		allTypes:      allTypes,
+		pkgNilIsEmpty: nilIsEmpty,
+		imports:       generator.NewImportTracker(),
+	}
+}
+
+func (g *genDeepEqual) Namers(c *generator.Context) namer.NameSystems {
+	// Have the raw namer for this file track what it imports.
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.targetPackage, g.imports),
+	}
+}
+
+func (g *genDeepEqual) Filter(c *generator.Context, t *types.Type) bool {
+	if !g.generates(t) {
+		return false
+	}
+	klog.V(4).Infof("Type %v is compared", t)
+	return true
+}
+
+// generates returns true if a DeepEqual method is generated for t.
+func (g *genDeepEqual) generates(t *types.Type) bool {
+	return t.Name.Package == g.targetPackage && comparedType(t, g.allTypes, extractDeepEqualTypeTag(t))
+}
+
+// comparedType returns true if t, a type of the package being generated whose
+// deep-equal or diff tag is tag, gets the generated comparison method. allTypes
+// is true if the package is tagged.
+func comparedType(t *types.Type, allTypes bool, tag string) bool {
+	if tag == "false" || tag != "true" && !allTypes {
+		return false
+	}
+	// Filter out private types, unless they opt in.
+	if namer.IsPrivateGoName(t.Name.Name) && tag != "true" {
+		return false
+	}
+	if t.Kind == types.Alias {
+		// Aliases of builtins are compared with ==.
+		return underlyingType(t).Kind != types.Builtin
+	}
+	// The parser gives named arrays the kind of their underlying type.
+	return t.Kind == types.Struct || t.Kind == types.Array
+}
+
+// isGraphType returns true if t is a struct of the package being generated
+// tagged with graphTagName, which has a generated DeepEqualGraph method.
+func (g *genDeepEqual) isGraphType(t *types.Type) bool {
+	return t.Kind == types.Struct && isGraphTagged(t, g.targetPackage) && g.generates(t) && deepEqualMethodOrDie(t) == nil
+}
+
+// owner returns the type or the field being compared, which the problems found
+// while generating its code are reported against.
+func (g *genDeepEqual) owner() decl {
+	return decl{pkg: g.targetPackage, path: g.path}
+}
+
+func (g *genDeepEqual) Imports(c *generator.Context) (imports []string) {
+	return g.imports.ImportLines()
+}
+
+func (g *genDeepEqual) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	if deepEqualMethodOrDie(t) != nil {
+		// The type has a hand-written DeepEqual.
+		return nil
+	}
+	klog.V(5).Infof("Generating deepequal function for type %v", t)
+
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+	args := argsFromType(t)
+	comments := append(append([]string{}, t.SecondClosestCommentLines...), t.CommentLines...)
+	g.path = t.Name.Name
+	g.typeNilIsEmpty = extractNilIsEmptyTag(comments, typeDecl(t))
+
+	if g.isGraphType(t) {
+		visitedType := &types.Type{Name: types.Name{Package: runtimePackage, Name: "Visited"}}
+		g.imports.AddType(visitedType)
+		args["visited"] = visitedType
+		args["runtime"] = g.imports.LocalNameOf(runtimePackage)
+		sw.Do("// DeepEqual is an autogenerated deepequal function, reporting whether the receiver and other are deeply equal.\n", args)
+		sw.Do("// The pairs of pointers reached more than once are compared once, so cyclic graphs are compared.\n", args)
+		sw.Do("func (in *$.type|raw$) DeepEqual(other *$.type|raw$) bool {\n", args)
+		sw.Do("if in == other {\nreturn true\n}\n", nil)
+		sw.Do("if in == nil || other == nil {\nreturn false\n}\n", nil)
+		sw.Do("visited := $.runtime$.NewVisited()\n", args)
+		sw.Do("visited.Visit(in, other)\n", nil)
+		sw.Do("return in.DeepEqualGraph(other, visited)\n", nil)
+		sw.Do("}\n\n", nil)
+
+		sw.Do("// DeepEqualGraph is an autogenerated deepequal function, reporting whether the receiver and other are deeply equal.\n", args)
+		sw.Do("// The pairs of pointers compared are recorded in visited, and the pairs already recorded there are not compared again.\n", args)
+		sw.Do("func (in *$.type|raw$) DeepEqualGraph(other *$.type|raw$, visited *$.visited|raw$) bool {\n", args)
+		g.graph = true
+		g.doStruct(t, sw)
+		g.graph = false
+		sw.Do("return true\n", nil)
+		sw.Do("}\n\n", nil)
+		return sw.Error()
+	}
+
+	sw.Do("// DeepEqual is an autogenerated deepequal function, reporting whether the receiver and other are deeply equal.\n", args)
+	if isReference(t) {
+		sw.Do("func (in $.type|raw$) DeepEqual(other $.type|raw$) bool {\n", args)
+		sw.Do("{in, other := &in, &other\n", nil)
+		g.equalFor(t, sw)
+		sw.Do("}\n", nil)
+	} else {
+		sw.Do("func (in *$.type|raw$) DeepEqual(other *$.type|raw$) bool {\n", args)
+		sw.Do("if in == other {\nreturn true\n}\n", nil)
+		sw.Do("if in == nil || other == nil {\nreturn false\n}\n", nil)
+		if t.Kind == types.Struct {
+			g.doStruct(t, sw)
+		} else {
+			g.equalFor(t, sw)
+		}
+	}
+	sw.Do("return true\n", nil)
+	sw.Do("}\n\n", nil)
+
+	return sw.Error()
+}
+
+// nilIsEmpty returns true if the nil and the empty slices and maps of the value
+// being compared are equal: with the nil-is-empty tag of the field being
+// compared, else of the type, else of the package.
+func (g *genDeepEqual) nilIsEmpty() bool {
+	if g.member != nil {
+		if v := extractNilIsEmptyTag(g.member.CommentLines, g.owner()); v != "" {
+			return v == "true"
+		}
+	}
+	if g.typeNilIsEmpty != "" {
+		return g.typeNilIsEmpty == "true"
+	}
+	return g.pkgNilIsEmpty == "true"
+}
+
+// Like the deep-copy functions, the generated code shadows 'in' and 'other',
+// pointers to the values being compared, so that the same code is valid at any
+// nesting level. It returns false as soon as the values differ.
+func (g *genDeepEqual) equalFor(t *types.Type, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+
+	if dem := deepEqualMethodOrDie(t); dem != nil {
+		if dem.Receiver.Kind == types.Pointer {
+			sw.Do("if !in.DeepEqual(other) {\nreturn false\n}\n", nil)
+		} else {
+			sw.Do("if !in.DeepEqual(*other) {\nreturn false\n}\n", nil)
+		}
+		return
+	}
+	if isShallow(t) {
+		sw.Do("if *in != *other {\nreturn false\n}\n", nil)
+		return
+	}
+
+	switch ut.Kind {
+	case types.Map:
+		g.doMap(t, sw)
+	case types.Slice:
+		g.doSlice(t, sw)
+	case types.Array:
+		sw.Do("for i := range *in {\n", nil)
+		g.doElement(ut.Elem, sw)
+		sw.Do("}\n", nil)
+	case types.Pointer:
+		sw.Do("if (*in == nil) != (*other == nil) {\nreturn false\n}\n", nil)
+		if g.graph {
+			// The pairs of pointers reached again are being compared.
+			sw.Do("if *in != nil && !visited.Visit(*in, *other) {\n", nil)
+		} else {
+			sw.Do("if *in != nil {\n", nil)
+		}
+		sw.Do("in, other := *in, *other\n", nil)
+		g.equalFor(ut.Elem, sw)
+		sw.Do("}\n", nil)
+	case types.Struct:
+		if g.graph && g.isGraphType(t) {
+			sw.Do("if !in.DeepEqualGraph(other, visited) {\nreturn false\n}\n", nil)
+		} else if g.generates(t) {
+			sw.Do("if !in.DeepEqual(other) {\nreturn false\n}\n", nil)
+		} else {
+			// Unnamed structs, and the structs of other packages with no
+			// DeepEqual method.
+			g.doReflect(sw)
+		}
+	case types.Interface:
+		// The dynamic type of the values is not known.
+		g.doReflect(sw)
+	case types.Chan:
+		sw.Do("if *in != *other {\nreturn false\n}\n", nil)
+	case types.Func:
+		// Like reflect.DeepEqual, functions are only equal if both are nil.
+		sw.Do("if *in != nil || *other != nil {\nreturn false\n}\n", nil)
+	default:
+		reportf(g.owner(), "hit an unsupported type %v.", t)
+	}
+}
+
+// doReflect generates code comparing the values with reflect.DeepEqual.
+func (g *genDeepEqual) doReflect(sw *generator.SnippetWriter) {
+	deepEqual := &types.Type{Name: types.Name{Package: "reflect", Name: "DeepEqual"}}
+	g.imports.AddType(deepEqual)
+	sw.Do("if !$.reflect$.DeepEqual(*in, *other) {\nreturn false\n}\n", generator.Args{
+		"reflect": g.imports.LocalNameOf("reflect"),
+	})
+}
+
+// doLen generates code comparing the lengths of the slices or maps, and
+// whether they are nil unless nil and empty values are equal.
+func (g *genDeepEqual) doLen(sw *generator.SnippetWriter) {
+	if !g.nilIsEmpty() {
+		sw.Do("if (*in == nil) != (*other == nil) {\nreturn false\n}\n", nil)
+	}
+	sw.Do("if len(*in) != len(*other) {\nreturn false\n}\n", nil)
+}
+
+// doMap generates code comparing maps, or aliases to maps.
+func (g *genDeepEqual) doMap(t *types.Type, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+
+	g.doLen(sw)
+	sw.Do("for key, val := range *in {\n", nil)
+	sw.Do("otherVal, ok := (*other)[key]\n", nil)
+	sw.Do("if !ok {\nreturn false\n}\n", nil)
+	if isShallow(ut.Elem) {
+		sw.Do("if val != otherVal {\nreturn false\n}\n", nil)
+	} else {
+		sw.Do("{\n", nil)
+		sw.Do("in, other := &val, &otherVal\n", nil)
+		g.equalFor(ut.Elem, sw)
+		sw.Do("}\n", nil)
+	}
+	sw.Do("}\n", nil)
+}
+
+// doSlice generates code comparing slices, or aliases to slices.
+func (g *genDeepEqual) doSlice(t *types.Type, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+
+	g.doLen(sw)
+	sw.Do("for i := range *in {\n", nil)
+	g.doElement(ut.Elem, sw)
+	sw.Do("}\n", nil)
+}
+
+// doElement generates code comparing the elements at index i, of type t, of
+// slices or arrays.
+func (g *genDeepEqual) doElement(t *types.Type, sw *generator.SnippetWriter) {
+	if isShallow(t) {
+		sw.Do("if (*in)[i] != (*other)[i] {\nreturn false\n}\n", nil)
+		return
+	}
+	sw.Do("{\n", nil)
+	sw.Do("in, other := &(*in)[i], &(*other)[i]\n", nil)
+	g.equalFor(t, sw)
+	sw.Do("}\n", nil)
+}
+
+// doStruct generates code comparing the fields of structs.
+func (g *genDeepEqual) doStruct(t *types.Type, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+
+	for i := range ut.Members {
+		m := &ut.Members[i]
+		g.member = m
+		g.path = t.Name.Name + "." + m.Name
+		args := generator.Args{
+			"name": m.Name,
+		}
+		if isShallow(m.Type) {
+			sw.Do("if in.$.name$ != other.$.name$ {\nreturn false\n}\n", args)
+			continue
+		}
+		sw.Do("{\n", nil)
+		sw.Do("in, other := &in.$.name$, &other.$.name$\n", args)
+		g.equalFor(m.Type, sw)
+		sw.Do("}\n", nil)
+	}
+	g.member = nil
+	g.path = t.Name.Name
+}
@@ -0,0 +1,173 @@
+package generators
+
+import (
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+func Test_deepEqualMethod(t *testing.T) {
+	typename := types.Name{Package: "pkgname", Name: "typename"}
+	ptr := &types.Type{Kind: types.Pointer, Elem: &types.Type{Kind: types.Struct, Name: typename}}
+	value := &types.Type{Kind: types.Struct, Name: typename}
+	method := func(receiver *types.Type, params []*types.Type, results []*types.Type) map[string]*types.Type {
+		return map[string]*types.Type{
+			"DeepEqual": {
+				Kind: types.Func,
+				Signature: &types.Signature{
+					Receiver:   receiver,
+					Parameters: params,
+					Results:    results,
+				},
+			},
+		}
+	}
+	testCases := []struct {
+		methods map[string]*types.Type
+		expect  bool
+		error   bool
+	}{
+		{
+			// No DeepEqual method.
+			methods: map[string]*types.Type{},
+			expect:  false,
+		},
+		{
+			methods: method(ptr, []*types.Type{ptr}, []*types.Type{types.Bool}),
+			expect:  true,
+		},
+		{
+			methods: method(value, []*types.Type{value}, []*types.Type{types.Bool}),
+			expect:  true,
+		},
+		{
+			// Wrong signature (pointer receiver, value parameter).
+			methods: method(ptr, []*types.Type{value}, []*types.Type{types.Bool}),
+			error:   true,
+		},
+		{
+			// Wrong signature (no parameter).
+			methods: method(ptr, []*types.Type{}, []*types.Type{types.Bool}),
+			error:   true,
+		},
+		{
+			// Wrong signature (int result).
+			methods: method(ptr, []*types.Type{ptr}, []*types.Type{types.Int}),
+			error:   true,
+		},
+	}
+
+	for i, tc := range testCases {
+		r, err := deepEqualMethod(&types.Type{Name: typename, Kind: types.Struct, Methods: tc.methods})
+		if tc.error && err == nil {
+			t.Errorf("case[%d]: expected an error, got none", i)
+		} else if !tc.error && err != nil {
+			t.Errorf("case[%d]: expected no error, got: %v", i, err)
+		} else if !tc.error && (r != nil) != tc.expect {
+			t.Errorf("case[%d]: expected result %v, got: %v", i, tc.expect, r)
+		}
+	}
+}
+
+func Test_generatesDeepEqual(t *testing.T) {
+	testCases := []struct {
+		allTypes bool
+		t        *types.Type
+		expect   bool
+	}{
+		{
+			t:      &types.Type{Name: types.Name{Package: "target", Name: "T"}, Kind: types.Struct},
+			expect: false,
+		},
+		{
+			allTypes: true,
+			t:        &types.Type{Name: types.Name{Package: "target", Name: "T"}, Kind: types.Struct},
+			expect:   true,
+		},
+		{
+			t: &types.Type{Name: types.Name{Package: "target", Name: "T"}, Kind: types.Struct,
+				CommentLines: []string{"+gengo:deepequal=true"}},
+			expect: true,
+		},
+		{
+			allTypes: true,
+			t: &types.Type{Name: types.Name{Package: "target", Name: "T"}, Kind: types.Struct,
+				CommentLines: []string{"+gengo:deepequal=false"}},
+			expect: false,
+		},
+		{
+			// Only the types of the package being generated.
+			allTypes: true,
+			t:        &types.Type{Name: types.Name{Package: "other", Name: "T"}, Kind: types.Struct},
+			expect:   false,
+		},
+		{
+			allTypes: true,
+			t:        &types.Type{Name: types.Name{Package: "target", Name: "t"}, Kind: types.Struct},
+			expect:   false,
+		},
+		{
+			t: &types.Type{Name: types.Name{Package: "target", Name: "t"}, Kind: types.Struct,
+				CommentLines: []string{"+gengo:deepequal=true"}},
+			expect: true,
+		},
+		{
+			allTypes: true,
+			t:        &types.Type{Name: types.Name{Package: "target", Name: "Level"}, Kind: types.Alias, Underlying: types.Int},
+			expect:   false,
+		},
+		{
+			allTypes: true,
+			t: &types.Type{Name: types.Name{Package: "target", Name: "Labels"}, Kind: types.Alias,
+				Underlying: &types.Type{Name: types.Name{Name: "map[string]string"}, Kind: types.Map, Key: types.String, Elem: types.String}},
+			expect: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		g := NewGenDeepEqual("", "target", tc.allTypes, "").(*genDeepEqual)
+		if r := g.generates(tc.t); r != tc.expect {
+			t.Errorf("case[%d]: expected %t, got %t", i, tc.expect, r)
+		}
+	}
+}
+
+func Test_nilIsEmpty(t *testing.T) {
+	testCases := []struct {
+		pkg, typ string
+		comments []string
+		expect   bool
+	}{
+		{
+			expect: false,
+		},
+		{
+			pkg:    "true",
+			expect: true,
+		},
+		{
+			pkg:    "true",
+			typ:    "false",
+			expect: false,
+		},
+		{
+			typ:      "false",
+			comments: []string{"+gengo:deepequal:nil-is-empty=true"},
+			expect:   true,
+		},
+		{
+			pkg:      "true",
+			comments: []string{"+gengo:deepequal:nil-is-empty=false"},
+			expect:   false,
+		},
+	}
+
+	for i, tc := range testCases {
+		g := NewGenDeepEqual("", "target", true, tc.pkg).(*genDeepEqual)
+		g.typeNilIsEmpty = tc.typ
+		g.member = &types.Member{Name: "Field", CommentLines: tc.comments}
+		if r := g.nilIsEmpty(); r != tc.expect {
+			t.Errorf("case[%d]: expected %t, got %t", i, tc.expect, r)
+		}
+	}
+}
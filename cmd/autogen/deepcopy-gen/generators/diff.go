@@ -0,0 +1,391 @@
+package generators
+
+import (
+	"io"
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+
+	"k8s.io/klog"
+)
+
+// This is the comment tag that carries parameters for diff generation.
+const tagDiffName = "gengo:diff"
+
+// extractDiffTypeTag returns the value of the diff tag of t, "" if it has
+// none.
+func extractDiffTypeTag(t *types.Type) string {
+	comments := append(append([]string{}, t.SecondClosestCommentLines...), t.CommentLines...)
+	value := extractDiffTag(comments, typeDecl(t))
+	if value != "" && value != "true" && value != "false" {
+		reportf(typeDecl(t), "unsupported %s value: %q", tagDiffName, value)
+		return ""
+	}
+	return value
+}
+
+// extractDiffTag returns the value of the diff tag in comments, "" if they
+// have none. owner is the type or the package the problems are reported
+// against.
+func extractDiffTag(comments []string, owner decl) string {
+	values := types.ExtractCommentTags("+", comments)[tagDiffName]
+	if len(values) == 0 {
+		return ""
+	}
+	if len(values) > 1 {
+		reportf(owner, "found %d %s tags: %q", len(values), tagDiffName, values)
+	}
+	return values[0]
+}
+
+// genDiff produces autogenerated diff functions, in the file of the deep-copy
+// functions. The values a Diff method reports no change for are equal for the
+// DeepEqual method: the nil-is-empty tags apply to both.
+type genDiff struct {
+	generator.DefaultGen
+	targetPackage string
+	allTypes      bool
+	pkgNilIsEmpty string // the nil-is-empty tag of the package, "" if untagged
+	imports       namer.ImportTracker
+
+	// runtime is the local name of the runtime package in the generated file.
+	runtime string
+	// typeNilIsEmpty is the nil-is-empty tag of the type being generated.
+	typeNilIsEmpty string
+	// member is the field of the struct being compared, while generating the
+	// code comparing its value.
+	member *types.Member
+	// path names the value being compared, e.g. T.Field, in the problems
+	// about it.
+	path string
+	// graph is true while generating the AppendDiffGraph method of a type
+	// tagged with graphTagName.
+	graph bool
+}
+
+func NewGenDiff(sanitizedName, targetPackage string, allTypes bool, nilIsEmpty string) generator.Generator {
+	return &genDiff{
+		DefaultGen: generator.DefaultGen{
+			OptionalName: sanitizedName,
+		},
+		targetPackage: targetPackage,
+		allTypes:      allTypes,
+		pkgNilIsEmpty: nilIsEmpty,
+		imports:       generator.NewImportTracker(),
+	}
+}
+
+func (g *genDiff) Namers(c *generator.Context) namer.NameSystems {
+	// Have the raw namer for this file track what it imports.
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.targetPackage, g.imports),
+	}
+}
+
+func (g *genDiff) Filter(c *generator.Context, t *types.Type) bool {
+	if !g.generates(t) {
+		return false
+	}
+	klog.V(4).Infof("Type %v is diffed", t)
+	return true
+}
+
+// generates returns true if Diff and AppendDiff methods are generated for t.
+func (g *genDiff) generates(t *types.Type) bool {
+	return t.Name.Package == g.targetPackage && comparedType(t, g.allTypes, extractDiffTypeTag(t))
+}
+
+// isGraphType returns true if t is a struct of the package being generated
+// tagged with graphTagName, which has a generated AppendDiffGraph method.
+func (g *genDiff) isGraphType(t *types.Type) bool {
+	return t.Kind == types.Struct && isGraphTagged(t, g.targetPackage) && g.generates(t)
+}
+
+// owner returns the type or the field being compared, which the problems found
+// while generating its code are reported against.
+func (g *genDiff) owner() decl {
+	return decl{pkg: g.targetPackage, path: g.path}
+}
+
+func (g *genDiff) Imports(c *generator.Context) (imports []string) {
+	return g.imports.ImportLines()
+}
+
+func (g *genDiff) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	klog.V(5).Infof("Generating diff function for type %v", t)
+
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+	fieldChange := &types.Type{Name: types.Name{Package: runtimePackage, Name: "FieldChange"}}
+	g.imports.AddType(fieldChange)
+	g.runtime = g.imports.LocalNameOf(runtimePackage)
+	args := argsFromType(t)
+	args["runtime"] = g.runtime
+	comments := append(append([]string{}, t.SecondClosestCommentLines...), t.CommentLines...)
+	g.path = t.Name.Name
+	g.typeNilIsEmpty = extractNilIsEmptyTag(comments, typeDecl(t))
+
+	receiver := "*$.type|raw$"
+	if isReference(t) {
+		receiver = "$.type|raw$"
+	}
+	sw.Do("// Diff is an autogenerated diff function, returning the changes from the receiver to other.\n", args)
+	sw.Do("func (in "+receiver+") Diff(other "+receiver+") []$.runtime$.FieldChange {\n", args)
+	sw.Do("return in.AppendDiff(nil, \"\", other)\n", nil)
+	sw.Do("}\n\n", nil)
+
+	sw.Do("// AppendDiff is an autogenerated diff function, appending the changes from the receiver to other to changes.\n", args)
+	sw.Do("// The paths of the changes start with path.\n", args)
+	sw.Do("func (in "+receiver+") AppendDiff(changes []$.runtime$.FieldChange, path string, other "+receiver+") []$.runtime$.FieldChange {\n", args)
+	if isReference(t) {
+		sw.Do("{in, other := &in, &other\n", nil)
+		g.diffFor(t, sw)
+		sw.Do("}\n", nil)
+	} else {
+		sw.Do("if in == other {\nreturn changes\n}\n", nil)
+		sw.Do("if in == nil || other == nil {\n", nil)
+		g.doChange("path", "in", "other", sw)
+		sw.Do("return changes\n", nil)
+		sw.Do("}\n", nil)
+		if g.isGraphType(t) {
+			visitedType := &types.Type{Name: types.Name{Package: runtimePackage, Name: "Visited"}}
+			g.imports.AddType(visitedType)
+			args["visited"] = visitedType
+			sw.Do("visited := $.runtime$.NewVisited()\n", args)
+			sw.Do("visited.Visit(in, other)\n", nil)
+			sw.Do("return in.AppendDiffGraph(changes, path, other, visited)\n", nil)
+			sw.Do("}\n\n", nil)
+
+			sw.Do("// AppendDiffGraph is an autogenerated diff function, appending the changes from the receiver to other to changes.\n", args)
+			sw.Do("// The pairs of pointers diffed are recorded in visited, and the pairs already recorded there are not diffed again:\n", args)
+			sw.Do("// the changes reached through several paths are reported at the first one.\n", args)
+			sw.Do("func (in *$.type|raw$) AppendDiffGraph(changes []$.runtime$.FieldChange, path string, other *$.type|raw$, visited *$.visited|raw$) []$.runtime$.FieldChange {\n", args)
+			g.graph = true
+			g.doStruct(t, sw)
+			g.graph = false
+		} else if t.Kind == types.Struct {
+			g.doStruct(t, sw)
+		} else {
+			g.diffFor(t, sw)
+		}
+	}
+	sw.Do("return changes\n", nil)
+	sw.Do("}\n\n", nil)
+
+	return sw.Error()
+}
+
+// nilIsEmpty returns true if the nil and the empty slices and maps of the value
+// being compared are equal: with the nil-is-empty tag of the field being
+// compared, else of the type, else of the package.
+func (g *genDiff) nilIsEmpty() bool {
+	if g.member != nil {
+		if v := extractNilIsEmptyTag(g.member.CommentLines, g.owner()); v != "" {
+			return v == "true"
+		}
+	}
+	if g.typeNilIsEmpty != "" {
+		return g.typeNilIsEmpty == "true"
+	}
+	return g.pkgNilIsEmpty == "true"
+}
+
+// doChange generates code appending the change at path from old to new.
+func (g *genDiff) doChange(path, old, new string, sw *generator.SnippetWriter) {
+	sw.Do("changes = append(changes, $.runtime$.FieldChange{Path: $.path$, Old: $.old$, New: $.new$})\n", generator.Args{
+		"runtime": g.runtime,
+		"path":    path,
+		"old":     old,
+		"new":     new,
+	})
+}
+
+// Like the deep-copy functions, the generated code shadows 'in' and 'other',
+// pointers to the values being compared, and 'path', their path, so that the
+// same code is valid at any nesting level. It appends the changes to 'changes'.
+func (g *genDiff) diffFor(t *types.Type, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+
+	dem := deepEqualMethodOrDie(t)
+	switch {
+	case g.graph && g.isGraphType(t):
+		sw.Do("changes = in.AppendDiffGraph(changes, path, other, visited)\n", nil)
+		return
+	case ut.Kind == types.Struct && g.generates(t):
+		sw.Do("changes = in.AppendDiff(changes, path, other)\n", nil)
+		return
+	case dem != nil:
+		if dem.Receiver.Kind == types.Pointer {
+			sw.Do("if !in.DeepEqual(other) {\n", nil)
+		} else {
+			sw.Do("if !in.DeepEqual(*other) {\n", nil)
+		}
+		g.doChange("path", "*in", "*other", sw)
+		sw.Do("}\n", nil)
+		return
+	case isShallow(t) && ut.Kind != types.Array:
+		sw.Do("if *in != *other {\n", nil)
+		g.doChange("path", "*in", "*other", sw)
+		sw.Do("}\n", nil)
+		return
+	}
+
+	switch ut.Kind {
+	case types.Map:
+		g.doMap(t, sw)
+	case types.Slice:
+		g.doSlice(t, sw)
+	case types.Array:
+		sw.Do("for i := range *in {\n", nil)
+		g.doElement(ut.Elem, sw)
+		sw.Do("}\n", nil)
+	case types.Pointer:
+		sw.Do("if *in == nil || *other == nil {\n", nil)
+		sw.Do("if *in != *other {\n", nil)
+		g.doChange("path", "*in", "*other", sw)
+		sw.Do("}\n", nil)
+		if g.graph {
+			// The pairs of pointers reached again are being diffed.
+			sw.Do("} else if !visited.Visit(*in, *other) {\n", nil)
+		} else {
+			sw.Do("} else {\n", nil)
+		}
+		sw.Do("in, other := *in, *other\n", nil)
+		g.diffFor(ut.Elem, sw)
+		sw.Do("}\n", nil)
+	case types.Struct, types.Interface:
+		// Unnamed structs, the structs of other packages with no DeepEqual
+		// method, and the values of interfaces, whose dynamic type is not
+		// known.
+		deepEqual := &types.Type{Name: types.Name{Package: "reflect", Name: "DeepEqual"}}
+		g.imports.AddType(deepEqual)
+		sw.Do("if !$.reflect$.DeepEqual(*in, *other) {\n", generator.Args{
+			"reflect": g.imports.LocalNameOf("reflect"),
+		})
+		g.doChange("path", "*in", "*other", sw)
+		sw.Do("}\n", nil)
+	case types.Chan:
+		sw.Do("if *in != *other {\n", nil)
+		g.doChange("path", "*in", "*other", sw)
+		sw.Do("}\n", nil)
+	case types.Func:
+		// Like reflect.DeepEqual, functions are only equal if both are nil.
+		sw.Do("if *in != nil || *other != nil {\n", nil)
+		g.doChange("path", "*in", "*other", sw)
+		sw.Do("}\n", nil)
+	default:
+		reportf(g.owner(), "hit an unsupported type %v.", t)
+	}
+}
+
+// doNilEmpty generates code appending a change if one of the slices or maps
+// is nil and the other is empty, unless nil and empty values are equal.
+func (g *genDiff) doNilEmpty(sw *generator.SnippetWriter) {
+	if g.nilIsEmpty() {
+		return
+	}
+	sw.Do("if len(*in) == 0 && len(*other) == 0 && (*in == nil) != (*other == nil) {\n", nil)
+	g.doChange("path", "*in", "*other", sw)
+	sw.Do("}\n", nil)
+}
+
+// doMap generates code diffing maps, or aliases to maps. The entries are
+// diffed in the order of their keys, or of the paths of their keys if the keys
+// are not ordered, e.g. structs, so that the changes are in the same order
+// each time.
+func (g *genDiff) doMap(t *types.Type, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	args := generator.Args{
+		"key": ut.Key,
+	}
+
+	g.doNilEmpty(sw)
+	sw.Do("keys := make([]$.key|raw$, 0, len(*in)+len(*other))\n", args)
+	sw.Do("for key := range *in {\n", nil)
+	sw.Do("keys = append(keys, key)\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("for key := range *other {\n", nil)
+	sw.Do("if _, ok := (*in)[key]; !ok {\n", nil)
+	sw.Do("keys = append(keys, key)\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("}\n", nil)
+	sortSlice := &types.Type{Name: types.Name{Package: "sort", Name: "Slice"}}
+	g.imports.AddType(sortSlice)
+	args["sort"] = g.imports.LocalNameOf("sort")
+	args["runtime"] = g.runtime
+	if isOrdered(ut.Key) {
+		sw.Do("$.sort$.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })\n", args)
+	} else {
+		sw.Do("$.sort$.Slice(keys, func(i, j int) bool { return $.runtime$.KeyLess(keys[i], keys[j]) })\n", args)
+	}
+	sw.Do("for _, key := range keys {\n", nil)
+	sw.Do("val, inOK := (*in)[key]\n", nil)
+	sw.Do("otherVal, otherOK := (*other)[key]\n", nil)
+	sw.Do("path := $.runtime$.KeyPath(path, key)\n", generator.Args{"runtime": g.runtime})
+	sw.Do("switch {\n", nil)
+	sw.Do("case !otherOK:\n", nil)
+	g.doChange("path", "val", "nil", sw)
+	sw.Do("case !inOK:\n", nil)
+	g.doChange("path", "nil", "otherVal", sw)
+	sw.Do("default:\n", nil)
+	sw.Do("in, other := &val, &otherVal\n", nil)
+	g.diffFor(ut.Elem, sw)
+	sw.Do("}\n", nil)
+	sw.Do("}\n", nil)
+}
+
+// isOrdered returns true if the values of t can be compared with <.
+func isOrdered(t *types.Type) bool {
+	ut := underlyingType(t)
+	return ut.Kind == types.Builtin && ut.Name.Name != "bool" && !strings.HasPrefix(ut.Name.Name, "complex")
+}
+
+// doSlice generates code diffing slices, or aliases to slices: the elements
+// of both are diffed, then the elements only one has are added or removed.
+func (g *genDiff) doSlice(t *types.Type, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	args := generator.Args{"runtime": g.runtime}
+
+	g.doNilEmpty(sw)
+	sw.Do("for i := range *in {\n", nil)
+	sw.Do("if i >= len(*other) {\n", nil)
+	g.doChange(g.runtime+".IndexPath(path, i)", "(*in)[i]", "nil", sw)
+	sw.Do("continue\n", nil)
+	sw.Do("}\n", nil)
+	g.doElement(ut.Elem, sw)
+	sw.Do("}\n", nil)
+	sw.Do("for i := len(*in); i < len(*other); i++ {\n", args)
+	g.doChange(g.runtime+".IndexPath(path, i)", "nil", "(*other)[i]", sw)
+	sw.Do("}\n", nil)
+}
+
+// doElement generates code diffing the elements at index i, of type t, of
+// slices or arrays.
+func (g *genDiff) doElement(t *types.Type, sw *generator.SnippetWriter) {
+	sw.Do("{\n", nil)
+	sw.Do("in, other, path := &(*in)[i], &(*other)[i], $.runtime$.IndexPath(path, i)\n", generator.Args{"runtime": g.runtime})
+	g.diffFor(t, sw)
+	sw.Do("}\n", nil)
+}
+
+// doStruct generates code diffing the fields of structs.
+func (g *genDiff) doStruct(t *types.Type, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+
+	for i := range ut.Members {
+		m := &ut.Members[i]
+		g.member = m
+		g.path = t.Name.Name + "." + m.Name
+		args := generator.Args{
+			"runtime": g.runtime,
+			"name":    m.Name,
+		}
+		sw.Do("{\n", nil)
+		sw.Do("in, other, path := &in.$.name$, &other.$.name$, $.runtime$.FieldPath(path, \"$.name$\")\n", args)
+		g.diffFor(m.Type, sw)
+		sw.Do("}\n", nil)
+	}
+	g.member = nil
+	g.path = t.Name.Name
+}
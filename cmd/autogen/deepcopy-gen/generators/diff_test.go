@@ -0,0 +1,70 @@
+package generators
+
+import (
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+func Test_isOrdered(t *testing.T) {
+	testCases := []struct {
+		t      *types.Type
+		expect bool
+	}{
+		{t: types.String, expect: true},
+		{t: types.Int, expect: true},
+		{t: types.Float64, expect: true},
+		{t: types.Bool, expect: false},
+		{t: &types.Type{Name: types.Name{Name: "complex128"}, Kind: types.Builtin}, expect: false},
+		{t: &types.Type{Name: types.Name{Package: "target", Name: "Level"}, Kind: types.Alias, Underlying: types.Int}, expect: true},
+		{t: &types.Type{Name: types.Name{Package: "target", Name: "Key"}, Kind: types.Struct}, expect: false},
+	}
+
+	for i, tc := range testCases {
+		if r := isOrdered(tc.t); r != tc.expect {
+			t.Errorf("case[%d]: expected %t, got %t", i, tc.expect, r)
+		}
+	}
+}
+
+func Test_generatesDiff(t *testing.T) {
+	testCases := []struct {
+		allTypes bool
+		comments []string
+		expect   bool
+	}{
+		{
+			expect: false,
+		},
+		{
+			allTypes: true,
+			expect:   true,
+		},
+		{
+			comments: []string{"+gengo:diff=true"},
+			expect:   true,
+		},
+		{
+			// The deep-equal tag does not apply to Diff.
+			comments: []string{"+gengo:deepequal=true"},
+			expect:   false,
+		},
+		{
+			allTypes: true,
+			comments: []string{"+gengo:diff=false"},
+			expect:   false,
+		},
+	}
+
+	for i, tc := range testCases {
+		g := NewGenDiff("", "target", tc.allTypes, "").(*genDiff)
+		typ := &types.Type{
+			Name:         types.Name{Package: "target", Name: "T"},
+			Kind:         types.Struct,
+			CommentLines: tc.comments,
+		}
+		if r := g.generates(typ); r != tc.expect {
+			t.Errorf("case[%d]: expected %t, got %t", i, tc.expect, r)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package generators
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+	"k8s.io/klog"
+)
+
+// decl is a declaration problems are reported against: a package, a type or a
+// field, e.g. T.Field, of a package.
+type decl struct {
+	pkg string
+	// path is the type or the field in pkg, "" for the package itself.
+	path string
+}
+
+func typeDecl(t *types.Type) decl {
+	return decl{pkg: t.Name.Package, path: t.Name.Name}
+}
+
+func (d decl) String() string {
+	if d.path == "" {
+		return "package " + d.pkg
+	}
+	return d.pkg + "." + d.path
+}
+
+// problem is an unsupported type, a bad tag value or a bad method signature
+// found in the input packages.
+type problem struct {
+	pos   token.Position
+	owner decl
+	msg   string
+}
+
+func (p *problem) Error() string {
+	if !p.pos.IsValid() {
+		return fmt.Sprintf("%v: %s", p.owner, p.msg)
+	}
+	return fmt.Sprintf("%v: %v: %s", p.pos, p.owner, p.msg)
+}
+
+// problemList collects the problems found while generating, so that a run
+// reports all of them rather than stopping at the first one.
+type problemList struct {
+	universe types.Universe
+	buildTag string
+	// decls holds the positions of the declarations of each package, by
+	// package path then by path in the package, as found by findDecls.
+	decls    map[string]map[string]token.Position
+	problems []error
+	seen     map[string]bool
+}
+
+// problems holds the problems of the packages being generated.
+var problems = &problemList{}
+
+// reportf records a problem of owner. A problem found again, e.g. each time a
+// type with a bad DeepCopy signature is copied, is recorded once.
+func reportf(owner decl, format string, args ...interface{}) {
+	p := &problem{owner: owner, msg: fmt.Sprintf(format, args...)}
+	key := owner.String() + ": " + p.msg
+	if problems.seen[key] {
+		return
+	}
+	if problems.seen == nil {
+		problems.seen = map[string]bool{}
+	}
+	problems.seen[key] = true
+	p.pos = problems.position(owner)
+	problems.problems = append(problems.problems, p)
+}
+
+// Problems returns the problems found in the input packages by the last run
+// of the generators, in the order they were found.
+func Problems() []error {
+	return problems.problems
+}
+
+// position returns the position of the declaration of owner, or of the
+// closest enclosing declaration found, e.g. of T for a field of an unnamed
+// struct of T.Field. The position is invalid if the package is not known.
+func (l *problemList) position(owner decl) token.Position {
+	decls, found := l.decls[owner.pkg]
+	if !found {
+		if pkg := l.universe[owner.pkg]; pkg != nil {
+			decls = findDecls(pkg, l.buildTag)
+		}
+		if l.decls == nil {
+			l.decls = map[string]map[string]token.Position{}
+		}
+		l.decls[owner.pkg] = decls
+	}
+	path := owner.path
+	for {
+		if pos, found := decls[path]; found {
+			return pos
+		}
+		if path == "" {
+			return token.Position{}
+		}
+		if i := strings.LastIndex(path, "."); i >= 0 {
+			path = path[:i]
+		} else {
+			path = ""
+		}
+	}
+}
+
+// findDecls returns the positions of the package clause, of the types and of
+// the fields of the structs of pkg, by path in the package. gengo does not
+// record positions, so the Go files of the package are parsed again; files
+// carrying buildTag are generated and skipped. The problems are reported
+// without positions if the files cannot be parsed.
+func findDecls(pkg *types.Package, buildTag string) map[string]token.Position {
+	decls := map[string]token.Position{}
+	ctx := build.Default
+	ctx.BuildTags = append(append([]string{}, ctx.BuildTags...), buildTag)
+	bp, err := ctx.ImportDir(pkg.SourcePath, 0)
+	if err != nil {
+		return decls
+	}
+
+	fset := token.NewFileSet()
+	for _, name := range bp.GoFiles {
+		file, err := parser.ParseFile(fset, filepath.Join(bp.Dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return decls
+		}
+		// The package is located by the file documenting it, where its tags
+		// are, else by its first file.
+		if _, found := decls[""]; !found || file.Doc != nil {
+			decls[""] = lineOf(fset, file.Package)
+		}
+		for _, d := range file.Decls {
+			d, ok := d.(*ast.GenDecl)
+			if !ok || d.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range d.Specs {
+				spec := spec.(*ast.TypeSpec)
+				decls[spec.Name.Name] = lineOf(fset, spec.Pos())
+				findFields(fset, spec.Name.Name, spec.Type, decls)
+			}
+		}
+	}
+	return decls
+}
+
+// findFields adds the positions of the fields of typ, if it is a struct, to
+// decls, with their path under path.
+func findFields(fset *token.FileSet, path string, typ ast.Expr, decls map[string]token.Position) {
+	st, ok := typ.(*ast.StructType)
+	if !ok {
+		return
+	}
+	for _, field := range st.Fields.List {
+		names := []string{}
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+		if len(names) == 0 {
+			names = append(names, embeddedName(field.Type))
+		}
+		for _, name := range names {
+			decls[path+"."+name] = lineOf(fset, field.Pos())
+			findFields(fset, path+"."+name, field.Type, decls)
+		}
+	}
+}
+
+// embeddedName returns the name of the embedded field of type typ, e.g. T
+// for *pkg.T.
+func embeddedName(typ ast.Expr) string {
+	switch typ := typ.(type) {
+	case *ast.StarExpr:
+		return embeddedName(typ.X)
+	case *ast.SelectorExpr:
+		return typ.Sel.Name
+	case *ast.Ident:
+		return typ.Name
+	}
+	return ""
+}
+
+// lineOf returns the file:line position of pos.
+func lineOf(fset *token.FileSet, pos token.Pos) token.Position {
+	position := fset.Position(pos)
+	position.Column = 0
+	return position
+}
+
+// findProblems generates packages without writing them, so that the
+// problems of every package are found before any package is written: gengo
+// writes each package as soon as it is generated, which the problems of the
+// packages generated after it could not stop otherwise.
+func findProblems(c *generator.Context, packages generator.Packages) {
+	dir, err := ioutil.TempDir("", "deepcopy-gen")
+	if err != nil {
+		klog.Fatalf("Failed making a directory to check the packages in: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	dryRun := *c
+	dryRun.Verify = true
+	for _, p := range packages {
+		// The errors are those of the problems, which are reported, and
+		// of the files not found in dir, which is empty.
+		dryRun.ExecutePackage(dir, p)
+	}
+}
+
+// problemCheck is the last generator of every package. If problems were
+// found in any package, it fails the package, so that no file is written with the code
+// generated in spite of them.
+type problemCheck struct {
+	generator.DefaultGen
+}
+
+func (g *problemCheck) Finalize(c *generator.Context, w io.Writer) error {
+	if len(problems.problems) > 0 {
+		return fmt.Errorf("not written: %d problems found in the input packages", len(problems.problems))
+	}
+	return nil
+}
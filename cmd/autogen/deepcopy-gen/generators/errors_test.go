@@ -0,0 +1,182 @@
+package generators
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/gengo/args"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/parser"
+	"k8s.io/gengo/types"
+)
+
+func Test_reportf(t *testing.T) {
+	defer func(saved *problemList) { problems = saved }(problems)
+	problems = &problemList{}
+
+	typename := types.Name{Package: "target", Name: "T"}
+	bad := &types.Type{
+		Name: typename,
+		Kind: types.Struct,
+		Methods: map[string]*types.Type{
+			"DeepCopy": {
+				Kind: types.Func,
+				Signature: &types.Signature{
+					Receiver: &types.Type{Kind: types.Pointer, Elem: &types.Type{Kind: types.Struct, Name: typename}},
+					Results:  []*types.Type{types.Int},
+				},
+			},
+		},
+	}
+	// Each problem is collected, instead of stopping at the first one, and
+	// the same problem found again is collected once.
+	deepCopyMethodOrDie(bad)
+	deepCopyMethodOrDie(bad)
+	extractEnabledTag([]string{"+gengo:deepcopy=package,copy"}, decl{pkg: "target"})
+	interfaceStrategy(&types.Member{Name: "Field", CommentLines: []string{"+gengo:deepcopy:interface=copy"}},
+		decl{pkg: "target", path: "T.Field"})
+	extractChanFuncTag([]string{"+gengo:deepcopy:chan-func=copy"}, decl{pkg: "target", path: "T.Func"})
+
+	expect := []string{
+		"target.T: type target.T: invalid DeepCopy signature, expected to return T or *T",
+		`package target: unsupported gengo:deepcopy param: "copy"`,
+		`target.T.Field: unsupported gengo:deepcopy:interface value: "copy"`,
+		`target.T.Func: unsupported gengo:deepcopy:chan-func value: "copy"`,
+	}
+	r := Problems()
+	if len(r) != len(expect) {
+		t.Fatalf("expected %d problems, got %d: %v", len(expect), len(r), r)
+	}
+	for i := range expect {
+		if r[i].Error() != expect[i] {
+			t.Errorf("problem[%d]: expected %q, got %q", i, expect[i], r[i].Error())
+		}
+	}
+}
+
+func Test_position(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deepcopy-gen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"doc.go": "// +gengo:deepcopy=package\n\npackage p\n",
+		"p.go": `package p
+
+type T struct {
+	Name string
+	Sub  struct {
+		Field int
+	}
+	*Embedded
+}
+
+type Embedded struct{}
+`,
+		"zz_generated.go": "// +build !ignore_autogenerated\n\npackage p\n\ntype Generated struct{}\n",
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l := &problemList{
+		universe: types.Universe{"example.com/p": &types.Package{Path: "example.com/p", SourcePath: dir}},
+		buildTag: "ignore_autogenerated",
+	}
+	testCases := []struct {
+		owner  decl
+		expect string
+	}{
+		{
+			owner:  decl{pkg: "example.com/p"},
+			expect: filepath.Join(dir, "doc.go") + ":3",
+		},
+		{
+			owner:  decl{pkg: "example.com/p", path: "T"},
+			expect: filepath.Join(dir, "p.go") + ":3",
+		},
+		{
+			owner:  decl{pkg: "example.com/p", path: "T.Name"},
+			expect: filepath.Join(dir, "p.go") + ":4",
+		},
+		{
+			owner:  decl{pkg: "example.com/p", path: "T.Sub.Field"},
+			expect: filepath.Join(dir, "p.go") + ":6",
+		},
+		{
+			owner:  decl{pkg: "example.com/p", path: "T.Embedded"},
+			expect: filepath.Join(dir, "p.go") + ":8",
+		},
+		{
+			// The closest enclosing declaration.
+			owner:  decl{pkg: "example.com/p", path: "T.Unknown"},
+			expect: filepath.Join(dir, "p.go") + ":3",
+		},
+		{
+			// The generated files are skipped.
+			owner:  decl{pkg: "example.com/p", path: "Generated"},
+			expect: filepath.Join(dir, "doc.go") + ":3",
+		},
+		{
+			owner:  decl{pkg: "example.com/unknown", path: "T"},
+			expect: "-",
+		},
+	}
+
+	for i, tc := range testCases {
+		if r := fmt.Sprint(l.position(tc.owner)); r != tc.expect {
+			t.Errorf("case[%d]: expected %q, got %q", i, tc.expect, r)
+		}
+	}
+}
+
+func TestPackagesWriteNothingWithProblems(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deepcopy-gen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Only the second package has a problem, found after the first
+	// package is generated.
+	b := parser.New()
+	sources := []struct{ pkg, src string }{
+		{"example.com/a", "// +gengo:deepcopy=package\n\npackage a\n\ntype A struct {\n\tP *int\n}\n"},
+		{"example.com/b", "// +gengo:deepcopy=package\n\npackage b\n\ntype B struct {\n\tStop chan int\n}\n"},
+	}
+	for _, s := range sources {
+		if err := b.AddFileForTest(s.pkg, filepath.Join(dir, "src", s.pkg, "doc.go"), []byte(s.src)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c, err := generator.NewContext(b, NameSystems(), DefaultNameSystem())
+	if err != nil {
+		t.Fatal(err)
+	}
+	arguments := &args.GeneratorArgs{
+		OutputBase:         filepath.Join(dir, "out"),
+		OutputFileBaseName: "zz_generated",
+		GeneratedBuildTag:  "ignore_autogenerated",
+		CustomArgs:         &CustomArgs{},
+	}
+
+	defer func(saved *problemList) { problems = saved }(problems)
+	if err := c.ExecutePackages(arguments.OutputBase, Packages(c, arguments)); err == nil {
+		t.Error("expected the packages to fail")
+	}
+	expect := "example.com/b.B.Stop: a chan cannot be deep-copied: tag it with +gengo:deepcopy:chan-func=share|zero"
+	if r := Problems(); len(r) != 1 || r[0].Error() != expect {
+		t.Errorf("expected the problem %q, got %v", expect, r)
+	}
+	for _, s := range sources {
+		if _, err := os.Stat(filepath.Join(arguments.OutputBase, s.pkg, "zz_generated.go")); !os.IsNotExist(err) {
+			t.Errorf("%s: expected no file to be written, got %v", s.pkg, err)
+		}
+	}
+}
@@ -54,6 +54,32 @@ limitations under the License.
 // of the type as receiver. For those special cases where the non-pointer object should
 // implement the interface, this can be done with:
 //   // +k8s:deepcopy-gen:nonpointer-interfaces=true
+//
+// DeepEqual methods, reporting whether two values are deeply equal without
+// reflection, are generated in the same file for the packages or the types
+// tagged with:
+//   // +gengo:deepequal=package
+//   // +gengo:deepequal=true
+// Like reflect.DeepEqual, nil and empty slices and maps are not equal, unless
+// the field, the type or the package is tagged with:
+//   // +gengo:deepequal:nil-is-empty=true
+// The structs tagged +gengo:deepcopy:graph=true compare each pair of pointers
+// once, so their comparisons end on cycles.
+//
+// Diff methods, returning the changes between two values with the paths of
+// the fields changed, e.g. Spec.Containers[2].Image, are generated in the same
+// file for the packages or the types tagged with:
+//   // +gengo:diff=package
+//   // +gengo:diff=true
+// A Diff method reports no change for the values its DeepEqual method finds
+// equal. The structs tagged +gengo:deepcopy:graph=true diff each pair of
+// pointers once.
+//
+// The unsupported types, the bad tag values and the bad method signatures
+// found are reported together, with the position of the type or the field at
+// fault, e.g.:
+//   /go/src/example.com/model/model.go:42: example.com/model.T.Field: unsupported gengo:deepcopy:interface value: "copy"
+// and no file is written for the packages being generated then.
 package main
 
 import (
@@ -78,11 +104,20 @@ func main() {
 	arguments.CustomArgs = customArgs
 
 	// Run it.
-	if err := arguments.Execute(
+	err := arguments.Execute(
 		generators.NameSystems(),
 		generators.DefaultNameSystem(),
 		generators.Packages,
-	); err != nil {
+	)
+	// The packages with problems are not written, which fails Execute too:
+	// the problems say why.
+	if problems := generators.Problems(); len(problems) > 0 {
+		for _, p := range problems {
+			klog.Error(p)
+		}
+		klog.Exitf("Found %d problems in the input packages", len(problems))
+	}
+	if err != nil {
 		klog.Fatalf("Error: %v", err)
 	}
 	klog.V(2).Info("Completed successfully.")
@@ -0,0 +1,4 @@
+// Package aliases holds named types of builtins, slices, maps and structs.
+//
+// +gengo:deepcopy=package
+package aliases
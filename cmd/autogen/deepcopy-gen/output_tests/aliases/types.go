@@ -0,0 +1,34 @@
+package aliases
+
+type Builtin int
+
+type String string
+
+type Slice []int
+
+type Map map[string]int
+
+type Struct struct {
+	Builtin Builtin
+	Slice   Slice
+}
+
+// SliceOfStructs is a named slice of a named struct.
+type SliceOfStructs []Struct
+
+// MapOfSlices is a named map of named slices.
+type MapOfSlices map[String]Slice
+
+// Holder holds the named types, and pointers to those.
+type Holder struct {
+	Builtin        Builtin
+	String         String
+	Slice          Slice
+	Map            Map
+	Struct         Struct
+	SliceOfStructs SliceOfStructs
+	MapOfSlices    MapOfSlices
+	BuiltinPtr     *Builtin
+	SlicePtr       *Slice
+	StructPtr      *Struct
+}
@@ -0,0 +1,189 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+package aliases
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Holder) DeepCopyInto(out *Holder) {
+	*out = *in
+	if in.Slice != nil {
+		in, out := &in.Slice, &out.Slice
+		*out = make(Slice, len(*in))
+		copy(*out, *in)
+	}
+	if in.Map != nil {
+		in, out := &in.Map, &out.Map
+		*out = make(Map, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Struct.DeepCopyInto(&out.Struct)
+	if in.SliceOfStructs != nil {
+		in, out := &in.SliceOfStructs, &out.SliceOfStructs
+		*out = make(SliceOfStructs, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MapOfSlices != nil {
+		in, out := &in.MapOfSlices, &out.MapOfSlices
+		*out = make(MapOfSlices, len(*in))
+		for key, val := range *in {
+			var outVal []int
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(Slice, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.BuiltinPtr != nil {
+		in, out := &in.BuiltinPtr, &out.BuiltinPtr
+		*out = new(Builtin)
+		**out = **in
+	}
+	if in.SlicePtr != nil {
+		in, out := &in.SlicePtr, &out.SlicePtr
+		*out = new(Slice)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]int, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.StructPtr != nil {
+		in, out := &in.StructPtr, &out.StructPtr
+		*out = new(Struct)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Holder.
+func (in *Holder) DeepCopy() *Holder {
+	if in == nil {
+		return nil
+	}
+	out := new(Holder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in Map) DeepCopyInto(out *Map) {
+	{
+		in := &in
+		*out = make(Map, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Map.
+func (in Map) DeepCopy() Map {
+	if in == nil {
+		return nil
+	}
+	out := new(Map)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in MapOfSlices) DeepCopyInto(out *MapOfSlices) {
+	{
+		in := &in
+		*out = make(MapOfSlices, len(*in))
+		for key, val := range *in {
+			var outVal []int
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(Slice, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MapOfSlices.
+func (in MapOfSlices) DeepCopy() MapOfSlices {
+	if in == nil {
+		return nil
+	}
+	out := new(MapOfSlices)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in Slice) DeepCopyInto(out *Slice) {
+	{
+		in := &in
+		*out = make(Slice, len(*in))
+		copy(*out, *in)
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Slice.
+func (in Slice) DeepCopy() Slice {
+	if in == nil {
+		return nil
+	}
+	out := new(Slice)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in SliceOfStructs) DeepCopyInto(out *SliceOfStructs) {
+	{
+		in := &in
+		*out = make(SliceOfStructs, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SliceOfStructs.
+func (in SliceOfStructs) DeepCopy() SliceOfStructs {
+	if in == nil {
+		return nil
+	}
+	out := new(SliceOfStructs)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Struct) DeepCopyInto(out *Struct) {
+	*out = *in
+	if in.Slice != nil {
+		in, out := &in.Slice, &out.Slice
+		*out = make(Slice, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Struct.
+func (in *Struct) DeepCopy() *Struct {
+	if in == nil {
+		return nil
+	}
+	out := new(Struct)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,4 @@
+// Package builtins holds structs of builtin types, copied by assignment.
+//
+// +gengo:deepcopy=package
+package builtins
@@ -0,0 +1,29 @@
+package builtins
+
+type Builtins struct {
+	Bool    bool
+	Byte    byte
+	Int     int
+	Int8    int8
+	Int16   int16
+	Int32   int32
+	Int64   int64
+	Uint    uint
+	Uint8   uint8
+	Uint16  uint16
+	Uint32  uint32
+	Uint64  uint64
+	Uintptr uintptr
+	Float32 float32
+	Float64 float64
+	String  string
+}
+
+// Pointers holds pointers to builtins, copied to new values.
+type Pointers struct {
+	Bool   *bool
+	Int    *int
+	String *string
+	// PP is copied to a new pointer to a new pointer.
+	PP **int
+}
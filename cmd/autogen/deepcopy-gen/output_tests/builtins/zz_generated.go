@@ -0,0 +1,60 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+package builtins
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Builtins) DeepCopyInto(out *Builtins) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Builtins.
+func (in *Builtins) DeepCopy() *Builtins {
+	if in == nil {
+		return nil
+	}
+	out := new(Builtins)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pointers) DeepCopyInto(out *Pointers) {
+	*out = *in
+	if in.Bool != nil {
+		in, out := &in.Bool, &out.Bool
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Int != nil {
+		in, out := &in.Int, &out.Int
+		*out = new(int)
+		**out = **in
+	}
+	if in.String != nil {
+		in, out := &in.String, &out.String
+		*out = new(string)
+		**out = **in
+	}
+	if in.PP != nil {
+		in, out := &in.PP, &out.PP
+		*out = new(*int)
+		if **in != nil {
+			in, out := *in, *out
+			*out = new(int)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pointers.
+func (in *Pointers) DeepCopy() *Pointers {
+	if in == nil {
+		return nil
+	}
+	out := new(Pointers)
+	in.DeepCopyInto(out)
+	return out
+}
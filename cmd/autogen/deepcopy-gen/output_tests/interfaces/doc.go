@@ -0,0 +1,5 @@
+// Package interfaces holds named interfaces whose values are copied by DeepCopy<Intf>
+// methods generated with the interfaces tag.
+//
+// +gengo:deepcopy=package
+package interfaces
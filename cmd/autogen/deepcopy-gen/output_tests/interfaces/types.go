@@ -0,0 +1,37 @@
+package interfaces
+
+// Shape is copied by the DeepCopyShape method of its values.
+type Shape interface {
+	Area() float64
+	DeepCopyShape() Shape
+}
+
+// +gengo:deepcopy:interfaces=github.com/zhaolion/gengo/cmd/autogen/deepcopy-gen/output_tests/interfaces.Shape
+type Square struct {
+	Side float64
+}
+
+func (s *Square) Area() float64 {
+	return s.Side * s.Side
+}
+
+// +gengo:deepcopy:interfaces=github.com/zhaolion/gengo/cmd/autogen/deepcopy-gen/output_tests/interfaces.Shape
+type Group struct {
+	Shapes []Shape
+}
+
+func (g *Group) Area() float64 {
+	area := 0.0
+	for _, s := range g.Shapes {
+		area += s.Area()
+	}
+	return area
+}
+
+// Drawing holds shapes in fields, slices and maps.
+type Drawing struct {
+	Main   Shape
+	Shapes []Shape
+	ByName map[string]Shape
+	Layers [][]Shape
+}
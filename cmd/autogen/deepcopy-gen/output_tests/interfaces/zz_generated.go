@@ -0,0 +1,115 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+package interfaces
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Drawing) DeepCopyInto(out *Drawing) {
+	*out = *in
+	if in.Main != nil {
+		out.Main = in.Main.DeepCopyShape()
+	}
+	if in.Shapes != nil {
+		in, out := &in.Shapes, &out.Shapes
+		*out = make([]Shape, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				(*out)[i] = (*in)[i].DeepCopyShape()
+			}
+		}
+	}
+	if in.ByName != nil {
+		in, out := &in.ByName, &out.ByName
+		*out = make(map[string]Shape, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = val.DeepCopyShape()
+			}
+		}
+	}
+	if in.Layers != nil {
+		in, out := &in.Layers, &out.Layers
+		*out = make([][]Shape, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make([]Shape, len(*in))
+				for i := range *in {
+					if (*in)[i] != nil {
+						(*out)[i] = (*in)[i].DeepCopyShape()
+					}
+				}
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Drawing.
+func (in *Drawing) DeepCopy() *Drawing {
+	if in == nil {
+		return nil
+	}
+	out := new(Drawing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Group) DeepCopyInto(out *Group) {
+	*out = *in
+	if in.Shapes != nil {
+		in, out := &in.Shapes, &out.Shapes
+		*out = make([]Shape, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				(*out)[i] = (*in)[i].DeepCopyShape()
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Group.
+func (in *Group) DeepCopy() *Group {
+	if in == nil {
+		return nil
+	}
+	out := new(Group)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyShape is an autogenerated deepcopy function, copying the receiver, creating a new Shape.
+func (in *Group) DeepCopyShape() Shape {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Square) DeepCopyInto(out *Square) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Square.
+func (in *Square) DeepCopy() *Square {
+	if in == nil {
+		return nil
+	}
+	out := new(Square)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyShape is an autogenerated deepcopy function, copying the receiver, creating a new Shape.
+func (in *Square) DeepCopyShape() Shape {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
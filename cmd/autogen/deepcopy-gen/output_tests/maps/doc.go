@@ -0,0 +1,4 @@
+// Package maps holds maps of builtins, pointers, structs, slices and maps.
+//
+// +gengo:deepcopy=package
+package maps
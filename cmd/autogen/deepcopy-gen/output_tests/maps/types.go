@@ -0,0 +1,22 @@
+package maps
+
+type Inner struct {
+	Name   string
+	Values []int
+}
+
+// Key is a comparable struct used as a map key.
+type Key struct {
+	Group, Name string
+}
+
+type Maps struct {
+	Builtins       map[string]int
+	Pointers       map[string]*int
+	Structs        map[string]Inner
+	StructPointers map[string]*Inner
+	Slices         map[string][]int
+	Maps           map[string]map[string]int
+	MapPointers    map[string]*map[string]int
+	StructKeys     map[Key]*Inner
+}
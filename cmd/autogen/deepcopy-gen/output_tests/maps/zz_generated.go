@@ -0,0 +1,169 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+package maps
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Inner) DeepCopyInto(out *Inner) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Inner.
+func (in *Inner) DeepCopy() *Inner {
+	if in == nil {
+		return nil
+	}
+	out := new(Inner)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Key) DeepCopyInto(out *Key) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Key.
+func (in *Key) DeepCopy() *Key {
+	if in == nil {
+		return nil
+	}
+	out := new(Key)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Maps) DeepCopyInto(out *Maps) {
+	*out = *in
+	if in.Builtins != nil {
+		in, out := &in.Builtins, &out.Builtins
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Pointers != nil {
+		in, out := &in.Pointers, &out.Pointers
+		*out = make(map[string]*int, len(*in))
+		for key, val := range *in {
+			var outVal *int
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(int)
+				**out = **in
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Structs != nil {
+		in, out := &in.Structs, &out.Structs
+		*out = make(map[string]Inner, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.StructPointers != nil {
+		in, out := &in.StructPointers, &out.StructPointers
+		*out = make(map[string]*Inner, len(*in))
+		for key, val := range *in {
+			var outVal *Inner
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(Inner)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Slices != nil {
+		in, out := &in.Slices, &out.Slices
+		*out = make(map[string][]int, len(*in))
+		for key, val := range *in {
+			var outVal []int
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]int, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Maps != nil {
+		in, out := &in.Maps, &out.Maps
+		*out = make(map[string]map[string]int, len(*in))
+		for key, val := range *in {
+			var outVal map[string]int
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[string]int, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.MapPointers != nil {
+		in, out := &in.MapPointers, &out.MapPointers
+		*out = make(map[string]*map[string]int, len(*in))
+		for key, val := range *in {
+			var outVal *map[string]int
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(map[string]int)
+				if **in != nil {
+					in, out := *in, *out
+					*out = make(map[string]int, len(*in))
+					for key, val := range *in {
+						(*out)[key] = val
+					}
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.StructKeys != nil {
+		in, out := &in.StructKeys, &out.StructKeys
+		*out = make(map[Key]*Inner, len(*in))
+		for key, val := range *in {
+			var outVal *Inner
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(Inner)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Maps.
+func (in *Maps) DeepCopy() *Maps {
+	if in == nil {
+		return nil
+	}
+	out := new(Maps)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,5 @@
+// Package nonpointer holds named interfaces implemented by non-pointer receivers,
+// with the nonpointer-interfaces tag.
+//
+// +gengo:deepcopy=package
+package nonpointer
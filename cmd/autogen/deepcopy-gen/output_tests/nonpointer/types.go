@@ -0,0 +1,35 @@
+package nonpointer
+
+// Value is copied by the DeepCopyValue method of its values, which are not
+// pointers.
+type Value interface {
+	Kind() string
+	DeepCopyValue() Value
+}
+
+// +gengo:deepcopy:interfaces=github.com/zhaolion/gengo/cmd/autogen/deepcopy-gen/output_tests/nonpointer.Value
+// +gengo:deepcopy:nonpointer-interfaces=true
+type Text struct {
+	Words []string
+}
+
+func (t Text) Kind() string {
+	return "text"
+}
+
+// +gengo:deepcopy:interfaces=github.com/zhaolion/gengo/cmd/autogen/deepcopy-gen/output_tests/nonpointer.Value
+// +gengo:deepcopy:nonpointer-interfaces=true
+type List struct {
+	Items []Value
+}
+
+func (l List) Kind() string {
+	return "list"
+}
+
+// Document holds values in fields, slices and maps.
+type Document struct {
+	Root   Value
+	Values []Value
+	ByName map[string]Value
+}
@@ -0,0 +1,99 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+package nonpointer
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Document) DeepCopyInto(out *Document) {
+	*out = *in
+	if in.Root != nil {
+		out.Root = in.Root.DeepCopyValue()
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]Value, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				(*out)[i] = (*in)[i].DeepCopyValue()
+			}
+		}
+	}
+	if in.ByName != nil {
+		in, out := &in.ByName, &out.ByName
+		*out = make(map[string]Value, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = val.DeepCopyValue()
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Document.
+func (in *Document) DeepCopy() *Document {
+	if in == nil {
+		return nil
+	}
+	out := new(Document)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *List) DeepCopyInto(out *List) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Value, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				(*out)[i] = (*in)[i].DeepCopyValue()
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new List.
+func (in *List) DeepCopy() *List {
+	if in == nil {
+		return nil
+	}
+	out := new(List)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyValue is an autogenerated deepcopy function, copying the receiver, creating a new Value.
+func (in List) DeepCopyValue() Value {
+	return *in.DeepCopy()
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Text) DeepCopyInto(out *Text) {
+	*out = *in
+	if in.Words != nil {
+		in, out := &in.Words, &out.Words
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Text.
+func (in *Text) DeepCopy() *Text {
+	if in == nil {
+		return nil
+	}
+	out := new(Text)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyValue is an autogenerated deepcopy function, copying the receiver, creating a new Value.
+func (in Text) DeepCopyValue() Value {
+	return *in.DeepCopy()
+}
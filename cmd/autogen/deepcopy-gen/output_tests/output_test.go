@@ -0,0 +1,242 @@
+package output_tests
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zhaolion/gengo/cmd/autogen/deepcopy-gen/generators"
+	"github.com/zhaolion/gengo/cmd/autogen/deepcopy-gen/output_tests/aliases"
+	"github.com/zhaolion/gengo/cmd/autogen/deepcopy-gen/output_tests/builtins"
+	"github.com/zhaolion/gengo/cmd/autogen/deepcopy-gen/output_tests/interfaces"
+	"github.com/zhaolion/gengo/cmd/autogen/deepcopy-gen/output_tests/maps"
+	"github.com/zhaolion/gengo/cmd/autogen/deepcopy-gen/output_tests/nonpointer"
+	"github.com/zhaolion/gengo/cmd/autogen/deepcopy-gen/output_tests/slices"
+	"k8s.io/gengo/args"
+)
+
+var update = flag.Bool("update", false, "write the generated code to the zz_generated.go golden files")
+
+// pkgPrefix is the import path of the packages of the output tests.
+const pkgPrefix = "github.com/zhaolion/gengo/cmd/autogen/deepcopy-gen/output_tests/"
+
+// goldenPackages are the packages whose zz_generated.go file holds the code
+// generated for them.
+var goldenPackages = []string{
+	"aliases",
+	"builtins",
+	"interfaces",
+	"maps",
+	"nonpointer",
+	"slices",
+}
+
+// TestGolden generates the deep-copy functions of goldenPackages and compares
+// them with their zz_generated.go file. Run it with -update to rewrite the
+// files after changing the generator.
+func TestGolden(t *testing.T) {
+	dir, err := ioutil.TempDir("", "output_tests")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	arguments := &args.GeneratorArgs{
+		OutputBase:         dir,
+		OutputFileBaseName: "zz_generated",
+		GeneratedBuildTag:  "ignore_autogenerated",
+		CustomArgs:         &generators.CustomArgs{},
+	}
+	for _, pkg := range goldenPackages {
+		arguments.InputDirs = append(arguments.InputDirs, pkgPrefix+pkg)
+	}
+	if err := arguments.Execute(
+		generators.NameSystems(),
+		generators.DefaultNameSystem(),
+		generators.Packages,
+	); err != nil {
+		t.Fatalf("%v\nproblems: %v", err, generators.Problems())
+	}
+
+	for _, pkg := range goldenPackages {
+		golden := filepath.Join(pkg, "zz_generated.go")
+		generated, err := ioutil.ReadFile(filepath.Join(dir, pkgPrefix+pkg, "zz_generated.go"))
+		if err != nil {
+			t.Errorf("%s: %v", pkg, err)
+			continue
+		}
+		if *update {
+			if err := ioutil.WriteFile(golden, generated, 0644); err != nil {
+				t.Error(err)
+			}
+			continue
+		}
+		expect, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Errorf("%v, run go test -update to create it", err)
+			continue
+		}
+		if !bytes.Equal(generated, expect) {
+			t.Errorf("%s is not the code generated, run go test -update if the change is expected:\n%s", golden, firstDiff(expect, generated))
+		}
+	}
+}
+
+// firstDiff returns the first line differing between expect and got.
+func firstDiff(expect, got []byte) string {
+	expectLines := strings.Split(string(expect), "\n")
+	gotLines := strings.Split(string(got), "\n")
+	for i := 0; i < len(expectLines) || i < len(gotLines); i++ {
+		var e, g string
+		if i < len(expectLines) {
+			e = expectLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if e != g {
+			return fmt.Sprintf("line %d:\n-%s\n+%s", i+1, e, g)
+		}
+	}
+	return ""
+}
+
+// TestDeepCopy checks that the generated code deep-copies the values: the
+// copy is equal to the value, and shares no pointer, map or slice with it.
+func TestDeepCopy(t *testing.T) {
+	b, i, s := true, 42, "s"
+	pi := &i
+	testCases := []interface{}{
+		&builtins.Builtins{
+			Bool: true, Byte: 1, Int: 2, Int8: 3, Int16: 4, Int32: 5, Int64: 6,
+			Uint: 7, Uint8: 8, Uint16: 9, Uint32: 10, Uint64: 11, Uintptr: 12,
+			Float32: 13.5, Float64: 14.5, String: "15",
+		},
+		&builtins.Pointers{Bool: &b, Int: &i, String: &s, PP: &pi},
+		&builtins.Pointers{},
+		&aliases.Holder{
+			Builtin:        1,
+			String:         "s",
+			Slice:          aliases.Slice{1, 2},
+			Map:            aliases.Map{"a": 1},
+			Struct:         aliases.Struct{Builtin: 2, Slice: aliases.Slice{3}},
+			SliceOfStructs: aliases.SliceOfStructs{{Slice: aliases.Slice{4}}, {}},
+			MapOfSlices:    aliases.MapOfSlices{"a": {5}, "nil": nil},
+			BuiltinPtr:     new(aliases.Builtin),
+			SlicePtr:       &aliases.Slice{6},
+			StructPtr:      &aliases.Struct{Slice: aliases.Slice{7}},
+		},
+		aliases.Slice{1, 2},
+		aliases.Map{"a": 1},
+		aliases.SliceOfStructs{{Slice: aliases.Slice{1}}},
+		aliases.MapOfSlices{"a": {1}},
+		&maps.Maps{
+			Builtins:       map[string]int{"a": 1},
+			Pointers:       map[string]*int{"a": &i, "nil": nil},
+			Structs:        map[string]maps.Inner{"a": {Name: "a", Values: []int{1}}},
+			StructPointers: map[string]*maps.Inner{"a": {Values: []int{2}}, "nil": nil},
+			Slices:         map[string][]int{"a": {3}, "nil": nil},
+			Maps:           map[string]map[string]int{"a": {"b": 4}, "nil": nil},
+			MapPointers:    map[string]*map[string]int{"a": {"b": 5}},
+			StructKeys:     map[maps.Key]*maps.Inner{{Group: "g", Name: "n"}: {Values: []int{6}}},
+		},
+		&slices.Slices{
+			Builtins:       []int{1},
+			Bytes:          []byte("bytes"),
+			Pointers:       []*int{&i, nil},
+			Structs:        []slices.Inner{{Values: []int{2}}},
+			StructPointers: []*slices.Inner{{Values: []int{3}}, nil},
+			Nested:         [][]int{{4}, nil},
+			Deep:           [][][]*slices.Inner{{{{Values: []int{5}}, nil}, nil}},
+			Maps:           []map[string]int{{"a": 6}, nil},
+			SlicePointers:  []*[]int{{7}, nil},
+		},
+		&interfaces.Drawing{
+			Main: &interfaces.Square{Side: 1},
+			Shapes: []interfaces.Shape{
+				&interfaces.Square{Side: 2},
+				&interfaces.Group{Shapes: []interfaces.Shape{&interfaces.Square{Side: 3}}},
+				nil,
+			},
+			ByName: map[string]interfaces.Shape{"a": &interfaces.Square{Side: 4}, "nil": nil},
+			Layers: [][]interfaces.Shape{{&interfaces.Square{Side: 5}}, nil},
+		},
+		&nonpointer.Document{
+			Root: nonpointer.Text{Words: []string{"root"}},
+			Values: []nonpointer.Value{
+				nonpointer.List{Items: []nonpointer.Value{nonpointer.Text{Words: []string{"item"}}}},
+				nil,
+			},
+			ByName: map[string]nonpointer.Value{"a": nonpointer.Text{Words: []string{"a"}}, "nil": nil},
+		},
+	}
+
+	for i, tc := range testCases {
+		in := reflect.ValueOf(tc)
+		out := in.MethodByName("DeepCopy").Call(nil)[0]
+		if !reflect.DeepEqual(in.Interface(), out.Interface()) {
+			t.Errorf("case[%d]: expected %#v, got %#v", i, in.Interface(), out.Interface())
+			continue
+		}
+		if path := sharedPath("in", in, out); path != "" {
+			t.Errorf("case[%d]: the copy shares %s", i, path)
+		}
+	}
+}
+
+// sharedPath returns the path of the first pointer, map or slice which a and
+// b, equal values, share, "" if they share none.
+func sharedPath(path string, a, b reflect.Value) string {
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() {
+			return ""
+		}
+		if a.Pointer() == b.Pointer() {
+			return path
+		}
+		return sharedPath("(*"+path+")", a.Elem(), b.Elem())
+	case reflect.Interface:
+		if a.IsNil() {
+			return ""
+		}
+		return sharedPath(path, a.Elem(), b.Elem())
+	case reflect.Map:
+		if a.IsNil() {
+			return ""
+		}
+		if a.Pointer() == b.Pointer() {
+			return path
+		}
+		for _, key := range a.MapKeys() {
+			if p := sharedPath(fmt.Sprintf("%s[%v]", path, key), a.MapIndex(key), b.MapIndex(key)); p != "" {
+				return p
+			}
+		}
+	case reflect.Slice:
+		if a.Len() == 0 {
+			return ""
+		}
+		if a.Pointer() == b.Pointer() {
+			return path
+		}
+		for i := 0; i < a.Len(); i++ {
+			if p := sharedPath(fmt.Sprintf("%s[%d]", path, i), a.Index(i), b.Index(i)); p != "" {
+				return p
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if p := sharedPath(path+"."+a.Type().Field(i).Name, a.Field(i), b.Field(i)); p != "" {
+				return p
+			}
+		}
+	}
+	return ""
+}
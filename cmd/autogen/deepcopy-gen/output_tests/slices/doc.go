@@ -0,0 +1,4 @@
+// Package slices holds slices of builtins, pointers, structs, slices and maps.
+//
+// +gengo:deepcopy=package
+package slices
@@ -0,0 +1,18 @@
+package slices
+
+type Inner struct {
+	Name   string
+	Values []int
+}
+
+type Slices struct {
+	Builtins       []int
+	Bytes          []byte
+	Pointers       []*int
+	Structs        []Inner
+	StructPointers []*Inner
+	Nested         [][]int
+	Deep           [][][]*Inner
+	Maps           []map[string]int
+	SlicePointers  []*[]int
+}
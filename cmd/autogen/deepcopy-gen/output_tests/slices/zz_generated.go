@@ -0,0 +1,142 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+package slices
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Inner) DeepCopyInto(out *Inner) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Inner.
+func (in *Inner) DeepCopy() *Inner {
+	if in == nil {
+		return nil
+	}
+	out := new(Inner)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Slices) DeepCopyInto(out *Slices) {
+	*out = *in
+	if in.Builtins != nil {
+		in, out := &in.Builtins, &out.Builtins
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.Bytes != nil {
+		in, out := &in.Bytes, &out.Bytes
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.Pointers != nil {
+		in, out := &in.Pointers, &out.Pointers
+		*out = make([]*int, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(int)
+				**out = **in
+			}
+		}
+	}
+	if in.Structs != nil {
+		in, out := &in.Structs, &out.Structs
+		*out = make([]Inner, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StructPointers != nil {
+		in, out := &in.StructPointers, &out.StructPointers
+		*out = make([]*Inner, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Inner)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Nested != nil {
+		in, out := &in.Nested, &out.Nested
+		*out = make([][]int, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make([]int, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
+	if in.Deep != nil {
+		in, out := &in.Deep, &out.Deep
+		*out = make([][][]*Inner, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make([][]*Inner, len(*in))
+				for i := range *in {
+					if (*in)[i] != nil {
+						in, out := &(*in)[i], &(*out)[i]
+						*out = make([]*Inner, len(*in))
+						for i := range *in {
+							if (*in)[i] != nil {
+								in, out := &(*in)[i], &(*out)[i]
+								*out = new(Inner)
+								(*in).DeepCopyInto(*out)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	if in.Maps != nil {
+		in, out := &in.Maps, &out.Maps
+		*out = make([]map[string]int, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make(map[string]int, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+		}
+	}
+	if in.SlicePointers != nil {
+		in, out := &in.SlicePointers, &out.SlicePointers
+		*out = make([]*[]int, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new([]int)
+				if **in != nil {
+					in, out := *in, *out
+					*out = make([]int, len(*in))
+					copy(*out, *in)
+				}
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Slices.
+func (in *Slices) DeepCopy() *Slices {
+	if in == nil {
+		return nil
+	}
+	out := new(Slices)
+	in.DeepCopyInto(out)
+	return out
+}
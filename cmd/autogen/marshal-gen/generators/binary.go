@@ -0,0 +1,531 @@
+package generators
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// Binary encoding comment tags. +gengo:marshal:binary=true on a struct
+// generates its MarshalBinary and UnmarshalBinary methods, along with those of
+// the structs of its package it refers to; every member of these structs
+// carries +gengo:marshal:binary-field=<number>, or =- to be left out. Field
+// numbers identify the fields in the encoding: they must not change, and the
+// numbers of removed fields must not be used again.
+const (
+	binaryTagName      = typeTagName + ":binary"
+	binaryFieldTagName = typeTagName + ":binary-field"
+)
+
+// binaryStruct is a struct with generated binary methods.
+type binaryStruct struct {
+	fields []binaryField
+}
+
+// binaryField is a member of a struct with generated binary methods.
+type binaryField struct {
+	member types.Member
+	number int
+}
+
+// binaryStructs holds the structs of the packages being generated with binary
+// methods, as found by findBinaryStructs.
+var binaryStructs = map[*types.Type]*binaryStruct{}
+
+// binaryStructOf returns the binary fields of t, or nil if t has no generated
+// binary methods.
+func binaryStructOf(t *types.Type) *binaryStruct {
+	return binaryStructs[t]
+}
+
+// findBinaryStructs returns the structs of pkg with binary methods: its
+// structs tagged +gengo:marshal:binary=true and the structs of pkg they refer
+// to.
+func findBinaryStructs(pkg *types.Package) (map[*types.Type]*binaryStruct, error) {
+	var names []string
+	for name := range pkg.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	found := map[*types.Type]*binaryStruct{}
+	for _, name := range names {
+		t := pkg.Types[name]
+		if !extractTypeTag(t, binaryTagName) {
+			continue
+		}
+		if t.Kind != types.Struct {
+			return nil, fmt.Errorf("%v: only structs can have binary methods", t)
+		}
+		if err := addBinaryStruct(t, found); err != nil {
+			return nil, err
+		}
+	}
+	return found, nil
+}
+
+// addBinaryStruct adds the struct t, and the structs of its package it refers
+// to, to found.
+func addBinaryStruct(t *types.Type, found map[*types.Type]*binaryStruct) error {
+	if found[t] != nil {
+		return nil
+	}
+	s := &binaryStruct{}
+	found[t] = s
+
+	numbers := map[int]string{}
+	for _, member := range t.Members {
+		values := types.ExtractCommentTags("+", member.CommentLines)[binaryFieldTagName]
+		if len(values) == 0 {
+			return fmt.Errorf("%v.%s: missing +%s=<number> comment tag, or =- to leave the member out", t, member.Name, binaryFieldTagName)
+		}
+		if values[0] == "-" {
+			continue
+		}
+		number, err := strconv.Atoi(values[0])
+		if err != nil || number < 1 || number > 1<<32-1 {
+			return fmt.Errorf("%v.%s: invalid binary field number %q", t, member.Name, values[0])
+		}
+		if other, taken := numbers[number]; taken {
+			return fmt.Errorf("%v.%s: field number %d is already used by %s", t, member.Name, number, other)
+		}
+		numbers[number] = member.Name
+
+		if member.Type.Kind == types.Pointer && member.Type.Elem.Kind == types.Pointer {
+			return fmt.Errorf("%v.%s: unsupported binary field type %v", t, member.Name, member.Type)
+		}
+		if err := checkBinaryType(t.Name.Package, member.Type, found); err != nil {
+			return fmt.Errorf("%v.%s: %v", t, member.Name, err)
+		}
+		s.fields = append(s.fields, binaryField{member: member, number: number})
+	}
+	return nil
+}
+
+// checkBinaryType returns an error if values of type t cannot be written in the
+// binary format. The structs of the package pkgPath t refers to are added to
+// found. The structs of the other generated packages may not be found yet:
+// checkBinaryStructs checks them once they are.
+func checkBinaryType(pkgPath string, t *types.Type, found map[*types.Type]*binaryStruct) error {
+	if hasBinaryMethods(t) || hasBinaryMarshaler(t) {
+		return nil
+	}
+	ut := underlyingType(t)
+	switch ut.Kind {
+	case types.Builtin:
+		if builtinKind(ut) != "" {
+			return nil
+		}
+	case types.Struct:
+		if t.Kind == types.Struct && t.Name.Package == pkgPath && t.Name.Name != "" {
+			return addBinaryStruct(t, found)
+		}
+		if t.Kind == types.Struct && t.Name.Name != "" {
+			if !generatedPackages[t.Name.Package] {
+				return fmt.Errorf("%v has no binary methods: its package is not generated and it has no MarshalBinary and UnmarshalBinary methods", t)
+			}
+			return nil
+		}
+	case types.Pointer, types.Slice, types.Array:
+		return checkBinaryType(pkgPath, ut.Elem, found)
+	case types.Map:
+		if key := underlyingType(ut.Key); key.Kind != types.Builtin || builtinKind(key) == "" {
+			return fmt.Errorf("unsupported binary map key %v", ut.Key)
+		}
+		return checkBinaryType(pkgPath, ut.Elem, found)
+	}
+	return fmt.Errorf("unsupported binary type %v", t)
+}
+
+// hasBinaryMethods returns true if t gets generated WriteBinary and ReadBinary
+// methods.
+func hasBinaryMethods(t *types.Type) bool {
+	return binaryStructOf(t) != nil
+}
+
+// checkBinaryStructs returns an error if a struct of pkg with binary methods
+// has a field of a struct of another package without binary methods. It is
+// called once the binary structs of every generated package are known.
+func checkBinaryStructs(pkg *types.Package) error {
+	var structs []*types.Type
+	for t := range binaryStructs {
+		if t.Name.Package == pkg.Path {
+			structs = append(structs, t)
+		}
+	}
+	sort.Slice(structs, func(i, j int) bool {
+		return structs[i].Name.Name < structs[j].Name.Name
+	})
+	for _, t := range structs {
+		for _, f := range binaryStructs[t].fields {
+			if other := foreignBinaryStruct(pkg.Path, f.member.Type); other != nil {
+				return fmt.Errorf("%v.%s: %v has no binary methods, tag it +%s=true in its package", t, f.member.Name, other, binaryTagName)
+			}
+		}
+	}
+	return nil
+}
+
+// foreignBinaryStruct returns the first struct of another package than
+// pkgPath t refers to which has no binary methods, or nil if there is none.
+func foreignBinaryStruct(pkgPath string, t *types.Type) *types.Type {
+	if hasBinaryMethods(t) || hasBinaryMarshaler(t) {
+		return nil
+	}
+	ut := underlyingType(t)
+	switch ut.Kind {
+	case types.Struct:
+		if t.Kind == types.Struct && t.Name.Package != pkgPath && t.Name.Name != "" {
+			return t
+		}
+	case types.Pointer, types.Slice, types.Array:
+		return foreignBinaryStruct(pkgPath, ut.Elem)
+	case types.Map:
+		return foreignBinaryStruct(pkgPath, ut.Elem)
+	}
+	return nil
+}
+
+// hasBinaryMarshaler returns true if t has hand-written MarshalBinary and
+// UnmarshalBinary methods, e.g. time.Time.
+func hasBinaryMarshaler(t *types.Type) bool {
+	_, marshal := t.Methods["MarshalBinary"]
+	_, unmarshal := t.Methods["UnmarshalBinary"]
+	return marshal && unmarshal
+}
+
+// binaryWriter writes the code converting values from and to the binary
+// format.
+type binaryWriter struct {
+	// rt is the local name of the runtime package.
+	rt  string
+	raw interface{ Name(*types.Type) string }
+	sw  *generator.SnippetWriter
+}
+
+// do writes the code format, whose verbs are replaced by a.
+func (b *binaryWriter) do(format string, a ...interface{}) {
+	b.sw.Do("$.$", fmt.Sprintf(format, a...))
+}
+
+// binaryScalar returns the BinaryWriter and BinaryReader method of the builtin t,
+// and the Go type they handle.
+func binaryScalar(t *types.Type) (method, goType string) {
+	switch t.Name.Name {
+	case "float32":
+		return "Float32", "float32"
+	case "float64":
+		return "Float64", "float64"
+	}
+	switch builtinKind(t) {
+	case "Bool":
+		return "Bool", "bool"
+	case "String":
+		return "String", "string"
+	case "Int":
+		return "Varint", "int64"
+	case "Uint":
+		return "Uvarint", "uint64"
+	}
+	return "", ""
+}
+
+// wireType returns the wire type of the fields of type t.
+func (b *binaryWriter) wireType(t *types.Type) string {
+	ut := underlyingType(t)
+	if hasBinaryMethods(t) || hasBinaryMarshaler(t) || ut.Kind != types.Builtin {
+		if ut.Kind == types.Pointer {
+			return b.wireType(ut.Elem)
+		}
+		return b.rt + ".BinaryBytes"
+	}
+	switch method, _ := binaryScalar(ut); method {
+	case "Bool", "Varint", "Uvarint":
+		return b.rt + ".BinaryVarint"
+	case "Float32":
+		return b.rt + ".BinaryFixed32"
+	case "Float64":
+		return b.rt + ".BinaryFixed64"
+	}
+	return b.rt + ".BinaryBytes"
+}
+
+// addressOf returns the address of the addressable expression expr.
+func addressOf(expr string) string {
+	if isDereference(expr) {
+		return expr[2 : len(expr)-1]
+	}
+	return "&" + expr
+}
+
+// unparen returns expr without the parentheses around a dereference, for the
+// places they are not needed.
+func unparen(expr string) string {
+	if isDereference(expr) {
+		return expr[1 : len(expr)-1]
+	}
+	return expr
+}
+
+// isDereference returns true if expr is a parenthesized dereference, e.g.
+// (*obj.Ptr).
+func isDereference(expr string) bool {
+	return len(expr) > 3 && expr[:2] == "(*" && expr[len(expr)-1] == ')'
+}
+
+// isBinaryByteSlice returns true if t is written as a byte string.
+func isBinaryByteSlice(t *types.Type) bool {
+	ut := underlyingType(t)
+	if ut.Kind != types.Slice || hasBinaryMarshaler(ut.Elem) || enumOf(ut.Elem) != nil {
+		return false
+	}
+	elem := underlyingType(ut.Elem)
+	return elem.Kind == types.Builtin && elem.Name.Name == "byte"
+}
+
+// writeValue writes the code writing expr, of type t.
+func (b *binaryWriter) writeValue(t *types.Type, expr string, depth int) {
+	ut := underlyingType(t)
+	switch {
+	case hasBinaryMethods(t):
+		b.do("w.Struct(%s)\n", addressOf(expr))
+	case hasBinaryMarshaler(t):
+		b.do("w.Marshaler(%s)\n", addressOf(expr))
+	case isBinaryByteSlice(t):
+		b.do("w.ByteSlice(%s)\n", b.convert(t, "[]byte", expr))
+	case ut.Kind == types.Builtin:
+		method, goType := binaryScalar(ut)
+		b.do("w.%s(%s)\n", method, b.convert(t, goType, expr))
+	case ut.Kind == types.Pointer:
+		b.do("if %s == nil {\n", expr)
+		b.do("w.Bool(false)\n")
+		b.do("} else {\n")
+		b.do("w.Bool(true)\n")
+		b.writeValue(ut.Elem, "(*"+expr+")", depth)
+		b.do("}\n")
+	case ut.Kind == types.Slice:
+		i := "i" + strconv.Itoa(depth)
+		b.do("w.Count(len(%s), %s == nil)\n", expr, expr)
+		b.do("for %s := range %s {\n", i, expr)
+		b.writeValue(ut.Elem, expr+"["+i+"]", depth+1)
+		b.do("}\n")
+	case ut.Kind == types.Array:
+		i := "i" + strconv.Itoa(depth)
+		b.do("for %s := range %s {\n", i, expr)
+		b.writeValue(ut.Elem, expr+"["+i+"]", depth+1)
+		b.do("}\n")
+	case ut.Kind == types.Map:
+		// Keys are sorted for the encoding to be deterministic.
+		keys, k, v := "keys"+strconv.Itoa(depth), "k"+strconv.Itoa(depth), "v"+strconv.Itoa(depth)
+		b.do("w.Count(len(%s), %s == nil)\n", expr, expr)
+		b.do("%s := make([]%s, 0, len(%s))\n", keys, b.raw.Name(ut.Key), expr)
+		b.do("for %s := range %s {\n", k, expr)
+		b.do("%s = append(%s, %s)\n", keys, keys, k)
+		b.do("}\n")
+		b.do("sort.Slice(%s, func(i, j int) bool {\n", keys)
+		if builtinKind(underlyingType(ut.Key)) == "Bool" {
+			b.do("return !bool(%s[i]) && bool(%s[j])\n", keys, keys)
+		} else {
+			b.do("return %s[i] < %s[j]\n", keys, keys)
+		}
+		b.do("})\n")
+		b.do("for _, %s := range %s {\n", k, keys)
+		b.writeValue(ut.Key, k, depth+1)
+		b.do("%s := %s[%s]\n", v, expr, k)
+		b.writeValue(ut.Elem, v, depth+1)
+		b.do("}\n")
+	}
+}
+
+// readValue writes the code reading target, of type t, from the reader r.
+// target is addressable and holds the zero value.
+func (b *binaryWriter) readValue(t *types.Type, target, r string, depth int) {
+	ut := underlyingType(t)
+	switch {
+	case hasBinaryMethods(t):
+		b.do("%s.Struct(%s)\n", r, addressOf(target))
+	case hasBinaryMarshaler(t):
+		b.do("%s.Unmarshaler(%s)\n", r, addressOf(target))
+	case isBinaryByteSlice(t):
+		b.do("%s = %s\n", unparen(target), b.convertTo(t, "[]byte", r+".ByteSlice()"))
+	case ut.Kind == types.Builtin:
+		method, goType := binaryScalar(ut)
+		b.do("%s = %s\n", unparen(target), b.convertTo(t, goType, r+"."+method+"()"))
+	case ut.Kind == types.Pointer:
+		b.do("if %s.Bool() {\n", r)
+		b.do("%s = new(%s)\n", unparen(target), b.raw.Name(ut.Elem))
+		b.readValue(ut.Elem, "(*"+target+")", r, depth)
+		b.do("}\n")
+	case ut.Kind == types.Slice:
+		n, i := "n"+strconv.Itoa(depth), "i"+strconv.Itoa(depth)
+		b.do("if %s, ok := %s.Count(); ok {\n", n, r)
+		b.do("%s = make(%s, %s)\n", unparen(target), b.raw.Name(t), n)
+		b.do("for %s := range %s {\n", i, target)
+		b.readValue(ut.Elem, target+"["+i+"]", r, depth+1)
+		b.do("}\n")
+		b.do("}\n")
+	case ut.Kind == types.Array:
+		i := "i" + strconv.Itoa(depth)
+		b.do("for %s := range %s {\n", i, target)
+		b.readValue(ut.Elem, target+"["+i+"]", r, depth+1)
+		b.do("}\n")
+	case ut.Kind == types.Map:
+		n, i, k, v := "n"+strconv.Itoa(depth), "i"+strconv.Itoa(depth), "k"+strconv.Itoa(depth), "v"+strconv.Itoa(depth)
+		b.do("if %s, ok := %s.Count(); ok {\n", n, r)
+		b.do("%s = make(%s, %s)\n", unparen(target), b.raw.Name(t), n)
+		b.do("for %s := 0; %s < %s; %s++ {\n", i, i, n, i)
+		b.do("var %s %s\n", k, b.raw.Name(ut.Key))
+		b.readValue(ut.Key, k, r, depth+1)
+		b.do("var %s %s\n", v, b.raw.Name(ut.Elem))
+		b.readValue(ut.Elem, v, r, depth+1)
+		b.do("%s[%s] = %s\n", target, k, v)
+		b.do("}\n")
+		b.do("}\n")
+	}
+}
+
+// convert returns expr, of type t, converted to goType.
+func (b *binaryWriter) convert(t *types.Type, goType, expr string) string {
+	if b.raw.Name(t) == goType {
+		return expr
+	}
+	return goType + "(" + unparen(expr) + ")"
+}
+
+// convertTo returns expr, of type goType, converted to t.
+func (b *binaryWriter) convertTo(t *types.Type, goType, expr string) string {
+	if name := b.raw.Name(t); name != goType {
+		return name + "(" + expr + ")"
+	}
+	return expr
+}
+
+// writeField writes the code writing the field f of obj. Scalars holding
+// their zero value and nil pointers, slices and maps are left out, readers
+// restore them as the zero value.
+func (b *binaryWriter) writeField(f binaryField) {
+	t, expr := f.member.Type, "obj."+f.member.Name
+	ut := underlyingType(t)
+	switch {
+	case hasBinaryMethods(t) || hasBinaryMarshaler(t):
+		b.do("w.Tag(%d, %s)\n", f.number, b.wireType(t))
+		b.writeValue(t, expr, 0)
+	case isBinaryByteSlice(t):
+		b.do("if %s != nil {\n", expr)
+		b.do("w.Tag(%d, %s)\n", f.number, b.wireType(t))
+		b.writeValue(t, expr, 0)
+		b.do("}\n")
+	case ut.Kind == types.Builtin:
+		switch builtinKind(ut) {
+		case "Bool":
+			b.do("if %s {\n", expr)
+		case "String":
+			b.do("if %s != \"\" {\n", expr)
+		default:
+			b.do("if %s != 0 {\n", expr)
+		}
+		b.do("w.Tag(%d, %s)\n", f.number, b.wireType(t))
+		b.writeValue(t, expr, 0)
+		b.do("}\n")
+	case ut.Kind == types.Pointer:
+		b.do("if %s != nil {\n", expr)
+		b.do("w.Tag(%d, %s)\n", f.number, b.wireType(t))
+		b.writeValue(ut.Elem, "(*"+expr+")", 0)
+		b.do("}\n")
+	default:
+		if ut.Kind == types.Array {
+			b.do("{\n")
+		} else {
+			b.do("if %s != nil {\n", expr)
+		}
+		b.do("w.Tag(%d, %s)\n", f.number, b.wireType(t))
+		b.do("mark := w.Begin()\n")
+		b.writeValue(t, expr, 0)
+		b.do("w.End(mark)\n")
+		b.do("}\n")
+	}
+}
+
+// readField writes the case of the switch on the field number reading the
+// field f into obj.
+func (b *binaryWriter) readField(f binaryField) {
+	t, expr := f.member.Type, "obj."+f.member.Name
+	ut := underlyingType(t)
+	b.do("case %d:\n", f.number)
+	b.do("if typ != %s {\n", b.wireType(t))
+	b.do("break\n")
+	b.do("}\n")
+	switch {
+	case hasBinaryMethods(t) || hasBinaryMarshaler(t) || isBinaryByteSlice(t) || ut.Kind == types.Builtin:
+		b.readValue(t, expr, "r", 0)
+	case ut.Kind == types.Pointer:
+		b.do("%s = new(%s)\n", expr, b.raw.Name(ut.Elem))
+		b.readValue(ut.Elem, "(*"+expr+")", "r", 0)
+	default:
+		b.do("sub := r.Sub()\n")
+		b.readValue(t, expr, "sub", 0)
+	}
+	b.do("continue\n")
+}
+
+// generateBinary writes the binary methods of t.
+func (g *marshalGen) generateBinary(c *generator.Context, t *types.Type, s *binaryStruct, sw *generator.SnippetWriter) {
+	b := &binaryWriter{rt: g.runtimeName(), raw: c.Namers["raw"], sw: sw}
+	args := generator.Args{"type": t, "marshal": b.rt}
+	sw.Do(binaryTemplateCode, args)
+
+	sw.Do("func (obj *$.type|raw$) WriteBinary(w *$.marshal$.BinaryWriter) {\n", args)
+	for _, f := range s.fields {
+		b.writeField(f)
+	}
+	sw.Do("}\n\n", nil)
+
+	sw.Do(readBinaryTemplateCode, args)
+	sw.Do("func (obj *$.type|raw$) ReadBinary(r *$.marshal$.BinaryReader) {\n", args)
+	sw.Do("var zero $.type|raw$\n", args)
+	sw.Do("*obj = zero\n", nil)
+	sw.Do("for r.More() {\n", nil)
+	sw.Do("num, typ := r.Tag()\n", nil)
+	if len(s.fields) > 0 {
+		sw.Do("switch num {\n", nil)
+		for _, f := range s.fields {
+			b.readField(f)
+		}
+		sw.Do("}\n", nil)
+		sw.Do("// Skip unknown fields, and fields of an unexpected wire type.\n", nil)
+	} else {
+		sw.Do("_ = num\n", nil)
+	}
+	sw.Do("r.Skip(typ)\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("}\n\n", nil)
+}
+
+var binaryTemplateCode = `
+// MarshalBinary returns the compact binary encoding of obj: the version of the
+// format, then the fields of obj which are set, tagged by their number.
+func (obj *$.type|raw$) MarshalBinary() ([]byte, error) {
+	w := $.marshal$.NewBinaryWriter()
+	w.Header()
+	obj.WriteBinary(w)
+	return w.Bytes(), w.Err()
+}
+
+// UnmarshalBinary sets obj to the binary encoding data. Unknown fields are
+// skipped, and fields missing from data are left to their zero value.
+func (obj *$.type|raw$) UnmarshalBinary(data []byte) error {
+	r := $.marshal$.NewBinaryReader(data)
+	r.Header()
+	obj.ReadBinary(r)
+	return r.Err()
+}
+
+// WriteBinary writes the fields of obj to w, without the version of the format.
+`
+
+var readBinaryTemplateCode = `
+// ReadBinary sets obj to the fields read from r, until the end of its data.
+`
@@ -0,0 +1,86 @@
+package generators
+
+import (
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+func Test_checkBinaryStructs(t *testing.T) {
+	defer func(saved map[*types.Type]*binaryStruct, generated map[string]bool) {
+		binaryStructs, generatedPackages = saved, generated
+	}(binaryStructs, generatedPackages)
+	binaryStructs = map[*types.Type]*binaryStruct{}
+	generatedPackages = map[string]bool{"pkg": true, "peer": true}
+
+	tagged := &types.Type{Name: types.Name{Package: "peer", Name: "Tagged"}, Kind: types.Struct}
+	binaryStructs[tagged] = &binaryStruct{}
+	untagged := &types.Type{Name: types.Name{Package: "peer", Name: "Untagged"}, Kind: types.Struct}
+	foreign := &types.Type{Name: types.Name{Package: "encoding/xml", Name: "Name"}, Kind: types.Struct}
+	marshaler := &types.Type{
+		Name:    types.Name{Package: "time", Name: "Time"},
+		Kind:    types.Struct,
+		Methods: map[string]*types.Type{"MarshalBinary": {}, "UnmarshalBinary": {}},
+	}
+
+	testCases := []struct {
+		field  *types.Type
+		expect string
+	}{
+		{field: tagged},
+		{field: marshaler},
+		{field: &types.Type{Kind: types.Slice, Elem: &types.Type{Kind: types.Pointer, Elem: tagged}}},
+		{
+			field:  foreign,
+			expect: "pkg.T.Field: encoding/xml.Name has no binary methods: its package is not generated and it has no MarshalBinary and UnmarshalBinary methods",
+		},
+		{
+			field:  untagged,
+			expect: "pkg.T.Field: peer.Untagged has no binary methods, tag it +gengo:marshal:binary=true in its package",
+		},
+		{
+			field:  &types.Type{Kind: types.Map, Key: types.String, Elem: untagged},
+			expect: "pkg.T.Field: peer.Untagged has no binary methods, tag it +gengo:marshal:binary=true in its package",
+		},
+	}
+
+	for i, tc := range testCases {
+		typ := &types.Type{
+			Name: types.Name{Package: "pkg", Name: "T"},
+			Kind: types.Struct,
+			Members: []types.Member{
+				{Name: "Field", Type: tc.field, CommentLines: []string{"+gengo:marshal:binary-field=1"}},
+			},
+		}
+		found := map[*types.Type]*binaryStruct{}
+		err := addBinaryStruct(typ, found)
+		if err == nil {
+			binaryStructs[typ] = found[typ]
+			err = checkBinaryStructs(&types.Package{Path: "pkg"})
+			delete(binaryStructs, typ)
+		}
+		if tc.expect == "" && err != nil {
+			t.Errorf("case[%d]: unexpected error %v", i, err)
+		}
+		if tc.expect != "" && (err == nil || err.Error() != tc.expect) {
+			t.Errorf("case[%d]: expected error %q, got %v", i, tc.expect, err)
+		}
+	}
+}
+
+func Test_isBinaryByteSlice(t *testing.T) {
+	testCases := []struct {
+		t      *types.Type
+		expect bool
+	}{
+		{t: &types.Type{Kind: types.Slice, Name: types.Name{Name: "[]byte"}, Elem: types.Byte}, expect: true},
+		{t: &types.Type{Kind: types.Slice, Name: types.Name{Name: "[]int8"}, Elem: int8Type}},
+		{t: &types.Type{Kind: types.Array, Name: types.Name{Name: "[4]byte"}, Elem: types.Byte}},
+	}
+
+	for i, tc := range testCases {
+		if r := isBinaryByteSlice(tc.t); r != tc.expect {
+			t.Errorf("case[%d]: expected %t, got %t", i, tc.expect, r)
+		}
+	}
+}
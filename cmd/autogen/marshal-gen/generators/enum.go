@@ -0,0 +1,326 @@
+package generators
+
+import (
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// Enum comment tags. +gengo:marshal:enum=false on a named integer or string
+// type turns off the enum methods of the type; +gengo:marshal:enum-name=<name>
+// on one of its constants sets the name of the constant.
+const (
+	enumTagName     = typeTagName + ":enum"
+	enumNameTagName = typeTagName + ":enum-name"
+)
+
+// enumConstant is a constant of an enum type.
+type enumConstant struct {
+	// ident is the identifier of the constant.
+	ident string
+	// name is the text form of the constant.
+	name string
+	// alias is set if the constant is declared as another constant of the
+	// enum, e.g. Default = Red, so its value is listed once.
+	alias bool
+}
+
+// enum is a named integer or string type with constants declared in its
+// package. Its values are written as the names of the constants.
+type enum struct {
+	constants []enumConstant
+}
+
+// enums holds the enum types of the packages being generated, as found by
+// findEnums.
+var enums = map[*types.Type]*enum{}
+
+// enumOf returns the enum t is, or nil if t is not an enum.
+func enumOf(t *types.Type) *enum {
+	return enums[t]
+}
+
+// findEnums returns the enum types of pkg. gengo does not record constants,
+// so the Go files of the package are parsed again; files carrying buildTag are
+// generated and skipped.
+func findEnums(pkg *types.Package, buildTag string) (map[*types.Type]*enum, error) {
+	ctx := build.Default
+	ctx.BuildTags = append(append([]string{}, ctx.BuildTags...), buildTag)
+	bp, err := ctx.ImportDir(pkg.SourcePath, 0)
+	if err != nil {
+		if _, ok := err.(*build.NoGoError); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// constants lists the typed constants of the package by type name.
+	constants := map[string][]enumConstant{}
+	typeOf := map[string]string{}
+	fset := token.NewFileSet()
+	for _, name := range bp.GoFiles {
+		file, err := parser.ParseFile(fset, filepath.Join(bp.Dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || decl.Tok != token.CONST {
+				continue
+			}
+			// Specs without a type nor values repeat the previous ones.
+			var prevType string
+			var prevValues []ast.Expr
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.ValueSpec)
+				typeName, values := "", spec.Values
+				if ident, ok := spec.Type.(*ast.Ident); ok {
+					typeName = ident.Name
+				}
+				if spec.Type == nil && len(values) == 0 {
+					typeName, values = prevType, prevValues
+				}
+				prevType, prevValues = typeName, values
+
+				comments := []*ast.CommentGroup{spec.Doc, spec.Comment}
+				if !decl.Lparen.IsValid() {
+					comments = append(comments, decl.Doc)
+				}
+				tags := types.ExtractCommentTags("+", commentLines(comments...))
+
+				for i, ident := range spec.Names {
+					if ident.Name == "_" {
+						continue
+					}
+					c := enumConstant{ident: ident.Name, name: ident.Name}
+					constType := typeName
+					if i < len(values) {
+						switch value := values[i].(type) {
+						case *ast.CallExpr:
+							// A conversion, e.g. Red = Color(1).
+							if fun, ok := value.Fun.(*ast.Ident); ok && constType == "" && len(value.Args) == 1 {
+								constType = fun.Name
+							}
+						case *ast.Ident:
+							if t, ok := typeOf[value.Name]; ok && (constType == "" || constType == t) {
+								constType, c.alias = t, true
+							}
+						case *ast.BasicLit:
+							if value.Kind == token.STRING {
+								// String constants are named by their value.
+								if s, err := strconv.Unquote(value.Value); err == nil {
+									c.name = s
+								}
+							}
+						}
+					}
+					if names := tags[enumNameTagName]; len(names) > 0 && len(spec.Names) == 1 {
+						c.name = names[0]
+					}
+					if constType == "" {
+						continue
+					}
+					typeOf[c.ident] = constType
+					constants[constType] = append(constants[constType], c)
+				}
+			}
+		}
+	}
+
+	found := map[*types.Type]*enum{}
+	for typeName, cs := range constants {
+		t := pkg.Types[typeName]
+		if t == nil || !isEnumType(t) {
+			continue
+		}
+		found[t] = &enum{constants: cs}
+	}
+	return found, nil
+}
+
+// isEnumType returns true if t can be an enum: a named integer or string type
+// without its own (un)marshal methods and not opting out with
+// +gengo:marshal:enum=false.
+func isEnumType(t *types.Type) bool {
+	if t.Kind != types.Alias || hasJSONMarshaler(t) {
+		return false
+	}
+	switch builtinKind(underlyingType(t)) {
+	case "Int", "Uint", "String":
+	default:
+		return false
+	}
+	comments := append(append([]string{}, t.SecondClosestCommentLines...), t.CommentLines...)
+	values := types.ExtractCommentTags("+", comments)[enumTagName]
+	return len(values) == 0 || values[0] != "false"
+}
+
+// commentLines returns the lines of the comment groups, without the comment
+// markers.
+func commentLines(groups ...*ast.CommentGroup) []string {
+	var lines []string
+	for _, group := range groups {
+		if group != nil {
+			lines = append(lines, strings.Split(group.Text(), "\n")...)
+		}
+	}
+	return lines
+}
+
+// generateEnum writes the String, MarshalText and UnmarshalText methods of the
+// enum t, and the functions parsing and listing its values.
+func (g *marshalGen) generateEnum(c *generator.Context, t *types.Type, e *enum, sw *generator.SnippetWriter) {
+	public := c.Namers["public"].Name(t)
+	parse, values := "Parse"+public, public+"Values"
+	if namer.IsPrivateGoName(t.Name.Name) {
+		parse, values = "parse"+public, c.Namers["private"].Name(t)+"Values"
+	}
+	var names, idents, distinct []string
+	for _, constant := range e.constants {
+		names = append(names, strconv.Quote(constant.name))
+		idents = append(idents, constant.ident)
+		if !constant.alias {
+			distinct = append(distinct, constant.ident)
+		}
+	}
+	// The value is converted to its underlying kind, so that formatting it
+	// does not call String. The values without a constant are written as
+	// their underlying value.
+	format, conversion := "%d", "int64"
+	raw := "strconv.FormatInt(int64(obj), 10)"
+	parseRaw := enumParseIntTemplateCode
+	switch builtinKind(underlyingType(t)) {
+	case "Uint":
+		conversion = "uint64"
+		raw, parseRaw = "strconv.FormatUint(uint64(obj), 10)", enumParseUintTemplateCode
+	case "String":
+		format, conversion = "%q", "string"
+		raw, parseRaw = "string(obj)", enumParseStringTemplateCode
+	}
+	args := generator.Args{
+		"type":       t,
+		"table":      c.Namers["private"].Name(t) + "EnumConstants",
+		"parse":      parse,
+		"values":     values,
+		"names":      strings.Join(names, ", "),
+		"idents":     strings.Join(idents, ", "),
+		"distinct":   strings.Join(distinct, ", "),
+		"format":     format,
+		"conversion": conversion,
+		"raw":        raw,
+	}
+
+	sw.Do(enumTemplateCode, args)
+	sw.Do(parseRaw, args)
+	if _, found := t.Methods["String"]; !found {
+		sw.Do(enumStringTemplateCode, args)
+	}
+}
+
+var enumTemplateCode = `
+// $.table$ pairs the names of the $.type|raw$ constants with their values.
+var $.table$ = struct {
+	names  []string
+	values []$.type|raw$
+}{
+	names:  []string{$.names$},
+	values: []$.type|raw${$.idents$},
+}
+
+// $.parse$ returns the $.type|raw$ constant called name.
+func $.parse$(name string) ($.type|raw$, error) {
+	for i, n := range $.table$.names {
+		if n == name {
+			return $.table$.values[i], nil
+		}
+	}
+	var zero $.type|raw$
+	return zero, fmt.Errorf("invalid $.type|raw$ %q", name)
+}
+
+// $.values$ returns the values of the $.type|raw$ constants, in declaration
+// order.
+func $.values$() []$.type|raw$ {
+	return []$.type|raw${$.distinct$}
+}
+
+// MarshalText implements encoding.TextMarshaler. It writes the name of the
+// constant obj equals, or the underlying value of obj if there is none, e.g.
+// for a zero value without a constant.
+func (obj $.type|raw$) MarshalText() ([]byte, error) {
+	for i, v := range $.table$.values {
+		if v == obj {
+			return []byte($.table$.names[i]), nil
+		}
+	}
+	return []byte($.raw$), nil
+}
+`
+
+var enumParseIntTemplateCode = `
+// UnmarshalText implements encoding.TextUnmarshaler. It reads the names
+// $.parse$ accepts, and the underlying values MarshalText writes.
+func (obj *$.type|raw$) UnmarshalText(text []byte) error {
+	if v, err := $.parse$(string(text)); err == nil {
+		*obj = v
+		return nil
+	}
+	n, err := strconv.ParseInt(string(text), 10, 64)
+	if err != nil || int64($.type|raw$(n)) != n {
+		return fmt.Errorf("invalid $.type|raw$ %q", text)
+	}
+	*obj = $.type|raw$(n)
+	return nil
+}
+`
+
+var enumParseUintTemplateCode = `
+// UnmarshalText implements encoding.TextUnmarshaler. It reads the names
+// $.parse$ accepts, and the underlying values MarshalText writes.
+func (obj *$.type|raw$) UnmarshalText(text []byte) error {
+	if v, err := $.parse$(string(text)); err == nil {
+		*obj = v
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 64)
+	if err != nil || uint64($.type|raw$(n)) != n {
+		return fmt.Errorf("invalid $.type|raw$ %q", text)
+	}
+	*obj = $.type|raw$(n)
+	return nil
+}
+`
+
+var enumParseStringTemplateCode = `
+// UnmarshalText implements encoding.TextUnmarshaler. It reads the names
+// $.parse$ accepts, and the underlying values MarshalText writes.
+func (obj *$.type|raw$) UnmarshalText(text []byte) error {
+	if v, err := $.parse$(string(text)); err == nil {
+		*obj = v
+		return nil
+	}
+	*obj = $.type|raw$(text)
+	return nil
+}
+`
+
+var enumStringTemplateCode = `
+// String returns the name of the constant obj equals, or the value of obj
+// after the type name if there is none.
+func (obj $.type|raw$) String() string {
+	for i, v := range $.table$.values {
+		if v == obj {
+			return $.table$.names[i]
+		}
+	}
+	return fmt.Sprintf("$.type|raw$($.format$)", $.conversion$(obj))
+}
+`
@@ -0,0 +1,136 @@
+package generators
+
+import (
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+
+	"k8s.io/gengo/types"
+)
+
+// int8Type is the builtin int8. gengo gives int8, uint8 and byte the same
+// builtin type, named "byte", so resolveInt8 gives int8Type to the types
+// declared with int8 instead.
+var int8Type = &types.Type{
+	Name: types.Name{Name: "int8"},
+	Kind: types.Builtin,
+}
+
+// resolveInt8 replaces the byte builtin by int8Type in the types of pkg
+// declared with int8, e.g. a field of type int8 or []int8, or a type declared
+// as int8. gengo does not record which of int8 and uint8 a type is declared
+// with, so the Go files of the package are parsed again; files carrying
+// buildTag are generated and skipped. The int8 types of other packages are not
+// followed.
+func resolveInt8(pkg *types.Package, buildTag string) error {
+	ctx := build.Default
+	ctx.BuildTags = append(append([]string{}, ctx.BuildTags...), buildTag)
+	bp, err := ctx.ImportDir(pkg.SourcePath, 0)
+	if err != nil {
+		if _, ok := err.(*build.NoGoError); ok {
+			return nil
+		}
+		return err
+	}
+
+	r := int8Resolver{specs: map[string]*ast.TypeSpec{}}
+	fset := token.NewFileSet()
+	for _, name := range bp.GoFiles {
+		file, err := parser.ParseFile(fset, filepath.Join(bp.Dir, name), nil, 0)
+		if err != nil {
+			return err
+		}
+		for _, decl := range file.Decls {
+			if decl, ok := decl.(*ast.GenDecl); ok && decl.Tok == token.TYPE {
+				for _, spec := range decl.Specs {
+					spec := spec.(*ast.TypeSpec)
+					r.specs[spec.Name.Name] = spec
+				}
+			}
+		}
+	}
+	for name, spec := range r.specs {
+		t := pkg.Types[name]
+		if t == nil {
+			continue
+		}
+		if t.Kind == types.Alias {
+			r.resolve(&t.Underlying, spec.Type)
+		} else {
+			r.resolve(&t, spec.Type)
+		}
+	}
+	return nil
+}
+
+// int8Resolver resolves the int8 types of a package.
+type int8Resolver struct {
+	// specs holds the type declarations of the package by name.
+	specs map[string]*ast.TypeSpec
+}
+
+// resolve replaces *t by int8Type if it is the byte builtin and expr, the
+// expression *t is declared with, names int8. The elements of *t, a composite
+// type, are resolved too.
+func (r *int8Resolver) resolve(t **types.Type, expr ast.Expr) {
+	switch expr := expr.(type) {
+	case *ast.ParenExpr:
+		r.resolve(t, expr.X)
+	case *ast.Ident:
+		if *t == types.Byte && r.isInt8(expr, len(r.specs)) {
+			*t = int8Type
+		}
+	case *ast.StarExpr:
+		if (*t).Kind == types.Pointer {
+			r.resolve(&(*t).Elem, expr.X)
+		}
+	case *ast.ArrayType:
+		if (*t).Kind == types.Slice || (*t).Kind == types.Array {
+			r.resolve(&(*t).Elem, expr.Elt)
+		}
+	case *ast.MapType:
+		if (*t).Kind == types.Map {
+			r.resolve(&(*t).Key, expr.Key)
+			r.resolve(&(*t).Elem, expr.Value)
+		}
+	case *ast.ChanType:
+		if (*t).Kind == types.Chan {
+			r.resolve(&(*t).Elem, expr.Value)
+		}
+	case *ast.StructType:
+		if (*t).Kind != types.Struct {
+			return
+		}
+		members := (*t).Members
+		i := 0
+		for _, field := range expr.Fields.List {
+			// Embedded fields have no name but are a member.
+			n := len(field.Names)
+			if n == 0 {
+				n = 1
+			}
+			for ; n > 0 && i < len(members); n-- {
+				r.resolve(&members[i].Type, field.Type)
+				i++
+			}
+		}
+	}
+}
+
+// isInt8 returns true if expr names int8, or a type of the package declared
+// as int8. depth bounds the declarations followed, which may be circular.
+func (r *int8Resolver) isInt8(expr ast.Expr, depth int) bool {
+	switch expr := expr.(type) {
+	case *ast.ParenExpr:
+		return r.isInt8(expr.X, depth)
+	case *ast.Ident:
+		spec, ok := r.specs[expr.Name]
+		if !ok {
+			return expr.Name == "int8"
+		}
+		return depth > 0 && r.isInt8(spec.Type, depth-1)
+	}
+	return false
+}
@@ -17,7 +17,13 @@ import (
 // CustomArgs is used tby the go2idl framework to pass args specific to this
 // generator.
 type CustomArgs struct {
-	ExtraPeerDirs []string // Always consider these as last-ditch possibilities for conversions.
+	ExtraPeerDirs []string // Packages generated by marshal-gen too, whose generated methods may be called.
+	FilePerType   bool     // Write one file per type instead of a single file per package.
+	GenerateTests bool     // Also write a round-trip test and fuzz targets per package.
+	JSONSchema    bool     // Also write a JSON Schema per package.
+	OpenAPI       bool     // Also write the OpenAPI components of the opted-in types per package.
+	Proto         bool     // Also write the protobuf messages of the opted-in types per package.
+	TypeScript    bool     // Also write TypeScript declarations per package.
 }
 
 // NameSystems returns the name system used by the generators in this package.
@@ -38,7 +44,67 @@ func DefaultNameSystem() string {
 // Packages makes the sets package definition.
 func Packages(context *generator.Context, arguments *args.GeneratorArgs) generator.Packages {
 	packages := generator.Packages{}
-	header := append([]byte(fmt.Sprintf("// +build !%s\n\n", arguments.GeneratedBuildTag)))
+	header := []byte(fmt.Sprintf("// +build !%s\n\n", arguments.GeneratedBuildTag))
+
+	filePerType, generateTests, jsonSchema, openAPI, proto, typeScript := false, false, false, false, false, false
+	var peerPkgs []string
+	if customArgs, ok := arguments.CustomArgs.(*CustomArgs); ok {
+		for _, pkg := range customArgs.ExtraPeerDirs {
+			if i := strings.Index(pkg, "/vendor/"); i != -1 {
+				pkg = pkg[i+len("/vendor/"):]
+			}
+			peerPkgs = append(peerPkgs, pkg)
+		}
+		filePerType = customArgs.FilePerType
+		generateTests = customArgs.GenerateTests
+		jsonSchema = customArgs.JSONSchema
+		openAPI = customArgs.OpenAPI
+		proto = customArgs.Proto
+		typeScript = customArgs.TypeScript
+	}
+	context.FileTypes[jsonFileType] = newJSONFile()
+	context.FileTypes[yamlFileType] = newTextFile()
+	context.FileTypes[protoFileType] = newTextFile()
+	context.FileTypes[typeScriptFileType] = newTypeScriptFile()
+
+	// The input and peer packages are known to be generated before their
+	// types are read, since they may refer to each other.
+	for _, pkg := range append(append([]string{}, context.Inputs...), peerPkgs...) {
+		generatedPackages[pkg] = true
+	}
+
+	// Make sure our peer-packages are added and fully parsed, so that their
+	// comment tags and enums are known.
+	for _, pp := range peerPkgs {
+		pkg, err := context.AddDirectory(pp)
+		if err != nil {
+			klog.Fatalf("Failed adding peer package %q: %v", pp, err)
+		}
+		if err := addGeneratedPackage(pkg, arguments.GeneratedBuildTag); err != nil {
+			klog.Fatalf("Failed reading peer package %q: %v", pp, err)
+		}
+	}
+
+	// Every input package is added before any is checked, so that the
+	// messages and binary structs of the input packages referring to each
+	// other are known.
+	for _, i := range context.Inputs {
+		if pkg := context.Universe[i]; pkg != nil {
+			if err := addGeneratedPackage(pkg, arguments.GeneratedBuildTag); err != nil {
+				klog.Fatalf("Failed reading %q: %v", i, err)
+			}
+		}
+	}
+	for _, i := range context.Inputs {
+		if pkg := context.Universe[i]; pkg != nil {
+			if err := checkProtoMessages(pkg); err != nil {
+				klog.Fatalf("Failed checking %q: %v", i, err)
+			}
+			if err := checkBinaryStructs(pkg); err != nil {
+				klog.Fatalf("Failed checking %q: %v", i, err)
+			}
+		}
+	}
 
 	// We are generating defaults only for packages that are explicitly
 	// passed as InputDir.
@@ -77,19 +143,34 @@ func Packages(context *generator.Context, arguments *args.GeneratorArgs) generat
 				// single file.
 				GeneratorFunc: func(c *generator.Context) (generators []generator.Generator) {
 					generators = make([]generator.Generator, 0)
-					// Since we want a file per type that we generate a set for, we
-					// have to provide a function for this.
-					for _, t := range c.Order {
-						generators = append(generators, &marshalGen{
-							DefaultGen: generator.DefaultGen{
-								// Use the privatized version of the
-								// type name as the file name.
-								OptionalName: ToSnake(ToSnake(c.Namers["private"].Name(t) + "Marshal")),
-							},
-							targetPackage: pkg.Path,
-							typeToMatch:   t,
-							imports:       generator.NewImportTracker(),
-						})
+					if !filePerType {
+						// All types go into a single file, in the context's
+						// canonical (sorted) order.
+						generators = append(generators, NewMarshalGen(arguments.OutputFileBaseName, pkg.Path, nil))
+					} else {
+						// Since we want a file per type that we generate a set for, we
+						// have to provide a function for this.
+						for _, t := range c.Order {
+							// Use the privatized version of the
+							// type name as the file name.
+							name := ToSnake(ToSnake(c.Namers["private"].Name(t) + "Marshal"))
+							generators = append(generators, NewMarshalGen(name, pkg.Path, t))
+						}
+					}
+					if generateTests {
+						generators = append(generators, NewRoundTripTestGen(arguments.OutputFileBaseName, pkg.Path))
+					}
+					if jsonSchema {
+						generators = append(generators, NewJSONSchemaGen(arguments.OutputFileBaseName, pkg.Path))
+					}
+					if openAPI && hasOpenAPITypes(c) {
+						generators = append(generators, NewOpenAPIGen(arguments.OutputFileBaseName, pkg.Path))
+					}
+					if proto && hasProtoMessages(c) {
+						generators = append(generators, NewProtoGen(arguments.OutputFileBaseName, pkg.Path))
+					}
+					if typeScript {
+						generators = append(generators, NewTypeScriptGen(arguments.OutputFileBaseName, pkg.Path))
 					}
 					return generators
 				},
@@ -111,6 +192,56 @@ func Packages(context *generator.Context, arguments *args.GeneratorArgs) generat
 	return packages
 }
 
+// generatedPackages holds the paths of the packages whose types have methods
+// generated by marshal-gen: the input packages and the peer packages.
+var generatedPackages = map[string]bool{}
+
+// addGeneratedPackage records pkg as generated, along with its enums, binary
+// structs, protobuf messages and XML structs, once its int8 types are
+// resolved.
+func addGeneratedPackage(pkg *types.Package, buildTag string) error {
+	generatedPackages[pkg.Path] = true
+	if err := resolveInt8(pkg, buildTag); err != nil {
+		return fmt.Errorf("resolving the int8 types: %v", err)
+	}
+	found, err := findEnums(pkg, buildTag)
+	if err != nil {
+		return fmt.Errorf("finding the enums: %v", err)
+	}
+	for t, e := range found {
+		enums[t] = e
+	}
+	structs, err := findBinaryStructs(pkg)
+	if err != nil {
+		return fmt.Errorf("finding the binary structs: %v", err)
+	}
+	for t, s := range structs {
+		binaryStructs[t] = s
+	}
+	messages, err := findProtoMessages(pkg)
+	if err != nil {
+		return fmt.Errorf("finding the protobuf messages: %v", err)
+	}
+	for t, m := range messages {
+		protoMessages[t] = m
+	}
+	xmlFound, err := findXMLStructs(pkg)
+	if err != nil {
+		return fmt.Errorf("finding the XML structs: %v", err)
+	}
+	for t, s := range xmlFound {
+		xmlStructs[t] = s
+	}
+	return nil
+}
+
+// isForeign returns true if t is a named type of a package which is not
+// generated. Whether it has generated methods, such as the MarshalText method
+// of an enum, is not known, so it is encoded through reflection.
+func isForeign(t *types.Type) bool {
+	return t.Name.Package != "" && !generatedPackages[t.Name.Package]
+}
+
 type marshalGen struct {
 	generator.DefaultGen
 	targetPackage string
@@ -118,8 +249,24 @@ type marshalGen struct {
 	imports       namer.ImportTracker
 }
 
-// Filter ignores all but one type because we're making a single file per type.
-func (g *marshalGen) Filter(c *generator.Context, t *types.Type) bool { return t == g.typeToMatch }
+// NewMarshalGen returns a generator writing the marshal methods into the file
+// sanitizedName. If typeToMatch is nil every type of the package is written,
+// otherwise only typeToMatch is.
+func NewMarshalGen(sanitizedName, targetPackage string, typeToMatch *types.Type) generator.Generator {
+	return &marshalGen{
+		DefaultGen: generator.DefaultGen{
+			OptionalName: sanitizedName,
+		},
+		targetPackage: targetPackage,
+		typeToMatch:   typeToMatch,
+		imports:       generator.NewImportTracker(),
+	}
+}
+
+// Filter ignores all but one type when we're making a single file per type.
+func (g *marshalGen) Filter(c *generator.Context, t *types.Type) bool {
+	return g.typeToMatch == nil || t == g.typeToMatch
+}
 
 func (g *marshalGen) Namers(c *generator.Context) namer.NameSystems {
 	return namer.NameSystems{
@@ -128,12 +275,18 @@ func (g *marshalGen) Namers(c *generator.Context) namer.NameSystems {
 }
 
 func (g *marshalGen) Imports(c *generator.Context) (imports []string) {
-	importLines := []string{"encoding/json"}
+	importLines := []string{"database/sql/driver", "encoding/json", "errors", "fmt", "io", "sort"}
+	// The enums use strconv, which other code may have imported already.
+	strconvImported := false
 	for _, singleImport := range g.imports.ImportLines() {
 		if g.isOtherPackage(singleImport) {
 			importLines = append(importLines, singleImport)
+			strconvImported = strconvImported || strings.HasSuffix(singleImport, "\"strconv\"")
 		}
 	}
+	if !strconvImported {
+		importLines = append(importLines, "strconv")
+	}
 
 	return importLines
 }
@@ -168,6 +321,33 @@ func (g *marshalGen) args(t *types.Type, kv ...interface{}) interface{} {
 func (g *marshalGen) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
 	sw := generator.NewSnippetWriter(w, c, "$", "$")
 	sw.Do(templateCode, g.args(t))
+	if e := enumOf(t); e != nil {
+		g.generateEnum(c, t, e, sw)
+	} else {
+		sw.Do(stringTemplateCode, g.args(t))
+	}
+	g.generateStream(t, sw)
+	if t.Kind == types.Struct {
+		g.generateStrict(t, sw)
+	}
+	if extractTypeTag(t, sqlTagName) {
+		g.generateSQL(c, t, sw)
+	}
+	if t.Kind == types.Struct && extractTypeTag(t, yamlTagName) {
+		g.generateYAML(t, sw)
+	}
+	if t.Kind == types.Struct && extractTypeTag(t, tomlTagName) {
+		g.generateTOML(t, sw)
+	}
+	if s := xmlStructOf(t); s != nil {
+		g.generateXML(t, s, sw)
+	}
+	if s := binaryStructOf(t); s != nil {
+		g.generateBinary(c, t, s, sw)
+	}
+	if m := protoMessageOf(t); m != nil {
+		g.generateProto(c, t, m, sw)
+	}
 	return sw.Error()
 }
 
@@ -188,7 +368,9 @@ func (obj *$.type|raw$) UnmarshalJSONBinary(data []byte) error {
 
 	return nil
 }
+`
 
+var stringTemplateCode = `
 // String is used to print values passed as an operand
 // to any format that accepts a string or to an unformatted printer
 // such as Print.
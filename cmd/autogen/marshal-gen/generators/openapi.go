@@ -0,0 +1,180 @@
+package generators
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// openAPITagName opts a type into the OpenAPI components, e.g.
+// +gengo:marshal:openapi=true.
+const openAPITagName = typeTagName + ":openapi"
+
+// yamlFileType is the file type of the generated YAML documents.
+const yamlFileType = "yaml"
+
+// openAPIVersion is the version of the generated OpenAPI documents, whose
+// schemas are JSON Schema draft 2020-12.
+const openAPIVersion = "3.1.0"
+
+type openAPIGen struct {
+	generator.DefaultGen
+	targetPackage string
+	builder       *schemaBuilder
+}
+
+// NewOpenAPIGen returns a generator writing the file
+// sanitizedName.openapi.yaml, an OpenAPI document holding the schemas of the
+// types tagged +gengo:marshal:openapi=true, and of the types of the package
+// they reference, under components/schemas. The opted-in types of other
+// generated packages are references into the sanitizedName.openapi.yaml file
+// of their package; the other types of other packages, which have no such
+// file, are defined in the document, e.g. as xml.Name.
+func NewOpenAPIGen(sanitizedName, targetPackage string) generator.Generator {
+	g := &openAPIGen{
+		DefaultGen: generator.DefaultGen{
+			OptionalName: sanitizedName,
+		},
+		targetPackage: targetPackage,
+	}
+	g.builder = newSchemaBuilder(targetPackage, func(t *types.Type) string {
+		if hasForeignOpenAPIDoc(targetPackage, t) {
+			return relativePath(targetPackage, t.Name.Package) + "/" + g.Filename() + "#/components/schemas/" + t.Name.Name
+		}
+		return "#/components/schemas/" + g.builder.defName(t)
+	})
+	g.builder.definedElsewhere = func(t *types.Type) bool {
+		return hasForeignOpenAPIDoc(targetPackage, t)
+	}
+	return g
+}
+
+// hasForeignOpenAPIDoc returns true if t, a type of another package than
+// targetPackage, is defined in the OpenAPI document of its package: its
+// package is generated and t opts into the OpenAPI components.
+func hasForeignOpenAPIDoc(targetPackage string, t *types.Type) bool {
+	return t.Name.Package != targetPackage && generatedPackages[t.Name.Package] && extractTypeTag(t, openAPITagName)
+}
+
+// hasOpenAPITypes returns true if a type of the context opts into the OpenAPI
+// components.
+func hasOpenAPITypes(c *generator.Context) bool {
+	for _, t := range c.Order {
+		if extractTypeTag(t, openAPITagName) {
+			return true
+		}
+	}
+	return false
+}
+
+// relativePath returns the path of the package to relative to the package
+// from, both given by their import path.
+func relativePath(from, to string) string {
+	rel, err := filepath.Rel(filepath.FromSlash(from), filepath.FromSlash(to))
+	if err != nil {
+		return to
+	}
+	return filepath.ToSlash(rel)
+}
+
+func (g *openAPIGen) Filename() string {
+	return g.OptionalName + ".openapi.yaml"
+}
+
+func (g *openAPIGen) FileType() string {
+	return yamlFileType
+}
+
+// Filter keeps the types opting into the OpenAPI components.
+func (g *openAPIGen) Filter(c *generator.Context, t *types.Type) bool {
+	return extractTypeTag(t, openAPITagName)
+}
+
+// GenerateType defines t and the types of the package it references.
+func (g *openAPIGen) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	g.builder.define(t)
+	return nil
+}
+
+// Finalize writes the document.
+func (g *openAPIGen) Finalize(c *generator.Context, w io.Writer) error {
+	defs := g.builder.defs
+	sort.SliceStable(defs, func(i, j int) bool {
+		return defs[i].name < defs[j].name
+	})
+	var buf bytes.Buffer
+	if err := encodeJSON(&buf, defs); err != nil {
+		return err
+	}
+	schemas, err := decodeOrdered(json.NewDecoder(&buf))
+	if err != nil {
+		return err
+	}
+	doc := yaml.MapSlice{
+		{Key: "openapi", Value: openAPIVersion},
+		{Key: "info", Value: yaml.MapSlice{
+			{Key: "title", Value: g.targetPackage},
+			{Key: "version", Value: "0.0.0"},
+		}},
+		{Key: "components", Value: yaml.MapSlice{
+			{Key: "schemas", Value: schemas},
+		}},
+	}
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// decodeOrdered reads the next JSON value of dec, keeping the order of the
+// keys of objects in yaml.MapSlice values.
+func decodeOrdered(dec *json.Decoder) (interface{}, error) {
+	dec.UseNumber()
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object := yaml.MapSlice{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, yaml.MapItem{Key: key, Value: value})
+		}
+		_, err = dec.Token()
+		return object, err
+	case json.Delim('['):
+		array := []interface{}{}
+		for dec.More() {
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err = dec.Token()
+		return array, err
+	}
+	if n, ok := token.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return i, nil
+		}
+		return n.Float64()
+	}
+	return token, nil
+}
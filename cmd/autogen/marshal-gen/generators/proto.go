@@ -0,0 +1,434 @@
+package generators
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// Protocol Buffers comment tags. +gengo:marshal:proto=true on a struct makes
+// it a message, along with the structs of its package it refers to; every
+// member of a message carries +gengo:marshal:proto-field=<number>, or =- to be
+// left out. +gengo:marshal:proto-reserved=<number>,... on a message reserves
+// the numbers of removed fields.
+const (
+	protoTagName         = typeTagName + ":proto"
+	protoFieldTagName    = typeTagName + ":proto-field"
+	protoReservedTagName = typeTagName + ":proto-reserved"
+)
+
+// protoFileType is the file type of the generated .proto files.
+const protoFileType = "proto"
+
+// protoMessage is a struct with a protobuf message.
+type protoMessage struct {
+	fields   []protoField
+	reserved []int
+}
+
+// protoField is a member of a struct with a protobuf message.
+type protoField struct {
+	member types.Member
+	number int
+	// name is the name of the field in the .proto file.
+	name  string
+	shape *protoShape
+}
+
+// protoMessages holds the messages of the packages being generated, as found
+// by findProtoMessages.
+var protoMessages = map[*types.Type]*protoMessage{}
+
+// protoMessageOf returns the message of t, or nil if t has none.
+func protoMessageOf(t *types.Type) *protoMessage {
+	return protoMessages[t]
+}
+
+// findProtoMessages returns the messages of pkg: its structs tagged
+// +gengo:marshal:proto=true and the structs of pkg they refer to.
+func findProtoMessages(pkg *types.Package) (map[*types.Type]*protoMessage, error) {
+	var names []string
+	for name := range pkg.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	found := map[*types.Type]*protoMessage{}
+	for _, name := range names {
+		t := pkg.Types[name]
+		if !extractTypeTag(t, protoTagName) {
+			continue
+		}
+		if t.Kind != types.Struct {
+			return nil, fmt.Errorf("%v: only structs can be protobuf messages", t)
+		}
+		if err := addProtoMessage(t, found); err != nil {
+			return nil, err
+		}
+	}
+	return found, nil
+}
+
+// addProtoMessage adds the message of the struct t, and of the structs of its
+// package it refers to, to found.
+func addProtoMessage(t *types.Type, found map[*types.Type]*protoMessage) error {
+	if found[t] != nil {
+		return nil
+	}
+	m := &protoMessage{}
+	found[t] = m
+
+	comments := append(append([]string{}, t.SecondClosestCommentLines...), t.CommentLines...)
+	numbers := map[int]string{}
+	for _, values := range types.ExtractCommentTags("+", comments)[protoReservedTagName] {
+		for _, value := range strings.Split(values, ",") {
+			number, err := parseProtoFieldNumber(strings.TrimSpace(value))
+			if err != nil {
+				return fmt.Errorf("%v: %v", t, err)
+			}
+			m.reserved = append(m.reserved, number)
+			numbers[number] = "reserved"
+		}
+	}
+
+	for _, member := range t.Members {
+		values := types.ExtractCommentTags("+", member.CommentLines)[protoFieldTagName]
+		if len(values) == 0 {
+			return fmt.Errorf("%v.%s: missing +%s=<number> comment tag, or =- to leave the member out", t, member.Name, protoFieldTagName)
+		}
+		if values[0] == "-" {
+			continue
+		}
+		number, err := parseProtoFieldNumber(values[0])
+		if err != nil {
+			return fmt.Errorf("%v.%s: %v", t, member.Name, err)
+		}
+		if other, taken := numbers[number]; taken {
+			return fmt.Errorf("%v.%s: field number %d is already used by %s", t, member.Name, number, other)
+		}
+		numbers[number] = member.Name
+
+		shape, err := protoShapeOf(member.Type)
+		if err != nil {
+			return fmt.Errorf("%v.%s: %v", t, member.Name, err)
+		}
+		m.fields = append(m.fields, protoField{member: member, number: number, name: ToSnake(member.Name), shape: shape})
+
+		if message := shape.value.message; message != nil && message.Name.Package == t.Name.Package {
+			if err := addProtoMessage(message, found); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkProtoMessages returns an error if a message of pkg has a field of a
+// struct of another package without a message. It is called once the messages
+// of every generated package are known.
+func checkProtoMessages(pkg *types.Package) error {
+	var messages []*types.Type
+	for t := range protoMessages {
+		if t.Name.Package == pkg.Path {
+			messages = append(messages, t)
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].Name.Name < messages[j].Name.Name
+	})
+	for _, t := range messages {
+		for _, f := range protoMessages[t].fields {
+			if message := f.shape.value.message; message != nil && protoMessageOf(message) == nil {
+				return fmt.Errorf("%v.%s: %v is not a protobuf message, tag it +%s=true in a generated package", t, f.member.Name, message, protoTagName)
+			}
+		}
+	}
+	return nil
+}
+
+// parseProtoFieldNumber parses a field number, which protobuf limits to
+// 1..2^29-1 outside of the 19000..19999 range it reserves.
+func parseProtoFieldNumber(s string) (int, error) {
+	number, err := strconv.Atoi(s)
+	if err != nil || number < 1 || number > 1<<29-1 || number >= 19000 && number <= 19999 {
+		return 0, fmt.Errorf("invalid protobuf field number %q", s)
+	}
+	return number, nil
+}
+
+// protoScalar describes how a Go type maps to a protobuf scalar type.
+type protoScalar struct {
+	// name is the protobuf type.
+	name string
+	// goType is the Go type protowire encodes and decodes the scalar as.
+	goType string
+	// wire is the wire type, e.g. Varint: the scalar is written by
+	// protowire.AppendVarint and read by protowire.ConsumeVarint, unless
+	// appendFunc or consumeFunc are set.
+	wire        string
+	appendFunc  string
+	consumeFunc string
+	// encode and decode are the formats of the conversion of a goType value
+	// to its wire value, and back.
+	encode string
+	decode string
+	// nonZero is the format of the check that a value is not the default,
+	// which proto3 does not write.
+	nonZero string
+}
+
+// packed returns true for the scalars whose repeated fields are packed.
+func (s *protoScalar) packed() bool {
+	return s.wire != "Bytes"
+}
+
+var (
+	protoBool   = &protoScalar{name: "bool", goType: "bool", wire: "Varint", encode: "%s.EncodeBool(%s)", decode: "%s.DecodeBool(%s)", nonZero: "%s"}
+	protoInt32  = &protoScalar{name: "int32", goType: "int32", wire: "Varint", encode: "uint64(%[2]s)", decode: "int32(%[2]s)", nonZero: "%s != 0"}
+	protoInt64  = &protoScalar{name: "int64", goType: "int64", wire: "Varint", encode: "uint64(%[2]s)", decode: "int64(%[2]s)", nonZero: "%s != 0"}
+	protoUint32 = &protoScalar{name: "uint32", goType: "uint32", wire: "Varint", encode: "uint64(%[2]s)", decode: "uint32(%[2]s)", nonZero: "%s != 0"}
+	protoUint64 = &protoScalar{name: "uint64", goType: "uint64", wire: "Varint", encode: "uint64(%[2]s)", decode: "%[2]s", nonZero: "%s != 0"}
+	protoFloat  = &protoScalar{name: "float", goType: "float32", wire: "Fixed32", encode: "math.Float32bits(%[2]s)", decode: "math.Float32frombits(%[2]s)", nonZero: "math.Float32bits(%s) != 0"}
+	protoDouble = &protoScalar{name: "double", goType: "float64", wire: "Fixed64", encode: "math.Float64bits(%[2]s)", decode: "math.Float64frombits(%[2]s)", nonZero: "math.Float64bits(%s) != 0"}
+	protoString = &protoScalar{name: "string", goType: "string", wire: "Bytes", appendFunc: "AppendString", consumeFunc: "ConsumeString", encode: "%[2]s", decode: "%[2]s", nonZero: `%s != ""`}
+	protoBytes  = &protoScalar{name: "bytes", goType: "[]byte", wire: "Bytes", appendFunc: "AppendBytes", consumeFunc: "ConsumeBytes", encode: "%[2]s", decode: "append([]byte(nil), %[2]s...)", nonZero: "len(%s) != 0"}
+)
+
+// protoScalarOf returns the scalar t maps to, or nil if t is not a scalar.
+func protoScalarOf(t *types.Type) *protoScalar {
+	if isByteSlice(underlyingType(t)) {
+		return protoBytes
+	}
+	ut := underlyingType(t)
+	if ut.Kind != types.Builtin {
+		return nil
+	}
+	switch ut.Name.Name {
+	case "bool":
+		return protoBool
+	case "int8", "int16", "int32", "rune":
+		return protoInt32
+	case "int", "int64":
+		return protoInt64
+	case "byte", "uint16", "uint32":
+		return protoUint32
+	case "uint", "uint64", "uintptr":
+		return protoUint64
+	case "float32":
+		return protoFloat
+	case "float64":
+		return protoDouble
+	case "string":
+		return protoString
+	}
+	return nil
+}
+
+// protoValue is a single protobuf value: a scalar or a message.
+type protoValue struct {
+	// t is the Go type of the value.
+	t      *types.Type
+	scalar *protoScalar
+	// message is the struct of a message value, which t is or points to.
+	message *types.Type
+}
+
+// protoValueOf returns the value t maps to.
+func protoValueOf(t *types.Type) (protoValue, bool) {
+	if scalar := protoScalarOf(t); scalar != nil {
+		return protoValue{t: t, scalar: scalar}, true
+	}
+	if t.Kind == types.Struct {
+		return protoValue{t: t, message: t}, true
+	}
+	if t.Kind == types.Pointer && t.Elem.Kind == types.Struct {
+		return protoValue{t: t, message: t.Elem}, true
+	}
+	return protoValue{}, false
+}
+
+// pointer returns true if the value is a message referred to by pointer.
+func (v protoValue) pointer() bool {
+	return v.message != nil && v.t.Kind == types.Pointer
+}
+
+// Field shapes.
+const (
+	protoSingular = "singular"
+	protoOptional = "optional"
+	protoRepeated = "repeated"
+	protoMap      = "map"
+)
+
+// protoShape describes how a Go type maps to a protobuf field.
+type protoShape struct {
+	kind string
+	// value is the value of singular fields, the value optional fields point
+	// to, the elements of repeated fields and the values of maps.
+	value protoValue
+	// key is the key of maps.
+	key protoValue
+}
+
+// protoShapeOf returns the shape of the fields of type t.
+func protoShapeOf(t *types.Type) (*protoShape, error) {
+	if value, ok := protoValueOf(t); ok {
+		return &protoShape{kind: protoSingular, value: value}, nil
+	}
+	ut := underlyingType(t)
+	switch ut.Kind {
+	case types.Pointer:
+		if scalar := protoScalarOf(ut.Elem); scalar != nil && scalar != protoBytes {
+			return &protoShape{kind: protoOptional, value: protoValue{t: ut.Elem, scalar: scalar}}, nil
+		}
+	case types.Slice:
+		if value, ok := protoValueOf(ut.Elem); ok {
+			return &protoShape{kind: protoRepeated, value: value}, nil
+		}
+	case types.Map:
+		key, ok := protoValueOf(ut.Key)
+		if !ok || key.scalar == nil || key.scalar == protoBytes || key.scalar == protoFloat || key.scalar == protoDouble {
+			return nil, fmt.Errorf("unsupported protobuf map key %v", ut.Key)
+		}
+		if value, ok := protoValueOf(ut.Elem); ok {
+			return &protoShape{kind: protoMap, key: key, value: value}, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported protobuf field type %v", t)
+}
+
+// protoPackageName returns the protobuf package of the Go package path.
+func protoPackageName(pkgPath string) string {
+	return protoIdentifier.ReplaceAllString(strings.Replace(pkgPath, "/", ".", -1), "_")
+}
+
+// protoIdentifier matches the characters not allowed in protobuf package
+// names.
+var protoIdentifier = regexp.MustCompile(`[^A-Za-z0-9_.]`)
+
+type protoGen struct {
+	generator.DefaultGen
+	targetPackage string
+	imports       map[string]bool
+	messages      strings.Builder
+}
+
+// NewProtoGen returns a generator writing the file sanitizedName.proto,
+// declaring the protobuf messages of the package. Messages of other packages
+// are imported from the sanitizedName.proto file of their package.
+func NewProtoGen(sanitizedName, targetPackage string) generator.Generator {
+	return &protoGen{
+		DefaultGen: generator.DefaultGen{
+			OptionalName: sanitizedName,
+		},
+		targetPackage: targetPackage,
+		imports:       map[string]bool{},
+	}
+}
+
+// hasProtoMessages returns true if a type of the context has a message.
+func hasProtoMessages(c *generator.Context) bool {
+	for _, t := range c.Order {
+		if protoMessageOf(t) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *protoGen) Filename() string {
+	return g.OptionalName + ".proto"
+}
+
+func (g *protoGen) FileType() string {
+	return protoFileType
+}
+
+// Filter keeps the types with a message.
+func (g *protoGen) Filter(c *generator.Context, t *types.Type) bool {
+	return protoMessageOf(t) != nil
+}
+
+// GenerateType declares the message of t.
+func (g *protoGen) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	m := protoMessageOf(t)
+	b := &g.messages
+	b.WriteString("\n")
+	writeProtoComment(b, t.CommentLines, "")
+	fmt.Fprintf(b, "message %s {\n", t.Name.Name)
+	if len(m.reserved) > 0 {
+		var numbers []string
+		for _, number := range m.reserved {
+			numbers = append(numbers, strconv.Itoa(number))
+		}
+		fmt.Fprintf(b, "  reserved %s;\n\n", strings.Join(numbers, ", "))
+	}
+	for _, f := range m.fields {
+		writeProtoComment(b, f.member.CommentLines, "  ")
+		typ := g.typeOf(f.shape.value)
+		switch f.shape.kind {
+		case protoOptional:
+			typ = "optional " + typ
+		case protoRepeated:
+			typ = "repeated " + typ
+		case protoMap:
+			typ = "map<" + g.typeOf(f.shape.key) + ", " + typ + ">"
+		}
+		fmt.Fprintf(b, "  %s %s = %d;\n", typ, f.name, f.number)
+	}
+	b.WriteString("}\n")
+	return nil
+}
+
+// typeOf returns the protobuf type of the value v.
+func (g *protoGen) typeOf(v protoValue) string {
+	if v.scalar != nil {
+		return v.scalar.name
+	}
+	if v.message.Name.Package == g.targetPackage {
+		return v.message.Name.Name
+	}
+	g.imports[v.message.Name.Package+"/"+g.Filename()] = true
+	return "." + protoPackageName(v.message.Name.Package) + "." + v.message.Name.Name
+}
+
+// Finalize writes the file.
+func (g *protoGen) Finalize(c *generator.Context, w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("// Code generated by marshal-gen. DO NOT EDIT.\n\n")
+	b.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&b, "package %s;\n\n", protoPackageName(g.targetPackage))
+	var imports []string
+	for i := range g.imports {
+		imports = append(imports, i)
+	}
+	sort.Strings(imports)
+	for _, i := range imports {
+		fmt.Fprintf(&b, "import %s;\n", strconv.Quote(i))
+	}
+	if len(imports) > 0 {
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "option go_package = %s;\n", strconv.Quote(g.targetPackage))
+	b.WriteString(g.messages.String())
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeProtoComment writes the doc comment lines, without their comment tags,
+// as a protobuf comment.
+func writeProtoComment(b *strings.Builder, lines []string, indent string) {
+	description := commentDescription(lines)
+	if description == "" {
+		return
+	}
+	for _, line := range strings.Split(description, "\n") {
+		fmt.Fprintf(b, "%s// %s\n", indent, strings.TrimRight(line, " "))
+	}
+}
@@ -0,0 +1,78 @@
+package generators
+
+import (
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+func Test_checkProtoMessages(t *testing.T) {
+	defer func(saved map[*types.Type]*protoMessage) { protoMessages = saved }(protoMessages)
+	protoMessages = map[*types.Type]*protoMessage{}
+
+	tagged := &types.Type{
+		Name:         types.Name{Package: "other", Name: "Tagged"},
+		Kind:         types.Struct,
+		CommentLines: []string{"+gengo:marshal:proto=true"},
+	}
+	untagged := &types.Type{Name: types.Name{Package: "encoding/xml", Name: "Name"}, Kind: types.Struct}
+	protoMessages[tagged] = &protoMessage{}
+
+	testCases := []struct {
+		field  *types.Type
+		expect string
+	}{
+		{field: tagged},
+		{field: &types.Type{Kind: types.Slice, Elem: &types.Type{Kind: types.Pointer, Elem: tagged}}},
+		{
+			field:  untagged,
+			expect: "pkg.T.Field: encoding/xml.Name is not a protobuf message, tag it +gengo:marshal:proto=true in a generated package",
+		},
+		{
+			field:  &types.Type{Kind: types.Map, Key: types.String, Elem: &types.Type{Kind: types.Pointer, Elem: untagged}},
+			expect: "pkg.T.Field: encoding/xml.Name is not a protobuf message, tag it +gengo:marshal:proto=true in a generated package",
+		},
+	}
+
+	for i, tc := range testCases {
+		typ := &types.Type{
+			Name: types.Name{Package: "pkg", Name: "T"},
+			Kind: types.Struct,
+			Members: []types.Member{
+				{Name: "Field", Type: tc.field, CommentLines: []string{"+gengo:marshal:proto-field=1"}},
+			},
+		}
+		found := map[*types.Type]*protoMessage{}
+		if err := addProtoMessage(typ, found); err != nil {
+			t.Fatalf("case[%d]: %v", i, err)
+		}
+		protoMessages[typ] = found[typ]
+		err := checkProtoMessages(&types.Package{Path: "pkg"})
+		delete(protoMessages, typ)
+		if tc.expect == "" && err != nil {
+			t.Errorf("case[%d]: unexpected error %v", i, err)
+		}
+		if tc.expect != "" && (err == nil || err.Error() != tc.expect) {
+			t.Errorf("case[%d]: expected error %q, got %v", i, tc.expect, err)
+		}
+	}
+}
+
+func Test_protoScalarOf(t *testing.T) {
+	testCases := []struct {
+		t      *types.Type
+		expect *protoScalar
+	}{
+		{t: int8Type, expect: protoInt32},
+		{t: types.Byte, expect: protoUint32},
+		{t: &types.Type{Kind: types.Alias, Name: types.Name{Package: "pkg", Name: "Offset"}, Underlying: int8Type}, expect: protoInt32},
+		{t: &types.Type{Kind: types.Slice, Name: types.Name{Name: "[]byte"}, Elem: types.Byte}, expect: protoBytes},
+		{t: &types.Type{Kind: types.Slice, Name: types.Name{Name: "[]int8"}, Elem: int8Type}},
+	}
+
+	for i, tc := range testCases {
+		if r := protoScalarOf(tc.t); r != tc.expect {
+			t.Errorf("case[%d]: expected %v, got %v", i, tc.expect, r)
+		}
+	}
+}
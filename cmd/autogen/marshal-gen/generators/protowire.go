@@ -0,0 +1,336 @@
+package generators
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// protowirePackage encodes and decodes the protobuf wire format for the
+// generated code.
+const protowirePackage = "google.golang.org/protobuf/encoding/protowire"
+
+// protowireName imports protowire into the generated file and returns the
+// local name it is imported as.
+func (g *marshalGen) protowireName() string {
+	g.imports.AddType(&types.Type{Name: types.Name{Package: protowirePackage, Name: "Number"}})
+	return g.imports.LocalNameOf(protowirePackage)
+}
+
+// generateProto writes the MarshalProto, AppendProto and UnmarshalProto
+// methods of t, converting it from and to its protobuf message m.
+func (g *marshalGen) generateProto(c *generator.Context, t *types.Type, m *protoMessage, sw *generator.SnippetWriter) {
+	p := &protoWriter{pw: g.protowireName(), raw: c.Namers["raw"], sw: sw}
+	for _, f := range m.fields {
+		for _, s := range []*protoScalar{f.shape.value.scalar, f.shape.key.scalar} {
+			if s == protoFloat || s == protoDouble {
+				g.imports.AddType(&types.Type{Name: types.Name{Package: "math", Name: "Float64bits"}})
+			}
+		}
+	}
+	args := generator.Args{"type": t, "pw": p.pw}
+	sw.Do(protoTemplateCode, args)
+
+	sw.Do("func (obj *$.type|raw$) AppendProto(b []byte) []byte {\n", args)
+	for _, f := range m.fields {
+		p.appendField(f, "obj."+f.member.Name)
+	}
+	sw.Do("return b\n", nil)
+	sw.Do("}\n\n", nil)
+
+	sw.Do(unmarshalProtoTemplateCode, args)
+	sw.Do("func (obj *$.type|raw$) UnmarshalProto(data []byte) error {\n", args)
+	sw.Do("var zero $.type|raw$\n", args)
+	sw.Do("*obj = zero\n", nil)
+	sw.Do("for len(data) > 0 {\n", nil)
+	sw.Do("num, typ, n := $.pw$.ConsumeTag(data)\n", args)
+	p.checkParsed()
+	sw.Do("data = data[n:]\n", nil)
+	sw.Do("switch num {\n", nil)
+	for _, f := range m.fields {
+		sw.Do("case $.$:\n", strconv.Itoa(f.number))
+		p.consumeField(f, "obj."+f.member.Name)
+	}
+	sw.Do("}\n", nil)
+	sw.Do("// Skip unknown fields, and fields of an unexpected wire type.\n", nil)
+	sw.Do("n = $.pw$.ConsumeFieldValue(num, typ, data)\n", args)
+	p.checkParsed()
+	sw.Do("data = data[n:]\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("return nil\n", nil)
+	sw.Do("}\n\n", nil)
+}
+
+// protoWriter writes the code converting the fields of a struct from and to
+// protobuf.
+type protoWriter struct {
+	// pw is the local name of the protowire package.
+	pw  string
+	raw interface{ Name(*types.Type) string }
+	sw  *generator.SnippetWriter
+}
+
+// do writes the code format, whose verbs are replaced by a.
+func (p *protoWriter) do(format string, a ...interface{}) {
+	p.sw.Do("$.$", fmt.Sprintf(format, a...))
+}
+
+// toGo returns expr, of type t, converted to the Go type protowire handles
+// the scalar s as.
+func (p *protoWriter) toGo(s *protoScalar, t *types.Type, expr string) string {
+	if p.raw.Name(t) == s.goType {
+		return expr
+	}
+	return s.goType + "(" + expr + ")"
+}
+
+// fromGo returns expr, of the Go type protowire handles the scalar s as,
+// converted to t.
+func (p *protoWriter) fromGo(s *protoScalar, t *types.Type, expr string) string {
+	if name := p.raw.Name(t); name != s.goType {
+		return name + "(" + expr + ")"
+	}
+	return expr
+}
+
+// wireType returns the wire type of the value v.
+func (p *protoWriter) wireType(v protoValue) string {
+	if v.message != nil {
+		return p.pw + ".BytesType"
+	}
+	return p.pw + "." + v.scalar.wire + "Type"
+}
+
+// appendTag writes the code appending the tag of the field number of value
+// v to the buffer b.
+func (p *protoWriter) appendTag(b string, number int, v protoValue) {
+	p.do("%s = %s.AppendTag(%s, %d, %s)\n", b, p.pw, b, number, p.wireType(v))
+}
+
+// appendValue writes the code appending expr, the value v, to the buffer b.
+func (p *protoWriter) appendValue(b string, v protoValue, expr string) {
+	if v.message != nil {
+		if v.pointer() {
+			p.do("if %s == nil {\n", expr)
+			p.do("%s = %s.AppendBytes(%s, nil)\n", b, p.pw, b)
+			p.do("} else {\n")
+			p.do("%s = %s.AppendBytes(%s, %s.AppendProto(nil))\n", b, p.pw, b, expr)
+			p.do("}\n")
+			return
+		}
+		p.do("%s = %s.AppendBytes(%s, %s.AppendProto(nil))\n", b, p.pw, b, expr)
+		return
+	}
+	s := v.scalar
+	appendFunc := s.appendFunc
+	if appendFunc == "" {
+		appendFunc = "Append" + s.wire
+	}
+	p.do("%s = %s.%s(%s, %s)\n", b, p.pw, appendFunc, b, fmt.Sprintf(s.encode, p.pw, p.toGo(s, v.t, expr)))
+}
+
+// appendField writes the code appending the field f, held by expr, to b.
+// proto3 leaves out singular scalars holding their default value.
+func (p *protoWriter) appendField(f protoField, expr string) {
+	v := f.shape.value
+	switch f.shape.kind {
+	case protoSingular:
+		switch {
+		case v.scalar != nil:
+			p.do("if %s {\n", fmt.Sprintf(v.scalar.nonZero, p.toGo(v.scalar, v.t, expr)))
+		case v.pointer():
+			p.do("if %s != nil {\n", expr)
+			p.appendTag("b", f.number, v)
+			p.do("b = %s.AppendBytes(b, %s.AppendProto(nil))\n", p.pw, expr)
+			p.do("}\n")
+			return
+		default:
+			p.do("{\n")
+		}
+		p.appendTag("b", f.number, v)
+		p.appendValue("b", v, expr)
+		p.do("}\n")
+	case protoOptional:
+		p.do("if %s != nil {\n", expr)
+		p.appendTag("b", f.number, v)
+		p.appendValue("b", v, "(*"+expr+")")
+		p.do("}\n")
+	case protoRepeated:
+		if v.scalar != nil && v.scalar.packed() {
+			p.do("if len(%s) != 0 {\n", expr)
+			p.do("var packed []byte\n")
+			p.do("for _, v := range %s {\n", expr)
+			p.appendValue("packed", v, "v")
+			p.do("}\n")
+			p.do("b = %s.AppendTag(b, %d, %s.BytesType)\n", p.pw, f.number, p.pw)
+			p.do("b = %s.AppendBytes(b, packed)\n", p.pw)
+			p.do("}\n")
+			return
+		}
+		p.do("for i := range %s {\n", expr)
+		p.appendTag("b", f.number, v)
+		p.appendValue("b", v, expr+"[i]")
+		p.do("}\n")
+	case protoMap:
+		// Keys are sorted for the encoding to be deterministic.
+		key := f.shape.key
+		p.do("if len(%s) != 0 {\n", expr)
+		p.do("keys := make([]%s, 0, len(%s))\n", p.raw.Name(key.t), expr)
+		p.do("for k := range %s {\n", expr)
+		p.do("keys = append(keys, k)\n")
+		p.do("}\n")
+		p.do("sort.Slice(keys, func(i, j int) bool {\n")
+		if key.scalar == protoBool {
+			p.do("return !bool(keys[i]) && bool(keys[j])\n")
+		} else {
+			p.do("return keys[i] < keys[j]\n")
+		}
+		p.do("})\n")
+		p.do("for _, k := range keys {\n")
+		p.do("v := %s[k]\n", expr)
+		p.do("var entry []byte\n")
+		p.appendTag("entry", 1, key)
+		p.appendValue("entry", key, "k")
+		p.appendTag("entry", 2, v)
+		p.appendValue("entry", v, "v")
+		p.do("b = %s.AppendTag(b, %d, %s.BytesType)\n", p.pw, f.number, p.pw)
+		p.do("b = %s.AppendBytes(b, entry)\n", p.pw)
+		p.do("}\n")
+		p.do("}\n")
+	}
+}
+
+// checkParsed writes the check of the length n returned by a protowire
+// Consume function.
+func (p *protoWriter) checkParsed() {
+	p.do("if n < 0 {\n")
+	p.do("return %s.ParseError(n)\n", p.pw)
+	p.do("}\n")
+}
+
+// consumeValue writes the code consuming the value v from the buffer b, then
+// calling assign with the expression of the value.
+func (p *protoWriter) consumeValue(b string, v protoValue, assign func(value string)) {
+	if v.message != nil {
+		p.do("x, n := %s.ConsumeBytes(%s)\n", p.pw, b)
+		p.checkParsed()
+		p.do("%s = %s[n:]\n", b, b)
+		if v.pointer() {
+			p.do("value := new(%s)\n", p.raw.Name(v.message))
+		} else {
+			p.do("var value %s\n", p.raw.Name(v.message))
+		}
+		p.do("if err := value.UnmarshalProto(x); err != nil {\n")
+		p.do("return err\n")
+		p.do("}\n")
+		assign("value")
+		return
+	}
+	s := v.scalar
+	consumeFunc := s.consumeFunc
+	if consumeFunc == "" {
+		consumeFunc = "Consume" + s.wire
+	}
+	p.do("x, n := %s.%s(%s)\n", p.pw, consumeFunc, b)
+	p.checkParsed()
+	p.do("%s = %s[n:]\n", b, b)
+	assign(p.fromGo(s, v.t, fmt.Sprintf(s.decode, p.pw, "x")))
+}
+
+// consumeField writes the code consuming the field f from data into expr,
+// ending with a continue once the field is read. Fields of an unexpected
+// wire type break out of the switch on the field number.
+func (p *protoWriter) consumeField(f protoField, expr string) {
+	v := f.shape.value
+	switch f.shape.kind {
+	case protoSingular:
+		p.do("if typ != %s {\n", p.wireType(v))
+		p.do("break\n")
+		p.do("}\n")
+		p.consumeValue("data", v, func(value string) {
+			p.do("%s = %s\n", expr, value)
+		})
+	case protoOptional:
+		p.do("if typ != %s {\n", p.wireType(v))
+		p.do("break\n")
+		p.do("}\n")
+		p.consumeValue("data", v, func(value string) {
+			p.do("v := %s\n", value)
+			p.do("%s = &v\n", expr)
+		})
+	case protoRepeated:
+		add := func(value string) {
+			p.do("%s = append(%s, %s)\n", expr, expr, value)
+		}
+		if v.scalar != nil && v.scalar.packed() {
+			p.do("if typ == %s.BytesType {\n", p.pw)
+			p.do("packed, n := %s.ConsumeBytes(data)\n", p.pw)
+			p.checkParsed()
+			p.do("data = data[n:]\n")
+			p.do("for len(packed) > 0 {\n")
+			p.consumeValue("packed", v, add)
+			p.do("}\n")
+			p.do("continue\n")
+			p.do("}\n")
+		}
+		p.do("if typ != %s {\n", p.wireType(v))
+		p.do("break\n")
+		p.do("}\n")
+		p.consumeValue("data", v, add)
+	case protoMap:
+		key := f.shape.key
+		p.do("if typ != %s.BytesType {\n", p.pw)
+		p.do("break\n")
+		p.do("}\n")
+		p.do("entry, n := %s.ConsumeBytes(data)\n", p.pw)
+		p.checkParsed()
+		p.do("data = data[n:]\n")
+		p.do("var k %s\n", p.raw.Name(key.t))
+		if v.pointer() {
+			p.do("v := new(%s)\n", p.raw.Name(v.message))
+		} else {
+			p.do("var v %s\n", p.raw.Name(v.t))
+		}
+		p.do("for len(entry) > 0 {\n")
+		p.do("num, typ, n := %s.ConsumeTag(entry)\n", p.pw)
+		p.checkParsed()
+		p.do("entry = entry[n:]\n")
+		p.do("switch {\n")
+		p.do("case num == 1 && typ == %s:\n", p.wireType(key))
+		p.consumeValue("entry", key, func(value string) {
+			p.do("k = %s\n", value)
+		})
+		p.do("case num == 2 && typ == %s:\n", p.wireType(v))
+		p.consumeValue("entry", v, func(value string) {
+			p.do("v = %s\n", value)
+		})
+		p.do("default:\n")
+		p.do("n := %s.ConsumeFieldValue(num, typ, entry)\n", p.pw)
+		p.checkParsed()
+		p.do("entry = entry[n:]\n")
+		p.do("}\n")
+		p.do("}\n")
+		p.do("if %s == nil {\n", expr)
+		p.do("%s = make(%s)\n", expr, p.raw.Name(f.member.Type))
+		p.do("}\n")
+		p.do("%s[k] = v\n", expr)
+	}
+	p.do("continue\n")
+}
+
+var protoTemplateCode = `
+// MarshalProto returns the protobuf wire encoding of obj, following the
+// message $.type|raw$ declared by the generated .proto file of the package.
+func (obj *$.type|raw$) MarshalProto() ([]byte, error) {
+	return obj.AppendProto(nil), nil
+}
+
+// AppendProto appends the protobuf wire encoding of obj to b.
+`
+
+var unmarshalProtoTemplateCode = `
+// UnmarshalProto sets obj to the protobuf message $.type|raw$ encoded in
+// data. Unknown fields are skipped.
+`
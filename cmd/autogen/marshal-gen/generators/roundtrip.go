@@ -0,0 +1,402 @@
+package generators
+
+import (
+	"io"
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+type roundTripGen struct {
+	generator.DefaultGen
+	targetPackage string
+	imports       namer.ImportTracker
+}
+
+// NewRoundTripTestGen returns a generator writing the file sanitizedName_test.go,
+// which tests that the generated marshal methods of every type of the package
+// round-trip: a table-driven test over populated sample values and a fuzz
+// target per type.
+func NewRoundTripTestGen(sanitizedName, targetPackage string) generator.Generator {
+	return &roundTripGen{
+		DefaultGen: generator.DefaultGen{
+			OptionalName: sanitizedName + "_test",
+		},
+		targetPackage: targetPackage,
+		imports:       generator.NewImportTracker(),
+	}
+}
+
+func (g *roundTripGen) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.targetPackage, g.imports),
+	}
+}
+
+func (g *roundTripGen) Imports(c *generator.Context) (imports []string) {
+	importLines := []string{"bytes", "io", "reflect", "testing"}
+	// The helpers use encoding/json, which the samples may have imported
+	// already.
+	jsonImported := false
+	for _, singleImport := range g.imports.ImportLines() {
+		if !strings.HasSuffix(singleImport, "\""+g.targetPackage+"\"") {
+			importLines = append(importLines, singleImport)
+			jsonImported = jsonImported || strings.HasSuffix(singleImport, "\"encoding/json\"")
+		}
+	}
+	if !jsonImported {
+		importLines = append(importLines, "encoding/json")
+	}
+	return importLines
+}
+
+// Init writes the test table and the helpers shared by all types.
+func (g *roundTripGen) Init(c *generator.Context, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+	sw.Do(roundTripTemplateCode, nil)
+
+	sw.Do("var generatedMarshalCases = []generatedMarshalCase{\n", nil)
+	for _, t := range c.Order {
+		args := generator.Args{"type": t, "sample": g.sampleOf(c, t)}
+		sw.Do("{\n", nil)
+		sw.Do("name: \"$.type|raw$\",\n", args)
+		sw.Do("sample: $.sample$,\n", args)
+		sw.Do("new: func() generatedMarshaler { return new($.type|raw$) },\n", args)
+		sw.Do("},\n", nil)
+	}
+	sw.Do("}\n\n", nil)
+	return sw.Error()
+}
+
+// GenerateType writes the fuzz target of t.
+func (g *roundTripGen) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+	sw.Do(fuzzTemplateCode, generator.Args{"type": t})
+	return sw.Error()
+}
+
+// sampleOf returns an expression of type *t pointing to a populated sample
+// value of t.
+func (g *roundTripGen) sampleOf(c *generator.Context, t *types.Type) string {
+	name := c.Namers["raw"].Name(t)
+	value := g.sampleValue(c, t, map[*types.Type]bool{})
+	if value == "" {
+		return "new(" + name + ")"
+	}
+	if t.Kind == types.Struct {
+		return "&" + value
+	}
+	return pointerTo(name, value)
+}
+
+// sampleValue returns an expression of type t in which every member, element
+// and map is populated, or "" if no such value can be written, e.g. for
+// interfaces or for a struct containing itself.
+func (g *roundTripGen) sampleValue(c *generator.Context, t *types.Type, visiting map[*types.Type]bool) string {
+	if e := enumOf(t); e != nil && t.Name.Package == g.targetPackage {
+		return e.constants[0].ident
+	}
+	if hasJSONMarshaler(t) {
+		// Only the type itself knows which of its values are valid.
+		return ""
+	}
+	name := c.Namers["raw"].Name(t)
+	ut := underlyingType(t)
+	switch ut.Kind {
+	case types.Builtin:
+		return sampleBuiltin(ut)
+	case types.Struct:
+		if visiting[t] {
+			return ""
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		return g.sampleStruct(c, t, jsonFields(t), 0, visiting)
+	case types.Pointer:
+		value := g.sampleValue(c, ut.Elem, visiting)
+		if value == "" {
+			return ""
+		}
+		if ut.Elem.Kind == types.Struct {
+			value = "&" + value
+		} else {
+			value = pointerTo(c.Namers["raw"].Name(ut.Elem), value)
+		}
+		if t.Kind == types.Alias {
+			return name + "(" + value + ")"
+		}
+		return value
+	case types.Slice:
+		value := g.sampleValue(c, ut.Elem, visiting)
+		if value == "" {
+			return ""
+		}
+		return name + "{" + value + "}"
+	case types.Map:
+		uk := underlyingType(ut.Key)
+		if uk.Kind != types.Builtin || builtinKind(uk) == "" || builtinKind(uk) == "Bool" || builtinKind(uk) == "Float" {
+			// encoding/json only writes string and integer keys.
+			return ""
+		}
+		key, value := g.sampleValue(c, ut.Key, visiting), g.sampleValue(c, ut.Elem, visiting)
+		if key == "" || value == "" {
+			return ""
+		}
+		return name + "{" + key + ": " + value + "}"
+	}
+	// Arrays (whose length is unknown to gengo), interfaces, channels and
+	// functions are left zero.
+	return ""
+}
+
+// sampleStruct returns a literal of struct t populating the given fields. The
+// fields are promoted into t through depth embedded structs, so their member
+// at that depth of the path is a member of t.
+func (g *roundTripGen) sampleStruct(c *generator.Context, t *types.Type, fields []jsonField, depth int, visiting map[*types.Type]bool) string {
+	var values []string
+	for _, m := range underlyingType(t).Members {
+		var promoted []jsonField
+		value := ""
+		for _, f := range fields {
+			if f.path[depth].Name != m.Name {
+				continue
+			}
+			if len(f.path) == depth+1 {
+				value = g.sampleValue(c, m.Type, visiting)
+			} else {
+				promoted = append(promoted, f)
+			}
+		}
+		if len(promoted) > 0 {
+			et := m.Type
+			if et.Kind == types.Pointer {
+				if namer.IsPrivateGoName(m.Name) {
+					// Decoding cannot allocate unexported embedded pointers.
+					continue
+				}
+				et = et.Elem
+			}
+			value = g.sampleStruct(c, et, promoted, depth+1, visiting)
+			if m.Type.Kind == types.Pointer {
+				value = "&" + value
+			}
+		}
+		if value != "" {
+			values = append(values, m.Name+": "+value+",\n")
+		}
+	}
+	name := c.Namers["raw"].Name(t)
+	if len(values) == 0 {
+		return name + "{}"
+	}
+	return name + "{\n" + strings.Join(values, "") + "}"
+}
+
+// sampleBuiltin returns an untyped constant assignable to the builtin t, or
+// "" for builtins encoding/json cannot write.
+func sampleBuiltin(t *types.Type) string {
+	switch builtinKind(t) {
+	case "Bool":
+		return "true"
+	case "String":
+		// Exercises the escaping of quotes, HTML and non-ASCII characters.
+		return `"sample \"<&>\" é"`
+	case "Int":
+		return "-7"
+	case "Uint":
+		return "7"
+	case "Float":
+		return "-1.5"
+	}
+	return ""
+}
+
+// builtinKind returns the Writer method writing the builtin t, e.g. "Int",
+// or "" if there is none.
+func builtinKind(t *types.Type) string {
+	method, _ := builtinWriter(t)
+	return method
+}
+
+// pointerTo returns an expression of type *name pointing to a copy of value.
+func pointerTo(name, value string) string {
+	return "func() *" + name + " {\nvar v " + name + " = " + value + "\nreturn &v\n}()"
+}
+
+var roundTripTemplateCode = `
+// generatedMarshaler is implemented by every type with generated marshal
+// methods.
+type generatedMarshaler interface {
+	MarshalJSONBinary() ([]byte, error)
+	UnmarshalJSONBinary(data []byte) error
+	EncodeJSON(w io.Writer) error
+	DecodeJSON(r io.Reader) error
+}
+
+// generatedMarshalCase holds a populated sample value of a type with
+// generated marshal methods.
+type generatedMarshalCase struct {
+	name   string
+	sample generatedMarshaler
+	new    func() generatedMarshaler
+}
+
+func TestGeneratedMarshalRoundTrip(t *testing.T) {
+	for _, tc := range generatedMarshalCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := tc.sample.MarshalJSONBinary()
+			if err != nil {
+				t.Fatalf("MarshalJSONBinary: %v", err)
+			}
+			checkGeneratedMarshalRoundTrip(t, tc, tc.sample, data)
+		})
+	}
+}
+
+// checkGeneratedMarshalRoundTrip checks that EncodeJSON writes obj exactly
+// like MarshalJSONBinary, which wrote data, and that both UnmarshalJSONBinary
+// and DecodeJSON read data back into a value equal to obj, as compared by
+// generatedMarshalEqual.
+func checkGeneratedMarshalRoundTrip(t *testing.T, tc generatedMarshalCase, obj generatedMarshaler, data []byte) {
+	var buf bytes.Buffer
+	if err := obj.EncodeJSON(&buf); err != nil {
+		t.Fatalf("EncodeJSON: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatalf("EncodeJSON wrote %s, MarshalJSONBinary wrote %s", buf.Bytes(), data)
+	}
+
+	out := tc.new()
+	if err := out.UnmarshalJSONBinary(data); err != nil {
+		t.Fatalf("UnmarshalJSONBinary(%s): %v", data, err)
+	}
+	if !generatedMarshalEqual(out, obj) {
+		t.Fatalf("UnmarshalJSONBinary(%s) does not round-trip", data)
+	}
+
+	out = tc.new()
+	if err := out.DecodeJSON(bytes.NewReader(data)); err != nil {
+		t.Fatalf("DecodeJSON(%s): %v", data, err)
+	}
+	if !generatedMarshalEqual(out, obj) {
+		t.Fatalf("DecodeJSON(%s) does not round-trip", data)
+	}
+}
+
+// generatedMarshalEqual reports whether a and b are deeply equal, except for
+// the differences encoding/json documents as lost by a round-trip: nil and
+// empty slices and maps are equal, and so are NaNs. -0 and 0 are equal too,
+// and so are the RawMessages holding the same JSON value.
+func generatedMarshalEqual(a, b interface{}) bool {
+	return generatedMarshalEqualValues(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+var generatedRawMessageType = reflect.TypeOf(json.RawMessage(nil))
+
+func generatedMarshalEqualValues(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+	if a.Type() == generatedRawMessageType {
+		// A RawMessage is written compacted, and a nil one as null,
+		// which is read back as is.
+		x, errA := json.Marshal(json.RawMessage(a.Bytes()))
+		y, errB := json.Marshal(json.RawMessage(b.Bytes()))
+		return errA == nil && errB == nil && bytes.Equal(x, y)
+	}
+	switch a.Kind() {
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !generatedMarshalEqualValues(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for _, key := range a.MapKeys() {
+			if !generatedMarshalEqualValues(a.MapIndex(key), b.MapIndex(key)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return generatedMarshalEqualValues(a.Elem(), b.Elem())
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !generatedMarshalEqualValues(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Float32, reflect.Float64:
+		x, y := a.Float(), b.Float()
+		return x == y || x != x && y != y
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.String:
+		return a.String() == b.String()
+	}
+	// Like reflect.DeepEqual, channels and functions are equal if both are
+	// nil.
+	return a.IsNil() && b.IsNil()
+}
+
+// fuzzGeneratedMarshal checks that every value UnmarshalJSONBinary reads into
+// the type of the case called name round-trips: writing it and reading it
+// back gives the same value, not only after a first round-trip.
+func fuzzGeneratedMarshal(f *testing.F, name string) {
+	var tc generatedMarshalCase
+	for _, c := range generatedMarshalCases {
+		if c.name == name {
+			tc = c
+		}
+	}
+	seed, err := tc.sample.MarshalJSONBinary()
+	if err != nil {
+		f.Fatalf("MarshalJSONBinary: %v", err)
+	}
+	f.Add(seed)
+	f.Add([]byte("null"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		obj := tc.new()
+		if err := obj.UnmarshalJSONBinary(data); err != nil {
+			return
+		}
+		data, err := obj.MarshalJSONBinary()
+		if err != nil {
+			// Not every value read can be written, e.g. values its
+			// MarshalJSON method rejects.
+			return
+		}
+		checkGeneratedMarshalRoundTrip(t, tc, obj, data)
+	})
+}
+
+`
+
+var fuzzTemplateCode = `
+func FuzzMarshal$.type|public$(f *testing.F) {
+	fuzzGeneratedMarshal(f, "$.type|raw$")
+}
+`
@@ -0,0 +1,366 @@
+package generators
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// jsonFileType is the file type of the generated JSON documents.
+const jsonFileType = "json"
+
+// newJSONFile returns the file type writing the body of the generated file
+// as is, after checking that it is valid JSON.
+func newJSONFile() *generator.DefaultFileType {
+	return &generator.DefaultFileType{
+		Format: func(src []byte) ([]byte, error) {
+			if !json.Valid(src) {
+				return nil, errors.New("invalid JSON")
+			}
+			return src, nil
+		},
+		Assemble: func(w io.Writer, f *generator.File) {
+			w.Write(f.Body.Bytes())
+		},
+	}
+}
+
+// newTextFile returns the file type writing the body of the generated file
+// as is.
+func newTextFile() *generator.DefaultFileType {
+	return &generator.DefaultFileType{
+		Format: func(src []byte) ([]byte, error) {
+			return src, nil
+		},
+		Assemble: func(w io.Writer, f *generator.File) {
+			w.Write(f.Body.Bytes())
+		},
+	}
+}
+
+// jsonSchemaDialect is the meta-schema of the generated schemas.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchema is a JSON Schema. Its keywords are written in the order of the
+// fields.
+type jsonSchema struct {
+	Schema               string        `json:"$schema,omitempty"`
+	Title                string        `json:"title,omitempty"`
+	Ref                  string        `json:"$ref,omitempty"`
+	Description          string        `json:"description,omitempty"`
+	Type                 interface{}   `json:"type,omitempty"`
+	Format               string        `json:"format,omitempty"`
+	ContentEncoding      string        `json:"contentEncoding,omitempty"`
+	Pattern              string        `json:"pattern,omitempty"`
+	Minimum              *int          `json:"minimum,omitempty"`
+	Enum                 []interface{} `json:"enum,omitempty"`
+	Items                *jsonSchema   `json:"items,omitempty"`
+	Properties           schemaMap     `json:"properties,omitempty"`
+	Required             []string      `json:"required,omitempty"`
+	PropertyNames        *jsonSchema   `json:"propertyNames,omitempty"`
+	AdditionalProperties *jsonSchema   `json:"additionalProperties,omitempty"`
+	AnyOf                []*jsonSchema `json:"anyOf,omitempty"`
+	Defs                 schemaMap     `json:"$defs,omitempty"`
+}
+
+// namedSchema is an entry of a schemaMap.
+type namedSchema struct {
+	name   string
+	schema *jsonSchema
+}
+
+// schemaMap is a JSON object of schemas, written in order.
+type schemaMap []namedSchema
+
+// MarshalJSON implements json.Marshaler.
+func (m schemaMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, entry := range m {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := encodeJSON(&buf, entry.name); err != nil {
+			return nil, err
+		}
+		buf.WriteByte(':')
+		if err := encodeJSON(&buf, entry.schema); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// encodeJSON writes v to w without escaping HTML characters, which are
+// common in descriptions.
+func encodeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
+
+// schemaBuilder builds the schemas of types from their JSON encoding. The
+// named types are defined once, in defs, and referenced through ref.
+type schemaBuilder struct {
+	targetPackage string
+	// ref returns the reference to the definition of the named type t.
+	ref func(t *types.Type) string
+	// definedElsewhere returns true if the type t of another package is
+	// defined in the document of its own package instead of in defs. It is
+	// nil if every type is defined in defs.
+	definedElsewhere func(t *types.Type) bool
+	defs        schemaMap
+	defined     map[*types.Type]bool
+}
+
+func newSchemaBuilder(targetPackage string, ref func(t *types.Type) string) *schemaBuilder {
+	return &schemaBuilder{
+		targetPackage: targetPackage,
+		ref:           ref,
+		defined:       map[*types.Type]bool{},
+	}
+}
+
+// defName returns the name of the definition of the named type t: its name,
+// qualified by its package name if it is declared in another package.
+func (b *schemaBuilder) defName(t *types.Type) string {
+	if t.Name.Package == b.targetPackage {
+		return t.Name.Name
+	}
+	return path.Base(t.Name.Package) + "." + t.Name.Name
+}
+
+// isDefined returns true if t is given a definition instead of being written
+// in-line.
+func isDefined(t *types.Type) bool {
+	return (t.Kind == types.Struct || t.Kind == types.Alias) && t.Name.Name != ""
+}
+
+// schemaOf returns the schema of the JSON encoding of t.
+func (b *schemaBuilder) schemaOf(t *types.Type) *jsonSchema {
+	if t.Name.Package == "time" && t.Name.Name == "Time" {
+		return &jsonSchema{Type: "string", Format: "date-time"}
+	}
+	if isDefined(t) {
+		b.define(t)
+		return &jsonSchema{Ref: b.ref(t)}
+	}
+	switch t.Kind {
+	case types.Builtin:
+		return builtinSchema(t)
+	case types.Pointer:
+		return nullable(b.schemaOf(t.Elem))
+	case types.Slice:
+		if isByteSlice(t) {
+			return nullable(&jsonSchema{Type: "string", ContentEncoding: "base64"})
+		}
+		return nullable(&jsonSchema{Type: "array", Items: b.schemaOf(t.Elem)})
+	case types.Array:
+		return &jsonSchema{Type: "array", Items: b.schemaOf(t.Elem)}
+	case types.Map:
+		s := &jsonSchema{Type: "object", AdditionalProperties: b.schemaOf(t.Elem)}
+		if enumOf(t.Key) != nil {
+			s.PropertyNames = enumSchema(t.Key)
+		}
+		return nullable(s)
+	}
+	// Interfaces hold any value. Channels and functions cannot be encoded.
+	return &jsonSchema{}
+}
+
+// define adds the definition of the named type t to the definitions.
+func (b *schemaBuilder) define(t *types.Type) {
+	if b.defined[t] || b.definedElsewhere != nil && b.definedElsewhere(t) {
+		return
+	}
+	b.defined[t] = true
+
+	var s *jsonSchema
+	switch {
+	case enumOf(t) != nil:
+		s = enumSchema(t)
+	case hasJSONMarshaler(t):
+		// The type writes itself, its encoding is unknown.
+		s = &jsonSchema{}
+	case t.Kind == types.Struct:
+		s = b.structSchema(t)
+	default:
+		copied := *b.schemaOf(t.Underlying)
+		s = &copied
+	}
+	if description := commentDescription(t.CommentLines); description != "" {
+		s.Description = description
+	}
+	b.defs = append(b.defs, namedSchema{name: b.defName(t), schema: s})
+}
+
+// structSchema returns the schema of the struct t, whose properties are its
+// JSON fields.
+func (b *schemaBuilder) structSchema(t *types.Type) *jsonSchema {
+	s := &jsonSchema{Type: "object"}
+	for _, f := range jsonFields(t) {
+		var fs *jsonSchema
+		if f.quoted && !hasJSONMarshaler(f.member.Type) {
+			fs = &jsonSchema{Type: "string"}
+			if f.member.Type.Kind == types.Pointer {
+				fs = nullable(fs)
+			}
+		} else {
+			fs = b.schemaOf(f.member.Type)
+		}
+		if description := commentDescription(f.member.CommentLines); description != "" {
+			fs.Description = description
+		}
+		s.Properties = append(s.Properties, namedSchema{name: f.name, schema: fs})
+		if f.required {
+			s.Required = append(s.Required, f.name)
+		}
+	}
+	return s
+}
+
+// builtinSchema returns the schema of the builtin t.
+func builtinSchema(t *types.Type) *jsonSchema {
+	switch builtinKind(t) {
+	case "Bool":
+		return &jsonSchema{Type: "boolean"}
+	case "String":
+		return &jsonSchema{Type: "string"}
+	case "Int":
+		return &jsonSchema{Type: "integer"}
+	case "Uint":
+		zero := 0
+		return &jsonSchema{Type: "integer", Minimum: &zero}
+	case "Float":
+		return &jsonSchema{Type: "number"}
+	}
+	return &jsonSchema{}
+}
+
+// enumSchema returns the schema of the enum t: the names of its constants,
+// or the underlying values without a constant, written as text.
+func enumSchema(t *types.Type) *jsonSchema {
+	names := &jsonSchema{Type: "string"}
+	for _, c := range enumOf(t).constants {
+		names.Enum = append(names.Enum, c.name)
+	}
+	values := &jsonSchema{Type: "string"}
+	switch builtinKind(underlyingType(t)) {
+	case "Int":
+		values.Pattern = "^-?[0-9]+$"
+	case "Uint":
+		values.Pattern = "^[0-9]+$"
+	}
+	return &jsonSchema{AnyOf: []*jsonSchema{names, values}}
+}
+
+// isByteSlice returns true if t is a slice of bytes, which encoding/json
+// writes as a base64 string.
+func isByteSlice(t *types.Type) bool {
+	if t.Kind != types.Slice || hasJSONMarshaler(t.Elem) {
+		return false
+	}
+	elem := underlyingType(t.Elem)
+	return elem.Kind == types.Builtin && elem.Name.Name == "byte"
+}
+
+// nullable returns a schema accepting null and the values s accepts.
+func nullable(s *jsonSchema) *jsonSchema {
+	switch typ := s.Type.(type) {
+	case []string:
+		// Already nullable.
+		return s
+	case string:
+		if s.Ref == "" && len(s.AnyOf) == 0 {
+			copied := *s
+			copied.Type = []string{typ, "null"}
+			if s.Enum != nil {
+				copied.Enum = append(append([]interface{}{}, s.Enum...), nil)
+			}
+			return &copied
+		}
+	case nil:
+		if s.Ref == "" && len(s.AnyOf) == 0 {
+			// Accepts anything already.
+			return s
+		}
+		if n := len(s.AnyOf); n > 0 && s.AnyOf[n-1].Type == "null" {
+			return s
+		}
+	}
+	return &jsonSchema{AnyOf: []*jsonSchema{s, {Type: "null"}}}
+}
+
+// commentDescription returns the doc comment lines as a description, without
+// the comment tags.
+func commentDescription(lines []string) string {
+	var kept []string
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "+") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+type jsonSchemaGen struct {
+	generator.DefaultGen
+	targetPackage string
+	builder       *schemaBuilder
+}
+
+// NewJSONSchemaGen returns a generator writing the file
+// sanitizedName.schema.json, a JSON Schema holding the definitions of every
+// type of the package under $defs.
+func NewJSONSchemaGen(sanitizedName, targetPackage string) generator.Generator {
+	g := &jsonSchemaGen{
+		DefaultGen: generator.DefaultGen{
+			OptionalName: sanitizedName,
+		},
+		targetPackage: targetPackage,
+	}
+	g.builder = newSchemaBuilder(targetPackage, func(t *types.Type) string {
+		return "#/$defs/" + g.builder.defName(t)
+	})
+	return g
+}
+
+func (g *jsonSchemaGen) Filename() string {
+	return g.OptionalName + ".schema.json"
+}
+
+func (g *jsonSchemaGen) FileType() string {
+	return jsonFileType
+}
+
+// GenerateType defines t and the types it references.
+func (g *jsonSchemaGen) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	g.builder.define(t)
+	return nil
+}
+
+// Finalize writes the schema.
+func (g *jsonSchemaGen) Finalize(c *generator.Context, w io.Writer) error {
+	defs := g.builder.defs
+	sort.SliceStable(defs, func(i, j int) bool {
+		return defs[i].name < defs[j].name
+	})
+	doc := &jsonSchema{
+		Schema: jsonSchemaDialect,
+		Title:  g.targetPackage,
+		Defs:   defs,
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
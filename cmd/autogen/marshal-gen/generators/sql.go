@@ -0,0 +1,52 @@
+package generators
+
+import (
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// generateSQL writes the Scan and Value methods of t, which let it be stored
+// in a JSON column through database/sql.
+func (g *marshalGen) generateSQL(c *generator.Context, t *types.Type, sw *generator.SnippetWriter) {
+	name := t.Name.Name
+	if pkg := c.Universe.Package(t.Name.Package); pkg.Name != "" {
+		name = pkg.Name + "." + name
+	}
+	sw.Do(sqlTemplateCode, generator.Args{"type": t, "name": name})
+}
+
+var sqlTemplateCode = `
+// Scan implements the sql.Scanner interface. It decodes the JSON held by a
+// []byte or string column value; a NULL column resets obj to its zero value.
+func (obj *$.type|raw$) Scan(src interface{}) error {
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		var zero $.type|raw$
+		*obj = zero
+		return nil
+	case []byte:
+		data = src
+	case string:
+		data = []byte(src)
+	default:
+		return fmt.Errorf("$.name$: cannot scan %T, want []byte, string or nil", src)
+	}
+
+	var value $.type|raw$
+	if err := value.UnmarshalJSONBinary(data); err != nil {
+		return fmt.Errorf("$.name$: cannot scan JSON column: %v", err)
+	}
+	*obj = value
+	return nil
+}
+
+// Value implements the driver.Valuer interface, storing obj as JSON.
+func (obj $.type|raw$) Value() (driver.Value, error) {
+	data, err := obj.MarshalJSONBinary()
+	if err != nil {
+		return nil, fmt.Errorf("$.name$: cannot encode JSON column: %v", err)
+	}
+	return data, nil
+}
+`
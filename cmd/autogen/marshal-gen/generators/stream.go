@@ -0,0 +1,667 @@
+package generators
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/zhaolion/gengo/marshal"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// hasCodec returns true if references to t are written and read through the
+// generated WriteJSON and ReadJSON methods of t, which the types of the input
+// and peer packages have.
+func (g *marshalGen) hasCodec(t *types.Type) bool {
+	if !generatedPackages[t.Name.Package] || hasJSONMarshaler(t) {
+		return false
+	}
+	switch t.Kind {
+	case types.Struct, types.Array:
+		return true
+	case types.Alias:
+		// Named builtins are cheaper to write in-line.
+		return underlyingType(t).Kind != types.Builtin
+	}
+	return false
+}
+
+// hasJSONMarshaler returns true if t has its own (un)marshal methods, which
+// encoding/json prefers over the default encoding of the type.
+func hasJSONMarshaler(t *types.Type) bool {
+	return hasPromotedJSONMarshaler(t, map[*types.Type]bool{})
+}
+
+// hasPromotedJSONMarshaler is like hasJSONMarshaler, but also considers the
+// methods promoted from embedded members of struct t.
+func hasPromotedJSONMarshaler(t *types.Type, visited map[*types.Type]bool) bool {
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+
+	if enumOf(t) != nil {
+		// The generated MarshalText and UnmarshalText methods of enums are
+		// not parsed by gengo.
+		return true
+	}
+	for _, name := range []string{"MarshalJSON", "UnmarshalJSON", "MarshalText", "UnmarshalText"} {
+		if _, found := t.Methods[name]; found {
+			return true
+		}
+	}
+	if t.Kind != types.Struct {
+		return false
+	}
+	for _, m := range t.Members {
+		if !m.Embedded {
+			continue
+		}
+		et := m.Type
+		if et.Kind == types.Pointer {
+			et = et.Elem
+		}
+		if hasPromotedJSONMarshaler(et, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateStream writes the EncodeJSON, DecodeJSON, WriteJSON and ReadJSON
+// methods of t.
+func (g *marshalGen) generateStream(t *types.Type, sw *generator.SnippetWriter) {
+	args := generator.Args{"type": t, "marshal": g.runtimeName()}
+	sw.Do(streamTemplateCode, args)
+
+	sw.Do("func (obj *$.type|raw$) WriteJSON(jw *$.marshal$.Writer) {\n", args)
+	sw.Do("if obj == nil {\n", nil)
+	sw.Do("jw.Null()\n", nil)
+	sw.Do("return\n", nil)
+	sw.Do("}\n", nil)
+	switch {
+	case enumOf(t) != nil:
+		g.writeValue(t, "(*obj)", true, sw)
+	case hasJSONMarshaler(t):
+		sw.Do("jw.Value(obj)\n", nil)
+	case t.Kind == types.Struct:
+		g.writeStruct(t, sw)
+	default:
+		g.writeValue(t, "(*obj)", true, sw)
+	}
+	sw.Do("}\n\n", nil)
+
+	sw.Do(readTemplateCode, args)
+	sw.Do("func (obj *$.type|raw$) ReadJSON(dec *$.marshal$.Decoder) error {\n", args)
+	switch {
+	case hasJSONMarshaler(t):
+		sw.Do("return dec.Decode(obj)\n", nil)
+	case t.Kind == types.Struct:
+		g.readStruct(t, sw)
+	default:
+		g.readValue(t, "(*obj)", sw)
+		sw.Do("return nil\n", nil)
+	}
+	sw.Do("}\n\n", nil)
+}
+
+// writeStruct writes the members of the struct 'obj'.
+func (g *marshalGen) writeStruct(t *types.Type, sw *generator.SnippetWriter) {
+	fields := jsonFields(t)
+	sw.Do("jw.RawByte('{')\n", nil)
+
+	// Members behind nil embedded pointers and empty omitempty members are
+	// left out, so whether a member needs a leading comma may only be known
+	// at run time. As long as no member is known to have been written, the
+	// generated code tracks it in 'wrote'.
+	const (
+		noneWritten = iota
+		someWritten
+		unknown
+	)
+	state := noneWritten
+	if len(fields) > 1 && len(g.fieldConditions(fields[0], "obj")) > 0 {
+		sw.Do("wrote := false\n", nil)
+	}
+	for i, f := range fields {
+		conditions := g.fieldConditions(f, "obj")
+		if len(conditions) > 0 {
+			sw.Do("if $.$ {\n", strings.Join(conditions, " && "))
+		}
+		key := jsonString(f.name) + ":"
+		switch state {
+		case someWritten:
+			key = "," + key
+		case unknown:
+			sw.Do("if wrote {\n", nil)
+			sw.Do("jw.RawByte(',')\n", nil)
+			sw.Do("}\n", nil)
+		}
+		sw.Do("jw.Raw($.$)\n", goString(key))
+		if len(conditions) > 0 && state != someWritten && i < len(fields)-1 {
+			sw.Do("wrote = true\n", nil)
+		}
+		if f.quoted {
+			g.writeQuoted(f.member.Type, f.selector("obj"), sw)
+		} else {
+			g.writeFor(f.member.Type, f.selector("obj"), true, sw)
+		}
+		if len(conditions) > 0 {
+			sw.Do("}\n", nil)
+		}
+
+		if len(conditions) == 0 {
+			state = someWritten
+		} else if state == noneWritten {
+			state = unknown
+		}
+	}
+	sw.Do("jw.RawByte('}')\n", nil)
+}
+
+// fieldConditions returns the conditions under which encoding/json writes
+// the field f of the struct expr: the embedded pointers it is promoted
+// through are not nil, and it is not empty if tagged omitempty.
+func (g *marshalGen) fieldConditions(f jsonField, expr string) []string {
+	var conditions []string
+	_, selectors := f.embeddedPointers(expr)
+	for _, selector := range selectors {
+		conditions = append(conditions, selector+" != nil")
+	}
+	if f.omitEmpty {
+		if condition := nonEmpty(f.member.Type, f.selector(expr)); condition != "" {
+			conditions = append(conditions, condition)
+		}
+	}
+	return conditions
+}
+
+// nonEmpty returns the condition under which expr, of type t, is not empty in
+// the sense of the omitempty option, or "" if it is never empty.
+func nonEmpty(t *types.Type, expr string) string {
+	ut := underlyingType(t)
+	switch ut.Kind {
+	case types.Builtin:
+		switch method, _ := builtinWriter(ut); method {
+		case "Bool":
+			return expr
+		case "String":
+			return expr + ` != ""`
+		case "Int", "Uint", "Float":
+			return expr + " != 0"
+		}
+	case types.Array, types.Map, types.Slice:
+		return "len(" + expr + ") != 0"
+	case types.Pointer, types.Interface:
+		return expr + " != nil"
+	}
+	return ""
+}
+
+// writeQuoted writes expr, of type t, for a field with the string option:
+// the JSON encoding of strings, floats, integers and booleans is wrapped in a
+// JSON string.
+func (g *marshalGen) writeQuoted(t *types.Type, expr string, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	switch {
+	case hasJSONMarshaler(t):
+		// Marshalers write themselves, the string option does not apply.
+		g.writeFor(t, expr, true, sw)
+	case t.Kind == types.Pointer:
+		sw.Do("if $.$ == nil {\n", expr)
+		sw.Do("jw.Null()\n", nil)
+		sw.Do("} else {\n", nil)
+		g.writeQuoted(ut.Elem, "(*"+expr+")", sw)
+		sw.Do("}\n", nil)
+	case isStringKind(ut):
+		if t.Kind == types.Builtin {
+			sw.Do("jw.QuotedString($.$)\n", argument(expr))
+		} else {
+			sw.Do("jw.QuotedString(string($.$))\n", argument(expr))
+		}
+	default:
+		sw.Do("jw.RawByte('\"')\n", nil)
+		g.writeValue(t, expr, true, sw)
+		sw.Do("jw.RawByte('\"')\n", nil)
+	}
+}
+
+// writeFor writes the code encoding the value of the expression expr, which is
+// of type t. addressable tells whether encoding/json would see the value as
+// addressable, which decides whether methods with pointer receivers are used.
+func (g *marshalGen) writeFor(t *types.Type, expr string, addressable bool, sw *generator.SnippetWriter) {
+	if g.hasCodec(t) {
+		sw.Do("$.$.WriteJSON(jw)\n", receiver(expr))
+		return
+	}
+	g.writeValue(t, expr, addressable, sw)
+}
+
+// writeValue is like writeFor, but never calls the WriteJSON method of t itself.
+func (g *marshalGen) writeValue(t *types.Type, expr string, addressable bool, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	args := generator.Args{
+		"type": t,
+		"elem": ut.Elem,
+		"key":  ut.Key,
+		"expr": expr,
+	}
+
+	if enumOf(t) != nil {
+		sw.Do("jw.Text($.$)\n", argument(expr))
+		return
+	}
+	if hasJSONMarshaler(t) {
+		if addressable {
+			sw.Do("jw.Value($.$)\n", address(expr))
+		} else {
+			sw.Do("jw.Value($.$)\n", argument(expr))
+		}
+		return
+	}
+
+	switch ut.Kind {
+	case types.Builtin:
+		if method, conversion := builtinWriter(ut); method != "" && !isForeign(t) {
+			if t.Name.Name != conversion {
+				expr = conversion + "(" + argument(expr) + ")"
+			} else {
+				expr = argument(expr)
+			}
+			if ut.Name.Name == "float32" {
+				sw.Do("jw.$.method$($.expr$, 32)\n", generator.Args{"method": method, "expr": expr})
+			} else if ut.Name.Name == "float64" {
+				sw.Do("jw.$.method$($.expr$, 64)\n", generator.Args{"method": method, "expr": expr})
+			} else {
+				sw.Do("jw.$.method$($.expr$)\n", generator.Args{"method": method, "expr": expr})
+			}
+			return
+		}
+	case types.Pointer:
+		if g.hasCodec(ut.Elem) {
+			// WriteJSON writes null for nil pointers.
+			sw.Do("$.expr$.WriteJSON(jw)\n", args)
+			return
+		}
+		sw.Do("if $.expr$ == nil {\n", args)
+		sw.Do("jw.Null()\n", nil)
+		sw.Do("} else {\n", nil)
+		g.writeFor(ut.Elem, "(*"+expr+")", true, sw)
+		sw.Do("}\n", nil)
+		return
+	case types.Slice:
+		if isByte(underlyingType(ut.Elem)) {
+			if !isByte(ut.Elem) {
+				// Named byte types, encoding/json writes them as base64 too.
+				sw.Do("jw.Value($.expr$)\n", args)
+			} else if t.Kind == types.Slice {
+				sw.Do("jw.Bytes($.expr$)\n", args)
+			} else {
+				sw.Do("jw.Bytes([]byte($.expr$))\n", args)
+			}
+			return
+		}
+		sw.Do("if $.expr$ == nil {\n", args)
+		sw.Do("jw.Null()\n", nil)
+		sw.Do("} else {\n", nil)
+		g.writeElements(ut, expr, sw)
+		sw.Do("}\n", nil)
+		return
+	case types.Array:
+		g.writeElements(ut, expr, sw)
+		return
+	case types.Map:
+		if g.writeMap(t, expr, sw) {
+			return
+		}
+	case types.Interface:
+		sw.Do("jw.Value($.$)\n", argument(expr))
+		return
+	}
+
+	// Everything else (structs of other packages, anonymous structs, ...) is
+	// written by encoding/json.
+	if addressable {
+		sw.Do("jw.Value($.$)\n", address(expr))
+	} else {
+		sw.Do("jw.Value($.$)\n", argument(expr))
+	}
+}
+
+// writeElements writes the elements of the slice or array expr as a JSON array.
+func (g *marshalGen) writeElements(ut *types.Type, expr string, sw *generator.SnippetWriter) {
+	sw.Do("jw.RawByte('[')\n", nil)
+	sw.Do("for i := range $.$ {\n", expr)
+	sw.Do("if i > 0 {\n", nil)
+	sw.Do("jw.RawByte(',')\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("in := &$.$[i]\n", expr)
+	g.writeFor(ut.Elem, "(*in)", true, sw)
+	sw.Do("}\n", nil)
+	sw.Do("jw.RawByte(']')\n", nil)
+}
+
+// writeMap writes the map expr as a JSON object with sorted keys. It returns
+// false if the keys are of a kind only encoding/json can write.
+func (g *marshalGen) writeMap(t *types.Type, expr string, sw *generator.SnippetWriter) bool {
+	ut := underlyingType(t)
+	key := underlyingType(ut.Key)
+	if key.Kind != types.Builtin || hasJSONMarshaler(ut.Key) || isForeign(ut.Key) {
+		return false
+	}
+	less, write := "", ""
+	switch method, _ := builtinWriter(key); method {
+	case "String":
+		less = "keys[i] < keys[j]"
+		write = "jw.String(string(key))\n"
+		if ut.Key.Kind == types.Builtin {
+			write = "jw.String(key)\n"
+		}
+	case "Int":
+		less = "$.marshal$.IntKeyLess(int64(keys[i]), int64(keys[j]))"
+		write = "jw.RawByte('\"')\njw.Int(int64(key))\njw.RawByte('\"')\n"
+	case "Uint":
+		less = "$.marshal$.UintKeyLess(uint64(keys[i]), uint64(keys[j]))"
+		write = "jw.RawByte('\"')\njw.Uint(uint64(key))\njw.RawByte('\"')\n"
+	default:
+		return false
+	}
+
+	args := generator.Args{
+		"key":     ut.Key,
+		"expr":    expr,
+		"marshal": g.runtimeName(),
+	}
+	sw.Do("if $.expr$ == nil {\n", args)
+	sw.Do("jw.Null()\n", nil)
+	sw.Do("} else {\n", nil)
+	sw.Do("keys := make([]$.key|raw$, 0, len($.expr$))\n", args)
+	sw.Do("for key := range $.expr$ {\n", args)
+	sw.Do("keys = append(keys, key)\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("sort.Slice(keys, func(i, j int) bool { return "+less+" })\n", args)
+	sw.Do("jw.RawByte('{')\n", nil)
+	sw.Do("for i, key := range keys {\n", nil)
+	sw.Do("if i > 0 {\n", nil)
+	sw.Do("jw.RawByte(',')\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do(write, nil)
+	sw.Do("jw.RawByte(':')\n", nil)
+	sw.Do("val := $.expr$[key]\n", args)
+	// Map values are not addressable.
+	g.writeFor(ut.Elem, "val", false, sw)
+	sw.Do("}\n", nil)
+	sw.Do("jw.RawByte('}')\n", nil)
+	sw.Do("}\n", nil)
+	return true
+}
+
+// builtinWriter returns the marshal.Writer method writing the builtin t and
+// the type its argument has to be converted to, or "" if there is none.
+func builtinWriter(t *types.Type) (method, conversion string) {
+	switch t.Name.Name {
+	case "bool":
+		return "Bool", "bool"
+	case "string":
+		return "String", "string"
+	case "int", "int8", "int16", "int32", "int64":
+		return "Int", "int64"
+	case "byte", "uint", "uint16", "uint32", "uint64", "uintptr":
+		return "Uint", "uint64"
+	case "float32", "float64":
+		return "Float", "float64"
+	}
+	return "", ""
+}
+
+func isByte(t *types.Type) bool {
+	return t.Kind == types.Builtin && t.Name.Name == "byte"
+}
+
+// readStruct reads the members of the struct 'obj'.
+func (g *marshalGen) readStruct(t *types.Type, sw *generator.SnippetWriter) {
+	sw.Do("if ok, err := dec.Begin('{'); err != nil || !ok {\n", nil)
+	sw.Do("return err\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("for dec.More() {\n", nil)
+
+	fields := jsonFields(t)
+	if len(fields) == 0 {
+		sw.Do("if _, err := dec.Key(); err != nil {\n", nil)
+		sw.Do("return err\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("if err := dec.Skip(); err != nil {\n", nil)
+		sw.Do("return err\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("return dec.End('}')\n", nil)
+		return
+	}
+
+	sw.Do("key, err := dec.Key()\n", nil)
+	sw.Do("if err != nil {\n", nil)
+	sw.Do("return err\n", nil)
+	sw.Do("}\n", nil)
+	g.matchKey(fields, sw)
+	sw.Do("switch key {\n", nil)
+	for _, f := range fields {
+		sw.Do("case $.$:\n", strconv.Quote(f.name))
+		members, selectors := f.embeddedPointers("obj")
+		for i, m := range members {
+			args := generator.Args{"selector": selectors[i], "member": m}
+			sw.Do("if $.selector$ == nil {\n", args)
+			if namer.IsPrivateGoName(m.Name) {
+				sw.Do("return $.$\n", embeddedPointerError(m))
+			} else {
+				sw.Do("$.selector$ = new($.member.Type.Elem|raw$)\n", args)
+			}
+			sw.Do("}\n", nil)
+		}
+		if f.quoted {
+			sw.Do("if err := dec.DecodeQuoted($.$); err != nil {\n", address(f.selector("obj")))
+			sw.Do("return err\n", nil)
+			sw.Do("}\n", nil)
+		} else {
+			g.readFor(f.member.Type, f.selector("obj"), sw)
+		}
+	}
+	sw.Do("default:\n", nil)
+	sw.Do("if err := dec.Skip(); err != nil {\n", nil)
+	sw.Do("return err\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("return dec.End('}')\n", nil)
+}
+
+// matchKey writes the code replacing 'key' by the name of the field it
+// matches case-insensitively, if it matches no field name exactly. Like for
+// encoding/json, the first field in order wins if several match.
+func (g *marshalGen) matchKey(fields []jsonField, sw *generator.SnippetWriter) {
+	var names []string
+	for _, f := range fields {
+		names = append(names, strconv.Quote(f.name))
+	}
+	sw.Do("switch key {\n", nil)
+	sw.Do("case $.$:\n", strings.Join(names, ", "))
+	sw.Do("default:\n", nil)
+	sw.Do("switch $.$.FoldName(key) {\n", g.runtimeName())
+	folded := map[string]bool{}
+	for _, f := range fields {
+		fold := marshal.FoldName(f.name)
+		if folded[fold] {
+			continue
+		}
+		folded[fold] = true
+		sw.Do("case $.$:\n", strconv.Quote(fold))
+		sw.Do("key = $.$\n", strconv.Quote(f.name))
+	}
+	sw.Do("}\n", nil)
+	sw.Do("}\n", nil)
+}
+
+// embeddedPointerError returns an expression for the error encoding/json
+// reports when it has to allocate the nil embedded pointer m, which it cannot
+// because the member is unexported.
+func embeddedPointerError(m types.Member) string {
+	elem := m.Type.Elem
+	name := filepath.Base(elem.Name.Package) + "." + elem.Name.Name
+	return "errors.New(" + strconv.Quote("json: cannot set embedded pointer to unexported struct: "+name) + ")"
+}
+
+// readFor writes the code decoding the next value of 'dec' into the
+// expression expr, which is of type t.
+func (g *marshalGen) readFor(t *types.Type, expr string, sw *generator.SnippetWriter) {
+	if g.hasCodec(t) {
+		sw.Do("if err := $.$.ReadJSON(dec); err != nil {\n", receiver(expr))
+		sw.Do("return err\n", nil)
+		sw.Do("}\n", nil)
+		return
+	}
+	g.readValue(t, expr, sw)
+}
+
+// readValue is like readFor, but never calls the ReadJSON method of t itself.
+func (g *marshalGen) readValue(t *types.Type, expr string, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	args := generator.Args{
+		"type": t,
+		"elem": ut.Elem,
+		"key":  ut.Key,
+		"expr": expr,
+	}
+
+	if !descendsInto(ut, g.hasCodec, map[*types.Type]bool{}) {
+		// Nothing below t has a ReadJSON method, let encoding/json decode it.
+		sw.Do("if err := dec.Decode($.$); err != nil {\n", address(expr))
+		sw.Do("return err\n", nil)
+		sw.Do("}\n", nil)
+		return
+	}
+
+	switch ut.Kind {
+	case types.Pointer:
+		sw.Do("if null, err := dec.Null(); err != nil {\n", nil)
+		sw.Do("return err\n", nil)
+		sw.Do("} else if null {\n", nil)
+		sw.Do("$.expr$ = nil\n", args)
+		sw.Do("} else {\n", nil)
+		sw.Do("if $.expr$ == nil {\n", args)
+		sw.Do("$.expr$ = new($.elem|raw$)\n", args)
+		sw.Do("}\n", nil)
+		g.readFor(ut.Elem, "(*"+expr+")", sw)
+		sw.Do("}\n", nil)
+	case types.Map:
+		sw.Do("if ok, err := dec.Begin('{'); err != nil {\n", nil)
+		sw.Do("return err\n", nil)
+		sw.Do("} else if !ok {\n", nil)
+		sw.Do("$.expr$ = nil\n", args)
+		sw.Do("} else {\n", nil)
+		sw.Do("if $.expr$ == nil {\n", args)
+		sw.Do("$.expr$ = make($.type|raw$)\n", args)
+		sw.Do("}\n", nil)
+		sw.Do("for dec.More() {\n", nil)
+		sw.Do("key, err := dec.Key()\n", nil)
+		sw.Do("if err != nil {\n", nil)
+		sw.Do("return err\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("var val $.elem|raw$\n", args)
+		g.readFor(ut.Elem, "val", sw)
+		if ut.Key.Kind == types.Builtin {
+			sw.Do("$.expr$[key] = val\n", args)
+		} else {
+			sw.Do("$.expr$[$.key|raw$(key)] = val\n", args)
+		}
+		sw.Do("}\n", nil)
+		sw.Do("if err := dec.End('}'); err != nil {\n", nil)
+		sw.Do("return err\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("}\n", nil)
+	case types.Slice:
+		sw.Do("if ok, err := dec.Begin('['); err != nil {\n", nil)
+		sw.Do("return err\n", nil)
+		sw.Do("} else if !ok {\n", nil)
+		sw.Do("$.expr$ = nil\n", args)
+		sw.Do("} else {\n", nil)
+		sw.Do("if $.expr$ == nil {\n", args)
+		sw.Do("$.expr$ = $.type|raw${}\n", args)
+		sw.Do("}\n", nil)
+		sw.Do("$.expr$ = $.expr$[:0]\n", args)
+		sw.Do("for dec.More() {\n", nil)
+		sw.Do("var val $.elem|raw$\n", args)
+		g.readFor(ut.Elem, "val", sw)
+		sw.Do("$.expr$ = append($.expr$, val)\n", args)
+		sw.Do("}\n", nil)
+		sw.Do("if err := dec.End(']'); err != nil {\n", nil)
+		sw.Do("return err\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("}\n", nil)
+	}
+}
+
+// receiver returns expr in a form suitable as a method receiver: (*p) becomes p.
+func receiver(expr string) string {
+	if strings.HasPrefix(expr, "(*") && strings.HasSuffix(expr, ")") {
+		return expr[2 : len(expr)-1]
+	}
+	return expr
+}
+
+// argument returns expr in a form suitable as a function argument: (*p)
+// becomes *p.
+func argument(expr string) string {
+	if strings.HasPrefix(expr, "(*") && strings.HasSuffix(expr, ")") {
+		return expr[1 : len(expr)-1]
+	}
+	return expr
+}
+
+// address returns an expression for the address of expr: (*p) becomes p.
+func address(expr string) string {
+	if strings.HasPrefix(expr, "(*") && strings.HasSuffix(expr, ")") {
+		return expr[2 : len(expr)-1]
+	}
+	return "&" + expr
+}
+
+// jsonString returns s encoded as a JSON string, the way encoding/json
+// writes it.
+func jsonString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// goString returns a Go string literal for s, preferring a raw string literal.
+func goString(s string) string {
+	if !strings.ContainsAny(s, "`\r") && strconv.CanBackquote(s) {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+var streamTemplateCode = `
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *$.type|raw$) EncodeJSON(w io.Writer) error {
+	jw := $.marshal$.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *$.type|raw$) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON($.marshal$.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+`
+
+var readTemplateCode = `// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+`
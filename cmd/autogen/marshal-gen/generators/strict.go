@@ -0,0 +1,216 @@
+package generators
+
+import (
+	"strconv"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// runtimePackage is imported by the generated code for its runtime support.
+const runtimePackage = "github.com/zhaolion/gengo/marshal"
+
+// runtimeName imports the runtime package into the generated file and
+// returns the local name it is imported as.
+func (g *marshalGen) runtimeName() string {
+	g.imports.AddType(&types.Type{Name: types.Name{Package: runtimePackage, Name: "StrictError"}})
+	return g.imports.LocalNameOf(runtimePackage)
+}
+
+// hasStrictMethod returns true if t has a generated UnmarshalJSONStrict
+// method: the structs of the input and peer packages do.
+func (g *marshalGen) hasStrictMethod(t *types.Type) bool {
+	return t.Kind == types.Struct && generatedPackages[t.Name.Package]
+}
+
+// needsStrict returns true if decoding t has to descend into a type with a
+// generated UnmarshalJSONStrict method. Everything else is decoded by
+// encoding/json directly.
+func (g *marshalGen) needsStrict(t *types.Type) bool {
+	return descendsInto(t, g.hasStrictMethod, map[*types.Type]bool{})
+}
+
+// descendsInto returns true if t is, or reaches through pointers, slices and
+// string keyed maps, a type for which has returns true.
+func descendsInto(t *types.Type, has func(*types.Type) bool, visited map[*types.Type]bool) bool {
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+
+	if has(t) {
+		return true
+	}
+	ut := underlyingType(t)
+	switch ut.Kind {
+	case types.Pointer, types.Slice:
+		return descendsInto(ut.Elem, has, visited)
+	case types.Map:
+		return isStringKind(ut.Key) && descendsInto(ut.Elem, has, visited)
+	}
+	return false
+}
+
+func isStringKind(t *types.Type) bool {
+	ut := underlyingType(t)
+	return ut.Kind == types.Builtin && ut.Name.Name == "string"
+}
+
+// generateStrict writes the UnmarshalJSONBinaryStrict and UnmarshalJSONStrict
+// methods of struct t.
+func (g *marshalGen) generateStrict(t *types.Type, sw *generator.SnippetWriter) {
+	args := generator.Args{"type": t, "marshal": g.runtimeName()}
+
+	sw.Do(strictTemplateCode, args)
+
+	sw.Do("func (obj *$.type|raw$) UnmarshalJSONStrict(data []byte, path string, errs *$.marshal$.StrictError) {\n", args)
+	sw.Do("var fields map[string]json.RawMessage\n", nil)
+	sw.Do("if err := json.Unmarshal(data, &fields); err != nil {\n", nil)
+	sw.Do("errs.Add(path, err)\n", nil)
+	sw.Do("return\n", nil)
+	sw.Do("}\n", nil)
+
+	fields := jsonFields(t)
+	if len(fields) == 0 {
+		sw.Do("for _, key := range $.marshal$.SortedKeys(fields) {\n", args)
+		sw.Do("errs.Add($.marshal$.Key(path, key), $.marshal$.ErrUnknownField)\n", args)
+		sw.Do("}\n", nil)
+		sw.Do("}\n\n", nil)
+		return
+	}
+
+	required := false
+	for _, f := range fields {
+		required = required || f.required
+	}
+	if required {
+		sw.Do("found := map[string]bool{}\n", nil)
+	}
+	sw.Do("for _, key := range $.marshal$.SortedKeys(fields) {\n", args)
+	sw.Do("data, path := fields[key], $.marshal$.Key(path, key)\n", args)
+	g.matchKey(fields, sw)
+	if required {
+		sw.Do("found[key] = true\n", nil)
+	}
+	sw.Do("switch key {\n", nil)
+	for _, f := range fields {
+		sw.Do("case $.$:\n", strconv.Quote(f.name))
+		members, selectors := f.embeddedPointers("obj")
+		for i, m := range members {
+			args := generator.Args{"selector": selectors[i], "member": m}
+			sw.Do("if $.selector$ == nil {\n", args)
+			if namer.IsPrivateGoName(m.Name) {
+				sw.Do("errs.Add(path, $.$)\n", embeddedPointerError(m))
+				sw.Do("break\n", nil)
+			} else {
+				sw.Do("$.selector$ = new($.member.Type.Elem|raw$)\n", args)
+			}
+			sw.Do("}\n", nil)
+		}
+		sw.Do("out := &$.$\n", f.selector("obj"))
+		if f.quoted {
+			sw.Do("if err := $.marshal$.UnmarshalQuoted(data, out); err != nil {\n", args)
+			sw.Do("errs.Add(path, err)\n", nil)
+			sw.Do("}\n", nil)
+		} else {
+			g.strictFor(f.member.Type, sw)
+		}
+	}
+	sw.Do("default:\n", nil)
+	sw.Do("errs.Add(path, $.marshal$.ErrUnknownField)\n", args)
+	sw.Do("}\n", nil)
+	sw.Do("}\n", nil)
+
+	for _, f := range fields {
+		if !f.required {
+			continue
+		}
+		args["name"] = strconv.Quote(f.name)
+		sw.Do("if !found[$.name$] {\n", args)
+		sw.Do("errs.Add($.marshal$.Key(path, $.name$), $.marshal$.ErrMissingField)\n", args)
+		sw.Do("}\n", nil)
+	}
+	sw.Do("}\n\n", nil)
+}
+
+// strictFor writes the code decoding the JSON value 'data' into '*out', which
+// is of type t, recording problems at 'path'. Like in deepcopy-gen the names
+// are shadowed at every nesting level, so the same code is valid at any depth.
+func (g *marshalGen) strictFor(t *types.Type, sw *generator.SnippetWriter) {
+	if !g.needsStrict(t) {
+		sw.Do("if err := json.Unmarshal(data, out); err != nil {\n", nil)
+		sw.Do("errs.Add(path, err)\n", nil)
+		sw.Do("}\n", nil)
+		return
+	}
+	if g.hasStrictMethod(t) {
+		sw.Do("out.UnmarshalJSONStrict(data, path, errs)\n", nil)
+		return
+	}
+
+	ut := underlyingType(t)
+	args := generator.Args{"type": t, "elem": ut.Elem, "marshal": g.runtimeName()}
+	switch ut.Kind {
+	case types.Pointer:
+		sw.Do("if $.marshal$.IsNull(data) {\n", args)
+		sw.Do("*out = nil\n", nil)
+		sw.Do("} else {\n", nil)
+		sw.Do("if *out == nil {\n", nil)
+		sw.Do("*out = new($.elem|raw$)\n", args)
+		sw.Do("}\n", nil)
+		sw.Do("out := *out\n", nil)
+		g.strictFor(ut.Elem, sw)
+		sw.Do("}\n", nil)
+	case types.Map:
+		sw.Do("var items map[string]json.RawMessage\n", nil)
+		sw.Do("if err := json.Unmarshal(data, &items); err != nil {\n", nil)
+		sw.Do("errs.Add(path, err)\n", nil)
+		sw.Do("} else if items == nil {\n", nil)
+		sw.Do("*out = nil\n", nil)
+		sw.Do("} else {\n", nil)
+		sw.Do("*out = make($.type|raw$, len(items))\n", args)
+		sw.Do("for _, key := range $.marshal$.SortedKeys(items) {\n", args)
+		sw.Do("var val $.elem|raw$\n", args)
+		sw.Do("{\n", nil)
+		sw.Do("data, path, out := items[key], $.marshal$.Key(path, key), &val\n", args)
+		g.strictFor(ut.Elem, sw)
+		sw.Do("}\n", nil)
+		if ut.Key.Kind == types.Builtin {
+			sw.Do("(*out)[key] = val\n", nil)
+		} else {
+			sw.Do("(*out)[$.|raw$(key)] = val\n", ut.Key)
+		}
+		sw.Do("}\n", nil)
+		sw.Do("}\n", nil)
+	case types.Slice:
+		sw.Do("var items []json.RawMessage\n", nil)
+		sw.Do("if err := json.Unmarshal(data, &items); err != nil {\n", nil)
+		sw.Do("errs.Add(path, err)\n", nil)
+		sw.Do("} else if items == nil {\n", nil)
+		sw.Do("*out = nil\n", nil)
+		sw.Do("} else {\n", nil)
+		sw.Do("*out = make($.type|raw$, len(items))\n", args)
+		sw.Do("for i := range items {\n", nil)
+		sw.Do("data, path, out := items[i], $.marshal$.Index(path, i), &(*out)[i]\n", args)
+		g.strictFor(ut.Elem, sw)
+		sw.Do("}\n", nil)
+		sw.Do("}\n", nil)
+	}
+}
+
+var strictTemplateCode = `
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged ` + "`" + `marshal:"required"` + "`" + ` which are missing.
+// The returned *$.marshal$.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *$.type|raw$) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &$.marshal$.StrictError{}
+	obj.UnmarshalJSONStrict(data, $.marshal$.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+`
@@ -0,0 +1,313 @@
+package generators
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// typeTagName is the comment tag prefix of the per type options, e.g.
+// +gengo:marshal:sql=true.
+const typeTagName = "gengo:marshal"
+
+// Per type comment tags.
+const (
+	sqlTagName = typeTagName + ":sql"
+)
+
+// extractTypeTag returns true if the comments of type t set the comment tag
+// name to true.
+func extractTypeTag(t *types.Type, name string) bool {
+	comments := append(append([]string{}, t.SecondClosestCommentLines...), t.CommentLines...)
+	values := types.ExtractCommentTags("+", comments)[name]
+	return len(values) > 0 && values[0] == "true"
+}
+
+// marshalTagName is the struct tag carrying marshal-gen specific options,
+// e.g. `marshal:"required"`.
+const marshalTagName = "marshal"
+
+// jsonField describes how a struct member appears in its JSON encoding.
+type jsonField struct {
+	member types.Member
+	// path lists the members leading to the field from the struct: the
+	// embedded structs the field is promoted through, then member itself.
+	path []types.Member
+	// index holds the positions of the members of path in their structs.
+	index []int
+	// name is the key of the member in the JSON object.
+	name string
+	// tagged is set if name comes from the json tag.
+	tagged bool
+	// omitEmpty is set by the omitempty option.
+	omitEmpty bool
+	// quoted is set by the string option on a string, boolean or numeric
+	// member, whose JSON encoding is then wrapped in a JSON string.
+	quoted bool
+	// required is set by `marshal:"required"`.
+	required bool
+}
+
+// selector returns the expression selecting the field from the struct expr.
+func (f jsonField) selector(expr string) string {
+	return pathSelector(f.path, expr)
+}
+
+// embeddedPointers returns the members of path that are embedded pointers,
+// along with the expressions selecting them from the struct expr.
+func (f jsonField) embeddedPointers(expr string) (members []types.Member, selectors []string) {
+	return pathEmbeddedPointers(f.path, expr)
+}
+
+// pathSelector returns the expression selecting the last member of path from
+// the struct expr.
+func pathSelector(path []types.Member, expr string) string {
+	for _, m := range path {
+		expr += "." + m.Name
+	}
+	return expr
+}
+
+// pathEmbeddedPointers returns the members of path, but the last one, that
+// are embedded pointers, along with the expressions selecting them from the
+// struct expr.
+func pathEmbeddedPointers(path []types.Member, expr string) (members []types.Member, selectors []string) {
+	for _, m := range path[:len(path)-1] {
+		expr += "." + m.Name
+		if m.Type.Kind == types.Pointer {
+			members = append(members, m)
+			selectors = append(selectors, expr)
+		}
+	}
+	return members, selectors
+}
+
+// jsonFields returns the members of struct t which take part in its JSON
+// encoding, in the order encoding/json writes them. It follows the rules of
+// encoding/json: unexported members and members tagged `json:"-"` are left
+// out, the fields of embedded structs are promoted unless the embedded member
+// is named by its tag, and of several fields with the same name only the
+// least nested one survives (or the only tagged one among the least nested).
+func jsonFields(t *types.Type) []jsonField {
+	type embedded struct {
+		typ   *types.Type
+		path  []types.Member
+		index []int
+	}
+
+	// Embedded structs to explore at the current level and the next.
+	current := []embedded{}
+	next := []embedded{{typ: t}}
+	// Count of queued names for the current level and the next.
+	var count, nextCount map[*types.Type]int
+	// Types already visited at an earlier level.
+	visited := map[*types.Type]bool{}
+
+	var fields []jsonField
+	for len(next) > 0 {
+		current, next = next, current[:0]
+		count, nextCount = nextCount, map[*types.Type]int{}
+
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			visited[e.typ] = true
+
+			for i, m := range underlyingType(e.typ).Members {
+				ft := m.Type
+				if ft.Kind == types.Pointer {
+					ft = ft.Elem
+				}
+				if m.Embedded {
+					if namer.IsPrivateGoName(m.Name) && underlyingType(ft).Kind != types.Struct {
+						// Embedded members of unexported non-struct types are ignored,
+						// those of unexported struct types may have exported fields.
+						continue
+					}
+				} else if namer.IsPrivateGoName(m.Name) {
+					continue
+				}
+				tag := reflect.StructTag(m.Tags)
+				jsonTag := tag.Get("json")
+				if jsonTag == "-" {
+					continue
+				}
+				name, opts := parseTag(jsonTag)
+				if !isValidTag(name) {
+					name = ""
+				}
+				path := append(append([]types.Member{}, e.path...), m)
+				index := append(append([]int{}, e.index...), i)
+
+				if name != "" || !m.Embedded || underlyingType(ft).Kind != types.Struct {
+					tagged := name != ""
+					if name == "" {
+						name = m.Name
+					}
+					marshalName, marshalOpts := parseTag(tag.Get(marshalTagName))
+					field := jsonField{
+						member:    m,
+						path:      path,
+						index:     index,
+						name:      name,
+						tagged:    tagged,
+						omitEmpty: opts.Contains("omitempty"),
+						quoted:    opts.Contains("string") && isQuotable(ft),
+						required:  marshalName == "required" || marshalOpts.Contains("required"),
+					}
+					fields = append(fields, field)
+					if count[e.typ] > 1 {
+						// If there were multiple instances, add a second, so
+						// that the annihilation code will see a duplicate.
+						fields = append(fields, field)
+					}
+					continue
+				}
+
+				// Record the new embedded struct to explore in the next round.
+				nextCount[ft]++
+				if nextCount[ft] == 1 {
+					next = append(next, embedded{typ: ft, path: path, index: index})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		// Sort fields by name, breaking ties with depth, then with "name
+		// came from json tag", then with index sequence.
+		a, b := fields[i], fields[j]
+		if a.name != b.name {
+			return a.name < b.name
+		}
+		if len(a.index) != len(b.index) {
+			return len(a.index) < len(b.index)
+		}
+		if a.tagged != b.tagged {
+			return a.tagged
+		}
+		return indexLess(a.index, b.index)
+	})
+
+	// Delete all fields that are hidden by the Go rules for embedded fields,
+	// except that fields with JSON tags are promoted.
+	out := fields[:0]
+	for advance, i := 0, 0; i < len(fields); i += advance {
+		// One iteration per name. Find the sequence of fields with this name.
+		fi := fields[i]
+		for advance = 1; i+advance < len(fields); advance++ {
+			if fields[i+advance].name != fi.name {
+				break
+			}
+		}
+		if advance == 1 {
+			out = append(out, fi)
+			continue
+		}
+		if dominant, ok := dominantField(fields[i : i+advance]); ok {
+			out = append(out, dominant)
+		}
+	}
+	fields = out
+
+	sort.Slice(fields, func(i, j int) bool {
+		return indexLess(fields[i].index, fields[j].index)
+	})
+	return fields
+}
+
+// dominantField looks through the fields, all of which are known to have the
+// same name, to find the single field that dominates the others using Go's
+// embedding rules, modified by the presence of JSON tags. If there are
+// multiple top-level fields, the boolean will be false: this condition is an
+// error in Go and we skip all the fields.
+func dominantField(fields []jsonField) (jsonField, bool) {
+	// The fields are sorted in increasing index-length order, then by presence
+	// of tag. That means that the first field is the dominant one. We need
+	// only check for error cases: two fields at top level, either both tagged
+	// or neither tagged.
+	if len(fields) > 1 && len(fields[0].index) == len(fields[1].index) && fields[0].tagged == fields[1].tagged {
+		return jsonField{}, false
+	}
+	return fields[0], true
+}
+
+func indexLess(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// isQuotable returns true if the string option applies to values of type t:
+// only strings, floats, integers and booleans can be quoted.
+func isQuotable(t *types.Type) bool {
+	ut := underlyingType(t)
+	if ut.Kind != types.Builtin {
+		return false
+	}
+	method, _ := builtinWriter(ut)
+	return method != ""
+}
+
+// isValidTag reports whether s is usable as a key in the json tag.
+func isValidTag(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+			// Backslash and quote chars are reserved, but otherwise any
+			// punctuation chars are allowed in a tag name.
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+	return true
+}
+
+// tagOptions is the string following a comma in a struct field's tag, or
+// the empty string.
+type tagOptions string
+
+// parseTag splits a struct field's tag into its name and comma-separated
+// options.
+func parseTag(tag string) (string, tagOptions) {
+	if idx := strings.Index(tag, ","); idx != -1 {
+		return tag[:idx], tagOptions(tag[idx+1:])
+	}
+	return tag, tagOptions("")
+}
+
+// Contains reports whether a comma-separated list of options contains a
+// particular option.
+func (o tagOptions) Contains(option string) bool {
+	s := string(o)
+	for s != "" {
+		var next string
+		i := strings.Index(s, ",")
+		if i >= 0 {
+			s, next = s[:i], s[i+1:]
+		}
+		if s == option {
+			return true
+		}
+		s = next
+	}
+	return false
+}
+
+func underlyingType(t *types.Type) *types.Type {
+	for t.Kind == types.Alias {
+		t = t.Underlying
+	}
+	return t
+}
@@ -0,0 +1,94 @@
+package generators
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+func Test_jsonFields(t *testing.T) {
+	str := &types.Type{Name: types.Name{Name: "string"}, Kind: types.Builtin}
+	typ := &types.Type{
+		Name: types.Name{Package: "pkgname", Name: "typename"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Plain", Type: str},
+			{Name: "Renamed", Type: str, Tags: `json:"renamed,omitempty"`},
+			{Name: "OptionsOnly", Type: str, Tags: `json:",omitempty"`},
+			{Name: "Skipped", Type: str, Tags: `json:"-"`},
+			{Name: "Dash", Type: str, Tags: `json:"-,"`},
+			{Name: "private", Type: str},
+			{Name: "Required", Type: str, Tags: `json:"req" marshal:"required"`},
+		},
+	}
+
+	var names []string
+	var required []string
+	for _, f := range jsonFields(typ) {
+		names = append(names, f.name)
+		if f.required {
+			required = append(required, f.name)
+		}
+	}
+	if expect := []string{"Plain", "renamed", "OptionsOnly", "-", "req"}; !reflect.DeepEqual(names, expect) {
+		t.Errorf("expected fields %v, got %v", expect, names)
+	}
+	if expect := []string{"req"}; !reflect.DeepEqual(required, expect) {
+		t.Errorf("expected required fields %v, got %v", expect, required)
+	}
+}
+
+func Test_jsonFieldsEmbedded(t *testing.T) {
+	str := &types.Type{Name: types.Name{Name: "string"}, Kind: types.Builtin}
+	integer := &types.Type{Name: types.Name{Name: "int"}, Kind: types.Builtin}
+	left := &types.Type{
+		Name: types.Name{Package: "pkgname", Name: "Left"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Dup", Type: str},
+			{Name: "Tagged", Type: str, Tags: `json:"Pick"`},
+			{Name: "Shadowed", Type: str},
+		},
+	}
+	right := &types.Type{
+		Name: types.Name{Package: "pkgname", Name: "Right"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Dup", Type: str},
+			{Name: "Pick", Type: str},
+			{Name: "Count", Type: integer, Tags: `json:"count,string,omitempty"`},
+		},
+	}
+	typ := &types.Type{
+		Name: types.Name{Package: "pkgname", Name: "typename"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Left", Embedded: true, Type: left},
+			{Name: "Right", Embedded: true, Type: &types.Type{Kind: types.Pointer, Elem: right}},
+			{Name: "Named", Embedded: true, Type: left, Tags: `json:"named"`},
+			{Name: "Shadowed", Type: str},
+		},
+	}
+
+	var paths []string
+	for _, f := range jsonFields(typ) {
+		path := f.selector("obj")
+		if f.omitEmpty {
+			path += ",omitempty"
+		}
+		if f.quoted {
+			path += ",string"
+		}
+		paths = append(paths, f.name+"="+path)
+	}
+	expect := []string{
+		"Pick=obj.Left.Tagged",
+		"count=obj.Right.Count,omitempty,string",
+		"named=obj.Named",
+		"Shadowed=obj.Shadowed",
+	}
+	if !reflect.DeepEqual(paths, expect) {
+		t.Errorf("expected fields %v, got %v", expect, paths)
+	}
+}
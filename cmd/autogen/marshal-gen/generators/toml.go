@@ -0,0 +1,71 @@
+package generators
+
+import (
+	"strconv"
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// tomlTagName is the comment tag generating the TOML methods of a struct,
+// +gengo:marshal:toml=true.
+const tomlTagName = typeTagName + ":toml"
+
+// generateTOML writes the MarshalTOML, WriteTOML and UnmarshalTOML methods of
+// struct t. Like for YAML, the fields are those of the JSON encoding, written
+// in declaration order with the doc comments of their members.
+func (g *marshalGen) generateTOML(t *types.Type, sw *generator.SnippetWriter) {
+	args := generator.Args{"type": t, "marshal": g.runtimeName()}
+	sw.Do(tomlTemplateCode, args)
+
+	sw.Do("func (obj $.type|raw$) WriteTOML(t *$.marshal$.TOMLTable) error {\n", args)
+	for _, f := range jsonFields(t) {
+		conditions := g.fieldConditions(f, "obj")
+		if len(conditions) > 0 {
+			sw.Do("if $.$ {\n", strings.Join(conditions, " && "))
+		}
+		add := "Add"
+		if f.quoted {
+			add = "AddQuoted"
+		}
+		sw.Do("if err := t.$.add$($.name$, $.comment$, $.selector$); err != nil {\n", generator.Args{
+			"add":      add,
+			"name":     strconv.Quote(f.name),
+			"comment":  strconv.Quote(commentDescription(f.member.CommentLines)),
+			"selector": f.selector("obj"),
+		})
+		sw.Do("return err\n", nil)
+		sw.Do("}\n", nil)
+		if len(conditions) > 0 {
+			sw.Do("}\n", nil)
+		}
+	}
+	sw.Do("return nil\n", nil)
+	sw.Do("}\n\n", nil)
+
+	sw.Do(unmarshalTOMLTemplateCode, args)
+}
+
+var tomlTemplateCode = `
+// MarshalTOML returns the TOML document of obj. Its keys follow the json tags
+// of the fields and keep their declaration order, with their doc comments.
+// TOML has no null: nil fields are left out.
+func (obj $.type|raw$) MarshalTOML() ([]byte, error) {
+	t := $.marshal$.NewTOMLTable()
+	if err := obj.WriteTOML(t); err != nil {
+		return nil, err
+	}
+	return t.MarshalTOML()
+}
+
+// WriteTOML adds the fields of obj to t. It is used by MarshalTOML of the
+// types containing this one.
+`
+
+var unmarshalTOMLTemplateCode = `// UnmarshalTOML decodes the value of a TOML table, as given by the TOML
+// library, into obj, matching keys to fields like UnmarshalJSONBinary does.
+func (obj *$.type|raw$) UnmarshalTOML(data interface{}) error {
+	return $.marshal$.DecodeTOML(data, obj)
+}
+`
@@ -0,0 +1,261 @@
+package generators
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// typeScriptFileType is the file type of the generated TypeScript
+// declarations.
+const typeScriptFileType = "typescript"
+
+// newTypeScriptFile returns the file type writing the imports of the file,
+// then its body.
+func newTypeScriptFile() *generator.DefaultFileType {
+	return &generator.DefaultFileType{
+		Format: func(src []byte) ([]byte, error) {
+			return src, nil
+		},
+		Assemble: func(w io.Writer, f *generator.File) {
+			fmt.Fprint(w, "// Code generated by marshal-gen. DO NOT EDIT.\n")
+			var imports []string
+			for i := range f.Imports {
+				imports = append(imports, i)
+			}
+			sort.Strings(imports)
+			if len(imports) > 0 {
+				fmt.Fprint(w, "\n")
+			}
+			for _, i := range imports {
+				fmt.Fprintf(w, "%s\n", i)
+			}
+			w.Write(f.Body.Bytes())
+		},
+	}
+}
+
+type typeScriptGen struct {
+	generator.DefaultGen
+	targetPackage string
+	// imports holds the import declaration of each package referred to, by
+	// package path.
+	imports map[string]string
+	// inlining holds the types of other packages being written in place, to
+	// stop at those referring to themselves.
+	inlining map[*types.Type]bool
+}
+
+// NewTypeScriptGen returns a generator writing the file sanitizedName.d.ts,
+// TypeScript declarations of the JSON encoding of every type of the package.
+// Types of the other generated packages are imported from the
+// sanitizedName.d.ts file of their package; types of the packages which are
+// not generated, such as xml.Name, are written in place.
+func NewTypeScriptGen(sanitizedName, targetPackage string) generator.Generator {
+	return &typeScriptGen{
+		DefaultGen: generator.DefaultGen{
+			OptionalName: sanitizedName,
+		},
+		targetPackage: targetPackage,
+		imports:       map[string]string{},
+		inlining:      map[*types.Type]bool{},
+	}
+}
+
+func (g *typeScriptGen) Filename() string {
+	return g.OptionalName + ".d.ts"
+}
+
+func (g *typeScriptGen) FileType() string {
+	return typeScriptFileType
+}
+
+func (g *typeScriptGen) Imports(c *generator.Context) (imports []string) {
+	for _, i := range g.imports {
+		imports = append(imports, i)
+	}
+	return imports
+}
+
+// GenerateType writes the declaration of t.
+func (g *typeScriptGen) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("\n")
+	writeDocComment(&b, t.CommentLines, "")
+	switch {
+	case enumOf(t) != nil:
+		var names []string
+		for _, c := range enumOf(t).constants {
+			names = append(names, strconv.Quote(c.name))
+		}
+		// The values without a constant are written as their underlying
+		// value, in text.
+		if builtinKind(underlyingType(t)) == "String" {
+			names = append(names, "string")
+		} else {
+			names = append(names, "`${number}`")
+		}
+		fmt.Fprintf(&b, "export type %s = %s;\n", t.Name.Name, strings.Join(names, " | "))
+	case hasJSONMarshaler(t):
+		// The type writes itself, its encoding is unknown.
+		fmt.Fprintf(&b, "export type %s = unknown;\n", t.Name.Name)
+	case t.Kind == types.Struct:
+		fmt.Fprintf(&b, "export interface %s {\n", t.Name.Name)
+		for _, f := range jsonFields(t) {
+			writeDocComment(&b, f.member.CommentLines, "  ")
+			optional := ""
+			if f.omitEmpty || f.member.Type.Kind == types.Pointer {
+				optional = "?"
+			}
+			fmt.Fprintf(&b, "  %s%s: %s;\n", typeScriptKey(f.name), optional, g.fieldType(f))
+		}
+		b.WriteString("}\n")
+	default:
+		fmt.Fprintf(&b, "export type %s = %s;\n", t.Name.Name, g.typeOf(t.Underlying))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// fieldType returns the TypeScript type of the value of the field f.
+func (g *typeScriptGen) fieldType(f jsonField) string {
+	if f.quoted && !hasJSONMarshaler(f.member.Type) {
+		if f.member.Type.Kind == types.Pointer {
+			return "string | null"
+		}
+		return "string"
+	}
+	return g.typeOf(f.member.Type)
+}
+
+// typeOf returns the TypeScript type of the JSON encoding of t.
+func (g *typeScriptGen) typeOf(t *types.Type) string {
+	if t.Name.Package == "time" && t.Name.Name == "Time" {
+		return "string"
+	}
+	if isDefined(t) && isForeign(t) {
+		return g.inlineTypeOf(t)
+	}
+	if isDefined(t) {
+		return g.nameOf(t)
+	}
+	switch t.Kind {
+	case types.Builtin:
+		switch builtinKind(t) {
+		case "Bool":
+			return "boolean"
+		case "String":
+			return "string"
+		case "Int", "Uint", "Float":
+			return "number"
+		}
+	case types.Pointer:
+		return orNull(g.typeOf(t.Elem))
+	case types.Slice:
+		if isByteSlice(t) {
+			return "string | null"
+		}
+		return orNull(arrayOf(g.typeOf(t.Elem)))
+	case types.Array:
+		return arrayOf(g.typeOf(t.Elem))
+	case types.Map:
+		if enumOf(t.Key) != nil {
+			return orNull("Partial<Record<" + g.nameOf(t.Key) + ", " + g.typeOf(t.Elem) + ">>")
+		}
+		return orNull("Record<string, " + g.typeOf(t.Elem) + ">")
+	}
+	// Interfaces hold any value. Channels and functions cannot be encoded.
+	return "unknown"
+}
+
+// inlineTypeOf returns the TypeScript type of the JSON encoding of the named
+// type t of a package which is not generated, and so has no declarations to
+// import, written in place.
+func (g *typeScriptGen) inlineTypeOf(t *types.Type) string {
+	if hasJSONMarshaler(t) || g.inlining[t] {
+		// The type writes itself, or refers to itself.
+		return "unknown"
+	}
+	g.inlining[t] = true
+	defer delete(g.inlining, t)
+	if t.Kind != types.Struct {
+		return g.typeOf(t.Underlying)
+	}
+	var fields []string
+	for _, f := range jsonFields(t) {
+		optional := ""
+		if f.omitEmpty || f.member.Type.Kind == types.Pointer {
+			optional = "?"
+		}
+		fields = append(fields, fmt.Sprintf("%s%s: %s;", typeScriptKey(f.name), optional, g.fieldType(f)))
+	}
+	if len(fields) == 0 {
+		return "{}"
+	}
+	return "{ " + strings.Join(fields, " ") + " }"
+}
+
+// nameOf returns the name of the named type t, qualified by the namespace
+// its package is imported as if it is declared in another package.
+func (g *typeScriptGen) nameOf(t *types.Type) string {
+	if t.Name.Package == g.targetPackage {
+		return t.Name.Name
+	}
+	alias := typeScriptIdentifier.ReplaceAllString(path.Base(t.Name.Package), "_")
+	from := relativePath(g.targetPackage, t.Name.Package) + "/" + g.OptionalName
+	if !strings.HasPrefix(from, ".") {
+		from = "./" + from
+	}
+	g.imports[t.Name.Package] = fmt.Sprintf("import * as %s from %s;", alias, strconv.Quote(from))
+	return alias + "." + t.Name.Name
+}
+
+// typeScriptIdentifier matches the characters not allowed in identifiers.
+var typeScriptIdentifier = regexp.MustCompile(`[^A-Za-z0-9_$]`)
+
+// typeScriptKey returns the property name key, quoted unless it is an
+// identifier.
+func typeScriptKey(key string) string {
+	if key != "" && !typeScriptIdentifier.MatchString(key) && (key[0] < '0' || key[0] > '9') {
+		return key
+	}
+	return strconv.Quote(key)
+}
+
+// orNull returns the union of typ and null.
+func orNull(typ string) string {
+	if strings.HasSuffix(typ, " | null") {
+		return typ
+	}
+	return typ + " | null"
+}
+
+// arrayOf returns the type of arrays of typ.
+func arrayOf(typ string) string {
+	if strings.Contains(typ, " ") {
+		return "Array<" + typ + ">"
+	}
+	return typ + "[]"
+}
+
+// writeDocComment writes the doc comment lines, without their comment tags,
+// as a JSDoc comment.
+func writeDocComment(b *strings.Builder, lines []string, indent string) {
+	description := commentDescription(lines)
+	if description == "" {
+		return
+	}
+	fmt.Fprintf(b, "%s/**\n", indent)
+	for _, line := range strings.Split(description, "\n") {
+		line = strings.Replace(line, "*/", "*\\/", -1)
+		fmt.Fprintf(b, "%s * %s\n", indent, strings.TrimRight(line, " "))
+	}
+	fmt.Fprintf(b, "%s */\n", indent)
+}
@@ -0,0 +1,1128 @@
+package generators
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// xmlTagName is the comment tag generating the XML methods of a struct,
+// +gengo:marshal:xml=true.
+const xmlTagName = typeTagName + ":xml"
+
+// xmlPackage is imported by the generated XML methods.
+const xmlPackage = "encoding/xml"
+
+// xmlName imports encoding/xml into the generated file and returns the local
+// name it is imported as.
+func (g *marshalGen) xmlName() string {
+	g.imports.AddType(&types.Type{Name: types.Name{Package: xmlPackage, Name: "Name"}})
+	return g.imports.LocalNameOf(xmlPackage)
+}
+
+// xmlMode is how a field appears in the XML encoding of its struct, as set by
+// the flags of its xml tag. The values are those of encoding/xml.
+type xmlMode int
+
+const (
+	xmlElement xmlMode = 1 << iota
+	xmlAttr
+	xmlCDATA
+	xmlCharData
+	xmlInnerXML
+	xmlComment
+	xmlAny
+)
+
+// xmlField is a member of a struct with generated XML methods.
+type xmlField struct {
+	member types.Member
+	// path lists the members leading to the field from the struct, like
+	// jsonField.path.
+	path []types.Member
+	// space and name are the namespace and the local name of the element or
+	// the attribute.
+	space, name string
+	// parents are the elements the element is nested in, from `xml:"a>b"`.
+	parents   []string
+	mode      xmlMode
+	omitEmpty bool
+}
+
+// selector returns the expression selecting the field from the struct expr.
+func (f xmlField) selector(expr string) string {
+	return pathSelector(f.path, expr)
+}
+
+// xmlStruct is a struct with generated XML methods.
+type xmlStruct struct {
+	// nameField is the XMLName member naming the element of the struct, or
+	// nil.
+	nameField *xmlField
+	fields    []xmlField
+}
+
+// xmlStructs holds the structs of the packages being generated with XML
+// methods, as found by findXMLStructs.
+var xmlStructs = map[*types.Type]*xmlStruct{}
+
+// xmlStructOf returns the XML fields of t, or nil if t has no generated XML
+// methods.
+func xmlStructOf(t *types.Type) *xmlStruct {
+	return xmlStructs[t]
+}
+
+// findXMLStructs returns the structs of pkg tagged +gengo:marshal:xml=true.
+// Their xml tags are checked the way encoding/xml checks them, so that the
+// mistakes it reports when marshaling are reported by marshal-gen instead.
+func findXMLStructs(pkg *types.Package) (map[*types.Type]*xmlStruct, error) {
+	var names []string
+	for name := range pkg.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	found := map[*types.Type]*xmlStruct{}
+	for _, name := range names {
+		t := pkg.Types[name]
+		if !extractTypeTag(t, xmlTagName) {
+			continue
+		}
+		if t.Kind != types.Struct {
+			return nil, fmt.Errorf("%v: only structs can have XML methods", t)
+		}
+		s, err := xmlFields(t)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range s.fields {
+			if err := checkXMLField(f); err != nil {
+				return nil, fmt.Errorf("%v.%s: %v", t, f.member.Name, err)
+			}
+		}
+		found[t] = s
+	}
+	return found, nil
+}
+
+// xmlFields returns the fields of struct t in the order encoding/xml writes
+// them. Like in encoding/xml, the fields of embedded structs are promoted
+// whatever their tag, and conflicting fields are resolved by depth.
+func xmlFields(t *types.Type) (*xmlStruct, error) {
+	s := &xmlStruct{}
+	for _, m := range t.Members {
+		tag := reflect.StructTag(m.Tags).Get("xml")
+		if namer.IsPrivateGoName(m.Name) && !m.Embedded || tag == "-" {
+			continue
+		}
+		if m.Embedded {
+			et := m.Type
+			if et.Kind == types.Pointer {
+				et = et.Elem
+			}
+			if et.Kind == types.Struct {
+				inner, err := xmlFields(et)
+				if err != nil {
+					return nil, err
+				}
+				if s.nameField == nil && inner.nameField != nil {
+					f := *inner.nameField
+					f.path = append([]types.Member{m}, f.path...)
+					s.nameField = &f
+				}
+				for _, f := range inner.fields {
+					f.path = append([]types.Member{m}, f.path...)
+					if err := s.add(t, f); err != nil {
+						return nil, err
+					}
+				}
+				continue
+			}
+			if namer.IsPrivateGoName(m.Name) {
+				continue
+			}
+		}
+
+		f, err := xmlFieldOf(t, m)
+		if err != nil {
+			return nil, err
+		}
+		if m.Name == "XMLName" {
+			s.nameField = f
+			continue
+		}
+		if err := s.add(t, *f); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// xmlFieldOf parses the xml tag of member m of struct t.
+func xmlFieldOf(t *types.Type, m types.Member) (*xmlField, error) {
+	f := &xmlField{member: m, path: []types.Member{m}}
+
+	tag := reflect.StructTag(m.Tags).Get("xml")
+	if i := strings.Index(tag, " "); i >= 0 {
+		f.space, tag = tag[:i], tag[i+1:]
+	}
+
+	tokens := strings.Split(tag, ",")
+	if len(tokens) == 1 {
+		f.mode = xmlElement
+	} else {
+		tag = tokens[0]
+		for _, flag := range tokens[1:] {
+			switch flag {
+			case "attr":
+				f.mode |= xmlAttr
+			case "cdata":
+				f.mode |= xmlCDATA
+			case "chardata":
+				f.mode |= xmlCharData
+			case "innerxml":
+				f.mode |= xmlInnerXML
+			case "comment":
+				f.mode |= xmlComment
+			case "any":
+				f.mode |= xmlAny
+			case "omitempty":
+				f.omitEmpty = true
+			}
+		}
+
+		valid := true
+		switch f.mode {
+		case 0:
+			f.mode = xmlElement
+		case xmlAttr, xmlCDATA, xmlCharData, xmlInnerXML, xmlComment, xmlAny, xmlAny | xmlAttr:
+			if m.Name == "XMLName" || tag != "" && f.mode != xmlAttr {
+				valid = false
+			}
+		default:
+			valid = false
+		}
+		if f.mode == xmlAny {
+			f.mode |= xmlElement
+		}
+		if f.omitEmpty && f.mode&(xmlElement|xmlAttr) == 0 {
+			valid = false
+		}
+		if !valid {
+			return nil, fmt.Errorf("%v.%s: invalid xml tag %q", t, m.Name, reflect.StructTag(m.Tags).Get("xml"))
+		}
+		if f.mode&(xmlCDATA|xmlInnerXML) != 0 {
+			return nil, fmt.Errorf("%v.%s: the xml tag flags cdata and innerxml are not supported", t, m.Name)
+		}
+	}
+
+	if f.space != "" && tag == "" {
+		return nil, fmt.Errorf("%v.%s: namespace without name in xml tag %q", t, m.Name, reflect.StructTag(m.Tags).Get("xml"))
+	}
+	if m.Name == "XMLName" {
+		f.name = tag
+		return f, nil
+	}
+	if tag == "" {
+		if name := lookupXMLName(m.Type); name != nil {
+			f.space, f.name = name.space, name.name
+		} else {
+			f.name = m.Name
+		}
+		return f, nil
+	}
+
+	parents := strings.Split(tag, ">")
+	if parents[0] == "" {
+		parents[0] = m.Name
+	}
+	if parents[len(parents)-1] == "" {
+		return nil, fmt.Errorf("%v.%s: trailing '>' in xml tag %q", t, m.Name, tag)
+	}
+	f.name = parents[len(parents)-1]
+	if len(parents) > 1 {
+		if f.mode&xmlElement == 0 {
+			return nil, fmt.Errorf("%v.%s: xml tag %q is not valid with the flags %s", t, m.Name, tag, strings.Join(tokens[1:], ","))
+		}
+		f.parents = parents[:len(parents)-1]
+	}
+	if f.mode&xmlElement != 0 {
+		if name := lookupXMLName(m.Type); name != nil && name.name != f.name {
+			return nil, fmt.Errorf("%v.%s: name %q in xml tag conflicts with name %q of %v.XMLName", t, m.Name, f.name, name.name, m.Type)
+		}
+	}
+	return f, nil
+}
+
+// lookupXMLName returns the XMLName member of struct t, or of the struct t
+// points to, if its tag names the element.
+func lookupXMLName(t *types.Type) *xmlField {
+	for t.Kind == types.Pointer {
+		t = t.Elem
+	}
+	if t.Kind != types.Struct {
+		return nil
+	}
+	for _, m := range t.Members {
+		if m.Name != "XMLName" {
+			continue
+		}
+		if f, err := xmlFieldOf(t, m); err == nil && f.name != "" {
+			return f
+		}
+		break
+	}
+	return nil
+}
+
+// add adds field f of struct t to s, unless a less nested field conflicts
+// with it, following the rules of encoding/xml.
+func (s *xmlStruct) add(t *types.Type, f xmlField) error {
+	var conflicts []int
+Fields:
+	for i, old := range s.fields {
+		if old.mode != f.mode {
+			continue
+		}
+		if old.space != "" && f.space != "" && old.space != f.space {
+			continue
+		}
+		for p := 0; p < len(old.parents) && p < len(f.parents); p++ {
+			if old.parents[p] != f.parents[p] {
+				continue Fields
+			}
+		}
+		switch {
+		case len(old.parents) > len(f.parents):
+			if old.parents[len(f.parents)] == f.name {
+				conflicts = append(conflicts, i)
+			}
+		case len(old.parents) < len(f.parents):
+			if f.parents[len(old.parents)] == old.name {
+				conflicts = append(conflicts, i)
+			}
+		default:
+			if f.name == old.name && f.space == old.space {
+				conflicts = append(conflicts, i)
+			}
+		}
+	}
+	if conflicts == nil {
+		s.fields = append(s.fields, f)
+		return nil
+	}
+
+	// A less nested field hides f.
+	for _, i := range conflicts {
+		if len(s.fields[i].path) < len(f.path) {
+			return nil
+		}
+	}
+	for _, i := range conflicts {
+		if old := s.fields[i]; len(old.path) == len(f.path) {
+			return fmt.Errorf("%v: field %s with xml tag %q conflicts with field %s with xml tag %q", t,
+				old.member.Name, reflect.StructTag(old.member.Tags).Get("xml"),
+				f.member.Name, reflect.StructTag(f.member.Tags).Get("xml"))
+		}
+	}
+	// f hides the more nested fields.
+	for c := len(conflicts) - 1; c >= 0; c-- {
+		i := conflicts[c]
+		s.fields = append(s.fields[:i], s.fields[i+1:]...)
+	}
+	s.fields = append(s.fields, f)
+	return nil
+}
+
+// checkXMLField returns an error if the generated code cannot write the
+// field f in its xml mode.
+func checkXMLField(f xmlField) error {
+	t := f.member.Type
+	switch {
+	case f.mode == xmlAny|xmlAttr:
+		if ut := underlyingType(t); ut.Kind != types.Slice || !isXMLName(ut.Elem, "Attr") {
+			return fmt.Errorf("the type of an any,attr field must be []xml.Attr, not %v", t)
+		}
+	case f.mode&xmlAttr != 0:
+		return checkXMLText(t, true)
+	case f.mode&xmlCharData != 0:
+		return checkXMLText(t, false)
+	case f.mode&xmlComment != 0:
+		if ut := underlyingType(t); !isStringKind(ut) && !isXMLBytes(ut) {
+			return fmt.Errorf("the type of a comment field must be a string or a []byte, not %v", t)
+		}
+	default:
+		return checkXMLElement(t)
+	}
+	return nil
+}
+
+// checkXMLText returns an error if values of type t have no text to be
+// written as an attribute, or as character data. Attributes are also written
+// once per item of a slice.
+func checkXMLText(t *types.Type, attr bool) error {
+	if isXMLText(t) {
+		return nil
+	}
+	ut := underlyingType(t)
+	switch {
+	case ut.Kind == types.Pointer:
+		return checkXMLText(ut.Elem, attr)
+	case ut.Kind == types.Slice && attr:
+		return checkXMLText(ut.Elem, attr)
+	}
+	return fmt.Errorf("unsupported xml text type %v", t)
+}
+
+// checkXMLElement returns an error if values of type t cannot be written as
+// an element.
+func checkXMLElement(t *types.Type) error {
+	if hasXMLMarshaler(t) || isXMLText(t) {
+		return nil
+	}
+	ut := underlyingType(t)
+	switch ut.Kind {
+	case types.Map, types.Chan, types.Func:
+		return fmt.Errorf("unsupported xml element type %v", t)
+	case types.Pointer, types.Slice, types.Array:
+		return checkXMLElement(ut.Elem)
+	}
+	return nil
+}
+
+// isXMLText returns true if values of type t are written as text, either
+// through methods or as a string, a boolean, a number or a byte slice.
+func isXMLText(t *types.Type) bool {
+	if hasXMLTextMarshaler(t) || isForeign(t) {
+		return true
+	}
+	ut := underlyingType(t)
+	if ut.Kind == types.Builtin {
+		method, _ := builtinWriter(ut)
+		return method != ""
+	}
+	return isXMLBytes(ut)
+}
+
+// isXMLBytes returns true if t is a slice of bytes, which encoding/xml writes
+// as text.
+func isXMLBytes(t *types.Type) bool {
+	ut := underlyingType(t)
+	return ut.Kind == types.Slice && isByte(underlyingType(ut.Elem))
+}
+
+// isXMLName returns true if t is the type name of encoding/xml.
+func isXMLName(t *types.Type, name string) bool {
+	return t.Name.Package == xmlPackage && t.Name.Name == name
+}
+
+// hasXMLMarshaler returns true if t has generated XML methods, or
+// hand-written MarshalXML and UnmarshalXML methods.
+func hasXMLMarshaler(t *types.Type) bool {
+	if xmlStructOf(t) != nil {
+		return true
+	}
+	_, marshal := t.Methods["MarshalXML"]
+	_, unmarshal := t.Methods["UnmarshalXML"]
+	return marshal && unmarshal
+}
+
+// hasXMLTextMarshaler returns true if t is written as the text of its
+// MarshalText method and read by its UnmarshalText method.
+func hasXMLTextMarshaler(t *types.Type) bool {
+	if enumOf(t) != nil {
+		return true
+	}
+	_, marshal := t.Methods["MarshalText"]
+	_, unmarshal := t.Methods["UnmarshalText"]
+	return marshal && unmarshal
+}
+
+// hasXMLAttrMarshaler returns true if t has hand-written MarshalXMLAttr and
+// UnmarshalXMLAttr methods, which encoding/xml prefers for attributes.
+func hasXMLAttrMarshaler(t *types.Type) bool {
+	_, marshal := t.Methods["MarshalXMLAttr"]
+	_, unmarshal := t.Methods["UnmarshalXMLAttr"]
+	return marshal && unmarshal
+}
+
+// xmlWriter writes the XML methods of a struct.
+type xmlWriter struct {
+	*marshalGen
+	sw *generator.SnippetWriter
+	// args holds the local names of encoding/xml and of the runtime.
+	args generator.Args
+}
+
+// do writes the code format. Unless a is some other value, which then is the
+// argument of format, $.xml$, $.marshal$ and $.strconv$ are the local names of
+// the packages in format, along with the keys of a if it is a generator.Args.
+func (w *xmlWriter) do(format string, a interface{}) {
+	args := generator.Args{}
+	for k, v := range w.args {
+		args[k] = v
+	}
+	switch a := a.(type) {
+	case nil:
+	case generator.Args:
+		for k, v := range a {
+			args[k] = v
+		}
+	default:
+		w.sw.Do(format, a)
+		return
+	}
+	w.sw.Do(format, args)
+}
+
+// nameLiteral returns the xml.Name literal of the element or attribute of
+// field f.
+func (w *xmlWriter) nameLiteral(f xmlField) string {
+	if f.space == "" {
+		return fmt.Sprintf("%s.Name{Local: %s}", w.args["xml"], strconv.Quote(f.name))
+	}
+	return fmt.Sprintf("%s.Name{Space: %s, Local: %s}", w.args["xml"], strconv.Quote(f.space), strconv.Quote(f.name))
+}
+
+// generateXML writes the MarshalXML and UnmarshalXML methods of struct t,
+// whose fields are s.
+func (g *marshalGen) generateXML(t *types.Type, s *xmlStruct, sw *generator.SnippetWriter) {
+	w := &xmlWriter{
+		marshalGen: g,
+		sw:         sw,
+		args:       generator.Args{"type": t, "xml": g.xmlName(), "marshal": g.runtimeName()},
+	}
+	g.imports.AddType(&types.Type{Name: types.Name{Package: "strconv", Name: "FormatInt"}})
+	w.args["strconv"] = g.imports.LocalNameOf("strconv")
+	sw.Do(xmlTemplateCode, w.args)
+	w.marshal(s)
+	sw.Do(unmarshalXMLTemplateCode, w.args)
+	w.unmarshal(s)
+}
+
+// embeddedConditions returns the conditions under which the embedded
+// pointers leading to f are set.
+func (w *xmlWriter) embeddedConditions(f xmlField) []string {
+	_, selectors := pathEmbeddedPointers(f.path, "obj")
+	for i := range selectors {
+		selectors[i] += " != nil"
+	}
+	return selectors
+}
+
+// marshal writes the MarshalXML method.
+func (w *xmlWriter) marshal(s *xmlStruct) {
+	w.do("func (obj $.type|raw$) MarshalXML(e *$.xml$.Encoder, start $.xml$.StartElement) error {\n", w.args)
+	if f := s.nameField; f != nil {
+		if f.name != "" {
+			w.do("start.Name = $.$\n", w.nameLiteral(*f))
+		} else if isXMLName(f.member.Type, "Name") {
+			conditions := append(w.embeddedConditions(*f), f.selector("obj")+`.Local != ""`)
+			w.do("if $.$ {\n", strings.Join(conditions, " && "))
+			w.do("start.Name = $.$\n", f.selector("obj"))
+			w.do("}\n", nil)
+		}
+	}
+	for _, f := range s.fields {
+		if f.mode&xmlAttr == 0 {
+			continue
+		}
+		t, expr := f.member.Type, f.selector("obj")
+		conditions := w.embeddedConditions(f)
+		if f.omitEmpty {
+			if condition := nonEmpty(t, expr); condition != "" {
+				conditions = append(conditions, condition)
+			}
+			if ut := underlyingType(t); ut.Kind == types.Pointer {
+				// The pointer is known not to be nil.
+				t, expr = ut.Elem, "(*"+expr+")"
+			}
+		}
+		if len(conditions) > 0 {
+			w.do("if $.$ {\n", strings.Join(conditions, " && "))
+		}
+		if f.mode&xmlAny != 0 {
+			w.do("start.Attr = append(start.Attr, $.$...)\n", expr)
+		} else {
+			w.marshalAttr(t, expr, w.nameLiteral(f))
+		}
+		if len(conditions) > 0 {
+			w.do("}\n", nil)
+		}
+	}
+	w.do("if err := e.EncodeToken(start); err != nil {\n", nil)
+	w.do("return err\n", nil)
+	w.do("}\n", nil)
+
+	for _, f := range s.fields {
+		if len(f.parents) > 0 {
+			w.do("parents := $.marshal$.NewXMLParents(e)\n", nil)
+			break
+		}
+	}
+	// open is set once parents may be open.
+	open := false
+	for _, f := range s.fields {
+		if f.mode&xmlAttr != 0 {
+			continue
+		}
+		conditions := w.embeddedConditions(f)
+		if len(conditions) > 0 {
+			w.do("if $.$ {\n", strings.Join(conditions, " && "))
+		}
+		if open {
+			w.do("if err := parents.Trim($.$); err != nil {\n", quoteAll(f.parents))
+			w.do("return err\n", nil)
+			w.do("}\n", nil)
+		}
+		open = open || len(f.parents) > 0
+		expr := f.selector("obj")
+		switch {
+		case f.mode&xmlCharData != 0:
+			w.marshalText(f.member.Type, expr, "if err := e.EncodeToken($.xml$.CharData($.text$)); err != nil {\nreturn err\n}\n")
+		case f.mode&xmlComment != 0:
+			w.do("if err := $.marshal$.EncodeXMLComment(e, $.comment$); err != nil {\n", generator.Args{"comment": stringOf(f.member.Type, expr)})
+			w.do("return err\n", nil)
+			w.do("}\n", nil)
+		default:
+			if len(f.parents) > 0 {
+				kind := underlyingType(f.member.Type).Kind
+				nilable := kind == types.Pointer || kind == types.Interface
+				if nilable {
+					w.do("if $.$ != nil {\n", expr)
+				}
+				w.do("if err := parents.Push($.$); err != nil {\n", quoteAll(f.parents))
+				w.do("return err\n", nil)
+				w.do("}\n", nil)
+				if nilable {
+					w.do("}\n", nil)
+				}
+			}
+			w.marshalElement(f.member.Type, expr, f)
+		}
+		if len(conditions) > 0 {
+			w.do("}\n", nil)
+		}
+	}
+	if open {
+		w.do("if err := parents.Trim(); err != nil {\n", nil)
+		w.do("return err\n", nil)
+		w.do("}\n", nil)
+	}
+	w.do("return e.EncodeToken(start.End())\n", nil)
+	w.do("}\n\n", nil)
+}
+
+// marshalAttr writes the code adding the attribute name with the value expr,
+// of type t, to start. Like in encoding/xml nil pointers are left out and
+// slices add an attribute per item.
+func (w *xmlWriter) marshalAttr(t *types.Type, expr, name string) {
+	ut := underlyingType(t)
+	switch {
+	case hasXMLAttrMarshaler(t):
+		w.do("{\n", nil)
+		w.do("attr, err := $.expr$.MarshalXMLAttr($.name$)\n", generator.Args{"expr": receiver(expr), "name": name})
+		w.do("if err != nil {\n", nil)
+		w.do("return err\n", nil)
+		w.do("}\n", nil)
+		w.do("if attr.Name.Local != \"\" {\n", nil)
+		w.do("start.Attr = append(start.Attr, attr)\n", nil)
+		w.do("}\n", nil)
+		w.do("}\n", nil)
+	case isXMLText(t):
+		w.marshalText(t, expr, "start.Attr = append(start.Attr, $.xml$.Attr{Name: "+name+", Value: $.text$})\n")
+	case ut.Kind == types.Pointer:
+		w.do("if $.$ != nil {\n", expr)
+		w.marshalAttr(ut.Elem, "(*"+expr+")", name)
+		w.do("}\n", nil)
+	case ut.Kind == types.Slice:
+		w.do("for _, v := range $.$ {\n", expr)
+		w.marshalAttr(ut.Elem, "v", name)
+		w.do("}\n", nil)
+	}
+}
+
+// marshalText writes the code passing the text of expr, of type t, to the
+// statements use, in which $.text$ stands for the text. Nil pointers have no
+// text.
+func (w *xmlWriter) marshalText(t *types.Type, expr, use string) {
+	ut := underlyingType(t)
+	switch {
+	case hasXMLTextMarshaler(t):
+		w.do("{\n", nil)
+		w.do("text, err := $.$.MarshalText()\n", receiver(expr))
+		w.do("if err != nil {\n", nil)
+		w.do("return err\n", nil)
+		w.do("}\n", nil)
+		w.do(use, generator.Args{"text": "string(text)"})
+		w.do("}\n", nil)
+	case isForeign(t):
+		w.do("{\n", nil)
+		w.do("text, err := $.marshal$.XMLText($.expr$)\n", generator.Args{"expr": argument(expr)})
+		w.do("if err != nil {\n", nil)
+		w.do("return err\n", nil)
+		w.do("}\n", nil)
+		w.do(use, generator.Args{"text": "text"})
+		w.do("}\n", nil)
+	case ut.Kind == types.Pointer:
+		w.do("if $.$ != nil {\n", expr)
+		w.marshalText(ut.Elem, "(*"+expr+")", use)
+		w.do("}\n", nil)
+	default:
+		w.do(use, generator.Args{"text": w.textOf(t, argument(expr))})
+	}
+}
+
+// textOf returns the expression of the text of expr, whose type t is a
+// string, a boolean, a number or a byte slice, as encoding/xml writes it.
+func (w *xmlWriter) textOf(t *types.Type, expr string) string {
+	ut := underlyingType(t)
+	if ut.Kind != types.Builtin {
+		return "string(" + expr + ")"
+	}
+	method, conversion := builtinWriter(ut)
+	if t.Name.Name != conversion {
+		expr = conversion + "(" + expr + ")"
+	}
+	strconvName := w.args["strconv"].(string)
+	switch method {
+	case "Bool":
+		return strconvName + ".FormatBool(" + expr + ")"
+	case "Int":
+		return strconvName + ".FormatInt(" + expr + ", 10)"
+	case "Uint":
+		return strconvName + ".FormatUint(" + expr + ", 10)"
+	case "Float":
+		return strconvName + ".FormatFloat(" + expr + ", 'g', -1, " + bitSize(ut) + ")"
+	}
+	return expr
+}
+
+// bitSize returns the bit size of the builtin number t, as given to strconv:
+// 0 stands for the size of int.
+func bitSize(t *types.Type) string {
+	for _, size := range []string{"8", "16", "32", "64"} {
+		if strings.HasSuffix(t.Name.Name, size) {
+			return size
+		}
+	}
+	if t.Name.Name == "byte" {
+		return "8"
+	}
+	return "0"
+}
+
+// stringOf returns expr, whose type t is a string or a byte slice, as a
+// string.
+func stringOf(t *types.Type, expr string) string {
+	if t.Kind == types.Builtin && t.Name.Name == "string" {
+		return expr
+	}
+	return "string(" + expr + ")"
+}
+
+// marshalElement writes the code writing the element of field f with the
+// value expr, of type t. Like in encoding/xml, nil pointers are left out, the
+// omitempty option applies to every item of a slice and the types without
+// generated or hand-written methods are written by e.EncodeElement.
+func (w *xmlWriter) marshalElement(t *types.Type, expr string, f xmlField) {
+	if f.omitEmpty {
+		if condition := nonEmpty(t, argument(expr)); condition != "" {
+			w.do("if $.$ {\n", condition)
+			defer w.do("}\n", nil)
+		}
+	}
+
+	args := generator.Args{"expr": expr, "name": w.nameLiteral(f)}
+	ut := underlyingType(t)
+	switch {
+	case hasXMLMarshaler(t):
+		args["expr"] = receiver(expr)
+		w.do("if err := $.expr$.MarshalXML(e, $.xml$.StartElement{Name: $.name$}); err != nil {\n", args)
+		w.do("return err\n", nil)
+		w.do("}\n", nil)
+	case hasXMLTextMarshaler(t), isXMLText(t) && !isForeign(t):
+		w.marshalText(t, expr, "if err := $.marshal$.EncodeXMLText(e, "+args["name"].(string)+", $.text$); err != nil {\nreturn err\n}\n")
+	case ut.Kind == types.Pointer:
+		// With the omitempty option the pointer is known not to be nil.
+		if !f.omitEmpty {
+			w.do("if $.$ != nil {\n", expr)
+		}
+		w.marshalElement(ut.Elem, "(*"+expr+")", xmlField{space: f.space, name: f.name})
+		if !f.omitEmpty {
+			w.do("}\n", nil)
+		}
+	case ut.Kind == types.Slice, ut.Kind == types.Array && !isByte(underlyingType(ut.Elem)):
+		w.do("for _, v := range $.$ {\n", expr)
+		w.marshalElement(ut.Elem, "v", f)
+		w.do("}\n", nil)
+	default:
+		// The template start given to EncodeElement is used instead of the
+		// XMLName of the struct, so it names the element the way the
+		// XMLName does without a template.
+		if name := lookupXMLName(t); name != nil {
+			args["name"] = w.nameLiteral(*name)
+		}
+		args["expr"] = argument(expr)
+		if m := xmlNameMember(t); m != nil && lookupXMLName(t) == nil && isXMLName(m.Type, "Name") {
+			args["selector"] = expr + ".XMLName"
+			w.do("{\n", nil)
+			w.do("start := $.xml$.StartElement{Name: $.name$}\n", args)
+			w.do("if $.selector$.Local != \"\" {\n", args)
+			w.do("start.Name = $.selector$\n", args)
+			w.do("}\n", nil)
+			w.do("if err := e.EncodeElement($.expr$, start); err != nil {\n", args)
+			w.do("return err\n", nil)
+			w.do("}\n", nil)
+			w.do("}\n", nil)
+			return
+		}
+		w.do("if err := e.EncodeElement($.expr$, $.xml$.StartElement{Name: $.name$}); err != nil {\n", args)
+		w.do("return err\n", nil)
+		w.do("}\n", nil)
+	}
+}
+
+// xmlNameMember returns the XMLName member of struct t, or nil.
+func xmlNameMember(t *types.Type) *types.Member {
+	if t.Kind != types.Struct {
+		return nil
+	}
+	for i := range t.Members {
+		if t.Members[i].Name == "XMLName" {
+			return &t.Members[i]
+		}
+	}
+	return nil
+}
+
+// unmarshal writes the UnmarshalXML method.
+func (w *xmlWriter) unmarshal(s *xmlStruct) {
+	w.do("func (obj *$.type|raw$) UnmarshalXML(d *$.xml$.Decoder, start $.xml$.StartElement) error {\n", w.args)
+	if f := s.nameField; f != nil {
+		if f.name != "" {
+			w.do("if err := $.marshal$.CheckXMLName(start, $.space$, $.name$); err != nil {\n", generator.Args{
+				"space": strconv.Quote(f.space),
+				"name":  strconv.Quote(f.name),
+			})
+			w.do("return err\n", nil)
+			w.do("}\n", nil)
+		}
+		if isXMLName(f.member.Type, "Name") {
+			w.allocEmbedded(*f, "return")
+			w.do("$.$ = start.Name\n", f.selector("obj"))
+		}
+	}
+
+	var attrs []xmlField
+	var anyAttr, charData, comment *xmlField
+	for i, f := range s.fields {
+		switch {
+		case f.mode == xmlAny|xmlAttr:
+			if anyAttr == nil {
+				anyAttr = &s.fields[i]
+			}
+		case f.mode&xmlAttr != 0:
+			attrs = append(attrs, f)
+		case f.mode&xmlCharData != 0:
+			if charData == nil {
+				charData = &s.fields[i]
+			}
+		case f.mode&xmlComment != 0:
+			if comment == nil {
+				comment = &s.fields[i]
+			}
+		}
+	}
+	if len(attrs) > 0 || anyAttr != nil {
+		w.do("for _, attr := range start.Attr {\n", nil)
+		w.do("switch {\n", nil)
+		for _, f := range attrs {
+			w.do("case $.$:\n", w.nameCondition("attr", f))
+			w.allocEmbedded(f, "return")
+			w.unmarshalText(f.member.Type, f.selector("obj"), "attr.Value", "return")
+		}
+		if anyAttr != nil {
+			w.do("default:\n", nil)
+			w.allocEmbedded(*anyAttr, "return")
+			w.do("$.$ = append($.$, attr)\n", anyAttr.selector("obj"))
+		}
+		w.do("}\n", nil)
+		w.do("}\n", nil)
+	}
+
+	// The character data and the comments are collected into variables of
+	// these names.
+	var names []string
+	texts := []string{"nil", "nil"}
+	if charData != nil {
+		names = append(names, "charData")
+		texts[0] = "&charData"
+	}
+	if comment != nil {
+		names = append(names, "comment")
+		texts[1] = "&comment"
+	}
+	if len(names) > 0 {
+		w.do("var $.$ []byte\n", strings.Join(names, ", "))
+	}
+	if charData == nil && comment == nil {
+		w.do("return ", nil)
+	} else {
+		w.do("err := ", nil)
+	}
+	w.do("$.marshal$.DecodeXMLElement(d, ", nil)
+	if hasXMLChildren(s, nil) {
+		w.unmarshalChildren(s, nil)
+	} else {
+		w.do("nil", nil)
+	}
+	w.do(", $.$)\n", strings.Join(texts, ", "))
+	if charData == nil && comment == nil {
+		w.do("}\n\n", nil)
+		return
+	}
+	w.do("if err != nil {\n", nil)
+	w.do("return err\n", nil)
+	w.do("}\n", nil)
+	if charData != nil {
+		w.allocEmbedded(*charData, "return")
+		w.unmarshalText(charData.member.Type, charData.selector("obj"), "string(charData)", "return")
+	}
+	if comment != nil {
+		w.allocEmbedded(*comment, "return")
+		if t := comment.member.Type; isXMLBytes(t) && t.Name.Name == "" {
+			w.do("$.$ = comment\n", comment.selector("obj"))
+		} else {
+			w.do("$.selector$ = $.type|raw$(comment)\n", generator.Args{"selector": comment.selector("obj"), "type": t})
+		}
+	}
+	w.do("return nil\n", nil)
+	w.do("}\n\n", nil)
+}
+
+// hasXMLChildren returns true if s has element fields below parents.
+func hasXMLChildren(s *xmlStruct, parents []string) bool {
+	for _, f := range s.fields {
+		if f.mode&xmlElement != 0 && hasParents(f, parents) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasParents returns true if the parents of f start with parents.
+func hasParents(f xmlField, parents []string) bool {
+	if len(f.parents) < len(parents) {
+		return false
+	}
+	for i := range parents {
+		if f.parents[i] != parents[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// unmarshalChildren writes the function decoding the child elements of the
+// element holding the fields nested in parents, for marshal.DecodeXMLElement.
+// Like in encoding/xml, the first field matching a child element in the
+// order of the fields gets it, and the children of the struct element which
+// no field matches go to the field tagged `xml:",any"`.
+func (w *xmlWriter) unmarshalChildren(s *xmlStruct, parents []string) {
+	w.do("func(start $.xml$.StartElement) (bool, error) {\n", nil)
+	w.do("switch {\n", nil)
+	groups := map[string]bool{}
+	for _, f := range s.fields {
+		if f.mode&xmlElement == 0 || !hasParents(f, parents) {
+			continue
+		}
+		if len(f.parents) == len(parents) {
+			w.do("case $.$:\n", w.nameCondition("start", f))
+			w.allocEmbedded(f, "return true,")
+			w.unmarshalElement(f.member.Type, f.selector("obj"))
+			w.do("return true, nil\n", nil)
+			continue
+		}
+		group := f.parents[len(parents)]
+		if groups[group] {
+			continue
+		}
+		groups[group] = true
+		w.do("case $.$:\n", w.nameCondition("start", xmlField{space: f.space, name: group}))
+		w.do("return true, $.marshal$.DecodeXMLElement(d, ", nil)
+		w.unmarshalChildren(s, f.parents[:len(parents)+1])
+		w.do(", nil, nil)\n", nil)
+	}
+	if len(parents) == 0 {
+		for _, f := range s.fields {
+			if f.mode == xmlAny|xmlElement {
+				w.do("default:\n", nil)
+				w.allocEmbedded(f, "return true,")
+				w.unmarshalElement(f.member.Type, f.selector("obj"))
+				w.do("return true, nil\n", nil)
+				w.do("}\n", nil)
+				w.do("}", nil)
+				return
+			}
+		}
+	}
+	w.do("}\n", nil)
+	w.do("return false, nil\n", nil)
+	w.do("}", nil)
+}
+
+// nameCondition returns the condition under which the name of v, an
+// element or an attribute, is that of field f.
+func (w *xmlWriter) nameCondition(v string, f xmlField) string {
+	condition := v + ".Name.Local == " + strconv.Quote(f.name)
+	if f.space != "" {
+		condition += " && " + v + ".Name.Space == " + strconv.Quote(f.space)
+	}
+	return condition
+}
+
+// allocEmbedded writes the code allocating the nil embedded pointers leading
+// to f, before it is decoded. ret returns from the function.
+func (w *xmlWriter) allocEmbedded(f xmlField, ret string) {
+	members, selectors := pathEmbeddedPointers(f.path, "obj")
+	for i, m := range members {
+		args := generator.Args{"selector": selectors[i], "member": m, "ret": ret}
+		w.do("if $.selector$ == nil {\n", args)
+		if namer.IsPrivateGoName(m.Name) {
+			w.do("$.ret$ $.err$\n", generator.Args{"ret": ret, "err": xmlEmbeddedPointerError(m)})
+		} else {
+			w.do("$.selector$ = new($.member.Type.Elem|raw$)\n", args)
+		}
+		w.do("}\n", nil)
+	}
+}
+
+// unmarshalElement writes the code decoding the element which starts with
+// start into expr, of type t, inside the function written by
+// unmarshalChildren.
+func (w *xmlWriter) unmarshalElement(t *types.Type, expr string) {
+	ut := underlyingType(t)
+	switch {
+	case hasXMLMarshaler(t):
+		w.do("if err := $.$.UnmarshalXML(d, start); err != nil {\n", receiver(expr))
+		w.do("return true, err\n", nil)
+		w.do("}\n", nil)
+	case ut.Kind == types.Pointer:
+		w.do("if $.$ == nil {\n", expr)
+		w.do("$.expr$ = new($.elem|raw$)\n", generator.Args{"expr": expr, "elem": ut.Elem})
+		w.do("}\n", nil)
+		w.unmarshalElement(ut.Elem, "(*"+expr+")")
+	case hasXMLTextMarshaler(t), isXMLText(t) && !isForeign(t):
+		w.do("text, err := $.marshal$.DecodeXMLText(d)\n", nil)
+		w.do("if err != nil {\n", nil)
+		w.do("return true, err\n", nil)
+		w.do("}\n", nil)
+		w.unmarshalText(t, expr, "text", "return true,")
+	case ut.Kind == types.Slice:
+		w.do("{\n", nil)
+		w.do("var item $.|raw$\n", ut.Elem)
+		w.unmarshalElement(ut.Elem, "item")
+		w.do("$.$ = append($.$, item)\n", argument(expr))
+		w.do("}\n", nil)
+	default:
+		w.do("if err := d.DecodeElement($.$, &start); err != nil {\n", address(expr))
+		w.do("return true, err\n", nil)
+		w.do("}\n", nil)
+	}
+}
+
+// unmarshalText writes the code decoding the text into expr, of type t, for
+// an attribute, character data or an element holding text. ret returns from
+// the function.
+func (w *xmlWriter) unmarshalText(t *types.Type, expr, text, ret string) {
+	args := generator.Args{"expr": expr, "text": text, "ret": ret, "type": t}
+	ut := underlyingType(t)
+	switch {
+	case text == "attr.Value" && hasXMLAttrMarshaler(t):
+		args["expr"] = receiver(expr)
+		w.do("if err := $.expr$.UnmarshalXMLAttr(attr); err != nil {\n", args)
+		w.do("$.ret$ err\n", args)
+		w.do("}\n", nil)
+	case hasXMLTextMarshaler(t):
+		args["expr"] = receiver(expr)
+		w.do("if err := $.expr$.UnmarshalText([]byte($.text$)); err != nil {\n", args)
+		w.do("$.ret$ err\n", args)
+		w.do("}\n", nil)
+	case isForeign(t):
+		args["expr"] = address(expr)
+		w.do("if err := $.marshal$.UnmarshalXMLText($.text$, $.expr$); err != nil {\n", args)
+		w.do("$.ret$ err\n", args)
+		w.do("}\n", nil)
+	case ut.Kind == types.Pointer:
+		w.do("if $.$ == nil {\n", expr)
+		w.do("$.expr$ = new($.elem|raw$)\n", generator.Args{"expr": expr, "elem": ut.Elem})
+		w.do("}\n", nil)
+		w.unmarshalText(ut.Elem, "(*"+expr+")", text, ret)
+	case ut.Kind == types.Slice && !isXMLBytes(ut):
+		w.do("{\n", nil)
+		w.do("var item $.|raw$\n", ut.Elem)
+		w.unmarshalText(ut.Elem, "item", text, ret)
+		w.do("$.$ = append($.$, item)\n", argument(expr))
+		w.do("}\n", nil)
+	case ut.Kind != types.Builtin:
+		w.do("$.expr$ = $.type|raw$($.text$)\n", generator.Args{"expr": argument(expr), "type": t, "text": text})
+	default:
+		method, conversion := builtinWriter(ut)
+		parse := map[string]string{"Bool": "ParseXMLBool", "Int": "ParseXMLInt", "Uint": "ParseXMLUint", "Float": "ParseXMLFloat"}[method]
+		if parse == "" {
+			if t.Kind == types.Builtin {
+				w.do("$.$ = "+text+"\n", argument(expr))
+			} else {
+				w.do("$.expr$ = $.type|raw$($.text$)\n", generator.Args{"expr": argument(expr), "type": t, "text": text})
+			}
+			return
+		}
+		if method != "Bool" {
+			text += ", " + bitSize(ut)
+		}
+		w.do("value, err := $.marshal$.$.parse$($.text$)\n", generator.Args{"parse": parse, "text": text})
+		w.do("if err != nil {\n", nil)
+		w.do("$.$ err\n", ret)
+		w.do("}\n", nil)
+		if t.Name.Name == conversion {
+			w.do("$.$ = value\n", argument(expr))
+		} else {
+			w.do("$.expr$ = $.type|raw$(value)\n", generator.Args{"expr": argument(expr), "type": t})
+		}
+	}
+}
+
+// quoteAll returns the strings quoted and separated by commas.
+func quoteAll(strs []string) string {
+	quoted := make([]string, len(strs))
+	for i, s := range strs {
+		quoted[i] = strconv.Quote(s)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// xmlEmbeddedPointerError returns the error returned when decoding into a
+// field promoted through a nil pointer to an unexported struct.
+func xmlEmbeddedPointerError(m types.Member) string {
+	elem := m.Type.Elem
+	name := filepath.Base(elem.Name.Package) + "." + elem.Name.Name
+	return "errors.New(" + strconv.Quote("xml: cannot set embedded pointer to unexported struct: "+name) + ")"
+}
+
+var xmlTemplateCode = `
+// MarshalXML writes the XML element of obj, following the xml tags of its
+// fields like encoding/xml does but without reflection.
+`
+
+var unmarshalXMLTemplateCode = `// UnmarshalXML decodes the XML element starting with start into obj,
+// following the xml tags of its fields like encoding/xml does. Unknown
+// attributes and elements are ignored.
+`
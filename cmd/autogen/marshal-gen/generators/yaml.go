@@ -0,0 +1,108 @@
+package generators
+
+import (
+	"strconv"
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// yamlTagName is the comment tag generating the YAML methods of a struct,
+// +gengo:marshal:yaml=true.
+const yamlTagName = typeTagName + ":yaml"
+
+// yamlPackage is imported by the generated YAML methods.
+const yamlPackage = "gopkg.in/yaml.v3"
+
+// yamlName imports the YAML package into the generated file and returns the
+// local name it is imported as.
+func (g *marshalGen) yamlName() string {
+	g.imports.AddType(&types.Type{Name: types.Name{Package: yamlPackage, Name: "Node"}})
+	return g.imports.LocalNameOf(yamlPackage)
+}
+
+// generateYAML writes the MarshalYAML and UnmarshalYAML methods of struct t.
+// The fields are those of the JSON encoding, written in declaration order
+// with the doc comments of their members.
+func (g *marshalGen) generateYAML(t *types.Type, sw *generator.SnippetWriter) {
+	args := generator.Args{"type": t, "marshal": g.runtimeName(), "yaml": g.yamlName()}
+	fields := jsonFields(t)
+
+	sw.Do(yamlTemplateCode, args)
+	sw.Do("func (obj $.type|raw$) MarshalYAML() (interface{}, error) {\n", args)
+	sw.Do("node := $.marshal$.NewYAMLMapping()\n", args)
+	for _, f := range fields {
+		conditions := g.fieldConditions(f, "obj")
+		if len(conditions) > 0 {
+			sw.Do("if $.$ {\n", strings.Join(conditions, " && "))
+		}
+		add := "AddYAMLField"
+		if f.quoted {
+			add = "AddYAMLQuoted"
+		}
+		sw.Do("if err := $.marshal$.$.add$(node, $.name$, $.comment$, $.selector$); err != nil {\n", generator.Args{
+			"marshal":  args["marshal"],
+			"add":      add,
+			"name":     strconv.Quote(f.name),
+			"comment":  strconv.Quote(commentDescription(f.member.CommentLines)),
+			"selector": f.selector("obj"),
+		})
+		sw.Do("return nil, err\n", nil)
+		sw.Do("}\n", nil)
+		if len(conditions) > 0 {
+			sw.Do("}\n", nil)
+		}
+	}
+	sw.Do("return node, nil\n", nil)
+	sw.Do("}\n\n", nil)
+
+	sw.Do(unmarshalYAMLTemplateCode, args)
+	sw.Do("func (obj *$.type|raw$) UnmarshalYAML(value *$.yaml$.Node) error {\n", args)
+	sw.Do("return $.marshal$.DecodeYAMLMapping(value, func(key string, value *$.yaml$.Node) error {\n", args)
+	if len(fields) == 0 {
+		sw.Do("return nil\n", nil)
+		sw.Do("})\n", nil)
+		sw.Do("}\n\n", nil)
+		return
+	}
+	g.matchKey(fields, sw)
+	sw.Do("switch key {\n", nil)
+	for _, f := range fields {
+		sw.Do("case $.$:\n", strconv.Quote(f.name))
+		members, selectors := f.embeddedPointers("obj")
+		for i, m := range members {
+			args := generator.Args{"selector": selectors[i], "member": m}
+			sw.Do("if $.selector$ == nil {\n", args)
+			if namer.IsPrivateGoName(m.Name) {
+				sw.Do("return $.$\n", embeddedPointerError(m))
+			} else {
+				sw.Do("$.selector$ = new($.member.Type.Elem|raw$)\n", args)
+			}
+			sw.Do("}\n", nil)
+		}
+		decode := "DecodeYAML"
+		if f.quoted {
+			decode = "DecodeYAMLQuoted"
+		}
+		sw.Do("return $.marshal$.$.decode$(value, $.address$)\n", generator.Args{
+			"marshal": args["marshal"],
+			"decode":  decode,
+			"address": address(f.selector("obj")),
+		})
+	}
+	sw.Do("}\n", nil)
+	sw.Do("return nil\n", nil)
+	sw.Do("})\n", nil)
+	sw.Do("}\n\n", nil)
+}
+
+var yamlTemplateCode = `
+// MarshalYAML returns the YAML mapping of obj. Its keys follow the json tags
+// of the fields and keep their declaration order, with their doc comments.
+`
+
+var unmarshalYAMLTemplateCode = `// UnmarshalYAML decodes the YAML mapping value into obj, matching keys to
+// fields like UnmarshalJSONBinary does. Unknown keys are ignored.
+`
@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/spf13/pflag"
 	"github.com/zhaolion/gengo/cmd/autogen/marshal-gen/generators"
 	"k8s.io/gengo/args"
 	"k8s.io/klog"
@@ -13,6 +14,27 @@ func main() {
 	klog.InitFlags(nil)
 	arguments := args.Default()
 
+	// Override defaults.
+	arguments.OutputFileBaseName = "zz_generated.marshal"
+
+	// Custom args.
+	customArgs := &generators.CustomArgs{}
+	pflag.CommandLine.StringSliceVar(&customArgs.ExtraPeerDirs, "extra-peer-dirs", customArgs.ExtraPeerDirs,
+		"Comma-separated list of import paths of packages also generated by marshal-gen. The generated code calls the generated methods of their types; the types of other packages are encoded through reflection.")
+	pflag.CommandLine.BoolVar(&customArgs.FilePerType, "file-per-type", customArgs.FilePerType,
+		"Write the methods of every type into its own <type>_marshal.go file instead of a single file per package.")
+	pflag.CommandLine.BoolVar(&customArgs.GenerateTests, "generate-tests", customArgs.GenerateTests,
+		"Also write a <output-file-base>_test.go file per package testing that the methods of every type round-trip, with a fuzz target per type.")
+	pflag.CommandLine.BoolVar(&customArgs.JSONSchema, "json-schema", customArgs.JSONSchema,
+		"Also write a <output-file-base>.schema.json file per package, a JSON Schema (draft 2020-12) defining every type under $defs.")
+	pflag.CommandLine.BoolVar(&customArgs.OpenAPI, "openapi", customArgs.OpenAPI,
+		"Also write a <output-file-base>.openapi.yaml file per package, holding the OpenAPI 3 components/schemas of the types tagged +gengo:marshal:openapi=true.")
+	pflag.CommandLine.BoolVar(&customArgs.Proto, "proto", customArgs.Proto,
+		"Also write a <output-file-base>.proto file per package, holding the protobuf messages of the structs tagged +gengo:marshal:proto=true, and generate their MarshalProto/UnmarshalProto methods.")
+	pflag.CommandLine.BoolVar(&customArgs.TypeScript, "typescript", customArgs.TypeScript,
+		"Also write a <output-file-base>.d.ts file per package, declaring the TypeScript types of the JSON encoding of every type.")
+	arguments.CustomArgs = customArgs
+
 	if err := arguments.Execute(
 		generators.NameSystems(),
 		generators.DefaultNameSystem(),
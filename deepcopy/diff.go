@@ -0,0 +1,58 @@
+package deepcopy
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// FieldChange is a difference between two versions of an object, as returned
+// by the Diff methods generated for the types tagged `+gengo:diff=true`.
+type FieldChange struct {
+	// Path locates the value changed from the object, e.g.
+	// Spec.Containers[2].Image or Labels["app"]. It is empty if the object
+	// itself changed, e.g. from nil.
+	Path string
+	// Old and New are the values in the two versions. Old is nil for the
+	// added map entries and slice elements, and New for the removed ones.
+	Old, New interface{}
+}
+
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s: %v -> %v", c.Path, c.Old, c.New)
+}
+
+// FieldPath returns the path of the field name of the value at path.
+func FieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// IndexPath returns the path of the element i of the slice or the array at
+// path.
+func IndexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+// KeyPath returns the path of the entry key of the map at path. String keys
+// are quoted.
+func KeyPath(path string, key interface{}) string {
+	if s, ok := key.(fmt.Stringer); ok {
+		key = s.String()
+	}
+	if s, ok := key.(string); ok {
+		return path + "[" + strconv.Quote(s) + "]"
+	}
+	return fmt.Sprintf("%s[%v]", path, key)
+}
+
+// KeyLess reports whether the key a of a map comes before the key b in the
+// changes, for the keys which cannot be compared with <, e.g. structs: they
+// are sorted by their path, then by their Go syntax.
+func KeyLess(a, b interface{}) bool {
+	if pathA, pathB := KeyPath("", a), KeyPath("", b); pathA != pathB {
+		return pathA < pathB
+	}
+	return fmt.Sprintf("%#v", a) < fmt.Sprintf("%#v", b)
+}
@@ -0,0 +1,53 @@
+package deepcopy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPaths(t *testing.T) {
+	testCases := []struct {
+		got, expect string
+	}{
+		{FieldPath("", "Spec"), "Spec"},
+		{FieldPath(IndexPath(FieldPath("Spec", "Containers"), 2), "Image"), "Spec.Containers[2].Image"},
+		{KeyPath("Labels", "app"), `Labels["app"]`},
+		{KeyPath("Ports", 80), "Ports[80]"},
+		{KeyPath("", time.Second), `["1s"]`},
+	}
+	for i, tc := range testCases {
+		if tc.got != tc.expect {
+			t.Errorf("case[%d]: expected %q, got %q", i, tc.expect, tc.got)
+		}
+	}
+}
+
+func TestKeyLess(t *testing.T) {
+	type key struct {
+		Group, Name string
+	}
+	testCases := []struct {
+		a, b   interface{}
+		expect bool
+	}{
+		{key{"a", "z"}, key{"b", "a"}, true},
+		{key{"b", "a"}, key{"a", "z"}, false},
+		{false, true, true},
+		// The keys with the same path are sorted by their Go syntax.
+		{time.Duration(1), "1ns", false},
+		{"1ns", time.Duration(1), true},
+		{key{}, key{}, false},
+	}
+	for i, tc := range testCases {
+		if got := KeyLess(tc.a, tc.b); got != tc.expect {
+			t.Errorf("case[%d]: expected %t, got %t", i, tc.expect, got)
+		}
+	}
+}
+
+func TestFieldChangeString(t *testing.T) {
+	c := FieldChange{Path: "Spec.Replicas", Old: 1, New: 3}
+	if got, expect := c.String(), "Spec.Replicas: 1 -> 3"; got != expect {
+		t.Errorf("expected %q, got %q", expect, got)
+	}
+}
@@ -0,0 +1,11 @@
+// Package deepcopy holds the runtime support used by the code deepcopy-gen
+// generates.
+//
+// Packages generated with `+gengo:deepcopy=package,register` record the
+// deep-copy function of each of their types in DefaultRegistry, so values
+// only known as interface{} can be copied with DeepCopyAny.
+//
+// The Diff methods generated for the types tagged `+gengo:diff=true` return
+// FieldChange values, whose paths are built with FieldPath, IndexPath and
+// KeyPath.
+package deepcopy
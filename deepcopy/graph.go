@@ -0,0 +1,53 @@
+package deepcopy
+
+// Graph records the pointers already copied while deep-copying a pointer
+// graph, and their copies. The code generated for the types tagged
+// `+gengo:deepcopy:graph=true` copies every pointer it reaches once, so the
+// copy of a graph has the same shared pointers and cycles as the original.
+type Graph struct {
+	copies map[interface{}]interface{}
+}
+
+// NewGraph returns a Graph with no pointer copied yet.
+func NewGraph() *Graph {
+	return &Graph{copies: map[interface{}]interface{}{}}
+}
+
+// Add records out as the copy of the pointer in. It is called before copying
+// the value in points to, so the cycles through in end at out.
+func (g *Graph) Add(in, out interface{}) {
+	g.copies[in] = out
+}
+
+// Lookup returns the copy of the pointer in, and whether it has been copied.
+// Pointers of different types are different even if their address is the
+// same, e.g. a pointer to a struct and a pointer to its first field.
+func (g *Graph) Lookup(in interface{}) (interface{}, bool) {
+	out, found := g.copies[in]
+	return out, found
+}
+
+// Visited records the pairs of pointers already reached while comparing or
+// diffing pointer graphs. The code generated for the types tagged
+// `+gengo:deepcopy:graph=true` compares every pair of pointers it reaches
+// once, so that it ends on cyclic graphs.
+type Visited struct {
+	pairs map[[2]interface{}]bool
+}
+
+// NewVisited returns a Visited with no pair of pointers reached yet.
+func NewVisited() *Visited {
+	return &Visited{pairs: map[[2]interface{}]bool{}}
+}
+
+// Visit records the pair of pointers a and b, and returns whether it was
+// already recorded. A pair reached again is being compared already: it is
+// equal as far as the comparison in progress is concerned.
+func (v *Visited) Visit(a, b interface{}) bool {
+	pair := [2]interface{}{a, b}
+	if v.pairs[pair] {
+		return true
+	}
+	v.pairs[pair] = true
+	return false
+}
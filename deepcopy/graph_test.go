@@ -0,0 +1,40 @@
+package deepcopy
+
+import (
+	"testing"
+)
+
+func TestGraph(t *testing.T) {
+	type pair struct {
+		A, B int
+	}
+	p := &pair{}
+	g := NewGraph()
+	if _, found := g.Lookup(p); found {
+		t.Fatalf("unexpected copy of %p", p)
+	}
+	c := &pair{}
+	g.Add(p, c)
+	if out, found := g.Lookup(p); !found || out.(*pair) != c {
+		t.Errorf("expected %p, got %v", c, out)
+	}
+	// A pointer to the first field has the same address, but another type.
+	if _, found := g.Lookup(&p.A); found {
+		t.Errorf("unexpected copy of %p", &p.A)
+	}
+}
+
+func TestVisited(t *testing.T) {
+	a, b := &struct{ N int }{}, &struct{ N int }{}
+	v := NewVisited()
+	if v.Visit(a, b) {
+		t.Fatal("unexpected visit of the first pair")
+	}
+	if !v.Visit(a, b) {
+		t.Error("expected the pair to be visited")
+	}
+	// The pairs are ordered.
+	if v.Visit(b, a) {
+		t.Error("unexpected visit of the reversed pair")
+	}
+}
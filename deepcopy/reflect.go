@@ -0,0 +1,104 @@
+package deepcopy
+
+import (
+	"reflect"
+)
+
+// Reflect returns a deep copy of in made through reflection, for the values
+// whose type is not known when generating code, e.g. the values held by
+// interface{} fields tagged `+gengo:deepcopy:interface=reflect`.
+//
+// Values of the types registered in DefaultRegistry are copied by their
+// registered function. Otherwise pointers, slices, maps, arrays, structs and
+// interfaces are copied recursively; pointers reached twice, e.g. in cyclic
+// graphs, are copied once. Channels, functions and unexported struct fields
+// cannot be copied through reflection: the copy shares them with in.
+func Reflect(in interface{}) interface{} {
+	if in == nil {
+		return nil
+	}
+	c := &reflectCopier{pointers: map[reflectPointer]reflect.Value{}}
+	return c.copy(reflect.ValueOf(in)).Interface()
+}
+
+// reflectPointer identifies a pointer already copied: pointers to a struct
+// and to its first field have the same address but different types.
+type reflectPointer struct {
+	t    reflect.Type
+	addr uintptr
+}
+
+type reflectCopier struct {
+	pointers map[reflectPointer]reflect.Value
+}
+
+// copy returns a deep copy of in, a valid value.
+func (c *reflectCopier) copy(in reflect.Value) reflect.Value {
+	t := in.Type()
+	if t.Kind() != reflect.Interface && t.Kind() != reflect.Ptr {
+		if f := DefaultRegistry.Lookup(t); f != nil {
+			return reflect.ValueOf(f(in.Interface()))
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		if in.IsNil() {
+			return in
+		}
+		key := reflectPointer{t: t, addr: in.Pointer()}
+		if out, found := c.pointers[key]; found {
+			return out
+		}
+		out := reflect.New(t.Elem())
+		c.pointers[key] = out
+		out.Elem().Set(c.copy(in.Elem()))
+		return out
+	case reflect.Interface:
+		if in.IsNil() {
+			return in
+		}
+		out := reflect.New(t).Elem()
+		out.Set(c.copy(in.Elem()))
+		return out
+	case reflect.Slice:
+		if in.IsNil() {
+			return in
+		}
+		out := reflect.MakeSlice(t, in.Len(), in.Len())
+		for i := 0; i < in.Len(); i++ {
+			out.Index(i).Set(c.copy(in.Index(i)))
+		}
+		return out
+	case reflect.Map:
+		if in.IsNil() {
+			return in
+		}
+		out := reflect.MakeMapWithSize(t, in.Len())
+		iter := in.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(c.copy(iter.Key()), c.copy(iter.Value()))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(t).Elem()
+		for i := 0; i < in.Len(); i++ {
+			out.Index(i).Set(c.copy(in.Index(i)))
+		}
+		return out
+	case reflect.Struct:
+		out := reflect.New(t).Elem()
+		out.Set(in)
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath != "" {
+				// Unexported: out shares it.
+				continue
+			}
+			out.Field(i).Set(c.copy(in.Field(i)))
+		}
+		return out
+	}
+	// Basic values hold no references. Channels and functions cannot be
+	// copied.
+	return in
+}
@@ -0,0 +1,68 @@
+package deepcopy
+
+import (
+	"reflect"
+	"testing"
+)
+
+type graphNode struct {
+	Name     string
+	Parent   *graphNode
+	Children []*graphNode
+	Attrs    map[string]interface{}
+	Grid     [2][]int
+	hidden   []int
+	Notify   func()
+}
+
+func TestReflect(t *testing.T) {
+	root := &graphNode{Name: "root", Attrs: map[string]interface{}{"n": 1, "list": []string{"a"}}}
+	child := &graphNode{Name: "child", Parent: root, Grid: [2][]int{{1}, nil}, hidden: []int{7}}
+	root.Children = []*graphNode{child, child}
+
+	out := Reflect(root).(*graphNode)
+	if out == root || out.Children[0] == child {
+		t.Fatalf("the pointers are not copied")
+	}
+	if out.Children[0] != out.Children[1] || out.Children[0].Parent != out {
+		t.Errorf("the shared pointers and the cycle are not preserved")
+	}
+	if out.Name != "root" || out.Children[0].Name != "child" || !reflect.DeepEqual(out.Attrs, root.Attrs) {
+		t.Errorf("unexpected copy %+v", out)
+	}
+
+	out.Attrs["list"].([]string)[0] = "changed"
+	out.Children[0].Grid[0][0] = 2
+	if root.Attrs["list"].([]string)[0] != "a" || child.Grid[0][0] != 1 {
+		t.Errorf("changing the copy changed the original")
+	}
+	// Unexported fields cannot be copied through reflection.
+	if &out.Children[0].hidden[0] != &child.hidden[0] {
+		t.Errorf("expected the unexported field to be shared")
+	}
+
+	for _, in := range []interface{}{nil, 3, "s", []int(nil), map[string]int(nil), (*graphNode)(nil)} {
+		if out := Reflect(in); !reflect.DeepEqual(out, in) {
+			t.Errorf("expected %#v, got %#v", in, out)
+		}
+	}
+}
+
+type registeredValue struct {
+	Items []int
+}
+
+func TestReflectRegistered(t *testing.T) {
+	calls := 0
+	DefaultRegistry.Register(reflect.TypeOf(registeredValue{}), func(obj interface{}) interface{} {
+		calls++
+		in := obj.(registeredValue)
+		return registeredValue{Items: append([]int(nil), in.Items...)}
+	})
+
+	in := []interface{}{registeredValue{Items: []int{1}}, &registeredValue{}}
+	out := Reflect(in).([]interface{})
+	if !reflect.DeepEqual(out, in) || calls != 2 {
+		t.Errorf("expected %v copied by the registered function, got %v after %d calls", in, out, calls)
+	}
+}
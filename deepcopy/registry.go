@@ -0,0 +1,88 @@
+package deepcopy
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Func returns a deep copy of in, a value of the type it is registered for.
+type Func func(in interface{}) interface{}
+
+// Registry maps types to their deep-copy functions. It is safe for
+// concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	funcs map[reflect.Type]Func
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{funcs: map[reflect.Type]Func{}}
+}
+
+// DefaultRegistry is the registry the generated init functions register
+// into, and the one DeepCopyAny looks types up in.
+var DefaultRegistry = NewRegistry()
+
+// Register records f as the deep-copy function of the values of type t. It
+// panics if t already has one.
+func (r *Registry) Register(t reflect.Type, f Func) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, found := r.funcs[t]; found {
+		panic(fmt.Sprintf("deepcopy: %v is registered twice", t))
+	}
+	r.funcs[t] = f
+}
+
+// Lookup returns the deep-copy function of the values of type t, or nil.
+func (r *Registry) Lookup(t reflect.Type) Func {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.funcs[t]
+}
+
+// DeepCopy returns a deep copy of in. Pointers to registered types are copied
+// too: the copy points to a copy of the value. Booleans, numbers and strings
+// hold no references and are returned as they are. The second result is false
+// if the type of in is none of these, in which case nil is returned.
+func (r *Registry) DeepCopy(in interface{}) (interface{}, bool) {
+	if in == nil {
+		return nil, true
+	}
+	t := reflect.TypeOf(in)
+	if f := r.Lookup(t); f != nil {
+		return f(in), true
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128, reflect.String:
+		return in, true
+	case reflect.Ptr:
+	default:
+		return nil, false
+	}
+	f := r.Lookup(t.Elem())
+	if f == nil {
+		return nil, false
+	}
+	v := reflect.ValueOf(in)
+	if v.IsNil() {
+		return in, true
+	}
+	out := reflect.New(t.Elem())
+	out.Elem().Set(reflect.ValueOf(f(v.Elem().Interface())))
+	return out.Interface(), true
+}
+
+// DeepCopyAny returns a deep copy of in using the functions registered in
+// DefaultRegistry. It panics if the type of in is not registered.
+func DeepCopyAny(in interface{}) interface{} {
+	out, ok := DefaultRegistry.DeepCopy(in)
+	if !ok {
+		panic(fmt.Sprintf("deepcopy: no deep-copy function registered for %T", in))
+	}
+	return out
+}
@@ -0,0 +1,88 @@
+package deepcopy
+
+import (
+	"reflect"
+	"testing"
+)
+
+type node struct {
+	Name     string
+	Children []string
+}
+
+type tags []string
+
+func newTestRegistry() *Registry {
+	r := NewRegistry()
+	r.Register(reflect.TypeOf(node{}), func(obj interface{}) interface{} {
+		in := obj.(node)
+		in.Children = append([]string(nil), in.Children...)
+		return in
+	})
+	r.Register(reflect.TypeOf(tags{}), func(obj interface{}) interface{} {
+		return append(tags(nil), obj.(tags)...)
+	})
+	return r
+}
+
+func TestRegistryDeepCopy(t *testing.T) {
+	r := newTestRegistry()
+
+	n := node{Name: "a", Children: []string{"b"}}
+	out, ok := r.DeepCopy(n)
+	if !ok || !reflect.DeepEqual(out, n) {
+		t.Fatalf("unexpected copy %v, %v", out, ok)
+	}
+	out.(node).Children[0] = "changed"
+	if n.Children[0] != "b" {
+		t.Errorf("the copy shares the children of the original")
+	}
+
+	// Pointers to registered types are copied through the function of their
+	// element type.
+	out, ok = r.DeepCopy(&n)
+	if !ok || !reflect.DeepEqual(out, &n) || out.(*node) == &n {
+		t.Fatalf("unexpected copy %v, %v", out, ok)
+	}
+	out, ok = r.DeepCopy((*node)(nil))
+	if !ok || out.(*node) != nil {
+		t.Errorf("expected a nil *node, got %v, %v", out, ok)
+	}
+
+	tg := tags{"x"}
+	out, ok = r.DeepCopy(tg)
+	if !ok || !reflect.DeepEqual(out, tg) {
+		t.Fatalf("unexpected copy %v, %v", out, ok)
+	}
+
+	for _, in := range []interface{}{nil, 1, "s", 2.5, true} {
+		out, ok := r.DeepCopy(in)
+		if !ok || out != in {
+			t.Errorf("%v: unexpected copy %v, %v", in, out, ok)
+		}
+	}
+	for _, in := range []interface{}{[]int{1}, map[string]int{}, &[]string{}} {
+		if out, ok := r.DeepCopy(in); ok || out != nil {
+			t.Errorf("%v: expected no copy, got %v, %v", in, out, ok)
+		}
+	}
+}
+
+func TestRegisterTwice(t *testing.T) {
+	r := newTestRegistry()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic")
+		}
+	}()
+	r.Register(reflect.TypeOf(tags{}), func(obj interface{}) interface{} { return obj })
+}
+
+func TestDeepCopyAny(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic for an unregistered type")
+		}
+	}()
+	DeepCopyAny(node{})
+}
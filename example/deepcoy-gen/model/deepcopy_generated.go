@@ -0,0 +1,2108 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+package model
+
+import (
+	reflect "reflect"
+	sort "sort"
+
+	deepcopy "github.com/zhaolion/gengo/deepcopy"
+)
+
+func init() {
+	RegisterDeepCopies(deepcopy.DefaultRegistry)
+}
+
+// RegisterDeepCopies records the deep-copy functions of the types of this
+// package in r, keyed by their reflect.Type.
+func RegisterDeepCopies(r *deepcopy.Registry) {
+	r.Register(reflect.TypeOf((*Edge)(nil)).Elem(), func(obj interface{}) interface{} {
+		in := obj.(Edge)
+		return *in.DeepCopy()
+	})
+	r.Register(reflect.TypeOf((*Grid)(nil)).Elem(), func(obj interface{}) interface{} {
+		in := obj.(Grid)
+		return *in.DeepCopy()
+	})
+	r.Register(reflect.TypeOf((*ID)(nil)).Elem(), func(obj interface{}) interface{} {
+		in := obj.(ID)
+		return *in.DeepCopy()
+	})
+	r.Register(reflect.TypeOf((*Labels)(nil)).Elem(), func(obj interface{}) interface{} {
+		in := obj.(Labels)
+		return in.DeepCopy()
+	})
+	r.Register(reflect.TypeOf((*Name)(nil)).Elem(), func(obj interface{}) interface{} {
+		in := obj.(Name)
+		return *in.DeepCopy()
+	})
+	r.Register(reflect.TypeOf((*Node)(nil)).Elem(), func(obj interface{}) interface{} {
+		in := obj.(Node)
+		return *in.DeepCopy()
+	})
+	r.Register(reflect.TypeOf((*Revision)(nil)).Elem(), func(obj interface{}) interface{} {
+		in := obj.(Revision)
+		return *in.DeepCopy()
+	})
+	r.Register(reflect.TypeOf((*Stats)(nil)).Elem(), func(obj interface{}) interface{} {
+		in := obj.(Stats)
+		return *in.DeepCopy()
+	})
+	r.Register(reflect.TypeOf((*T1)(nil)).Elem(), func(obj interface{}) interface{} {
+		in := obj.(T1)
+		return *in.DeepCopy()
+	})
+	r.Register(reflect.TypeOf((*T2)(nil)).Elem(), func(obj interface{}) interface{} {
+		in := obj.(T2)
+		return *in.DeepCopy()
+	})
+	r.Register(reflect.TypeOf((*T3)(nil)).Elem(), func(obj interface{}) interface{} {
+		in := obj.(T3)
+		return *in.DeepCopy()
+	})
+	r.Register(reflect.TypeOf((*Version)(nil)).Elem(), func(obj interface{}) interface{} {
+		in := obj.(Version)
+		return *in.DeepCopy()
+	})
+	r.Register(reflect.TypeOf((*Watcher)(nil)).Elem(), func(obj interface{}) interface{} {
+		in := obj.(Watcher)
+		return *in.DeepCopy()
+	})
+	r.Register(reflect.TypeOf((*counter)(nil)).Elem(), func(obj interface{}) interface{} {
+		in := obj.(counter)
+		return *in.DeepCopy()
+	})
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+// The pointers reached more than once are copied once, so the copy has the same shared pointers and cycles.
+func (in *Edge) DeepCopyInto(out *Edge) {
+	graph := deepcopy.NewGraph()
+	graph.Add(in, out)
+	in.DeepCopyIntoGraph(out, graph)
+}
+
+// DeepCopyIntoGraph is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+// The pointers copied are recorded in graph, and the pointers already recorded there are not copied again.
+func (in *Edge) DeepCopyIntoGraph(out *Edge, graph *deepcopy.Graph) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		if c, found := graph.Lookup(*in); found {
+			*out = c.(*Node)
+		} else {
+			*out = new(Node)
+			graph.Add(*in, *out)
+			(*in).DeepCopyIntoGraph(*out, graph)
+		}
+	}
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		if c, found := graph.Lookup(*in); found {
+			*out = c.(*Node)
+		} else {
+			*out = new(Node)
+			graph.Add(*in, *out)
+			(*in).DeepCopyIntoGraph(*out, graph)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Edge.
+func (in *Edge) DeepCopy() *Edge {
+	if in == nil {
+		return nil
+	}
+	out := new(Edge)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Grid) DeepCopyInto(out *Grid) {
+	*out = *in
+	{
+		in, out := &in.Nodes, &out.Nodes
+		*out = *in
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(T1)
+				**out = **in
+			}
+		}
+	}
+	{
+		in, out := &in.Rows, &out.Rows
+		*out = *in
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
+	{
+		in, out := &in.Nested, &out.Nested
+		*out = *in
+		for i := range *in {
+			{
+				in, out := &(*in)[i], &(*out)[i]
+				*out = *in
+				for i := range *in {
+					if (*in)[i] != nil {
+						in, out := &(*in)[i], &(*out)[i]
+						*out = new(T1)
+						**out = **in
+					}
+				}
+			}
+		}
+	}
+	{
+		in, out := &in.Items, &out.Items
+		*out = *in
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ByKey != nil {
+		in, out := &in.ByKey, &out.ByKey
+		*out = make(map[string][2]*T1, len(*in))
+		for key, val := range *in {
+			var outVal [2]*T1
+			{
+				in, out := &val, &outVal
+				*out = *in
+				for i := range *in {
+					if (*in)[i] != nil {
+						in, out := &(*in)[i], &(*out)[i]
+						*out = new(T1)
+						**out = **in
+					}
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Ptr != nil {
+		in, out := &in.Ptr, &out.Ptr
+		*out = new([2]*T1)
+		{
+			in, out := *in, *out
+			*out = *in
+			for i := range *in {
+				if (*in)[i] != nil {
+					in, out := &(*in)[i], &(*out)[i]
+					*out = new(T1)
+					**out = **in
+				}
+			}
+		}
+	}
+	if in.Shallow != nil {
+		in, out := &in.Shallow, &out.Shallow
+		*out = new([3]int)
+		**out = **in
+	}
+	if in.Slices != nil {
+		in, out := &in.Slices, &out.Slices
+		*out = make([][2]*T1, len(*in))
+		for i := range *in {
+			{
+				in, out := &(*in)[i], &(*out)[i]
+				*out = *in
+				for i := range *in {
+					if (*in)[i] != nil {
+						in, out := &(*in)[i], &(*out)[i]
+						*out = new(T1)
+						**out = **in
+					}
+				}
+			}
+		}
+	}
+	if in.IDs != nil {
+		in, out := &in.IDs, &out.IDs
+		*out = make([]ID, len(*in))
+		copy(*out, *in)
+	}
+	{
+		in, out := &in.Values, &out.Values
+		*out = *in
+		for i := range *in {
+			if (*in)[i] != nil {
+				(*out)[i] = deepcopy.Reflect((*in)[i])
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Grid.
+func (in *Grid) DeepCopy() *Grid {
+	if in == nil {
+		return nil
+	}
+	out := new(Grid)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ID) DeepCopyInto(out *ID) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ID.
+func (in *ID) DeepCopy() *ID {
+	if in == nil {
+		return nil
+	}
+	out := new(ID)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in Labels) DeepCopyInto(out *Labels) {
+	{
+		in := &in
+		*out = make(Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Labels.
+func (in Labels) DeepCopy() Labels {
+	if in == nil {
+		return nil
+	}
+	out := new(Labels)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Name) DeepCopyInto(out *Name) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Name.
+func (in *Name) DeepCopy() *Name {
+	if in == nil {
+		return nil
+	}
+	out := new(Name)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+// The pointers reached more than once are copied once, so the copy has the same shared pointers and cycles.
+func (in *Node) DeepCopyInto(out *Node) {
+	graph := deepcopy.NewGraph()
+	graph.Add(in, out)
+	in.DeepCopyIntoGraph(out, graph)
+}
+
+// DeepCopyIntoGraph is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+// The pointers copied are recorded in graph, and the pointers already recorded there are not copied again.
+func (in *Node) DeepCopyIntoGraph(out *Node, graph *deepcopy.Graph) {
+	*out = *in
+	if in.Parent != nil {
+		in, out := &in.Parent, &out.Parent
+		if c, found := graph.Lookup(*in); found {
+			*out = c.(*Node)
+		} else {
+			*out = new(Node)
+			graph.Add(*in, *out)
+			(*in).DeepCopyIntoGraph(*out, graph)
+		}
+	}
+	if in.Children != nil {
+		in, out := &in.Children, &out.Children
+		*out = make([]*Node, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				if c, found := graph.Lookup(*in); found {
+					*out = c.(*Node)
+				} else {
+					*out = new(Node)
+					graph.Add(*in, *out)
+					(*in).DeepCopyIntoGraph(*out, graph)
+				}
+			}
+		}
+	}
+	if in.ByName != nil {
+		in, out := &in.ByName, &out.ByName
+		*out = make(map[string]*Node, len(*in))
+		for key, val := range *in {
+			var outVal *Node
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				if c, found := graph.Lookup(*in); found {
+					*out = c.(*Node)
+				} else {
+					*out = new(Node)
+					graph.Add(*in, *out)
+					(*in).DeepCopyIntoGraph(*out, graph)
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Prev != nil {
+		in, out := &in.Prev, &out.Prev
+		if c, found := graph.Lookup(*in); found {
+			*out = c.(*Node)
+		} else {
+			*out = new(Node)
+			graph.Add(*in, *out)
+			(*in).DeepCopyIntoGraph(*out, graph)
+		}
+	}
+	if in.Next != nil {
+		in, out := &in.Next, &out.Next
+		if c, found := graph.Lookup(*in); found {
+			*out = c.(*Node)
+		} else {
+			*out = new(Node)
+			graph.Add(*in, *out)
+			(*in).DeepCopyIntoGraph(*out, graph)
+		}
+	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		if c, found := graph.Lookup(*in); found {
+			*out = c.(*int)
+		} else {
+			*out = new(int)
+			graph.Add(*in, *out)
+			**out = **in
+		}
+	}
+	if in.Owner != nil {
+		in, out := &in.Owner, &out.Owner
+		if c, found := graph.Lookup(*in); found {
+			*out = c.(*T1)
+		} else {
+			*out = new(T1)
+			graph.Add(*in, *out)
+			**out = **in
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Edges != nil {
+		in, out := &in.Edges, &out.Edges
+		*out = make([]Edge, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyIntoGraph(&(*out)[i], graph)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Node.
+func (in *Node) DeepCopy() *Node {
+	if in == nil {
+		return nil
+	}
+	out := new(Node)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Revision) DeepCopyInto(out *Revision) {
+	*out = *in
+	if in.Note != nil {
+		in, out := &in.Note, &out.Note
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Revision.
+func (in *Revision) DeepCopy() *Revision {
+	if in == nil {
+		return nil
+	}
+	out := new(Revision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Stats) DeepCopyInto(out *Stats) {
+	*out = *in
+	if in.Current != nil {
+		in, out := &in.Current, &out.Current
+		*out = new(counter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]counter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ByName != nil {
+		in, out := &in.ByName, &out.ByName
+		*out = make(map[string]counter, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Stats.
+func (in *Stats) DeepCopy() *Stats {
+	if in == nil {
+		return nil
+	}
+	out := new(Stats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *T1) DeepCopyInto(out *T1) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new T1.
+func (in *T1) DeepCopy() *T1 {
+	if in == nil {
+		return nil
+	}
+	out := new(T1)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *T2) DeepCopyInto(out *T2) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.First != nil {
+		in, out := &in.First, &out.First
+		*out = new(T1)
+		**out = **in
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]T1, len(*in))
+		copy(*out, *in)
+	}
+	if in.ByName != nil {
+		in, out := &in.ByName, &out.ByName
+		*out = make(map[string]*T1, len(*in))
+		for key, val := range *in {
+			var outVal *T1
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(T1)
+				**out = **in
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new T2.
+func (in *T2) DeepCopy() *T2 {
+	if in == nil {
+		return nil
+	}
+	out := new(T2)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *T3) DeepCopyInto(out *T3) {
+	*out = *in
+	if in.Value != nil {
+		out.Value = deepcopy.Reflect(in.Value)
+	}
+	if in.Registered != nil {
+		out.Registered = deepcopy.DeepCopyAny(in.Registered)
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]interface{}, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				(*out)[i] = deepcopy.Reflect((*in)[i])
+			}
+		}
+	}
+	if in.SharedValues != nil {
+		in, out := &in.SharedValues, &out.SharedValues
+		*out = make([]interface{}, len(*in))
+		copy(*out, *in)
+	}
+	if in.ByName != nil {
+		in, out := &in.ByName, &out.ByName
+		*out = make(map[string]interface{}, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = deepcopy.DeepCopyAny(val)
+			}
+		}
+	}
+	if in.Lengther != nil {
+		out.Lengther = deepcopy.Reflect(in.Lengther).(interface {
+			Close(...bool) error
+			Len() int
+		})
+	}
+	if in.Stringer != nil {
+		out.Stringer = in.Stringer.DeepCopyStringer()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new T3.
+func (in *T3) DeepCopy() *T3 {
+	if in == nil {
+		return nil
+	}
+	out := new(T3)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Version) DeepCopyInto(out *Version) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Owner != nil {
+		in, out := &in.Owner, &out.Owner
+		*out = new(T1)
+		**out = **in
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]T2, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ByName != nil {
+		in, out := &in.ByName, &out.ByName
+		*out = make(map[string]*T1, len(*in))
+		for key, val := range *in {
+			var outVal *T1
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(T1)
+				**out = **in
+			}
+			(*out)[key] = outVal
+		}
+	}
+	in.Revision.DeepCopyInto(&out.Revision)
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = make([]*Revision, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Revision)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	{
+		in, out := &in.Grid, &out.Grid
+		*out = *in
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make([]int, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make(map[bool]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Value != nil {
+		out.Value = deepcopy.Reflect(in.Value)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Version.
+func (in *Version) DeepCopy() *Version {
+	if in == nil {
+		return nil
+	}
+	out := new(Version)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Watcher) DeepCopyInto(out *Watcher) {
+	*out = *in
+	out.Done = nil
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]func(string) bool, len(*in))
+	}
+	if in.Handlers != nil {
+		in, out := &in.Handlers, &out.Handlers
+		*out = make(map[string]func(), len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Closers != nil {
+		in, out := &in.Closers, &out.Closers
+		*out = make(map[string]func() error, len(*in))
+		for key := range *in {
+			(*out)[key] = nil
+		}
+	}
+	if in.Callback != nil {
+		in, out := &in.Callback, &out.Callback
+		*out = new(func())
+		**out = **in
+	}
+	{
+		in, out := &in.Queues, &out.Queues
+		*out = *in
+		for i := range *in {
+			(*out)[i] = nil
+		}
+	}
+	if in.Recvs != nil {
+		in, out := &in.Recvs, &out.Recvs
+		*out = make([]<-chan int, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sends != nil {
+		in, out := &in.Sends, &out.Sends
+		*out = make(map[string]chan<- int, len(*in))
+		for key := range *in {
+			(*out)[key] = nil
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Watcher.
+func (in *Watcher) DeepCopy() *Watcher {
+	if in == nil {
+		return nil
+	}
+	out := new(Watcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *counter) DeepCopyInto(out *counter) {
+	*out = *in
+	if in.Counts != nil {
+		in, out := &in.Counts, &out.Counts
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Last != nil {
+		in, out := &in.Last, &out.Last
+		*out = new(T1)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new counter.
+func (in *counter) DeepCopy() *counter {
+	if in == nil {
+		return nil
+	}
+	out := new(counter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepEqual is an autogenerated deepequal function, reporting whether the receiver and other are deeply equal.
+// The pairs of pointers reached more than once are compared once, so cyclic graphs are compared.
+func (in *Edge) DeepEqual(other *Edge) bool {
+	if in == other {
+		return true
+	}
+	if in == nil || other == nil {
+		return false
+	}
+	visited := deepcopy.NewVisited()
+	visited.Visit(in, other)
+	return in.DeepEqualGraph(other, visited)
+}
+
+// DeepEqualGraph is an autogenerated deepequal function, reporting whether the receiver and other are deeply equal.
+// The pairs of pointers compared are recorded in visited, and the pairs already recorded there are not compared again.
+func (in *Edge) DeepEqualGraph(other *Edge, visited *deepcopy.Visited) bool {
+	{
+		in, other := &in.From, &other.From
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if *in != nil && !visited.Visit(*in, *other) {
+			in, other := *in, *other
+			if !in.DeepEqualGraph(other, visited) {
+				return false
+			}
+		}
+	}
+	{
+		in, other := &in.To, &other.To
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if *in != nil && !visited.Visit(*in, *other) {
+			in, other := *in, *other
+			if !in.DeepEqualGraph(other, visited) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, reporting whether the receiver and other are deeply equal.
+func (in Labels) DeepEqual(other Labels) bool {
+	{
+		in, other := &in, &other
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if len(*in) != len(*other) {
+			return false
+		}
+		for key, val := range *in {
+			otherVal, ok := (*other)[key]
+			if !ok {
+				return false
+			}
+			if val != otherVal {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, reporting whether the receiver and other are deeply equal.
+// The pairs of pointers reached more than once are compared once, so cyclic graphs are compared.
+func (in *Node) DeepEqual(other *Node) bool {
+	if in == other {
+		return true
+	}
+	if in == nil || other == nil {
+		return false
+	}
+	visited := deepcopy.NewVisited()
+	visited.Visit(in, other)
+	return in.DeepEqualGraph(other, visited)
+}
+
+// DeepEqualGraph is an autogenerated deepequal function, reporting whether the receiver and other are deeply equal.
+// The pairs of pointers compared are recorded in visited, and the pairs already recorded there are not compared again.
+func (in *Node) DeepEqualGraph(other *Node, visited *deepcopy.Visited) bool {
+	if in.Name != other.Name {
+		return false
+	}
+	{
+		in, other := &in.Parent, &other.Parent
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if *in != nil && !visited.Visit(*in, *other) {
+			in, other := *in, *other
+			if !in.DeepEqualGraph(other, visited) {
+				return false
+			}
+		}
+	}
+	{
+		in, other := &in.Children, &other.Children
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if len(*in) != len(*other) {
+			return false
+		}
+		for i := range *in {
+			{
+				in, other := &(*in)[i], &(*other)[i]
+				if (*in == nil) != (*other == nil) {
+					return false
+				}
+				if *in != nil && !visited.Visit(*in, *other) {
+					in, other := *in, *other
+					if !in.DeepEqualGraph(other, visited) {
+						return false
+					}
+				}
+			}
+		}
+	}
+	{
+		in, other := &in.ByName, &other.ByName
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if len(*in) != len(*other) {
+			return false
+		}
+		for key, val := range *in {
+			otherVal, ok := (*other)[key]
+			if !ok {
+				return false
+			}
+			{
+				in, other := &val, &otherVal
+				if (*in == nil) != (*other == nil) {
+					return false
+				}
+				if *in != nil && !visited.Visit(*in, *other) {
+					in, other := *in, *other
+					if !in.DeepEqualGraph(other, visited) {
+						return false
+					}
+				}
+			}
+		}
+	}
+	{
+		in, other := &in.Prev, &other.Prev
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if *in != nil && !visited.Visit(*in, *other) {
+			in, other := *in, *other
+			if !in.DeepEqualGraph(other, visited) {
+				return false
+			}
+		}
+	}
+	{
+		in, other := &in.Next, &other.Next
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if *in != nil && !visited.Visit(*in, *other) {
+			in, other := *in, *other
+			if !in.DeepEqualGraph(other, visited) {
+				return false
+			}
+		}
+	}
+	{
+		in, other := &in.Weight, &other.Weight
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if *in != nil && !visited.Visit(*in, *other) {
+			in, other := *in, *other
+			if *in != *other {
+				return false
+			}
+		}
+	}
+	{
+		in, other := &in.Owner, &other.Owner
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if *in != nil && !visited.Visit(*in, *other) {
+			in, other := *in, *other
+			if *in != *other {
+				return false
+			}
+		}
+	}
+	{
+		in, other := &in.Labels, &other.Labels
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if len(*in) != len(*other) {
+			return false
+		}
+		for key, val := range *in {
+			otherVal, ok := (*other)[key]
+			if !ok {
+				return false
+			}
+			if val != otherVal {
+				return false
+			}
+		}
+	}
+	{
+		in, other := &in.Edges, &other.Edges
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if len(*in) != len(*other) {
+			return false
+		}
+		for i := range *in {
+			{
+				in, other := &(*in)[i], &(*other)[i]
+				if !in.DeepEqualGraph(other, visited) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, reporting whether the receiver and other are deeply equal.
+func (in *T1) DeepEqual(other *T1) bool {
+	if in == other {
+		return true
+	}
+	if in == nil || other == nil {
+		return false
+	}
+	if in.Byte != other.Byte {
+		return false
+	}
+	if in.Int16 != other.Int16 {
+		return false
+	}
+	if in.Int32 != other.Int32 {
+		return false
+	}
+	if in.Int64 != other.Int64 {
+		return false
+	}
+	if in.Uint8 != other.Uint8 {
+		return false
+	}
+	if in.Uint16 != other.Uint16 {
+		return false
+	}
+	if in.Uint32 != other.Uint32 {
+		return false
+	}
+	if in.Uint64 != other.Uint64 {
+		return false
+	}
+	if in.Float32 != other.Float32 {
+		return false
+	}
+	if in.Float64 != other.Float64 {
+		return false
+	}
+	if in.Str != other.Str {
+		return false
+	}
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, reporting whether the receiver and other are deeply equal.
+func (in *T2) DeepEqual(other *T2) bool {
+	if in == other {
+		return true
+	}
+	if in == nil || other == nil {
+		return false
+	}
+	{
+		in, other := &in.Labels, &other.Labels
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if len(*in) != len(*other) {
+			return false
+		}
+		for key, val := range *in {
+			otherVal, ok := (*other)[key]
+			if !ok {
+				return false
+			}
+			if val != otherVal {
+				return false
+			}
+		}
+	}
+	{
+		in, other := &in.Tags, &other.Tags
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if len(*in) != len(*other) {
+			return false
+		}
+		for i := range *in {
+			if (*in)[i] != (*other)[i] {
+				return false
+			}
+		}
+	}
+	{
+		in, other := &in.First, &other.First
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if *in != nil {
+			in, other := *in, *other
+			if *in != *other {
+				return false
+			}
+		}
+	}
+	{
+		in, other := &in.Items, &other.Items
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if len(*in) != len(*other) {
+			return false
+		}
+		for i := range *in {
+			if (*in)[i] != (*other)[i] {
+				return false
+			}
+		}
+	}
+	{
+		in, other := &in.ByName, &other.ByName
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if len(*in) != len(*other) {
+			return false
+		}
+		for key, val := range *in {
+			otherVal, ok := (*other)[key]
+			if !ok {
+				return false
+			}
+			{
+				in, other := &val, &otherVal
+				if (*in == nil) != (*other == nil) {
+					return false
+				}
+				if *in != nil {
+					in, other := *in, *other
+					if *in != *other {
+						return false
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, reporting whether the receiver and other are deeply equal.
+func (in *Version) DeepEqual(other *Version) bool {
+	if in == other {
+		return true
+	}
+	if in == nil || other == nil {
+		return false
+	}
+	if in.Name != other.Name {
+		return false
+	}
+	{
+		in, other := &in.Tags, &other.Tags
+		if len(*in) != len(*other) {
+			return false
+		}
+		for i := range *in {
+			if (*in)[i] != (*other)[i] {
+				return false
+			}
+		}
+	}
+	{
+		in, other := &in.Labels, &other.Labels
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if len(*in) != len(*other) {
+			return false
+		}
+		for key, val := range *in {
+			otherVal, ok := (*other)[key]
+			if !ok {
+				return false
+			}
+			if val != otherVal {
+				return false
+			}
+		}
+	}
+	{
+		in, other := &in.Owner, &other.Owner
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if *in != nil {
+			in, other := *in, *other
+			if *in != *other {
+				return false
+			}
+		}
+	}
+	{
+		in, other := &in.Items, &other.Items
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if len(*in) != len(*other) {
+			return false
+		}
+		for i := range *in {
+			{
+				in, other := &(*in)[i], &(*other)[i]
+				if !in.DeepEqual(other) {
+					return false
+				}
+			}
+		}
+	}
+	{
+		in, other := &in.ByName, &other.ByName
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if len(*in) != len(*other) {
+			return false
+		}
+		for key, val := range *in {
+			otherVal, ok := (*other)[key]
+			if !ok {
+				return false
+			}
+			{
+				in, other := &val, &otherVal
+				if (*in == nil) != (*other == nil) {
+					return false
+				}
+				if *in != nil {
+					in, other := *in, *other
+					if *in != *other {
+						return false
+					}
+				}
+			}
+		}
+	}
+	{
+		in, other := &in.Revision, &other.Revision
+		if !in.DeepEqual(other) {
+			return false
+		}
+	}
+	{
+		in, other := &in.Revisions, &other.Revisions
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if len(*in) != len(*other) {
+			return false
+		}
+		for i := range *in {
+			{
+				in, other := &(*in)[i], &(*other)[i]
+				if (*in == nil) != (*other == nil) {
+					return false
+				}
+				if *in != nil {
+					in, other := *in, *other
+					if !in.DeepEqual(other) {
+						return false
+					}
+				}
+			}
+		}
+	}
+	{
+		in, other := &in.Grid, &other.Grid
+		for i := range *in {
+			{
+				in, other := &(*in)[i], &(*other)[i]
+				if (*in == nil) != (*other == nil) {
+					return false
+				}
+				if len(*in) != len(*other) {
+					return false
+				}
+				for i := range *in {
+					if (*in)[i] != (*other)[i] {
+						return false
+					}
+				}
+			}
+		}
+	}
+	{
+		in, other := &in.Flags, &other.Flags
+		if (*in == nil) != (*other == nil) {
+			return false
+		}
+		if len(*in) != len(*other) {
+			return false
+		}
+		for key, val := range *in {
+			otherVal, ok := (*other)[key]
+			if !ok {
+				return false
+			}
+			if val != otherVal {
+				return false
+			}
+		}
+	}
+	{
+		in, other := &in.Value, &other.Value
+		if !reflect.DeepEqual(*in, *other) {
+			return false
+		}
+	}
+	{
+		in, other := &in.OnChange, &other.OnChange
+		if *in != nil || *other != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Diff is an autogenerated diff function, returning the changes from the receiver to other.
+func (in *Edge) Diff(other *Edge) []deepcopy.FieldChange {
+	return in.AppendDiff(nil, "", other)
+}
+
+// AppendDiff is an autogenerated diff function, appending the changes from the receiver to other to changes.
+// The paths of the changes start with path.
+func (in *Edge) AppendDiff(changes []deepcopy.FieldChange, path string, other *Edge) []deepcopy.FieldChange {
+	if in == other {
+		return changes
+	}
+	if in == nil || other == nil {
+		changes = append(changes, deepcopy.FieldChange{Path: path, Old: in, New: other})
+		return changes
+	}
+	visited := deepcopy.NewVisited()
+	visited.Visit(in, other)
+	return in.AppendDiffGraph(changes, path, other, visited)
+}
+
+// AppendDiffGraph is an autogenerated diff function, appending the changes from the receiver to other to changes.
+// The pairs of pointers diffed are recorded in visited, and the pairs already recorded there are not diffed again:
+// the changes reached through several paths are reported at the first one.
+func (in *Edge) AppendDiffGraph(changes []deepcopy.FieldChange, path string, other *Edge, visited *deepcopy.Visited) []deepcopy.FieldChange {
+	{
+		in, other, path := &in.From, &other.From, deepcopy.FieldPath(path, "From")
+		if *in == nil || *other == nil {
+			if *in != *other {
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+			}
+		} else if !visited.Visit(*in, *other) {
+			in, other := *in, *other
+			changes = in.AppendDiffGraph(changes, path, other, visited)
+		}
+	}
+	{
+		in, other, path := &in.To, &other.To, deepcopy.FieldPath(path, "To")
+		if *in == nil || *other == nil {
+			if *in != *other {
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+			}
+		} else if !visited.Visit(*in, *other) {
+			in, other := *in, *other
+			changes = in.AppendDiffGraph(changes, path, other, visited)
+		}
+	}
+	return changes
+}
+
+// Diff is an autogenerated diff function, returning the changes from the receiver to other.
+func (in Labels) Diff(other Labels) []deepcopy.FieldChange {
+	return in.AppendDiff(nil, "", other)
+}
+
+// AppendDiff is an autogenerated diff function, appending the changes from the receiver to other to changes.
+// The paths of the changes start with path.
+func (in Labels) AppendDiff(changes []deepcopy.FieldChange, path string, other Labels) []deepcopy.FieldChange {
+	{
+		in, other := &in, &other
+		if len(*in) == 0 && len(*other) == 0 && (*in == nil) != (*other == nil) {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+		keys := make([]string, 0, len(*in)+len(*other))
+		for key := range *in {
+			keys = append(keys, key)
+		}
+		for key := range *other {
+			if _, ok := (*in)[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		for _, key := range keys {
+			val, inOK := (*in)[key]
+			otherVal, otherOK := (*other)[key]
+			path := deepcopy.KeyPath(path, key)
+			switch {
+			case !otherOK:
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: val, New: nil})
+			case !inOK:
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: nil, New: otherVal})
+			default:
+				in, other := &val, &otherVal
+				if *in != *other {
+					changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+				}
+			}
+		}
+	}
+	return changes
+}
+
+// Diff is an autogenerated diff function, returning the changes from the receiver to other.
+func (in *Node) Diff(other *Node) []deepcopy.FieldChange {
+	return in.AppendDiff(nil, "", other)
+}
+
+// AppendDiff is an autogenerated diff function, appending the changes from the receiver to other to changes.
+// The paths of the changes start with path.
+func (in *Node) AppendDiff(changes []deepcopy.FieldChange, path string, other *Node) []deepcopy.FieldChange {
+	if in == other {
+		return changes
+	}
+	if in == nil || other == nil {
+		changes = append(changes, deepcopy.FieldChange{Path: path, Old: in, New: other})
+		return changes
+	}
+	visited := deepcopy.NewVisited()
+	visited.Visit(in, other)
+	return in.AppendDiffGraph(changes, path, other, visited)
+}
+
+// AppendDiffGraph is an autogenerated diff function, appending the changes from the receiver to other to changes.
+// The pairs of pointers diffed are recorded in visited, and the pairs already recorded there are not diffed again:
+// the changes reached through several paths are reported at the first one.
+func (in *Node) AppendDiffGraph(changes []deepcopy.FieldChange, path string, other *Node, visited *deepcopy.Visited) []deepcopy.FieldChange {
+	{
+		in, other, path := &in.Name, &other.Name, deepcopy.FieldPath(path, "Name")
+		if *in != *other {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+	}
+	{
+		in, other, path := &in.Parent, &other.Parent, deepcopy.FieldPath(path, "Parent")
+		if *in == nil || *other == nil {
+			if *in != *other {
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+			}
+		} else if !visited.Visit(*in, *other) {
+			in, other := *in, *other
+			changes = in.AppendDiffGraph(changes, path, other, visited)
+		}
+	}
+	{
+		in, other, path := &in.Children, &other.Children, deepcopy.FieldPath(path, "Children")
+		if len(*in) == 0 && len(*other) == 0 && (*in == nil) != (*other == nil) {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+		for i := range *in {
+			if i >= len(*other) {
+				changes = append(changes, deepcopy.FieldChange{Path: deepcopy.IndexPath(path, i), Old: (*in)[i], New: nil})
+				continue
+			}
+			{
+				in, other, path := &(*in)[i], &(*other)[i], deepcopy.IndexPath(path, i)
+				if *in == nil || *other == nil {
+					if *in != *other {
+						changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+					}
+				} else if !visited.Visit(*in, *other) {
+					in, other := *in, *other
+					changes = in.AppendDiffGraph(changes, path, other, visited)
+				}
+			}
+		}
+		for i := len(*in); i < len(*other); i++ {
+			changes = append(changes, deepcopy.FieldChange{Path: deepcopy.IndexPath(path, i), Old: nil, New: (*other)[i]})
+		}
+	}
+	{
+		in, other, path := &in.ByName, &other.ByName, deepcopy.FieldPath(path, "ByName")
+		if len(*in) == 0 && len(*other) == 0 && (*in == nil) != (*other == nil) {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+		keys := make([]string, 0, len(*in)+len(*other))
+		for key := range *in {
+			keys = append(keys, key)
+		}
+		for key := range *other {
+			if _, ok := (*in)[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		for _, key := range keys {
+			val, inOK := (*in)[key]
+			otherVal, otherOK := (*other)[key]
+			path := deepcopy.KeyPath(path, key)
+			switch {
+			case !otherOK:
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: val, New: nil})
+			case !inOK:
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: nil, New: otherVal})
+			default:
+				in, other := &val, &otherVal
+				if *in == nil || *other == nil {
+					if *in != *other {
+						changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+					}
+				} else if !visited.Visit(*in, *other) {
+					in, other := *in, *other
+					changes = in.AppendDiffGraph(changes, path, other, visited)
+				}
+			}
+		}
+	}
+	{
+		in, other, path := &in.Prev, &other.Prev, deepcopy.FieldPath(path, "Prev")
+		if *in == nil || *other == nil {
+			if *in != *other {
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+			}
+		} else if !visited.Visit(*in, *other) {
+			in, other := *in, *other
+			changes = in.AppendDiffGraph(changes, path, other, visited)
+		}
+	}
+	{
+		in, other, path := &in.Next, &other.Next, deepcopy.FieldPath(path, "Next")
+		if *in == nil || *other == nil {
+			if *in != *other {
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+			}
+		} else if !visited.Visit(*in, *other) {
+			in, other := *in, *other
+			changes = in.AppendDiffGraph(changes, path, other, visited)
+		}
+	}
+	{
+		in, other, path := &in.Weight, &other.Weight, deepcopy.FieldPath(path, "Weight")
+		if *in == nil || *other == nil {
+			if *in != *other {
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+			}
+		} else if !visited.Visit(*in, *other) {
+			in, other := *in, *other
+			if *in != *other {
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+			}
+		}
+	}
+	{
+		in, other, path := &in.Owner, &other.Owner, deepcopy.FieldPath(path, "Owner")
+		if *in == nil || *other == nil {
+			if *in != *other {
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+			}
+		} else if !visited.Visit(*in, *other) {
+			in, other := *in, *other
+			changes = in.AppendDiff(changes, path, other)
+		}
+	}
+	{
+		in, other, path := &in.Labels, &other.Labels, deepcopy.FieldPath(path, "Labels")
+		if len(*in) == 0 && len(*other) == 0 && (*in == nil) != (*other == nil) {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+		keys := make([]string, 0, len(*in)+len(*other))
+		for key := range *in {
+			keys = append(keys, key)
+		}
+		for key := range *other {
+			if _, ok := (*in)[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		for _, key := range keys {
+			val, inOK := (*in)[key]
+			otherVal, otherOK := (*other)[key]
+			path := deepcopy.KeyPath(path, key)
+			switch {
+			case !otherOK:
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: val, New: nil})
+			case !inOK:
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: nil, New: otherVal})
+			default:
+				in, other := &val, &otherVal
+				if *in != *other {
+					changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+				}
+			}
+		}
+	}
+	{
+		in, other, path := &in.Edges, &other.Edges, deepcopy.FieldPath(path, "Edges")
+		if len(*in) == 0 && len(*other) == 0 && (*in == nil) != (*other == nil) {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+		for i := range *in {
+			if i >= len(*other) {
+				changes = append(changes, deepcopy.FieldChange{Path: deepcopy.IndexPath(path, i), Old: (*in)[i], New: nil})
+				continue
+			}
+			{
+				in, other, path := &(*in)[i], &(*other)[i], deepcopy.IndexPath(path, i)
+				changes = in.AppendDiffGraph(changes, path, other, visited)
+			}
+		}
+		for i := len(*in); i < len(*other); i++ {
+			changes = append(changes, deepcopy.FieldChange{Path: deepcopy.IndexPath(path, i), Old: nil, New: (*other)[i]})
+		}
+	}
+	return changes
+}
+
+// Diff is an autogenerated diff function, returning the changes from the receiver to other.
+func (in *T1) Diff(other *T1) []deepcopy.FieldChange {
+	return in.AppendDiff(nil, "", other)
+}
+
+// AppendDiff is an autogenerated diff function, appending the changes from the receiver to other to changes.
+// The paths of the changes start with path.
+func (in *T1) AppendDiff(changes []deepcopy.FieldChange, path string, other *T1) []deepcopy.FieldChange {
+	if in == other {
+		return changes
+	}
+	if in == nil || other == nil {
+		changes = append(changes, deepcopy.FieldChange{Path: path, Old: in, New: other})
+		return changes
+	}
+	{
+		in, other, path := &in.Byte, &other.Byte, deepcopy.FieldPath(path, "Byte")
+		if *in != *other {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+	}
+	{
+		in, other, path := &in.Int16, &other.Int16, deepcopy.FieldPath(path, "Int16")
+		if *in != *other {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+	}
+	{
+		in, other, path := &in.Int32, &other.Int32, deepcopy.FieldPath(path, "Int32")
+		if *in != *other {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+	}
+	{
+		in, other, path := &in.Int64, &other.Int64, deepcopy.FieldPath(path, "Int64")
+		if *in != *other {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+	}
+	{
+		in, other, path := &in.Uint8, &other.Uint8, deepcopy.FieldPath(path, "Uint8")
+		if *in != *other {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+	}
+	{
+		in, other, path := &in.Uint16, &other.Uint16, deepcopy.FieldPath(path, "Uint16")
+		if *in != *other {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+	}
+	{
+		in, other, path := &in.Uint32, &other.Uint32, deepcopy.FieldPath(path, "Uint32")
+		if *in != *other {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+	}
+	{
+		in, other, path := &in.Uint64, &other.Uint64, deepcopy.FieldPath(path, "Uint64")
+		if *in != *other {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+	}
+	{
+		in, other, path := &in.Float32, &other.Float32, deepcopy.FieldPath(path, "Float32")
+		if *in != *other {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+	}
+	{
+		in, other, path := &in.Float64, &other.Float64, deepcopy.FieldPath(path, "Float64")
+		if *in != *other {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+	}
+	{
+		in, other, path := &in.Str, &other.Str, deepcopy.FieldPath(path, "Str")
+		if *in != *other {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+	}
+	return changes
+}
+
+// Diff is an autogenerated diff function, returning the changes from the receiver to other.
+func (in *T2) Diff(other *T2) []deepcopy.FieldChange {
+	return in.AppendDiff(nil, "", other)
+}
+
+// AppendDiff is an autogenerated diff function, appending the changes from the receiver to other to changes.
+// The paths of the changes start with path.
+func (in *T2) AppendDiff(changes []deepcopy.FieldChange, path string, other *T2) []deepcopy.FieldChange {
+	if in == other {
+		return changes
+	}
+	if in == nil || other == nil {
+		changes = append(changes, deepcopy.FieldChange{Path: path, Old: in, New: other})
+		return changes
+	}
+	{
+		in, other, path := &in.Labels, &other.Labels, deepcopy.FieldPath(path, "Labels")
+		if len(*in) == 0 && len(*other) == 0 && (*in == nil) != (*other == nil) {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+		keys := make([]string, 0, len(*in)+len(*other))
+		for key := range *in {
+			keys = append(keys, key)
+		}
+		for key := range *other {
+			if _, ok := (*in)[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		for _, key := range keys {
+			val, inOK := (*in)[key]
+			otherVal, otherOK := (*other)[key]
+			path := deepcopy.KeyPath(path, key)
+			switch {
+			case !otherOK:
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: val, New: nil})
+			case !inOK:
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: nil, New: otherVal})
+			default:
+				in, other := &val, &otherVal
+				if *in != *other {
+					changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+				}
+			}
+		}
+	}
+	{
+		in, other, path := &in.Tags, &other.Tags, deepcopy.FieldPath(path, "Tags")
+		if len(*in) == 0 && len(*other) == 0 && (*in == nil) != (*other == nil) {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+		for i := range *in {
+			if i >= len(*other) {
+				changes = append(changes, deepcopy.FieldChange{Path: deepcopy.IndexPath(path, i), Old: (*in)[i], New: nil})
+				continue
+			}
+			{
+				in, other, path := &(*in)[i], &(*other)[i], deepcopy.IndexPath(path, i)
+				if *in != *other {
+					changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+				}
+			}
+		}
+		for i := len(*in); i < len(*other); i++ {
+			changes = append(changes, deepcopy.FieldChange{Path: deepcopy.IndexPath(path, i), Old: nil, New: (*other)[i]})
+		}
+	}
+	{
+		in, other, path := &in.First, &other.First, deepcopy.FieldPath(path, "First")
+		if *in == nil || *other == nil {
+			if *in != *other {
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+			}
+		} else {
+			in, other := *in, *other
+			changes = in.AppendDiff(changes, path, other)
+		}
+	}
+	{
+		in, other, path := &in.Items, &other.Items, deepcopy.FieldPath(path, "Items")
+		if len(*in) == 0 && len(*other) == 0 && (*in == nil) != (*other == nil) {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+		for i := range *in {
+			if i >= len(*other) {
+				changes = append(changes, deepcopy.FieldChange{Path: deepcopy.IndexPath(path, i), Old: (*in)[i], New: nil})
+				continue
+			}
+			{
+				in, other, path := &(*in)[i], &(*other)[i], deepcopy.IndexPath(path, i)
+				changes = in.AppendDiff(changes, path, other)
+			}
+		}
+		for i := len(*in); i < len(*other); i++ {
+			changes = append(changes, deepcopy.FieldChange{Path: deepcopy.IndexPath(path, i), Old: nil, New: (*other)[i]})
+		}
+	}
+	{
+		in, other, path := &in.ByName, &other.ByName, deepcopy.FieldPath(path, "ByName")
+		if len(*in) == 0 && len(*other) == 0 && (*in == nil) != (*other == nil) {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+		keys := make([]string, 0, len(*in)+len(*other))
+		for key := range *in {
+			keys = append(keys, key)
+		}
+		for key := range *other {
+			if _, ok := (*in)[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		for _, key := range keys {
+			val, inOK := (*in)[key]
+			otherVal, otherOK := (*other)[key]
+			path := deepcopy.KeyPath(path, key)
+			switch {
+			case !otherOK:
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: val, New: nil})
+			case !inOK:
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: nil, New: otherVal})
+			default:
+				in, other := &val, &otherVal
+				if *in == nil || *other == nil {
+					if *in != *other {
+						changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+					}
+				} else {
+					in, other := *in, *other
+					changes = in.AppendDiff(changes, path, other)
+				}
+			}
+		}
+	}
+	return changes
+}
+
+// Diff is an autogenerated diff function, returning the changes from the receiver to other.
+func (in *Version) Diff(other *Version) []deepcopy.FieldChange {
+	return in.AppendDiff(nil, "", other)
+}
+
+// AppendDiff is an autogenerated diff function, appending the changes from the receiver to other to changes.
+// The paths of the changes start with path.
+func (in *Version) AppendDiff(changes []deepcopy.FieldChange, path string, other *Version) []deepcopy.FieldChange {
+	if in == other {
+		return changes
+	}
+	if in == nil || other == nil {
+		changes = append(changes, deepcopy.FieldChange{Path: path, Old: in, New: other})
+		return changes
+	}
+	{
+		in, other, path := &in.Name, &other.Name, deepcopy.FieldPath(path, "Name")
+		if *in != *other {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+	}
+	{
+		in, other, path := &in.Tags, &other.Tags, deepcopy.FieldPath(path, "Tags")
+		for i := range *in {
+			if i >= len(*other) {
+				changes = append(changes, deepcopy.FieldChange{Path: deepcopy.IndexPath(path, i), Old: (*in)[i], New: nil})
+				continue
+			}
+			{
+				in, other, path := &(*in)[i], &(*other)[i], deepcopy.IndexPath(path, i)
+				if *in != *other {
+					changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+				}
+			}
+		}
+		for i := len(*in); i < len(*other); i++ {
+			changes = append(changes, deepcopy.FieldChange{Path: deepcopy.IndexPath(path, i), Old: nil, New: (*other)[i]})
+		}
+	}
+	{
+		in, other, path := &in.Labels, &other.Labels, deepcopy.FieldPath(path, "Labels")
+		if len(*in) == 0 && len(*other) == 0 && (*in == nil) != (*other == nil) {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+		keys := make([]string, 0, len(*in)+len(*other))
+		for key := range *in {
+			keys = append(keys, key)
+		}
+		for key := range *other {
+			if _, ok := (*in)[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		for _, key := range keys {
+			val, inOK := (*in)[key]
+			otherVal, otherOK := (*other)[key]
+			path := deepcopy.KeyPath(path, key)
+			switch {
+			case !otherOK:
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: val, New: nil})
+			case !inOK:
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: nil, New: otherVal})
+			default:
+				in, other := &val, &otherVal
+				if *in != *other {
+					changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+				}
+			}
+		}
+	}
+	{
+		in, other, path := &in.Owner, &other.Owner, deepcopy.FieldPath(path, "Owner")
+		if *in == nil || *other == nil {
+			if *in != *other {
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+			}
+		} else {
+			in, other := *in, *other
+			changes = in.AppendDiff(changes, path, other)
+		}
+	}
+	{
+		in, other, path := &in.Items, &other.Items, deepcopy.FieldPath(path, "Items")
+		if len(*in) == 0 && len(*other) == 0 && (*in == nil) != (*other == nil) {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+		for i := range *in {
+			if i >= len(*other) {
+				changes = append(changes, deepcopy.FieldChange{Path: deepcopy.IndexPath(path, i), Old: (*in)[i], New: nil})
+				continue
+			}
+			{
+				in, other, path := &(*in)[i], &(*other)[i], deepcopy.IndexPath(path, i)
+				changes = in.AppendDiff(changes, path, other)
+			}
+		}
+		for i := len(*in); i < len(*other); i++ {
+			changes = append(changes, deepcopy.FieldChange{Path: deepcopy.IndexPath(path, i), Old: nil, New: (*other)[i]})
+		}
+	}
+	{
+		in, other, path := &in.ByName, &other.ByName, deepcopy.FieldPath(path, "ByName")
+		if len(*in) == 0 && len(*other) == 0 && (*in == nil) != (*other == nil) {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+		keys := make([]string, 0, len(*in)+len(*other))
+		for key := range *in {
+			keys = append(keys, key)
+		}
+		for key := range *other {
+			if _, ok := (*in)[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		for _, key := range keys {
+			val, inOK := (*in)[key]
+			otherVal, otherOK := (*other)[key]
+			path := deepcopy.KeyPath(path, key)
+			switch {
+			case !otherOK:
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: val, New: nil})
+			case !inOK:
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: nil, New: otherVal})
+			default:
+				in, other := &val, &otherVal
+				if *in == nil || *other == nil {
+					if *in != *other {
+						changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+					}
+				} else {
+					in, other := *in, *other
+					changes = in.AppendDiff(changes, path, other)
+				}
+			}
+		}
+	}
+	{
+		in, other, path := &in.Revision, &other.Revision, deepcopy.FieldPath(path, "Revision")
+		if !in.DeepEqual(other) {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+	}
+	{
+		in, other, path := &in.Revisions, &other.Revisions, deepcopy.FieldPath(path, "Revisions")
+		if len(*in) == 0 && len(*other) == 0 && (*in == nil) != (*other == nil) {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+		for i := range *in {
+			if i >= len(*other) {
+				changes = append(changes, deepcopy.FieldChange{Path: deepcopy.IndexPath(path, i), Old: (*in)[i], New: nil})
+				continue
+			}
+			{
+				in, other, path := &(*in)[i], &(*other)[i], deepcopy.IndexPath(path, i)
+				if *in == nil || *other == nil {
+					if *in != *other {
+						changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+					}
+				} else {
+					in, other := *in, *other
+					if !in.DeepEqual(other) {
+						changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+					}
+				}
+			}
+		}
+		for i := len(*in); i < len(*other); i++ {
+			changes = append(changes, deepcopy.FieldChange{Path: deepcopy.IndexPath(path, i), Old: nil, New: (*other)[i]})
+		}
+	}
+	{
+		in, other, path := &in.Grid, &other.Grid, deepcopy.FieldPath(path, "Grid")
+		for i := range *in {
+			{
+				in, other, path := &(*in)[i], &(*other)[i], deepcopy.IndexPath(path, i)
+				if len(*in) == 0 && len(*other) == 0 && (*in == nil) != (*other == nil) {
+					changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+				}
+				for i := range *in {
+					if i >= len(*other) {
+						changes = append(changes, deepcopy.FieldChange{Path: deepcopy.IndexPath(path, i), Old: (*in)[i], New: nil})
+						continue
+					}
+					{
+						in, other, path := &(*in)[i], &(*other)[i], deepcopy.IndexPath(path, i)
+						if *in != *other {
+							changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+						}
+					}
+				}
+				for i := len(*in); i < len(*other); i++ {
+					changes = append(changes, deepcopy.FieldChange{Path: deepcopy.IndexPath(path, i), Old: nil, New: (*other)[i]})
+				}
+			}
+		}
+	}
+	{
+		in, other, path := &in.Flags, &other.Flags, deepcopy.FieldPath(path, "Flags")
+		if len(*in) == 0 && len(*other) == 0 && (*in == nil) != (*other == nil) {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+		keys := make([]bool, 0, len(*in)+len(*other))
+		for key := range *in {
+			keys = append(keys, key)
+		}
+		for key := range *other {
+			if _, ok := (*in)[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return deepcopy.KeyLess(keys[i], keys[j]) })
+		for _, key := range keys {
+			val, inOK := (*in)[key]
+			otherVal, otherOK := (*other)[key]
+			path := deepcopy.KeyPath(path, key)
+			switch {
+			case !otherOK:
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: val, New: nil})
+			case !inOK:
+				changes = append(changes, deepcopy.FieldChange{Path: path, Old: nil, New: otherVal})
+			default:
+				in, other := &val, &otherVal
+				if *in != *other {
+					changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+				}
+			}
+		}
+	}
+	{
+		in, other, path := &in.Value, &other.Value, deepcopy.FieldPath(path, "Value")
+		if !reflect.DeepEqual(*in, *other) {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+	}
+	{
+		in, other, path := &in.OnChange, &other.OnChange, deepcopy.FieldPath(path, "OnChange")
+		if *in != nil || *other != nil {
+			changes = append(changes, deepcopy.FieldChange{Path: path, Old: *in, New: *other})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,415 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zhaolion/gengo/deepcopy"
+)
+
+func testT2() T2 {
+	return T2{
+		Labels: Labels{"app": "web"},
+		Tags:   []string{"a", "b"},
+		First:  &T1{Str: "first"},
+		Items:  []T1{{Int16: 1}, {Int16: 2}},
+		ByName: map[string]*T1{"x": {Str: "x"}, "nil": nil},
+	}
+}
+
+func TestDeepCopyAny(t *testing.T) {
+	in := testT2()
+	for _, v := range []interface{}{in, &in, in.Labels, *in.First, (*T2)(nil)} {
+		out := deepcopy.DeepCopyAny(v)
+		if !reflect.DeepEqual(out, v) {
+			t.Errorf("expected %+v, got %+v", v, out)
+		}
+	}
+
+	out := deepcopy.DeepCopyAny(in).(T2)
+	out.Labels["app"] = "db"
+	out.Tags[0] = "c"
+	out.First.Str = "changed"
+	out.ByName["x"].Str = "changed"
+	if !reflect.DeepEqual(in, testT2()) {
+		t.Errorf("changing the copy changed the original: %+v", in)
+	}
+}
+
+func TestRegisterDeepCopies(t *testing.T) {
+	r := deepcopy.NewRegistry()
+	RegisterDeepCopies(r)
+	for _, v := range []interface{}{T1{}, T2{}, Labels{}} {
+		if r.Lookup(reflect.TypeOf(v)) == nil {
+			t.Errorf("%T is not registered", v)
+		}
+	}
+}
+
+type lengther []int
+
+func (l lengther) Len() int { return len(l) }
+
+func (l lengther) Close(force ...bool) error { return nil }
+
+func TestInterfaceStrategies(t *testing.T) {
+	name := "n"
+	shared := &T1{Str: "shared"}
+	in := &T3{
+		Value:          map[string][]int{"a": {1}},
+		Shared:         shared,
+		Registered:     T2{Tags: []string{"r"}},
+		Values:         []interface{}{&T1{Str: "v"}, nil},
+		SharedValues:   []interface{}{shared},
+		ByName:         map[string]interface{}{"l": Labels{"k": "v"}, "nil": nil},
+		Lengther:       lengther{1, 2},
+		Stringer:       &Name{Value: &name},
+		SharedStringer: &Name{Value: &name},
+	}
+	out := in.DeepCopy()
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("expected %+v, got %+v", in, out)
+	}
+
+	if out.Shared != interface{}(shared) || out.SharedValues[0] != interface{}(shared) || out.SharedStringer != in.SharedStringer {
+		t.Errorf("the shared values are copied")
+	}
+	out.Value.(map[string][]int)["a"][0] = 2
+	out.Registered.(T2).Tags[0] = "changed"
+	out.Values[0].(*T1).Str = "changed"
+	out.ByName["l"].(Labels)["k"] = "changed"
+	out.Lengther.(lengther)[0] = 3
+	*out.Stringer.(*Name).Value = "changed"
+	if in.Value.(map[string][]int)["a"][0] != 1 || in.Registered.(T2).Tags[0] != "r" || in.Values[0].(*T1).Str != "v" ||
+		in.ByName["l"].(Labels)["k"] != "v" || in.Lengther.(lengther)[0] != 1 || name != "n" {
+		t.Errorf("changing the copy changed the original: %+v", in)
+	}
+}
+
+func TestGraphDeepCopy(t *testing.T) {
+	weight := 3
+	owner := &T1{Str: "owner"}
+	root := &Node{Name: "root", Weight: &weight, Owner: owner}
+	a := &Node{Name: "a", Parent: root, Weight: &weight, Owner: owner}
+	b := &Node{Name: "b", Parent: root, Prev: a}
+	a.Next = b
+	root.Children = []*Node{a, b}
+	root.ByName = map[string]*Node{"a": a, "b": b, "self": root}
+	root.Edges = []Edge{{From: root, To: a}, {From: a, To: b}}
+
+	out := root.DeepCopy()
+	ca, cb := out.Children[0], out.Children[1]
+	if out == root || ca == a || cb == b || out.Weight == &weight || out.Owner == owner {
+		t.Fatalf("the pointers are not copied")
+	}
+	if ca.Parent != out || cb.Parent != out || ca.Next != cb || cb.Prev != ca {
+		t.Errorf("the cycles are not preserved")
+	}
+	if out.ByName["a"] != ca || out.ByName["b"] != cb || out.ByName["self"] != out {
+		t.Errorf("the map values are not the copied nodes")
+	}
+	if out.Edges[0].From != out || out.Edges[0].To != ca || out.Edges[1].From != ca || out.Edges[1].To != cb {
+		t.Errorf("the edges do not link the copied nodes")
+	}
+	if ca.Weight != out.Weight || ca.Owner != out.Owner || *out.Weight != 3 || out.Owner.Str != "owner" {
+		t.Errorf("the shared pointers are not shared in the copy")
+	}
+
+	// A value copied into is recorded too: the cycles back to it end at out.
+	var into Node
+	a.DeepCopyInto(&into)
+	if into.Next.Prev != &into || into.Parent.Children[0] != &into {
+		t.Errorf("the cycles through the copied value are not preserved")
+	}
+}
+
+func TestArrayDeepCopy(t *testing.T) {
+	t1 := &T1{Str: "t1"}
+	in := &Grid{
+		ID:      ID{1, 2},
+		Raw:     [4]byte{3},
+		Cells:   [2][3]int{{1, 2, 3}, {4}},
+		Nodes:   [4]*T1{t1, nil, {Int16: 2}},
+		Rows:    [2][]string{{"a"}, nil},
+		Nested:  [2][2]*T1{{t1}, {nil, t1}},
+		Items:   [2]T2{{Tags: []string{"x"}}},
+		ByKey:   map[string][2]*T1{"k": {t1}},
+		Ptr:     &[2]*T1{t1},
+		Shallow: &[3]int{7},
+		Slices:  [][2]*T1{{t1, t1}},
+		IDs:     []ID{{9}},
+		Values:  [2]interface{}{[]int{1}},
+	}
+	out := in.DeepCopy()
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("expected %+v, got %+v", in, out)
+	}
+
+	out.Nodes[0].Str = "changed"
+	out.Rows[0][0] = "changed"
+	out.Nested[1][1].Str = "changed"
+	out.Items[0].Tags[0] = "changed"
+	out.ByKey["k"][0].Str = "changed"
+	out.Ptr[0].Str = "changed"
+	out.Shallow[0] = 8
+	out.Slices[0][1].Str = "changed"
+	out.IDs[0][0] = 10
+	out.Values[0].([]int)[0] = 2
+	if t1.Str != "t1" || in.Rows[0][0] != "a" || in.Items[0].Tags[0] != "x" || in.Shallow[0] != 7 ||
+		in.IDs[0][0] != 9 || in.Values[0].([]int)[0] != 1 {
+		t.Errorf("changing the copy changed the original: %+v", in)
+	}
+
+	id := ID{5}
+	if c := id.DeepCopy(); *c != id || c == &id {
+		t.Errorf("unexpected copy %v of %v", c, id)
+	}
+}
+
+func TestChanFuncPolicies(t *testing.T) {
+	events := make(chan string, 1)
+	calls := 0
+	callback := func() { calls++ }
+	in := &Watcher{
+		Name:     "w",
+		Events:   events,
+		Done:     make(chan struct{}),
+		OnChange: func(string) { calls++ },
+		Filters:  []func(string) bool{nil, func(string) bool { return true }},
+		Handlers: map[string]func(){"a": callback},
+		Closers:  map[string]func() error{"a": nil, "b": func() error { return nil }},
+		Callback: &callback,
+		Queues:   [2]chan int{make(chan int)},
+		Recvs:    []<-chan int{make(chan int)},
+		Sends:    map[string]chan<- int{"a": make(chan int)},
+	}
+	out := in.DeepCopy()
+
+	if out.Name != "w" || out.Events != events || out.OnChange == nil || out.Done != nil {
+		t.Errorf("unexpected copy %+v", out)
+	}
+	if len(out.Filters) != 2 || out.Filters[1] != nil {
+		t.Errorf("expected nil filters, got %v", out.Filters)
+	}
+	if len(out.Closers) != 2 || out.Closers["b"] != nil {
+		t.Errorf("expected nil closers, got %v", out.Closers)
+	}
+	if out.Queues[0] != nil {
+		t.Errorf("expected nil queues, got %v", out.Queues)
+	}
+	if len(out.Recvs) != 1 || out.Recvs[0] != in.Recvs[0] {
+		t.Errorf("expected the shared receive channels, got %v", out.Recvs)
+	}
+	if len(out.Sends) != 1 || out.Sends["a"] != nil {
+		t.Errorf("expected nil send channels, got %v", out.Sends)
+	}
+
+	// The shared channels and functions are the same.
+	out.Events <- "changed"
+	if e := <-in.Events; e != "changed" {
+		t.Errorf("expected the shared channel, got %q", e)
+	}
+	out.OnChange("")
+	out.Handlers["a"]()
+	(*out.Callback)()
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+
+	// The containers are copies.
+	out.Handlers["b"] = nil
+	*out.Callback = nil
+	if len(in.Handlers) != 1 || *in.Callback == nil {
+		t.Errorf("changing the copy changed the original: %+v", in)
+	}
+}
+
+func TestGraphDeepEqual(t *testing.T) {
+	root := &Node{Name: "root"}
+	a := &Node{Name: "a", Parent: root}
+	b := &Node{Name: "b", Parent: root, Prev: a}
+	a.Next = b
+	root.Children = []*Node{a, b}
+	root.ByName = map[string]*Node{"self": root}
+	root.Edges = []Edge{{From: root, To: a}}
+
+	// The comparisons end on the cycles.
+	out := root.DeepCopy()
+	if !root.DeepEqual(out) || !out.DeepEqual(root) {
+		t.Fatal("expected the copy of the graph to be equal")
+	}
+	out.Children[1].Prev.Name = "changed"
+	if root.DeepEqual(out) {
+		t.Error("expected a change reached through a cycle to be found")
+	}
+	if reflect.DeepEqual(root, out) {
+		t.Error("expected reflect.DeepEqual to find the change too")
+	}
+
+	// The diffs end on the cycles too, and report the change reached
+	// through several paths once.
+	expect := []deepcopy.FieldChange{{Path: "Children[0].Name", Old: "a", New: "changed"}}
+	if changes := root.Diff(out); !reflect.DeepEqual(changes, expect) {
+		t.Errorf("expected %v, got %v", expect, changes)
+	}
+	if changes := root.Diff(root.DeepCopy()); changes != nil {
+		t.Errorf("expected no change, got %v", changes)
+	}
+}
+
+func TestUnexportedDeepCopy(t *testing.T) {
+	in := &Stats{
+		Current: &counter{Counts: map[string]int{"a": 1}, Last: &T1{Str: "last"}},
+		History: []counter{{Counts: map[string]int{"b": 2}}},
+		ByName:  map[string]counter{"c": {Last: &T1{Str: "c"}}},
+	}
+	out := in.DeepCopy()
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("expected %+v, got %+v", in, out)
+	}
+
+	out.Current.Counts["a"] = 2
+	out.Current.Last.Str = "changed"
+	out.History[0].Counts["b"] = 3
+	out.ByName["c"].Last.Str = "changed"
+	if in.Current.Counts["a"] != 1 || in.Current.Last.Str != "last" || in.History[0].Counts["b"] != 2 || in.ByName["c"].Last.Str != "c" {
+		t.Errorf("changing the copy changed the original: %+v", in)
+	}
+
+	// Only the unexported types opting in are generated.
+	if _, found := reflect.TypeOf(&hidden{}).MethodByName("DeepCopy"); found {
+		t.Errorf("expected no DeepCopy method for hidden")
+	}
+}
+
+func testVersion() *Version {
+	note := "first"
+	return &Version{
+		Name:      "v1",
+		Tags:      []string{"a"},
+		Labels:    Labels{"app": "web"},
+		Owner:     &T1{Str: "owner"},
+		Items:     []T2{testT2(), {}},
+		ByName:    map[string]*T1{"x": {Str: "x"}, "nil": nil},
+		Revision:  Revision{Number: 1, Note: &note},
+		Revisions: []*Revision{{Number: 2}, nil},
+		Grid:      [2][]int{{1, 2}, nil},
+		Flags:     map[bool]string{true: "on"},
+		Value:     []int{3},
+	}
+}
+
+// versionChanges change the versions returned by testVersion.
+var versionChanges = []func(v *Version){
+	func(v *Version) {},
+	func(v *Version) { v.Name = "v2" },
+	func(v *Version) { v.Labels["app"] = "db" },
+	func(v *Version) { v.Labels = nil },
+	func(v *Version) { v.Owner.Str = "changed" },
+	func(v *Version) { v.Owner = nil },
+	func(v *Version) { v.Items[0].Tags[1] = "changed" },
+	func(v *Version) { v.Items[0].ByName["nil"] = &T1{} },
+	func(v *Version) { v.Items[1].Tags = []string{} },
+	func(v *Version) { v.Items = v.Items[:1] },
+	func(v *Version) { v.ByName["x"].Int16 = 1 },
+	func(v *Version) { delete(v.ByName, "nil"); v.ByName["other"] = nil },
+	func(v *Version) { v.Revisions[1] = &Revision{} },
+	func(v *Version) { v.Grid[1] = []int{} },
+	func(v *Version) { v.Grid[0][1] = 3 },
+	func(v *Version) { v.Value = []int{4} },
+}
+
+func TestDeepEqual(t *testing.T) {
+	for i, change := range versionChanges {
+		in, other := testVersion(), testVersion()
+		change(other)
+		want := reflect.DeepEqual(in, other)
+		if got := in.DeepEqual(other); got != want {
+			t.Errorf("case[%d]: expected %t, got %t", i, want, got)
+		}
+		if got := other.DeepEqual(in); got != want {
+			t.Errorf("case[%d]: expected %t for the reverse comparison, got %t", i, want, got)
+		}
+	}
+
+	var nilVersion *Version
+	if !nilVersion.DeepEqual(nil) || nilVersion.DeepEqual(testVersion()) || testVersion().DeepEqual(nil) {
+		t.Errorf("unexpected comparison with nil")
+	}
+}
+
+func TestDeepEqualSemantics(t *testing.T) {
+	in, other := testVersion(), testVersion()
+
+	// The nil and empty Tags are equal.
+	in.Tags, other.Tags = nil, []string{}
+	if !in.DeepEqual(other) {
+		t.Errorf("expected nil and empty tags to be equal")
+	}
+
+	// The hand-written Revision.DeepEqual ignores the notes.
+	note := "second"
+	other.Revision.Note = &note
+	if !in.DeepEqual(other) {
+		t.Errorf("expected Revision.DeepEqual to be called")
+	}
+
+	// Like reflect.DeepEqual, non-nil functions are never equal.
+	in.OnChange = func() {}
+	other.OnChange = in.OnChange
+	if in.DeepEqual(other) {
+		t.Errorf("expected functions not to be equal")
+	}
+
+	if !(Labels{}).DeepEqual(Labels{}) || (Labels{}).DeepEqual(nil) {
+		t.Errorf("unexpected comparison of labels")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	for i, change := range versionChanges {
+		in, other := testVersion(), testVersion()
+		change(other)
+		// Diff reports no change for the equal versions only.
+		if changes, equal := in.Diff(other), in.DeepEqual(other); (len(changes) == 0) != equal {
+			t.Errorf("case[%d]: unexpected changes %v for equal=%t", i, changes, equal)
+		}
+	}
+
+	in, other := testVersion(), testVersion()
+	other.Labels["app"] = "db"
+	other.Labels["tier"] = "front"
+	other.Owner.Str = "changed"
+	other.Items[0].Tags = append(other.Items[0].Tags[:1], "c", "d")
+	other.Items[1].ByName = map[string]*T1{"y": nil}
+	delete(other.ByName, "x")
+	other.Revisions[1] = &Revision{Number: 3}
+	other.Grid[1] = []int{}
+	other.Flags[true] = "yes"
+	other.Flags[false] = "off"
+	expect := []deepcopy.FieldChange{
+		{Path: `Labels["app"]`, Old: "web", New: "db"},
+		{Path: `Labels["tier"]`, New: "front"},
+		{Path: "Owner.Str", Old: "owner", New: "changed"},
+		{Path: "Items[0].Tags[1]", Old: "b", New: "c"},
+		{Path: "Items[0].Tags[2]", New: "d"},
+		{Path: `Items[1].ByName["y"]`, New: (*T1)(nil)},
+		{Path: `ByName["x"]`, Old: in.ByName["x"]},
+		{Path: "Revisions[1]", Old: (*Revision)(nil), New: other.Revisions[1]},
+		{Path: "Grid[1]", Old: []int(nil), New: []int{}},
+		{Path: "Flags[false]", New: "off"},
+		{Path: "Flags[true]", Old: "on", New: "yes"},
+	}
+	if changes := in.Diff(other); !reflect.DeepEqual(changes, expect) {
+		t.Errorf("expected %v, got %v", expect, changes)
+	}
+
+	var nilVersion *Version
+	expect = []deepcopy.FieldChange{{Old: nilVersion, New: in}}
+	if changes := nilVersion.Diff(in); !reflect.DeepEqual(changes, expect) {
+		t.Errorf("expected %v, got %v", expect, changes)
+	}
+	if changes := in.Diff(in); changes != nil {
+		t.Errorf("expected no change, got %v", changes)
+	}
+}
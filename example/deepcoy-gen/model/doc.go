@@ -1,7 +1,7 @@
 // model test model structs
 //
-// gengo:deepcopy=package
+// +gengo:deepcopy=package,register
 //
 package model
 
-//go:generate deepcopy-gen -i github.com/zhaolion/gengo/example/marshal-gen/model
+//go:generate deepcopy-gen -i github.com/zhaolion/gengo/example/deepcoy-gen/model
@@ -1,5 +1,7 @@
 package model
 
+// +gengo:deepequal=true
+// +gengo:diff=true
 type T1 struct {
 	Byte byte
 	//Int8    int8 //TODO: int8 becomes byte in SnippetWriter
@@ -14,3 +16,197 @@ type T1 struct {
 	Float64 float64
 	Str     string
 }
+
+// Labels is a map: its DeepCopy method has a value receiver.
+// +gengo:deepequal=true
+// +gengo:diff=true
+type Labels map[string]string
+
+// +gengo:deepequal=true
+// +gengo:diff=true
+type T2 struct {
+	Labels Labels
+	Tags   []string
+	First  *T1
+	Items  []T1
+	ByName map[string]*T1
+}
+
+// Stringer is a named interface: its values are copied by DeepCopyStringer.
+type Stringer interface {
+	String() string
+	DeepCopyStringer() Stringer
+}
+
+// Name implements Stringer.
+type Name struct {
+	Value *string
+}
+
+func (n *Name) String() string {
+	if n.Value == nil {
+		return ""
+	}
+	return *n.Value
+}
+
+// DeepCopyStringer implements Stringer.
+func (n *Name) DeepCopyStringer() Stringer {
+	return n.DeepCopy()
+}
+
+// T3 holds interfaces copied with the strategies tagged on its fields.
+type T3 struct {
+	// +gengo:deepcopy:interface=reflect
+	Value interface{}
+	// +gengo:deepcopy:interface=share
+	Shared interface{}
+	// +gengo:deepcopy:interface=registry
+	Registered interface{}
+	// +gengo:deepcopy:interface=reflect
+	Values []interface{}
+	// +gengo:deepcopy:interface=share
+	SharedValues []interface{}
+	// +gengo:deepcopy:interface=registry
+	ByName map[string]interface{}
+	// +gengo:deepcopy:interface=reflect
+	Lengther interface {
+		Len() int
+		Close(force ...bool) error
+	}
+	Stringer Stringer
+	// +gengo:deepcopy:interface=share
+	SharedStringer Stringer
+}
+
+// Node is a node of a tree whose nodes point to their parent, and to their
+// siblings as in a doubly linked list. Its copies keep the cycles and the
+// shared pointers, and its comparisons and diffs end on the cycles.
+// +gengo:deepcopy:graph=true
+// +gengo:deepequal=true
+// +gengo:diff=true
+type Node struct {
+	Name     string
+	Parent   *Node
+	Children []*Node
+	ByName   map[string]*Node
+	Prev     *Node
+	Next     *Node
+	Weight   *int
+	Owner    *T1
+	Labels   Labels
+	Edges    []Edge
+}
+
+// Edge links two nodes of a graph.
+// +gengo:deepcopy:graph=true
+// +gengo:deepequal=true
+// +gengo:diff=true
+type Edge struct {
+	From, To *Node
+}
+
+// ID is a fixed-size array of shallow elements: it is copied by value.
+type ID [16]byte
+
+// Grid holds arrays of shallow and of deep elements.
+type Grid struct {
+	ID      ID
+	Raw     [4]byte
+	Cells   [2][3]int
+	Nodes   [4]*T1
+	Rows    [2][]string
+	Nested  [2][2]*T1
+	Items   [2]T2
+	ByKey   map[string][2]*T1
+	Ptr     *[2]*T1
+	Shallow *[3]int
+	Slices  [][2]*T1
+	IDs     []ID
+	// +gengo:deepcopy:interface=reflect
+	Values [2]interface{}
+}
+
+// Watcher holds channels and functions, which cannot be deep-copied: they are
+// copied with the policies tagged on its fields.
+type Watcher struct {
+	Name string
+	// +gengo:deepcopy:chan-func=share
+	Events chan string
+	// +gengo:deepcopy:chan-func=zero
+	Done chan struct{}
+	// +gengo:deepcopy:chan-func=share
+	OnChange func(string)
+	// +gengo:deepcopy:chan-func=zero
+	Filters []func(string) bool
+	// +gengo:deepcopy:chan-func=share
+	Handlers map[string]func()
+	// +gengo:deepcopy:chan-func=zero
+	Closers map[string]func() error
+	// +gengo:deepcopy:chan-func=share
+	Callback *func()
+	// +gengo:deepcopy:chan-func=zero
+	Queues [2]chan int
+	// +gengo:deepcopy:chan-func=share
+	Recvs []<-chan int
+	// +gengo:deepcopy:chan-func=zero
+	Sends map[string]chan<- int
+}
+
+// counter is unexported: it has deep-copy functions because it opts in.
+// +gengo:deepcopy=true
+type counter struct {
+	Counts map[string]int
+	Last   *T1
+}
+
+// hidden is unexported and does not opt in: it has no deep-copy functions.
+type hidden struct {
+	Values []int
+}
+
+// Stats holds values of unexported types.
+type Stats struct {
+	Current *counter
+	History []counter
+	ByName  map[string]counter
+}
+
+// Revision has a hand-written DeepEqual method, which the generated DeepEqual
+// methods call: revisions with the same number are equal.
+type Revision struct {
+	Number int
+	Note   *string
+}
+
+// DeepEqual reports whether r and other have the same number.
+func (r *Revision) DeepEqual(other *Revision) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+	return r.Number == other.Number
+}
+
+// Version has generated DeepEqual and Diff methods. Its nil and empty Tags are
+// equal, as opposed to its other slices and maps.
+// +gengo:deepequal=true
+// +gengo:diff=true
+type Version struct {
+	Name string
+	// +gengo:deepequal:nil-is-empty=true
+	Tags      []string
+	Labels    Labels
+	Owner     *T1
+	Items     []T2
+	ByName    map[string]*T1
+	Revision  Revision
+	Revisions []*Revision
+	Grid      [2][]int
+	// Flags has keys which cannot be compared with <: its changes are
+	// sorted by their paths.
+	Flags map[bool]string
+	// +gengo:deepcopy:interface=reflect
+	Value interface{}
+	// +gengo:deepcopy:chan-func=share
+	OnChange func()
+}
@@ -0,0 +1,117 @@
+package model
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zhaolion/gengo/example/marshal-gen/shared"
+	"github.com/zhaolion/gengo/marshal"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	level := LevelError
+	testCases := []*Session{
+		{},
+		{
+			ID:      "s",
+			Visits:  -3,
+			Color:   DarkBlue,
+			Level:   &level,
+			Expires: time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC),
+			Items:   []SessionItem{{Name: "a", Count: 2, Flags: []bool{true, false}}, {Flags: []bool{}}},
+			ByName:  map[string]*SessionItem{"b": {Count: 1}, "nil": nil},
+			Scores:  [2]float64{-1, 0.5},
+			Matrix:  [][]int16{nil, {}, {-1, 1}},
+			Data:    []byte{},
+			Home:    &shared.Address{City: "c"},
+			Ratio:   0.25,
+			Seen:    map[Level]bool{LevelInfo: true, LevelDebug: false},
+			Deltas:  []int8{-1, 0, 127},
+		},
+		{Items: []SessionItem{}, ByName: map[string]*SessionItem{}, Data: []byte{0, 1}, Home: &shared.Address{}},
+	}
+
+	for i, tc := range testCases {
+		data, err := tc.MarshalBinary()
+		if err != nil {
+			t.Fatalf("case[%d]: %v", i, err)
+		}
+		got := &Session{Cache: "reset"}
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Fatalf("case[%d]: %v", i, err)
+		}
+		if !reflect.DeepEqual(got, tc) {
+			t.Errorf("case[%d]: expected %+v, got %+v", i, tc, got)
+		}
+		again, _ := got.MarshalBinary()
+		if !bytes.Equal(again, data) {
+			t.Errorf("case[%d]: encoding is not deterministic: %x, then %x", i, data, again)
+		}
+	}
+}
+
+func TestBinaryEncoding(t *testing.T) {
+	data, err := (&Session{ID: "a", Visits: -1, Cache: "left out"}).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The version, then the tags and values of ID and Visits, then the
+	// tag and encoding of the zero Expires.
+	expires, _ := time.Time{}.MarshalBinary()
+	want := append([]byte{marshal.BinaryVersion, 1<<2 | 3, 1, 'a', 2 << 2, 1, 6<<2 | 3, byte(len(expires))}, expires...)
+	// The scores are always written.
+	want = append(want, 9<<2|3, 16)
+	want = append(want, make([]byte, 16)...)
+	if !bytes.Equal(data, want) {
+		t.Errorf("expected %x, got %x", want, data)
+	}
+}
+
+func TestBinarySchemaChanges(t *testing.T) {
+	// Readers of the first version skip the fields added since.
+	data, err := (&Session{ID: "a", Visits: 2, Items: []SessionItem{{Name: "b"}}, Home: &shared.Address{}}).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var old SessionV1
+	if err := old.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if want := (SessionV1{ID: "a", Visits: 2}); old != want {
+		t.Errorf("expected %+v, got %+v", want, old)
+	}
+
+	// Readers of the current version skip the removed token, and leave the
+	// fields missing from the first version to their zero value.
+	data, err = (&SessionV1{ID: "a", Visits: 2, Token: "t"}).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var current Session
+	if err := current.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if want := (&Session{ID: "a", Visits: 2}); !reflect.DeepEqual(&current, want) {
+		t.Errorf("expected %+v, got %+v", want, current)
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	valid, _ := (&Session{ID: "a", Items: []SessionItem{{Name: "b"}}}).MarshalBinary()
+	testCases := [][]byte{
+		nil,
+		{marshal.BinaryVersion + 1},
+		valid[:len(valid)-1],
+		// A field of an unexpected wire type, then a truncated value.
+		{marshal.BinaryVersion, 1 << 2, 0x80},
+		// A count larger than the data.
+		{marshal.BinaryVersion, 7<<2 | 3, 1, 100},
+	}
+	for i, data := range testCases {
+		if err := new(Session).UnmarshalBinary(data); err == nil {
+			t.Errorf("case[%d]: expected an error decoding %x", i, data)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+func testConfig() *Config {
+	timeout := 2.5
+	return &Config{
+		ConfigBase: ConfigBase{Version: 2},
+		Name:       "api",
+		Port:       8080,
+		Debug:      true,
+		Level:      LevelError,
+		Timeout:    &timeout,
+		Started:    time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Listeners:  []Listener{{Address: "a:1", TLS: true}, {Address: "b:2"}},
+		Primary:    &Listener{Address: "p:3"},
+		Labels:     map[string]string{"zone": "z", "app": "x"},
+		Palette:    []Color{DarkBlue, Red},
+		Secret:     "left out",
+	}
+}
+
+func TestMarshalYAML(t *testing.T) {
+	data, err := yaml.Marshal(testConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# Version of the configuration format.
+version: 2
+# Name identifies the service.
+name: api
+# Port is the port to listen on.
+# Zero picks a free one.
+port: 8080
+# Debug enables verbose logs.
+debug: "true"
+level: err
+timeout: 2.5
+started: "2020-01-02T03:04:05Z"
+# Listeners are tried in order.
+listeners:
+    - # Address is a host:port pair.
+      address: a:1
+      tls: true
+    - # Address is a host:port pair.
+      address: b:2
+primary:
+    # Address is a host:port pair.
+    address: p:3
+labels:
+    app: x
+    zone: z
+palette:
+    - dark-blue
+    - Red
+`
+	if string(data) != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, data)
+	}
+}
+
+func TestYAMLRoundTrip(t *testing.T) {
+	testCases := []*Config{testConfig(), {}}
+	for i, tc := range testCases {
+		data, err := yaml.Marshal(tc)
+		if err != nil {
+			t.Fatalf("case[%d]: %v", i, err)
+		}
+		got := &Config{}
+		if err := yaml.Unmarshal(data, got); err != nil {
+			t.Fatalf("case[%d]: %v", i, err)
+		}
+		want := *tc
+		want.Secret = ""
+		if !reflect.DeepEqual(got, &want) {
+			t.Errorf("case[%d]: expected %+v, got %+v", i, want, got)
+		}
+	}
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	// Keys match fields case-insensitively, unknown keys and the excluded
+	// field are ignored.
+	data := `
+NAME: api
+Version: 3
+debug: "false"
+secret: s
+unknown: [1, 2]
+primary: {address: p}
+timeout: null
+`
+	got := Config{Port: 1, Timeout: new(float64)}
+	if err := yaml.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := Config{ConfigBase: ConfigBase{Version: 3}, Name: "api", Port: 1, Primary: &Listener{Address: "p"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	for _, data := range []string{"[1]", "debug: true", "port: x"} {
+		if err := yaml.Unmarshal([]byte(data), &got); err == nil {
+			t.Errorf("expected an error decoding %q", data)
+		}
+	}
+}
+
+func TestMarshalTOML(t *testing.T) {
+	data, err := testConfig().MarshalTOML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# Version of the configuration format.
+version = 2
+# Name identifies the service.
+name = "api"
+# Port is the port to listen on.
+# Zero picks a free one.
+port = 8080
+# Debug enables verbose logs.
+debug = "true"
+level = "err"
+timeout = 2.5
+started = 2020-01-02T03:04:05Z
+palette = ["dark-blue", "Red"]
+
+# Listeners are tried in order.
+[[listeners]]
+# Address is a host:port pair.
+address = "a:1"
+tls = true
+
+[[listeners]]
+# Address is a host:port pair.
+address = "b:2"
+
+[primary]
+# Address is a host:port pair.
+address = "p:3"
+
+[labels]
+app = "x"
+zone = "z"
+`
+	if string(data) != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, data)
+	}
+}
+
+func TestTOMLRoundTrip(t *testing.T) {
+	testCases := []*Config{testConfig(), {Listeners: []Listener{}, Palette: []Color{}}}
+	for i, tc := range testCases {
+		data, err := tc.MarshalTOML()
+		if err != nil {
+			t.Fatalf("case[%d]: %v", i, err)
+		}
+		got := &Config{}
+		if _, err := toml.Decode(string(data), got); err != nil {
+			t.Fatalf("case[%d]: %v\n%s", i, err, data)
+		}
+		want := *tc
+		want.Secret = ""
+		if !reflect.DeepEqual(got, &want) {
+			t.Errorf("case[%d]: expected %+v, got %+v", i, want, got)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zhaolion/gengo/example/marshal-gen/shared"
+)
+
+type jsonCodec interface {
+	EncodeJSON(w io.Writer) error
+	DecodeJSON(r io.Reader) error
+	UnmarshalJSONBinaryStrict(data []byte) error
+}
+
+func TestEncodeJSONConformance(t *testing.T) {
+	note := "note <b>\"quoted\"</b>"
+	green := Green
+	testCases := []jsonCodec{
+		&T1{},
+		&T1{Int16: -1, Float32: 0.1, Float64: 1e21, Str: " <>&\xff"},
+		// int8 values are numbers, and []int8 is not a byte string.
+		&T1{Int8: -5, Offset: -128, Int8s: []int8{-1, 0, 127}},
+		&T4{},
+		&T4{Name: "a", T1: &T1{}, Items: []T1{}, ByKey: map[string]*T1{"k": nil}},
+		&T5{},
+		&T5{
+			Base:     Base{ID: -12, Kind: "base", Name: "hidden by T5.Name"},
+			Meta:     &Meta{},
+			Left:     Left{Dup: "left", Pick: "left"},
+			Right:    Right{Dup: "right", Pick: "right", Only: 3},
+			inner:    inner{Hidden: "hidden"},
+			innerPtr: &innerPtr{Unreachable: "reachable"},
+			Named:    Named{X: 4},
+			Name:     "name",
+			Skipped:  "skipped",
+			Dash:     "dash",
+			Count:    5,
+			Ratio:    math.Copysign(0, -1), // empty, like positive zero
+			Enabled:  true,
+			Title:    "a \"title\" <with> html",
+			T1:       &T1{Uint64: math.MaxUint64},
+			List:     []string{"a", "b"},
+			private:  "private",
+		},
+		&T5{Meta: &Meta{Labels: map[string]string{"b": "1", "a": "2"}, Note: &note}, Ratio: 0.5, List: []string{}},
+		&T6{},
+		&T6{Color: DarkBlue, Level: LevelError, Palette: []Color{Green, Default}, ByLevel: map[Level]int{LevelInfo: 1, LevelDebug: 2}, Ptr: &green},
+		&T7{},
+		// The types of the peer package are written by their generated methods.
+		&T7{Home: shared.Address{City: "c", Zip: "z"}, Work: &shared.Address{}, Kind: shared.KindWork, Kinds: map[shared.Kind]bool{shared.KindHome: true}},
+	}
+
+	for i, obj := range testCases {
+		expect, err := json.Marshal(obj)
+		if err != nil {
+			t.Fatalf("case[%d]: json.Marshal: %v", i, err)
+		}
+		var buf bytes.Buffer
+		if err := obj.EncodeJSON(&buf); err != nil {
+			t.Errorf("case[%d]: EncodeJSON: %v", i, err)
+			continue
+		}
+		if buf.String() != string(expect) {
+			t.Errorf("case[%d]: expected %s, got %s", i, expect, buf.String())
+		}
+	}
+}
+
+func TestDecodeJSONConformance(t *testing.T) {
+	testCases := []struct {
+		data   string
+		newObj func() jsonCodec
+	}{
+		{data: `{"Str":"exact","str":"folded","INT16":3}`, newObj: func() jsonCodec { return &T1{} }},
+		{data: `{"Int8":-5,"Offset":-128,"Int8s":[-1,0,127]}`, newObj: func() jsonCodec { return &T1{} }},
+		{data: `{"name":"a","NAME":"b","T1":{"byte":1},"By_Key":{"k":null}}`, newObj: func() jsonCodec { return &T4{} }},
+		{data: `{"id":"42","Kind":"k","labels":{"a":"b"},"note":"\"n\"","Pick":"p","pick":"q","only":7}`, newObj: func() jsonCodec { return &T5{} }},
+		{data: `{"Dup":"ignored","hidden":"h","named":{"X":1},"X":2,"-":"dash","Skipped":"no"}`, newObj: func() jsonCodec { return &T5{} }},
+		{data: `{"Count":-1,"ratio":-0,"enabled":"true","title":"\"t\"","t1":{},"list":["a"]}`, newObj: func() jsonCodec { return &T5{} }},
+		{data: `{"note":null,"id":null,"enabled":null}`, newObj: func() jsonCodec { return &T5{} }},
+		{data: `{"ΣIGMA":1,"σigma":2}`, newObj: func() jsonCodec { return &T5{} }},
+		{data: `{"HOME":{"City":"c"},"work":{"zip":"z"},"kind":"home","kinds":{"work":false}}`, newObj: func() jsonCodec { return &T7{} }},
+		{data: `{"work":null}`, newObj: func() jsonCodec { return &T7{Work: &shared.Address{}} }},
+	}
+
+	for i, tc := range testCases {
+		expect := tc.newObj()
+		if err := json.Unmarshal([]byte(tc.data), expect); err != nil {
+			t.Fatalf("case[%d]: json.Unmarshal: %v", i, err)
+		}
+
+		got := tc.newObj()
+		if err := got.DecodeJSON(strings.NewReader(tc.data)); err != nil {
+			t.Errorf("case[%d]: DecodeJSON: %v", i, err)
+		} else if !reflect.DeepEqual(got, expect) {
+			t.Errorf("case[%d]: DecodeJSON: expected %+v, got %+v", i, expect, got)
+		}
+	}
+}
+
+func TestDecodeJSONConformanceErrors(t *testing.T) {
+	testCases := []string{
+		`{"id":42}`,
+		`{"enabled":"yes"}`,
+		`{"unreachable":"x"}`,
+	}
+
+	for i, data := range testCases {
+		if err := json.Unmarshal([]byte(data), &T5{}); err == nil {
+			t.Fatalf("case[%d]: expected json.Unmarshal to fail", i)
+		}
+		if err := (&T5{}).DecodeJSON(strings.NewReader(data)); err == nil {
+			t.Errorf("case[%d]: expected DecodeJSON to fail", i)
+		}
+		if err := (&T5{}).UnmarshalJSONBinaryStrict([]byte(data)); err == nil {
+			t.Errorf("case[%d]: expected UnmarshalJSONBinaryStrict to fail", i)
+		}
+	}
+}
+
+func TestUnmarshalJSONBinaryStrictConformance(t *testing.T) {
+	data := `{"ID":"42","labels":{"a":"b"},"note":"\"n\"","Pick":"p","hidden":"h","named":{"X":1},"-":"dash","Count":1,"enabled":"false","title":"\"t\""}`
+
+	expect := &T5{}
+	if err := json.Unmarshal([]byte(data), expect); err != nil {
+		t.Fatal(err)
+	}
+	got := &T5{}
+	if err := got.UnmarshalJSONBinaryStrict([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("expected %+v, got %+v", expect, got)
+	}
+}
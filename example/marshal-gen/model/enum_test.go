@@ -0,0 +1,112 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestColorEnum(t *testing.T) {
+	testCases := []struct {
+		color Color
+		name  string
+	}{
+		{Red, "Red"},
+		{Green, "Green"},
+		{DarkBlue, "dark-blue"},
+		{Default, "Red"},
+	}
+	for _, tc := range testCases {
+		if s := tc.color.String(); s != tc.name {
+			t.Errorf("%d: expected String %q, got %q", tc.color, tc.name, s)
+		}
+		text, err := tc.color.MarshalText()
+		if err != nil || string(text) != tc.name {
+			t.Errorf("%d: expected MarshalText %q, got %q, %v", tc.color, tc.name, text, err)
+		}
+		color, err := ParseColor(tc.name)
+		if err != nil || color != tc.color {
+			t.Errorf("ParseColor(%q): expected %d, got %d, %v", tc.name, tc.color, color, err)
+		}
+	}
+
+	if color, err := ParseColor("Default"); err != nil || color != Red {
+		t.Errorf("ParseColor(\"Default\"): expected Red, got %v, %v", color, err)
+	}
+	if _, err := ParseColor("DarkBlue"); err == nil {
+		t.Error("expected an error parsing the identifier of a renamed constant")
+	}
+	if values := ColorValues(); !reflect.DeepEqual(values, []Color{Red, Green, DarkBlue}) {
+		t.Errorf("unexpected ColorValues %v", values)
+	}
+}
+
+func TestLevelEnum(t *testing.T) {
+	if values := LevelValues(); !reflect.DeepEqual(values, []Level{LevelDebug, LevelInfo, LevelError}) {
+		t.Errorf("unexpected LevelValues %v", values)
+	}
+	for level, name := range map[Level]string{LevelDebug: "debug", LevelInfo: "info", LevelError: "err"} {
+		if s := fmt.Sprint(level); s != name {
+			t.Errorf("expected %q, got %q", name, s)
+		}
+		if parsed, err := ParseLevel(name); err != nil || parsed != level {
+			t.Errorf("ParseLevel(%q): expected %q, got %q, %v", name, level, parsed, err)
+		}
+	}
+	if s := Level("trace").String(); s != `Level("trace")` {
+		t.Errorf("unexpected String of an unknown level: %s", s)
+	}
+}
+
+func TestEnumJSON(t *testing.T) {
+	obj := T6{Color: DarkBlue, Level: LevelError, Palette: []Color{Green}}
+	data, err := obj.MarshalJSONBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := `{"color":"dark-blue","level":"err","palette":["Green"]}`; string(data) != expect {
+		t.Errorf("expected %s, got %s", expect, data)
+	}
+	var out T6
+	if err := out.UnmarshalJSONBinary(data); err != nil || !reflect.DeepEqual(out, obj) {
+		t.Errorf("expected %v, got %v, %v", obj, out, err)
+	}
+
+	if err := out.UnmarshalJSONBinary([]byte(`{"color":"Blue"}`)); err == nil {
+		t.Error("expected an error reading an unknown name")
+	}
+	if err := out.UnmarshalJSONBinary([]byte(`{"color":1}`)); err == nil {
+		t.Error("expected an error reading a number")
+	}
+
+	// The values without a constant are written as their underlying value
+	// and read back.
+	unknown := T6{Color: Color(7), Level: Level("trace")}
+	expect, err := json.Marshal(&unknown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(expect), `"color":"7"`) {
+		t.Errorf("expected the color to be written as \"7\", got %s", expect)
+	}
+	if data, err := unknown.MarshalJSONBinary(); err != nil || string(data) != string(expect) {
+		t.Errorf("expected %s, got %s, %v", expect, data, err)
+	}
+	var buf bytes.Buffer
+	if err := unknown.EncodeJSON(&buf); err != nil || strings.TrimSpace(buf.String()) != string(expect) {
+		t.Errorf("expected EncodeJSON to write %s, got %s, %v", expect, buf.String(), err)
+	}
+	out = T6{}
+	if err := out.UnmarshalJSONBinary(expect); err != nil || !reflect.DeepEqual(out, unknown) {
+		t.Errorf("expected %v, got %v, %v", unknown, out, err)
+	}
+	if err := out.UnmarshalJSONBinary([]byte(`{"color":"99999999999999999999"}`)); err == nil {
+		t.Error("expected an error reading an out of range value")
+	}
+	if s := unknown.Color.String(); s != "Color(7)" {
+		t.Errorf("unexpected String of an unknown value: %s", s)
+	}
+}
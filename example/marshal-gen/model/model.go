@@ -1,10 +1,18 @@
-//go:generate marshal-gen -i github.com/zhaolion/gengo/example/marshal-gen/model
+//go:generate marshal-gen -i github.com/zhaolion/gengo/example/marshal-gen/model --extra-peer-dirs github.com/zhaolion/gengo/example/marshal-gen/shared --generate-tests --json-schema --openapi --proto --typescript
 
 package model
 
+import (
+	"encoding/json"
+	"encoding/xml"
+	"time"
+
+	"github.com/zhaolion/gengo/example/marshal-gen/shared"
+)
+
 type T1 struct {
-	Byte byte
-	//Int8    int8 //TODO: int8 becomes byte in SnippetWriter
+	Byte    byte
+	Int8    int8
 	Int16   int16
 	Int32   int32
 	Int64   int64
@@ -15,8 +23,13 @@ type T1 struct {
 	Float32 float32
 	Float64 float64
 	Str     string
+	Offset  Offset
+	Int8s   []int8
 }
 
+// Offset is a named int8, written as a number like int8.
+type Offset int8
+
 type Inner interface {
 	Function() float64
 	DeepCopyInner() Inner
@@ -27,8 +40,8 @@ type T2 struct {
 }
 
 type T3 struct {
-	Byte map[string]byte
-	//Int8    map[string]int8 //TODO: int8 becomes byte in SnippetWriter
+	Byte         map[string]byte
+	Int8         map[string]int8
 	Int16        map[string]int16
 	Int32        map[string]int32
 	Int64        map[string]int64
@@ -47,3 +60,328 @@ type T3 struct {
 	Struct       map[string]T1
 	StructPtr    map[string]*T2
 }
+
+// T4 is stored in a JSON column.
+// +gengo:marshal:sql=true
+// +gengo:marshal:openapi=true
+type T4 struct {
+	Name  string         `json:"name" marshal:"required"`
+	T1    *T1            `json:"t1,omitempty"`
+	Items []T1           `json:"items"`
+	ByKey map[string]*T1 `json:"by_key"`
+}
+
+// Event holds a payload of any JSON value.
+type Event struct {
+	Kind    string          `json:"kind"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// T5 follows the encoding/json struct tag rules: renamed keys, omitempty,
+// the string option, excluded members and promoted embedded fields.
+type T5 struct {
+	Base
+	*Meta
+	Left
+	Right
+	inner
+	*innerPtr
+	Named `json:"named"`
+
+	Name    string   `json:"name"`
+	Skipped string   `json:"-"`
+	Dash    string   `json:"-,"`
+	Count   int      `json:",omitempty"`
+	Ratio   float64  `json:"ratio,omitempty"`
+	Enabled bool     `json:"enabled,string"`
+	Title   string   `json:"title,string,omitempty"`
+	T1      *T1      `json:"t1,omitempty"`
+	List    []string `json:"list,omitempty"`
+	private string
+}
+
+// Base is embedded into T5.
+type Base struct {
+	ID   int64  `json:"id,string"`
+	Kind string `json:"kind,omitempty"`
+	// Name is hidden by the less nested T5.Name.
+	Name string `json:"name"`
+}
+
+// Meta is embedded into T5 by pointer, its fields are left out while nil.
+type Meta struct {
+	Labels map[string]string `json:"labels,omitempty"`
+	Note   *string           `json:"note,string"`
+}
+
+// Left and Right are embedded side by side into T5.
+type Left struct {
+	// Dup conflicts with Right.Dup, neither is encoded.
+	Dup string
+	// Pick wins over Right.Pick since it is tagged.
+	Pick string `json:"Pick"`
+}
+
+// Right is embedded into T5 next to Left.
+type Right struct {
+	Dup  string
+	Pick string
+	Only uint16 `json:"only"`
+}
+
+type inner struct {
+	Hidden string `json:"hidden"`
+}
+
+type innerPtr struct {
+	Unreachable string `json:"unreachable"`
+}
+
+// Named is embedded into T5 under a json tag, so its fields are not promoted.
+type Named struct {
+	X int
+}
+
+// Color is an enum: its values are written as the names of its constants.
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	// +gengo:marshal:enum-name=dark-blue
+	DarkBlue
+	// Default is another name of Red.
+	Default = Red
+)
+
+// Level is a string enum, its constants are named by their values.
+type Level string
+
+const (
+	LevelDebug Level = "debug"
+	LevelInfo  Level = "info"
+	LevelError Level = "error" // +gengo:marshal:enum-name=err
+)
+
+// T6 holds enums.
+// +gengo:marshal:openapi=true
+type T6 struct {
+	Color   Color         `json:"color"`
+	Level   Level         `json:"level,omitempty"`
+	Palette []Color       `json:"palette,omitempty"`
+	ByLevel map[Level]int `json:"by_level,omitempty"`
+	Ptr     *Color        `json:"ptr,omitempty"`
+}
+
+// T7 refers to a type of another package.
+// +gengo:marshal:openapi=true
+type T7 struct {
+	Home    shared.Address   `json:"home"`
+	Work    *shared.Address  `json:"work,omitempty"`
+	Contact map[string]Level `json:"contact"`
+	// Kind is an enum of the shared package: it is written through its
+	// generated MarshalText method.
+	Kind  shared.Kind          `json:"kind,omitempty"`
+	Kinds map[shared.Kind]bool `json:"kinds,omitempty"`
+}
+
+// T8 is a protobuf message.
+// +gengo:marshal:proto=true
+// +gengo:marshal:proto-reserved=4
+type T8 struct {
+	// +gengo:marshal:proto-field=1
+	Name string `json:"name"`
+	// +gengo:marshal:proto-field=2
+	Color Color `json:"color"`
+	// +gengo:marshal:proto-field=3
+	Level Level `json:"level,omitempty"`
+	// +gengo:marshal:proto-field=5
+	Next *T8 `json:"next,omitempty"`
+	// +gengo:marshal:proto-field=6
+	Items []T9 `json:"items,omitempty"`
+	// +gengo:marshal:proto-field=7
+	ByName map[string]*T9 `json:"by_name,omitempty"`
+	// +gengo:marshal:proto-field=8
+	Counts map[int32]uint64 `json:"counts,omitempty"`
+	// +gengo:marshal:proto-field=9
+	Nickname *string `json:"nickname,omitempty"`
+	// +gengo:marshal:proto-field=10
+	Raw []byte `json:"raw,omitempty"`
+	// +gengo:marshal:proto-field=11
+	Home shared.Address `json:"home"`
+	// +gengo:marshal:proto-field=12
+	Ratio float32 `json:"ratio"`
+	// +gengo:marshal:proto-field=13
+	Enabled bool `json:"enabled"`
+	// +gengo:marshal:proto-field=14
+	Scores []int64 `json:"scores,omitempty"`
+	// +gengo:marshal:proto-field=15
+	Offset int8 `json:"offset"`
+	// Cache is not part of the message.
+	// +gengo:marshal:proto-field=-
+	Cache string `json:"-"`
+}
+
+// T9 is a message T8 refers to.
+type T9 struct {
+	// +gengo:marshal:proto-field=1
+	ID uint32 `json:"id"`
+	// +gengo:marshal:proto-field=2
+	Tags []string `json:"tags,omitempty"`
+}
+
+// Session is stored in the binary format. Field 3, the former Token, must not
+// be used again.
+// +gengo:marshal:binary=true
+type Session struct {
+	// +gengo:marshal:binary-field=1
+	ID string `json:"id"`
+	// +gengo:marshal:binary-field=2
+	Visits int `json:"visits"`
+	// +gengo:marshal:binary-field=4
+	Color Color `json:"color"`
+	// +gengo:marshal:binary-field=5
+	Level *Level `json:"level,omitempty"`
+	// +gengo:marshal:binary-field=6
+	Expires time.Time `json:"expires"`
+	// +gengo:marshal:binary-field=7
+	Items []SessionItem `json:"items"`
+	// +gengo:marshal:binary-field=8
+	ByName map[string]*SessionItem `json:"by_name"`
+	// +gengo:marshal:binary-field=9
+	Scores [2]float64 `json:"scores"`
+	// +gengo:marshal:binary-field=10
+	Matrix [][]int16 `json:"matrix"`
+	// +gengo:marshal:binary-field=11
+	Data []byte `json:"data"`
+	// +gengo:marshal:binary-field=12
+	Home *shared.Address `json:"home,omitempty"`
+	// +gengo:marshal:binary-field=13
+	Ratio float32 `json:"ratio"`
+	// +gengo:marshal:binary-field=14
+	Seen map[Level]bool `json:"seen"`
+	// +gengo:marshal:binary-field=15
+	Deltas []int8 `json:"deltas"`
+	// +gengo:marshal:binary-field=-
+	Cache string `json:"-"`
+}
+
+// SessionItem is an item of a Session.
+type SessionItem struct {
+	// +gengo:marshal:binary-field=1
+	Name string `json:"name"`
+	// +gengo:marshal:binary-field=2
+	Count uint16 `json:"count"`
+	// +gengo:marshal:binary-field=3
+	Flags []bool `json:"flags"`
+}
+
+// SessionV1 is the first version of Session, as older readers know it.
+// +gengo:marshal:binary=true
+type SessionV1 struct {
+	// +gengo:marshal:binary-field=1
+	ID string `json:"id"`
+	// +gengo:marshal:binary-field=2
+	Visits int `json:"visits"`
+	// +gengo:marshal:binary-field=3
+	Token string `json:"token"`
+}
+
+// Config is a configuration file, written as YAML or TOML.
+// +gengo:marshal:yaml=true
+// +gengo:marshal:toml=true
+type Config struct {
+	ConfigBase
+
+	// Name identifies the service.
+	Name string `json:"name"`
+	// Port is the port to listen on.
+	// Zero picks a free one.
+	Port int `json:"port,omitempty"`
+	// Debug enables verbose logs.
+	Debug   bool      `json:"debug,string"`
+	Level   Level     `json:"level,omitempty"`
+	Timeout *float64  `json:"timeout,omitempty"`
+	Started time.Time `json:"started"`
+	// Listeners are tried in order.
+	Listeners []Listener        `json:"listeners"`
+	Primary   *Listener         `json:"primary,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Palette   []Color           `json:"palette"`
+	Secret    string            `json:"-"`
+}
+
+// ConfigBase is embedded into Config, its fields are promoted.
+type ConfigBase struct {
+	// Version of the configuration format.
+	Version int `json:"version"`
+}
+
+// Listener is an address a Config listens on.
+// +gengo:marshal:yaml=true
+// +gengo:marshal:toml=true
+type Listener struct {
+	// Address is a host:port pair.
+	Address string `json:"address"`
+	TLS     bool   `json:"tls,omitempty"`
+}
+
+// Order is a purchase order exchanged with partners as XML.
+// +gengo:marshal:xml=true
+// +gengo:marshal:openapi=true
+type Order struct {
+	XMLName xml.Name `xml:"urn:example:orders order"`
+	OrderBase
+
+	Status Level `xml:"status,attr,omitempty"`
+	// Kind is an enum of the shared package, written through its generated
+	// MarshalText method.
+	Kind     shared.Kind `xml:"kind,attr,omitempty"`
+	Rush     *bool       `xml:"rush,attr"`
+	Currency string      `xml:"urn:example:money currency,attr,omitempty"`
+	// Extra holds the attributes no other field matches.
+	Extra    []xml.Attr      `xml:",any,attr"`
+	Note     string          `xml:",comment"`
+	Customer Customer        `xml:"customer"`
+	Billing  *shared.Address `xml:"billing,omitempty"`
+	Lines    []OrderLine     `xml:"lines>line"`
+	Coupons  []string        `xml:"lines>coupon,omitempty"`
+	Placed   time.Time       `xml:"placed"`
+	Total    float64         `xml:"total"`
+	Ref      string          `xml:"urn:example:refs ref,omitempty"`
+	Colors   []Color         `xml:"color,omitempty"`
+	// Rest holds the elements no other field matches.
+	Rest     []Extension `xml:",any"`
+	Internal string      `xml:"-"`
+}
+
+// OrderBase is embedded into Order, its fields are promoted.
+type OrderBase struct {
+	ID      int64 `xml:"id,attr"`
+	Version uint  `xml:"version"`
+}
+
+// Customer is the customer of an Order, named by the text of its element.
+// +gengo:marshal:xml=true
+type Customer struct {
+	Name  string `xml:",chardata"`
+	Email string `xml:"email,attr,omitempty"`
+	VIP   bool   `xml:"vip,attr"`
+}
+
+// OrderLine is a line of an Order.
+// +gengo:marshal:xml=true
+type OrderLine struct {
+	SKU      string  `xml:"sku,attr"`
+	Quantity uint16  `xml:"qty"`
+	Price    float32 `xml:"price"`
+	Gift     []byte  `xml:"gift,omitempty"`
+	Discount int8    `xml:"discount,attr,omitempty"`
+}
+
+// Extension is an element of an Order which no field of Order matches. It has
+// no XML methods: encoding/xml writes it.
+type Extension struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+// loadOpenAPISchemas returns the component schemas of the OpenAPI document
+// in file.
+func loadOpenAPISchemas(t *testing.T, file string) map[interface{}]interface{} {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["openapi"] != "3.1.0" {
+		t.Fatalf("%s: unexpected openapi version %v", file, doc["openapi"])
+	}
+	components := doc["components"].(map[interface{}]interface{})
+	return components["schemas"].(map[interface{}]interface{})
+}
+
+// collectRefs returns the $ref values found in v.
+func collectRefs(v interface{}) []string {
+	var refs []string
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		for key, value := range v {
+			if ref, ok := value.(string); ok && key == "$ref" {
+				refs = append(refs, ref)
+			} else {
+				refs = append(refs, collectRefs(value)...)
+			}
+		}
+	case []interface{}:
+		for _, value := range v {
+			refs = append(refs, collectRefs(value)...)
+		}
+	}
+	return refs
+}
+
+func TestOpenAPIComponents(t *testing.T) {
+	const file = "zz_generated.marshal.openapi.yaml"
+	schemas := loadOpenAPISchemas(t, file)
+
+	// Opted-in types and the types of the package they refer to, and the
+	// types of packages without an OpenAPI document.
+	for _, name := range []string{"T4", "T6", "T7", "T1", "Color", "Level", "Order", "xml.Name", "xml.Attr"} {
+		if _, found := schemas[name]; !found {
+			t.Errorf("expected a schema for %s", name)
+		}
+	}
+	for _, name := range []string{"T2", "T5", "shared.Address"} {
+		if _, found := schemas[name]; found {
+			t.Errorf("expected no schema for %s", name)
+		}
+	}
+
+	refs := collectRefs(schemas)
+	foreign := 0
+	for _, ref := range refs {
+		parts := strings.SplitN(ref, "#", 2)
+		target := schemas
+		if parts[0] != "" {
+			foreign++
+			target = loadOpenAPISchemas(t, filepath.FromSlash(parts[0]))
+		}
+		name := strings.TrimPrefix(parts[1], "/components/schemas/")
+		if _, found := target[name]; !found {
+			t.Errorf("unresolved $ref %s", ref)
+		}
+	}
+	if foreign == 0 {
+		t.Error("expected references into the document of the shared package")
+	}
+}
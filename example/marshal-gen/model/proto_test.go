@@ -0,0 +1,146 @@
+package model
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/zhaolion/gengo/example/marshal-gen/shared"
+)
+
+type protoMarshaler interface {
+	MarshalProto() ([]byte, error)
+	UnmarshalProto(data []byte) error
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	nickname := "nick"
+	testCases := []protoMarshaler{
+		&T8{},
+		&T8{
+			Name:     "a",
+			Color:    DarkBlue,
+			Level:    LevelError,
+			Next:     &T8{Name: "b", Enabled: true},
+			Items:    []T9{{ID: 1, Tags: []string{"x", ""}}, {}},
+			ByName:   map[string]*T9{"k": {ID: 2}, "": {}},
+			Counts:   map[int32]uint64{-1: 1, 0: 0, 3: 1 << 63},
+			Nickname: &nickname,
+			Raw:      []byte{0, 1},
+			Home:     shared.Address{Street: "s", City: "c"},
+			Ratio:    -0.5,
+			Scores:   []int64{-1, 0, 1 << 40},
+			Offset:   -5,
+		},
+	}
+	for _, tc := range generatedMarshalCases {
+		if m, ok := tc.sample.(protoMarshaler); ok {
+			testCases = append(testCases, m)
+		}
+	}
+
+	for i, tc := range testCases {
+		data, err := tc.MarshalProto()
+		if err != nil {
+			t.Fatalf("case[%d]: %v", i, err)
+		}
+		got := reflect.New(reflect.TypeOf(tc).Elem()).Interface().(protoMarshaler)
+		if err := got.UnmarshalProto(data); err != nil {
+			t.Fatalf("case[%d]: %v", i, err)
+		}
+		if !reflect.DeepEqual(got, tc) {
+			t.Errorf("case[%d]: expected %+v, got %+v", i, tc, got)
+		}
+		again, _ := got.MarshalProto()
+		if !bytes.Equal(again, data) {
+			t.Errorf("case[%d]: encoding is not deterministic: %x, then %x", i, data, again)
+		}
+	}
+}
+
+func TestProtoWire(t *testing.T) {
+	nickname := ""
+	obj := &T8{
+		Name:     "a",
+		Nickname: &nickname,
+		Counts:   map[int32]uint64{2: 1, 1: 0},
+		Scores:   []int64{1, 2},
+		Offset:   -5,
+		Cache:    "left out",
+	}
+	data, err := obj.MarshalProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want []byte
+	want = protowire.AppendTag(want, 1, protowire.BytesType)
+	want = protowire.AppendString(want, "a")
+	for _, k := range []int32{1, 2} {
+		var entry []byte
+		entry = protowire.AppendTag(entry, 1, protowire.VarintType)
+		entry = protowire.AppendVarint(entry, uint64(k))
+		entry = protowire.AppendTag(entry, 2, protowire.VarintType)
+		entry = protowire.AppendVarint(entry, obj.Counts[k])
+		want = protowire.AppendTag(want, 8, protowire.BytesType)
+		want = protowire.AppendBytes(want, entry)
+	}
+	// Set optional fields are written even when empty.
+	want = protowire.AppendTag(want, 9, protowire.BytesType)
+	want = protowire.AppendString(want, "")
+	// Message fields are always written.
+	want = protowire.AppendTag(want, 11, protowire.BytesType)
+	want = protowire.AppendBytes(want, nil)
+	want = protowire.AppendTag(want, 14, protowire.BytesType)
+	want = protowire.AppendBytes(want, []byte{1, 2})
+	// int8 is an int32: negative values are sign-extended.
+	want = protowire.AppendTag(want, 15, protowire.VarintType)
+	want = protowire.AppendVarint(want, uint64(obj.Offset))
+
+	if !bytes.Equal(data, want) {
+		t.Errorf("expected %x, got %x", want, data)
+	}
+}
+
+func TestUnmarshalProto(t *testing.T) {
+	var data []byte
+	// An unknown field.
+	data = protowire.AppendTag(data, 100, protowire.BytesType)
+	data = protowire.AppendString(data, "unknown")
+	// A known field of an unexpected wire type.
+	data = protowire.AppendTag(data, 1, protowire.VarintType)
+	data = protowire.AppendVarint(data, 1)
+	// Unpacked repeated scalars are accepted.
+	data = protowire.AppendTag(data, 14, protowire.VarintType)
+	data = protowire.AppendVarint(data, 3)
+	data = protowire.AppendTag(data, 14, protowire.BytesType)
+	data = protowire.AppendBytes(data, []byte{4, 5})
+	// A map entry without a value.
+	var entry []byte
+	entry = protowire.AppendTag(entry, 1, protowire.BytesType)
+	entry = protowire.AppendString(entry, "k")
+	data = protowire.AppendTag(data, 7, protowire.BytesType)
+	data = protowire.AppendBytes(data, entry)
+
+	obj := &T8{Name: "reset", Cache: "reset"}
+	if err := obj.UnmarshalProto(data); err != nil {
+		t.Fatal(err)
+	}
+	want := &T8{Scores: []int64{3, 4, 5}, ByName: map[string]*T9{"k": {}}}
+	if !reflect.DeepEqual(obj, want) {
+		t.Errorf("expected %+v, got %+v", want, obj)
+	}
+
+	for i, data := range [][]byte{
+		{0x0a},
+		{0x0a, 0x05, 'a'},
+		protowire.AppendTag(nil, 0, protowire.VarintType),
+		{0x5a, 0x02, 0x0a, 0x05},
+	} {
+		if err := new(T8).UnmarshalProto(data); err == nil {
+			t.Errorf("case[%d]: expected an error decoding %x", i, data)
+		}
+	}
+}
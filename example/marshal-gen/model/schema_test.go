@@ -0,0 +1,204 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// loadSchemaDefs returns the definitions of the generated JSON Schema.
+func loadSchemaDefs(t *testing.T) map[string]interface{} {
+	data, err := ioutil.ReadFile("zz_generated.marshal.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["$schema"] != "https://json-schema.org/draft/2020-12/schema" {
+		t.Fatalf("unexpected $schema %v", doc["$schema"])
+	}
+	return doc["$defs"].(map[string]interface{})
+}
+
+// validateSchema returns an error if the decoded JSON value v does not match
+// schema. It knows the keywords marshal-gen writes.
+func validateSchema(defs map[string]interface{}, schema map[string]interface{}, v interface{}, path string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		def, ok := defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: unknown $ref %s", path, ref)
+		}
+		if err := validateSchema(defs, def, v, path); err != nil {
+			return err
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		var errs []string
+		for _, s := range anyOf {
+			err := validateSchema(defs, s.(map[string]interface{}), v, path)
+			if err == nil {
+				errs = nil
+				break
+			}
+			errs = append(errs, err.Error())
+		}
+		if errs != nil {
+			return fmt.Errorf("%s: matches no schema of anyOf: %s", path, strings.Join(errs, "; "))
+		}
+	}
+	if typ, ok := schema["type"]; ok {
+		var types []interface{}
+		if list, ok := typ.([]interface{}); ok {
+			types = list
+		} else {
+			types = []interface{}{typ}
+		}
+		matched := false
+		for _, name := range types {
+			matched = matched || jsonTypeOf(v, name.(string))
+		}
+		if !matched {
+			return fmt.Errorf("%s: %v is not of type %v", path, v, typ)
+		}
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			found = found || e == v
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", path, v, enum)
+		}
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		if s, ok := v.(string); ok && !regexp.MustCompile(pattern).MatchString(s) {
+			return fmt.Errorf("%s: %q does not match %s", path, s, pattern)
+		}
+	}
+	if min, ok := schema["minimum"].(float64); ok {
+		if n, ok := v.(float64); ok && n < min {
+			return fmt.Errorf("%s: %v is less than %v", path, v, min)
+		}
+	}
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		list, _ := v.([]interface{})
+		for i, item := range list {
+			if err := validateSchema(defs, items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	object, _ := v.(map[string]interface{})
+	properties, _ := schema["properties"].(map[string]interface{})
+	for name, value := range object {
+		property, ok := properties[name].(map[string]interface{})
+		if !ok {
+			property, ok = schema["additionalProperties"].(map[string]interface{})
+		}
+		if ok {
+			if err := validateSchema(defs, property, value, path+"."+name); err != nil {
+				return err
+			}
+		}
+		if names, ok := schema["propertyNames"].(map[string]interface{}); ok {
+			if err := validateSchema(defs, names, name, path+"."+name); err != nil {
+				return err
+			}
+		}
+	}
+	if required, ok := schema["required"].([]interface{}); ok && object != nil {
+		for _, name := range required {
+			if _, found := object[name.(string)]; !found {
+				return fmt.Errorf("%s: missing required property %v", path, name)
+			}
+		}
+	}
+	return nil
+}
+
+// jsonTypeOf returns true if the decoded JSON value v is of the JSON Schema
+// type name.
+func jsonTypeOf(v interface{}, name string) bool {
+	switch v := v.(type) {
+	case nil:
+		return name == "null"
+	case bool:
+		return name == "boolean"
+	case string:
+		return name == "string"
+	case float64:
+		return name == "number" || name == "integer" && v == math.Trunc(v)
+	case []interface{}:
+		return name == "array"
+	case map[string]interface{}:
+		return name == "object"
+	}
+	return false
+}
+
+func TestJSONSchemaMatchesEncoding(t *testing.T) {
+	defs := loadSchemaDefs(t)
+
+	green := Green
+	note := "note"
+	type schemaCase struct {
+		def string
+		obj interface{}
+	}
+	testCases := []schemaCase{
+		{"T4", &T4{Name: "a", T1: &T1{Uint64: math.MaxUint64}, Items: []T1{{}}, ByKey: map[string]*T1{"k": nil}}},
+		{"T5", &T5{Meta: &Meta{Labels: map[string]string{"a": "b"}, Note: &note}, innerPtr: &innerPtr{}, Count: 3, Enabled: true}},
+		{"T6", &T6{Color: DarkBlue, Level: LevelError, Palette: []Color{Green}, ByLevel: map[Level]int{LevelInfo: 1}, Ptr: &green}},
+		{"T6", &T6{Color: Color(7), Level: "trace", ByLevel: map[Level]int{"trace": 1}}},
+	}
+	for _, tc := range generatedMarshalCases {
+		testCases = append(testCases, schemaCase{tc.name, tc.sample}, schemaCase{tc.name, tc.new()})
+	}
+
+	for i, tc := range testCases {
+		schema, ok := defs[tc.def].(map[string]interface{})
+		if !ok {
+			t.Fatalf("case[%d]: no definition of %s", i, tc.def)
+		}
+		data, err := json.Marshal(tc.obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var v interface{}
+		if err := json.Unmarshal(data, &v); err != nil {
+			t.Fatal(err)
+		}
+		if err := validateSchema(defs, schema, v, "$"); err != nil {
+			t.Errorf("case[%d]: %s does not match the schema of %s: %v", i, data, tc.def, err)
+		}
+	}
+}
+
+func TestJSONSchemaRejects(t *testing.T) {
+	defs := loadSchemaDefs(t)
+	testCases := []struct {
+		def  string
+		data string
+	}{
+		{"T4", `{"t1":null,"items":null,"by_key":null}`},
+		{"T4", `{"name":"a","items":[{"Uint8":-1}]}`},
+		{"T6", `{"color":"Blue"}`},
+		{"T6", `{"color":"-"}`},
+		{"T5", `{"id":12}`},
+	}
+	for i, tc := range testCases {
+		var v interface{}
+		if err := json.Unmarshal([]byte(tc.data), &v); err != nil {
+			t.Fatal(err)
+		}
+		if err := validateSchema(defs, defs[tc.def].(map[string]interface{}), v, "$"); err == nil {
+			t.Errorf("case[%d]: expected %s not to match the schema of %s", i, tc.data, tc.def)
+		}
+	}
+}
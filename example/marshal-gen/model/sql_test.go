@@ -0,0 +1,169 @@
+package model
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func init() {
+	sql.Register("fakejson", &fakeDriver{tables: map[string][]driver.Value{}})
+}
+
+// fakeDriver is an in-process database/sql driver keeping a single column
+// table per DSN. The statement "INSERT" appends its argument to the table and
+// "SELECT" returns all rows.
+type fakeDriver struct {
+	mu     sync.Mutex
+	tables map[string][]driver.Value
+}
+
+func (d *fakeDriver) Open(dsn string) (driver.Conn, error) {
+	return &fakeConn{driver: d, dsn: dsn}, nil
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+	dsn    string
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	if query != "INSERT" && query != "SELECT" {
+		return nil, errors.New("fake: unsupported statement " + query)
+	}
+	return &fakeStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("fake: transactions are not supported")
+}
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error { return nil }
+
+func (s *fakeStmt) NumInput() int {
+	if s.query == "INSERT" {
+		return 1
+	}
+	return 0
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if s.query != "INSERT" {
+		return nil, errors.New("fake: Exec of " + s.query)
+	}
+	d := s.conn.driver
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.tables[s.conn.dsn] = append(d.tables[s.conn.dsn], args[0])
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if s.query != "SELECT" {
+		return nil, errors.New("fake: Query of " + s.query)
+	}
+	d := s.conn.driver
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	rows := append([]driver.Value(nil), d.tables[s.conn.dsn]...)
+	return &fakeRows{rows: rows}, nil
+}
+
+type fakeRows struct {
+	rows []driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return []string{"data"} }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	dest[0], r.rows = r.rows[0], r.rows[1:]
+	return nil
+}
+
+// scanAll inserts every value and scans them back in order. Each row is
+// scanned into a non-zero T4 to show Scan replaces the previous content.
+func scanAll(t *testing.T, values ...interface{}) ([]T4, []error) {
+	db, err := sql.Open("fakejson", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	for _, v := range values {
+		if _, err := db.Exec("INSERT", v); err != nil {
+			t.Fatalf("insert %#v: %v", v, err)
+		}
+	}
+
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var objs []T4
+	var errs []error
+	for rows.Next() {
+		obj := T4{Name: "previous", Items: []T1{{Str: "previous"}}}
+		errs = append(errs, rows.Scan(&obj))
+		objs = append(objs, obj)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return objs, errs
+}
+
+func TestT4ScanValue(t *testing.T) {
+	obj := T4{
+		Name:  "a",
+		T1:    &T1{Int16: 1},
+		Items: []T1{{Str: "b"}},
+		ByKey: map[string]*T1{"k": {Uint64: 2}, "nil": nil},
+	}
+
+	objs, errs := scanAll(t, obj, &obj, (*T4)(nil), `{"name":"b"}`, nil)
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("row[%d]: unexpected error: %v", i, err)
+		}
+	}
+	expect := []T4{obj, obj, {}, {Name: "b"}, {}}
+	if !reflect.DeepEqual(objs, expect) {
+		t.Errorf("expected %v, got %v", expect, objs)
+	}
+}
+
+func TestT4ScanErrors(t *testing.T) {
+	_, errs := scanAll(t, int64(1), `{"name":`, []byte(`{"items":{}}`))
+	expect := []string{
+		"model.T4: cannot scan int64, want []byte, string or nil",
+		"model.T4: cannot scan JSON column: unexpected end of JSON input",
+		"model.T4: cannot scan JSON column: json: cannot unmarshal object into Go struct field",
+	}
+	if len(errs) != len(expect) {
+		t.Fatalf("expected %d rows, got %d", len(expect), len(errs))
+	}
+	for i, err := range errs {
+		if err == nil || !strings.Contains(err.Error(), expect[i]) {
+			t.Errorf("row[%d]: expected error containing %q, got: %v", i, expect[i], err)
+		}
+	}
+}
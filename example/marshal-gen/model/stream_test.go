@@ -0,0 +1,72 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestT3EncodeDecodeJSON(t *testing.T) {
+	str := "str"
+	strPtr := &str
+	m := map[string]string{"a": "b"}
+	s := []string{"x", "y"}
+	in := &T3{
+		Byte:         map[string]byte{"b": 1, "a": 2},
+		Int16:        map[string]int16{"min": -32768},
+		Int64:        map[string]int64{"big": 1 << 62},
+		Uint64:       map[string]uint64{"max": 1<<64 - 1},
+		Float32:      map[string]float32{"f": 0.1},
+		Float64:      map[string]float64{"tiny": 1e-9, "huge": 1e22},
+		StringPtr:    map[string]*string{"set": &str, "nil": nil},
+		StringPtrPtr: map[string]**string{"set": &strPtr},
+		Map:          map[string]map[string]string{"m": m, "nil": nil},
+		MapPtr:       map[string]*map[string]string{"m": &m},
+		Slice:        map[string][]string{"s": s, "empty": {}},
+		SlicePtr:     map[string]*[]string{"s": &s},
+		Struct:       map[string]T1{"t": {Str: "<escaped>", Float64: 2.5}},
+		StructPtr:    map[string]*T2{"t": {I: []Inner{nil}}, "nil": nil},
+	}
+
+	expect, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	if err := in.EncodeJSON(buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != string(expect) {
+		t.Fatalf("EncodeJSON differs from encoding/json:\n%s\n%s", buf.String(), expect)
+	}
+
+	out := &T3{}
+	if err := out.DecodeJSON(buf); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("DecodeJSON did not round-trip:\n%#v\n%#v", in, out)
+	}
+}
+
+func TestT4DecodeJSON(t *testing.T) {
+	out := &T4{Name: "kept", Items: []T1{{Str: "dropped"}}}
+	err := out.DecodeJSON(strings.NewReader(`{"unknown": {"a": [1]}, "items": [{"Str": "a"}, {"Int16": 2}], "by_key": {"x": null, "y": {"Str": "y"}}, "t1": null}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := &T4{
+		Name:  "kept",
+		Items: []T1{{Str: "a"}, {Int16: 2}},
+		ByKey: map[string]*T1{"x": nil, "y": {Str: "y"}},
+	}
+	if !reflect.DeepEqual(out, expect) {
+		t.Errorf("expected %#v, got %#v", expect, out)
+	}
+
+	if err := out.DecodeJSON(strings.NewReader(`{"items": {}}`)); err == nil {
+		t.Error("expected an error decoding an object into a slice")
+	}
+}
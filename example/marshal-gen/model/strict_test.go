@@ -0,0 +1,84 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zhaolion/gengo/marshal"
+)
+
+func TestT4UnmarshalJSONBinaryStrict(t *testing.T) {
+	testCases := []struct {
+		data   string
+		expect []marshal.Problem
+	}{
+		{
+			data: `{"name":"a","items":[{"Str":"b"}],"by_key":{"k":{"Int16":1},"nil":null}}`,
+		},
+		{
+			data: `{"items":null}`,
+			expect: []marshal.Problem{
+				{Path: "$.name", Err: marshal.ErrMissingField},
+			},
+		},
+		{
+			data: `{"name":"a","extra":1,"items":[{"Str":"b"},{"Nope":true}],"by_key":{"a.b":{"Str":"c","Other":1}}}`,
+			expect: []marshal.Problem{
+				{Path: `$.by_key["a.b"].Other`, Err: marshal.ErrUnknownField},
+				{Path: "$.extra", Err: marshal.ErrUnknownField},
+				{Path: "$.items[1].Nope", Err: marshal.ErrUnknownField},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		obj := &T4{}
+		err := obj.UnmarshalJSONBinaryStrict([]byte(tc.data))
+		if tc.expect == nil {
+			if err != nil {
+				t.Errorf("case[%d]: expected no error, got: %v", i, err)
+			}
+			continue
+		}
+		strictErr, ok := err.(*marshal.StrictError)
+		if !ok {
+			t.Errorf("case[%d]: expected a *marshal.StrictError, got: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(strictErr.Problems, tc.expect) {
+			t.Errorf("case[%d]: expected %v, got %v", i, tc.expect, strictErr.Problems)
+		}
+	}
+}
+
+func TestT4UnmarshalJSONBinaryStrictTypeErrors(t *testing.T) {
+	obj := &T4{}
+	err := obj.UnmarshalJSONBinaryStrict([]byte(`{"name":1,"items":[{"Int16":"x"}]}`))
+	strictErr, ok := err.(*marshal.StrictError)
+	if !ok {
+		t.Fatalf("expected a *marshal.StrictError, got: %v", err)
+	}
+	var paths []string
+	for _, p := range strictErr.Problems {
+		paths = append(paths, p.Path)
+	}
+	if expect := []string{"$.items[0].Int16", "$.name"}; !reflect.DeepEqual(paths, expect) {
+		t.Errorf("expected problems at %v, got %v", expect, paths)
+	}
+}
+
+func TestT7UnmarshalJSONBinaryStrict(t *testing.T) {
+	// Strict decoding descends into the types of the peer package.
+	err := (&T7{}).UnmarshalJSONBinaryStrict([]byte(`{"home":{"zip":"z"},"work":{"city":"c","floor":1},"contact":{},"kind":"home"}`))
+	strictErr, ok := err.(*marshal.StrictError)
+	if !ok {
+		t.Fatalf("expected a *marshal.StrictError, got: %v", err)
+	}
+	expect := []marshal.Problem{
+		{Path: "$.home.city", Err: marshal.ErrMissingField},
+		{Path: "$.work.floor", Err: marshal.ErrUnknownField},
+	}
+	if !reflect.DeepEqual(strictErr.Problems, expect) {
+		t.Errorf("expected %v, got %v", expect, strictErr.Problems)
+	}
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestTypeScriptDeclarations(t *testing.T) {
+	data, err := ioutil.ReadFile("zz_generated.marshal.d.ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	declarations := string(data)
+	for _, expect := range []string{
+		`import * as shared from "../shared/zz_generated.marshal";`,
+		"export type Color = \"Red\" | \"Green\" | \"dark-blue\" | \"Default\" | `${number}`;",
+		`export type Level = "debug" | "info" | "err" | string;`,
+		// Embedded fields are promoted, tagged names and the string option
+		// are applied, omitempty fields and pointers are optional.
+		"export interface T5 {\n  id: string;\n  kind?: string;\n",
+		`  "-": string;`,
+		`  enabled: string;`,
+		`  t1?: T1 | null;`,
+		`  by_level?: Partial<Record<Level, number>> | null;`,
+		`  home: shared.Address;`,
+		`  contact: Record<string, Level> | null;`,
+		`  I: unknown[] | null;`,
+		// The types of encoding/xml, which is not generated, are written in
+		// place.
+		`  XMLName: { Space: string; Local: string; };`,
+		`  Extra: Array<{ Name: { Space: string; Local: string; }; Value: string; }> | null;`,
+	} {
+		if !strings.Contains(declarations, expect) {
+			t.Errorf("expected the declarations to contain %q", expect)
+		}
+	}
+	if strings.Count(declarations, "import ") != 1 {
+		t.Error("expected the declarations to import the shared package only")
+	}
+	for _, unexpected := range []string{"Skipped", "private"} {
+		if strings.Contains(declarations, "  "+unexpected) {
+			t.Errorf("expected the declarations not to contain %q", unexpected)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package model
+
+import (
+	"bytes"
+	"encoding/xml"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zhaolion/gengo/example/marshal-gen/shared"
+)
+
+// The plain types have no methods: encoding/xml encodes them through
+// reflection, which the generated methods are compared with.
+type (
+	plainOrder     Order
+	plainCustomer  Customer
+	plainOrderLine OrderLine
+)
+
+func testOrder() *Order {
+	rush := true
+	return &Order{
+		OrderBase: OrderBase{ID: 42, Version: 3},
+		Status:    LevelError,
+		Kind:      shared.KindWork,
+		Rush:      &rush,
+		Currency:  "EUR",
+		Extra:     []xml.Attr{{Name: xml.Name{Local: "source"}, Value: "edi"}},
+		Note:      "leave at the door -",
+		Customer:  Customer{Name: "Ada & <Co>", Email: "ada@example.com", VIP: true},
+		Billing:   &shared.Address{Street: "1 Main St", City: "Springfield"},
+		Lines: []OrderLine{
+			{SKU: "A-1", Quantity: 2, Price: 9.99, Gift: []byte("wrap it"), Discount: -5},
+			{SKU: "B-2", Quantity: 1, Price: 0.1},
+		},
+		Coupons: []string{"SPRING", ""},
+		Placed:  time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Total:   20.08,
+		Ref:     "r-7",
+		Colors:  []Color{Red, DarkBlue},
+		Rest: []Extension{
+			{XMLName: xml.Name{Local: "memo"}, Value: "call first"},
+			{Value: "unnamed"},
+		},
+		Internal: "left out",
+	}
+}
+
+// encodeElement encodes v as the element named v, or as the element named by
+// its XMLName if start is false.
+func encodeElement(v interface{}, start bool, indent bool) (string, error) {
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+	if indent {
+		e.Indent("", "  ")
+	}
+	var err error
+	if start {
+		err = e.EncodeElement(v, xml.StartElement{Name: xml.Name{Local: "v"}})
+	} else {
+		err = e.Encode(v)
+	}
+	if err == nil {
+		err = e.Flush()
+	}
+	return buf.String(), err
+}
+
+func TestMarshalXMLConformance(t *testing.T) {
+	order := testOrder()
+	testCases := []struct {
+		v, plain interface{}
+		start    bool
+		// comment is set if the element holds a comment: encoding/xml puts
+		// it on a line of its own when indenting, which an xml.Encoder
+		// cannot do.
+		comment bool
+	}{
+		{v: &Order{}, plain: &plainOrder{}},
+		{v: order, plain: (*plainOrder)(order), comment: true},
+		{v: Order{Lines: []OrderLine{}, Coupons: []string{"", ""}}, plain: plainOrder{Coupons: []string{"", ""}}},
+		{v: Customer{}, plain: plainCustomer{}, start: true},
+		{v: &order.Customer, plain: (*plainCustomer)(&order.Customer), start: true},
+		{v: &order.Lines[0], plain: (*plainOrderLine)(&order.Lines[0]), start: true},
+	}
+	for i, tc := range testCases {
+		for _, indent := range []bool{false, true} {
+			if indent && tc.comment {
+				continue
+			}
+			want, err := encodeElement(tc.plain, tc.start, indent)
+			if err != nil {
+				t.Fatalf("case[%d]: %v", i, err)
+			}
+			got, err := encodeElement(tc.v, tc.start, indent)
+			if err != nil {
+				t.Fatalf("case[%d]: %v", i, err)
+			}
+			if got != want {
+				t.Errorf("case[%d]: expected:\n%s\ngot:\n%s", i, want, got)
+			}
+		}
+	}
+}
+
+func TestMarshalXML(t *testing.T) {
+	data, err := xml.MarshalIndent(testOrder(), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<order xmlns="urn:example:orders" id="42" status="err" kind="work" rush="true" xmlns:_="urn:example:money" _:currency="EUR" source="edi">
+  <version>3</version><!--leave at the door - -->
+  <customer email="ada@example.com" vip="true">Ada &amp; &lt;Co&gt;</customer>
+  <billing>
+    <Street>1 Main St</Street>
+    <City>Springfield</City>
+    <Zip></Zip>
+  </billing>
+  <lines>
+    <line sku="A-1" discount="-5">
+      <qty>2</qty>
+      <price>9.99</price>
+      <gift>wrap it</gift>
+    </line>
+    <line sku="B-2">
+      <qty>1</qty>
+      <price>0.1</price>
+    </line>
+    <coupon>SPRING</coupon>
+  </lines>
+  <placed>2020-01-02T03:04:05Z</placed>
+  <total>20.08</total>
+  <ref xmlns="urn:example:refs">r-7</ref>
+  <color>dark-blue</color>
+  <memo xmlns="">call first</memo>
+  <Rest xmlns="">unnamed</Rest>
+</order>`
+	if string(data) != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, data)
+	}
+}
+
+func TestMarshalXMLErrors(t *testing.T) {
+	// Like encoding/xml, the generated methods reject comments holding "--".
+	for i, order := range []*Order{{Note: "a--b"}} {
+		if _, err := xml.Marshal((*plainOrder)(order)); err == nil {
+			t.Fatalf("case[%d]: expected an error from encoding/xml", i)
+		}
+		if _, err := xml.Marshal(order); err == nil {
+			t.Errorf("case[%d]: expected an error", i)
+		}
+	}
+}
+
+func TestMarshalXMLNewlines(t *testing.T) {
+	// The generated methods write the newlines of character data as they
+	// are, where encoding/xml escapes them: the decoded values are the same.
+	c := Customer{Name: "line 1\nline 2\r\n"}
+	got, err := encodeElement(c, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "<v vip=\"false\">line 1\nline 2&#xD;\n</v>"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	var decoded Customer
+	if err := xml.Unmarshal([]byte(got), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != c {
+		t.Errorf("expected %+v, got %+v", c, decoded)
+	}
+}
+
+func TestUnmarshalXMLConformance(t *testing.T) {
+	full, err := xml.Marshal(testOrder())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []string{
+		string(full),
+		`<order xmlns="urn:example:orders"/>`,
+		// Unknown attributes go to Extra, unknown elements to Rest, and
+		// the elements of other namespaces do not match.
+		`<order xmlns="urn:example:orders" xmlns:m="urn:example:money" xmlns:o="urn:other"
+			id=" 7" o:id="8" m:currency="USD" currency="CHF" rush="1">
+			text is ignored <!-- first --> <!-- second -->
+			<version> 2 </version>
+			<version>5<nested>6</nested></version>
+			<customer vip="false" extra="x">Bob<b>old</b> Smith</customer>
+			<lines><coupon>A</coupon><unknown><line/></unknown><line sku="s"><qty/><price>1e2</price></line></lines>
+			<lines><coupon>B</coupon></lines>
+			<ref>no namespace</ref>
+			<ref xmlns="urn:example:refs">namespaced</ref>
+			<color>Green</color><color>dark-blue</color>
+			<Rest>named after the field</Rest>
+			<placed>2021-02-03T04:05:06+01:00</placed>
+			<billing><City>X</City></billing>
+		</order>`,
+		// Errors.
+		`<orders xmlns="urn:example:orders"/>`,
+		`<order/>`,
+		`<order xmlns="urn:example:other"/>`,
+		`<order xmlns="urn:example:orders" id="x"/>`,
+		`<order xmlns="urn:example:orders" rush="yes"/>`,
+		`<order xmlns="urn:example:orders"><version>-1</version></order>`,
+		`<order xmlns="urn:example:orders"><color>blue</color></order>`,
+		`<order xmlns="urn:example:orders"><lines><line><qty>70000</qty></line></lines></order>`,
+		`<order xmlns="urn:example:orders"><lines><line discount="-129"/></lines></order>`,
+		`<order xmlns="urn:example:orders"><customer vip="maybe"/></order>`,
+		`<order xmlns="urn:example:orders"><placed>yesterday</placed></order>`,
+		`<order xmlns="urn:example:orders"><lines>`,
+	}
+	for i, data := range testCases {
+		var want plainOrder
+		wantErr := xml.Unmarshal([]byte(data), &want)
+		var got Order
+		err := xml.Unmarshal([]byte(data), &got)
+		if (err == nil) != (wantErr == nil) || err != nil && err.Error() != wantErr.Error() {
+			t.Errorf("case[%d]: expected error %v, got %v", i, wantErr, err)
+			continue
+		}
+		if err == nil && !reflect.DeepEqual(got, Order(want)) {
+			t.Errorf("case[%d]: expected %+v, got %+v", i, want, got)
+		}
+	}
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	// Empty items and the Internal field are left out, so the round trip
+	// only holds for orders without them.
+	order := testOrder()
+	order.Coupons, order.Colors, order.Internal = []string{"SPRING"}, []Color{DarkBlue}, ""
+	testCases := []*Order{order, {}}
+	for i, tc := range testCases {
+		data, err := xml.Marshal(tc)
+		if err != nil {
+			t.Fatalf("case[%d]: %v", i, err)
+		}
+		got := &Order{}
+		if err := xml.Unmarshal(data, got); err != nil {
+			t.Fatalf("case[%d]: %v", i, err)
+		}
+		// The namespace declarations are attributes like the others.
+		var extra []xml.Attr
+		for _, attr := range got.Extra {
+			if attr.Name.Space != "xmlns" && attr.Name.Local != "xmlns" {
+				extra = append(extra, attr)
+			}
+		}
+		got.Extra = extra
+		want := *tc
+		want.XMLName = xml.Name{Space: "urn:example:orders", Local: "order"}
+		if tc.Rest != nil {
+			// The namespace of the order is inherited.
+			want.Rest = []Extension{
+				{XMLName: xml.Name{Space: "", Local: "memo"}, Value: "call first"},
+				{XMLName: xml.Name{Space: "", Local: "Rest"}, Value: "unnamed"},
+			}
+			want.Note += " "
+		}
+		if !reflect.DeepEqual(got, &want) {
+			t.Errorf("case[%d]: expected %+v, got %+v", i, want, got)
+		}
+	}
+}
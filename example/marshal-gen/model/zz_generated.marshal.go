@@ -0,0 +1,8877 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	xml "encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	math "math"
+	"sort"
+	strconv "strconv"
+
+	shared "github.com/zhaolion/gengo/example/marshal-gen/shared"
+	marshal "github.com/zhaolion/gengo/marshal"
+	protowire "google.golang.org/protobuf/encoding/protowire"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *Base) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *Base) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *Base) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *Base) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *Base) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *Base) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"id":`)
+	jw.RawByte('"')
+	jw.Int(obj.ID)
+	jw.RawByte('"')
+	if obj.Kind != "" {
+		jw.Raw(`,"kind":`)
+		jw.String(obj.Kind)
+	}
+	jw.Raw(`,"name":`)
+	jw.String(obj.Name)
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *Base) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "id", "kind", "name":
+		default:
+			switch marshal.FoldName(key) {
+			case "ID":
+				key = "id"
+			case "KIND":
+				key = "kind"
+			case "NAME":
+				key = "name"
+			}
+		}
+		switch key {
+		case "id":
+			if err := dec.DecodeQuoted(&obj.ID); err != nil {
+				return err
+			}
+		case "kind":
+			if err := dec.Decode(&obj.Kind); err != nil {
+				return err
+			}
+		case "name":
+			if err := dec.Decode(&obj.Name); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *Base) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *Base) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "id", "kind", "name":
+		default:
+			switch marshal.FoldName(key) {
+			case "ID":
+				key = "id"
+			case "KIND":
+				key = "kind"
+			case "NAME":
+				key = "name"
+			}
+		}
+		switch key {
+		case "id":
+			out := &obj.ID
+			if err := marshal.UnmarshalQuoted(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "kind":
+			out := &obj.Kind
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "name":
+			out := &obj.Name
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *Color) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *Color) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// colorEnumConstants pairs the names of the Color constants with their values.
+var colorEnumConstants = struct {
+	names  []string
+	values []Color
+}{
+	names:  []string{"Red", "Green", "dark-blue", "Default"},
+	values: []Color{Red, Green, DarkBlue, Default},
+}
+
+// ParseColor returns the Color constant called name.
+func ParseColor(name string) (Color, error) {
+	for i, n := range colorEnumConstants.names {
+		if n == name {
+			return colorEnumConstants.values[i], nil
+		}
+	}
+	var zero Color
+	return zero, fmt.Errorf("invalid Color %q", name)
+}
+
+// ColorValues returns the values of the Color constants, in declaration
+// order.
+func ColorValues() []Color {
+	return []Color{Red, Green, DarkBlue}
+}
+
+// MarshalText implements encoding.TextMarshaler. It writes the name of the
+// constant obj equals, or the underlying value of obj if there is none, e.g.
+// for a zero value without a constant.
+func (obj Color) MarshalText() ([]byte, error) {
+	for i, v := range colorEnumConstants.values {
+		if v == obj {
+			return []byte(colorEnumConstants.names[i]), nil
+		}
+	}
+	return []byte(strconv.FormatInt(int64(obj), 10)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It reads the names
+// ParseColor accepts, and the underlying values MarshalText writes.
+func (obj *Color) UnmarshalText(text []byte) error {
+	if v, err := ParseColor(string(text)); err == nil {
+		*obj = v
+		return nil
+	}
+	n, err := strconv.ParseInt(string(text), 10, 64)
+	if err != nil || int64(Color(n)) != n {
+		return fmt.Errorf("invalid Color %q", text)
+	}
+	*obj = Color(n)
+	return nil
+}
+
+// String returns the name of the constant obj equals, or the value of obj
+// after the type name if there is none.
+func (obj Color) String() string {
+	for i, v := range colorEnumConstants.values {
+		if v == obj {
+			return colorEnumConstants.names[i]
+		}
+	}
+	return fmt.Sprintf("Color(%d)", int64(obj))
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *Color) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *Color) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *Color) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.Text(*obj)
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *Color) ReadJSON(dec *marshal.Decoder) error {
+	return dec.Decode(obj)
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *Config) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *Config) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *Config) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *Config) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *Config) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *Config) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"version":`)
+	jw.Int(int64(obj.ConfigBase.Version))
+	jw.Raw(`,"name":`)
+	jw.String(obj.Name)
+	if obj.Port != 0 {
+		jw.Raw(`,"port":`)
+		jw.Int(int64(obj.Port))
+	}
+	jw.Raw(`,"debug":`)
+	jw.RawByte('"')
+	jw.Bool(obj.Debug)
+	jw.RawByte('"')
+	if obj.Level != "" {
+		jw.Raw(`,"level":`)
+		jw.Text(obj.Level)
+	}
+	if obj.Timeout != nil {
+		jw.Raw(`,"timeout":`)
+		if obj.Timeout == nil {
+			jw.Null()
+		} else {
+			jw.Float(*obj.Timeout, 64)
+		}
+	}
+	jw.Raw(`,"started":`)
+	jw.Value(&obj.Started)
+	jw.Raw(`,"listeners":`)
+	if obj.Listeners == nil {
+		jw.Null()
+	} else {
+		jw.RawByte('[')
+		for i := range obj.Listeners {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			in := &obj.Listeners[i]
+			in.WriteJSON(jw)
+		}
+		jw.RawByte(']')
+	}
+	if obj.Primary != nil {
+		jw.Raw(`,"primary":`)
+		obj.Primary.WriteJSON(jw)
+	}
+	if len(obj.Labels) != 0 {
+		jw.Raw(`,"labels":`)
+		if obj.Labels == nil {
+			jw.Null()
+		} else {
+			keys := make([]string, 0, len(obj.Labels))
+			for key := range obj.Labels {
+				keys = append(keys, key)
+			}
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+			jw.RawByte('{')
+			for i, key := range keys {
+				if i > 0 {
+					jw.RawByte(',')
+				}
+				jw.String(key)
+				jw.RawByte(':')
+				val := obj.Labels[key]
+				jw.String(val)
+			}
+			jw.RawByte('}')
+		}
+	}
+	jw.Raw(`,"palette":`)
+	if obj.Palette == nil {
+		jw.Null()
+	} else {
+		jw.RawByte('[')
+		for i := range obj.Palette {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			in := &obj.Palette[i]
+			jw.Text(*in)
+		}
+		jw.RawByte(']')
+	}
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *Config) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "version", "name", "port", "debug", "level", "timeout", "started", "listeners", "primary", "labels", "palette":
+		default:
+			switch marshal.FoldName(key) {
+			case "VERSION":
+				key = "version"
+			case "NAME":
+				key = "name"
+			case "PORT":
+				key = "port"
+			case "DEBUG":
+				key = "debug"
+			case "LEVEL":
+				key = "level"
+			case "TIMEOUT":
+				key = "timeout"
+			case "STARTED":
+				key = "started"
+			case "LISTENERS":
+				key = "listeners"
+			case "PRIMARY":
+				key = "primary"
+			case "LABELS":
+				key = "labels"
+			case "PALETTE":
+				key = "palette"
+			}
+		}
+		switch key {
+		case "version":
+			if err := dec.Decode(&obj.ConfigBase.Version); err != nil {
+				return err
+			}
+		case "name":
+			if err := dec.Decode(&obj.Name); err != nil {
+				return err
+			}
+		case "port":
+			if err := dec.Decode(&obj.Port); err != nil {
+				return err
+			}
+		case "debug":
+			if err := dec.DecodeQuoted(&obj.Debug); err != nil {
+				return err
+			}
+		case "level":
+			if err := dec.Decode(&obj.Level); err != nil {
+				return err
+			}
+		case "timeout":
+			if err := dec.Decode(&obj.Timeout); err != nil {
+				return err
+			}
+		case "started":
+			if err := dec.Decode(&obj.Started); err != nil {
+				return err
+			}
+		case "listeners":
+			if ok, err := dec.Begin('['); err != nil {
+				return err
+			} else if !ok {
+				obj.Listeners = nil
+			} else {
+				if obj.Listeners == nil {
+					obj.Listeners = []Listener{}
+				}
+				obj.Listeners = obj.Listeners[:0]
+				for dec.More() {
+					var val Listener
+					if err := val.ReadJSON(dec); err != nil {
+						return err
+					}
+					obj.Listeners = append(obj.Listeners, val)
+				}
+				if err := dec.End(']'); err != nil {
+					return err
+				}
+			}
+		case "primary":
+			if null, err := dec.Null(); err != nil {
+				return err
+			} else if null {
+				obj.Primary = nil
+			} else {
+				if obj.Primary == nil {
+					obj.Primary = new(Listener)
+				}
+				if err := obj.Primary.ReadJSON(dec); err != nil {
+					return err
+				}
+			}
+		case "labels":
+			if err := dec.Decode(&obj.Labels); err != nil {
+				return err
+			}
+		case "palette":
+			if err := dec.Decode(&obj.Palette); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *Config) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *Config) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "version", "name", "port", "debug", "level", "timeout", "started", "listeners", "primary", "labels", "palette":
+		default:
+			switch marshal.FoldName(key) {
+			case "VERSION":
+				key = "version"
+			case "NAME":
+				key = "name"
+			case "PORT":
+				key = "port"
+			case "DEBUG":
+				key = "debug"
+			case "LEVEL":
+				key = "level"
+			case "TIMEOUT":
+				key = "timeout"
+			case "STARTED":
+				key = "started"
+			case "LISTENERS":
+				key = "listeners"
+			case "PRIMARY":
+				key = "primary"
+			case "LABELS":
+				key = "labels"
+			case "PALETTE":
+				key = "palette"
+			}
+		}
+		switch key {
+		case "version":
+			out := &obj.ConfigBase.Version
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "name":
+			out := &obj.Name
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "port":
+			out := &obj.Port
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "debug":
+			out := &obj.Debug
+			if err := marshal.UnmarshalQuoted(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "level":
+			out := &obj.Level
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "timeout":
+			out := &obj.Timeout
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "started":
+			out := &obj.Started
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "listeners":
+			out := &obj.Listeners
+			var items []json.RawMessage
+			if err := json.Unmarshal(data, &items); err != nil {
+				errs.Add(path, err)
+			} else if items == nil {
+				*out = nil
+			} else {
+				*out = make([]Listener, len(items))
+				for i := range items {
+					data, path, out := items[i], marshal.Index(path, i), &(*out)[i]
+					out.UnmarshalJSONStrict(data, path, errs)
+				}
+			}
+		case "primary":
+			out := &obj.Primary
+			if marshal.IsNull(data) {
+				*out = nil
+			} else {
+				if *out == nil {
+					*out = new(Listener)
+				}
+				out := *out
+				out.UnmarshalJSONStrict(data, path, errs)
+			}
+		case "labels":
+			out := &obj.Labels
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "palette":
+			out := &obj.Palette
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalYAML returns the YAML mapping of obj. Its keys follow the json tags
+// of the fields and keep their declaration order, with their doc comments.
+func (obj Config) MarshalYAML() (interface{}, error) {
+	node := marshal.NewYAMLMapping()
+	if err := marshal.AddYAMLField(node, "version", "Version of the configuration format.", obj.ConfigBase.Version); err != nil {
+		return nil, err
+	}
+	if err := marshal.AddYAMLField(node, "name", "Name identifies the service.", obj.Name); err != nil {
+		return nil, err
+	}
+	if obj.Port != 0 {
+		if err := marshal.AddYAMLField(node, "port", "Port is the port to listen on.\nZero picks a free one.", obj.Port); err != nil {
+			return nil, err
+		}
+	}
+	if err := marshal.AddYAMLQuoted(node, "debug", "Debug enables verbose logs.", obj.Debug); err != nil {
+		return nil, err
+	}
+	if obj.Level != "" {
+		if err := marshal.AddYAMLField(node, "level", "", obj.Level); err != nil {
+			return nil, err
+		}
+	}
+	if obj.Timeout != nil {
+		if err := marshal.AddYAMLField(node, "timeout", "", obj.Timeout); err != nil {
+			return nil, err
+		}
+	}
+	if err := marshal.AddYAMLField(node, "started", "", obj.Started); err != nil {
+		return nil, err
+	}
+	if err := marshal.AddYAMLField(node, "listeners", "Listeners are tried in order.", obj.Listeners); err != nil {
+		return nil, err
+	}
+	if obj.Primary != nil {
+		if err := marshal.AddYAMLField(node, "primary", "", obj.Primary); err != nil {
+			return nil, err
+		}
+	}
+	if len(obj.Labels) != 0 {
+		if err := marshal.AddYAMLField(node, "labels", "", obj.Labels); err != nil {
+			return nil, err
+		}
+	}
+	if err := marshal.AddYAMLField(node, "palette", "", obj.Palette); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// UnmarshalYAML decodes the YAML mapping value into obj, matching keys to
+// fields like UnmarshalJSONBinary does. Unknown keys are ignored.
+func (obj *Config) UnmarshalYAML(value *yamlv3.Node) error {
+	return marshal.DecodeYAMLMapping(value, func(key string, value *yamlv3.Node) error {
+		switch key {
+		case "version", "name", "port", "debug", "level", "timeout", "started", "listeners", "primary", "labels", "palette":
+		default:
+			switch marshal.FoldName(key) {
+			case "VERSION":
+				key = "version"
+			case "NAME":
+				key = "name"
+			case "PORT":
+				key = "port"
+			case "DEBUG":
+				key = "debug"
+			case "LEVEL":
+				key = "level"
+			case "TIMEOUT":
+				key = "timeout"
+			case "STARTED":
+				key = "started"
+			case "LISTENERS":
+				key = "listeners"
+			case "PRIMARY":
+				key = "primary"
+			case "LABELS":
+				key = "labels"
+			case "PALETTE":
+				key = "palette"
+			}
+		}
+		switch key {
+		case "version":
+			return marshal.DecodeYAML(value, &obj.ConfigBase.Version)
+		case "name":
+			return marshal.DecodeYAML(value, &obj.Name)
+		case "port":
+			return marshal.DecodeYAML(value, &obj.Port)
+		case "debug":
+			return marshal.DecodeYAMLQuoted(value, &obj.Debug)
+		case "level":
+			return marshal.DecodeYAML(value, &obj.Level)
+		case "timeout":
+			return marshal.DecodeYAML(value, &obj.Timeout)
+		case "started":
+			return marshal.DecodeYAML(value, &obj.Started)
+		case "listeners":
+			return marshal.DecodeYAML(value, &obj.Listeners)
+		case "primary":
+			return marshal.DecodeYAML(value, &obj.Primary)
+		case "labels":
+			return marshal.DecodeYAML(value, &obj.Labels)
+		case "palette":
+			return marshal.DecodeYAML(value, &obj.Palette)
+		}
+		return nil
+	})
+}
+
+// MarshalTOML returns the TOML document of obj. Its keys follow the json tags
+// of the fields and keep their declaration order, with their doc comments.
+// TOML has no null: nil fields are left out.
+func (obj Config) MarshalTOML() ([]byte, error) {
+	t := marshal.NewTOMLTable()
+	if err := obj.WriteTOML(t); err != nil {
+		return nil, err
+	}
+	return t.MarshalTOML()
+}
+
+// WriteTOML adds the fields of obj to t. It is used by MarshalTOML of the
+// types containing this one.
+func (obj Config) WriteTOML(t *marshal.TOMLTable) error {
+	if err := t.Add("version", "Version of the configuration format.", obj.ConfigBase.Version); err != nil {
+		return err
+	}
+	if err := t.Add("name", "Name identifies the service.", obj.Name); err != nil {
+		return err
+	}
+	if obj.Port != 0 {
+		if err := t.Add("port", "Port is the port to listen on.\nZero picks a free one.", obj.Port); err != nil {
+			return err
+		}
+	}
+	if err := t.AddQuoted("debug", "Debug enables verbose logs.", obj.Debug); err != nil {
+		return err
+	}
+	if obj.Level != "" {
+		if err := t.Add("level", "", obj.Level); err != nil {
+			return err
+		}
+	}
+	if obj.Timeout != nil {
+		if err := t.Add("timeout", "", obj.Timeout); err != nil {
+			return err
+		}
+	}
+	if err := t.Add("started", "", obj.Started); err != nil {
+		return err
+	}
+	if err := t.Add("listeners", "Listeners are tried in order.", obj.Listeners); err != nil {
+		return err
+	}
+	if obj.Primary != nil {
+		if err := t.Add("primary", "", obj.Primary); err != nil {
+			return err
+		}
+	}
+	if len(obj.Labels) != 0 {
+		if err := t.Add("labels", "", obj.Labels); err != nil {
+			return err
+		}
+	}
+	if err := t.Add("palette", "", obj.Palette); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnmarshalTOML decodes the value of a TOML table, as given by the TOML
+// library, into obj, matching keys to fields like UnmarshalJSONBinary does.
+func (obj *Config) UnmarshalTOML(data interface{}) error {
+	return marshal.DecodeTOML(data, obj)
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *ConfigBase) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *ConfigBase) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *ConfigBase) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *ConfigBase) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *ConfigBase) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *ConfigBase) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"version":`)
+	jw.Int(int64(obj.Version))
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *ConfigBase) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "version":
+		default:
+			switch marshal.FoldName(key) {
+			case "VERSION":
+				key = "version"
+			}
+		}
+		switch key {
+		case "version":
+			if err := dec.Decode(&obj.Version); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *ConfigBase) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *ConfigBase) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "version":
+		default:
+			switch marshal.FoldName(key) {
+			case "VERSION":
+				key = "version"
+			}
+		}
+		switch key {
+		case "version":
+			out := &obj.Version
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *Customer) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *Customer) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *Customer) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *Customer) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *Customer) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *Customer) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"Name":`)
+	jw.String(obj.Name)
+	jw.Raw(`,"Email":`)
+	jw.String(obj.Email)
+	jw.Raw(`,"VIP":`)
+	jw.Bool(obj.VIP)
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *Customer) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "Name", "Email", "VIP":
+		default:
+			switch marshal.FoldName(key) {
+			case "NAME":
+				key = "Name"
+			case "EMAIL":
+				key = "Email"
+			case "VIP":
+				key = "VIP"
+			}
+		}
+		switch key {
+		case "Name":
+			if err := dec.Decode(&obj.Name); err != nil {
+				return err
+			}
+		case "Email":
+			if err := dec.Decode(&obj.Email); err != nil {
+				return err
+			}
+		case "VIP":
+			if err := dec.Decode(&obj.VIP); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *Customer) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *Customer) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "Name", "Email", "VIP":
+		default:
+			switch marshal.FoldName(key) {
+			case "NAME":
+				key = "Name"
+			case "EMAIL":
+				key = "Email"
+			case "VIP":
+				key = "VIP"
+			}
+		}
+		switch key {
+		case "Name":
+			out := &obj.Name
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Email":
+			out := &obj.Email
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "VIP":
+			out := &obj.VIP
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalXML writes the XML element of obj, following the xml tags of its
+// fields like encoding/xml does but without reflection.
+func (obj Customer) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if obj.Email != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "email"}, Value: obj.Email})
+	}
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "vip"}, Value: strconv.FormatBool(obj.VIP)})
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := e.EncodeToken(xml.CharData(obj.Name)); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// UnmarshalXML decodes the XML element starting with start into obj,
+// following the xml tags of its fields like encoding/xml does. Unknown
+// attributes and elements are ignored.
+func (obj *Customer) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch {
+		case attr.Name.Local == "email":
+			obj.Email = attr.Value
+		case attr.Name.Local == "vip":
+			value, err := marshal.ParseXMLBool(attr.Value)
+			if err != nil {
+				return err
+			}
+			obj.VIP = value
+		}
+	}
+	var charData []byte
+	err := marshal.DecodeXMLElement(d, nil, &charData, nil)
+	if err != nil {
+		return err
+	}
+	obj.Name = string(charData)
+	return nil
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *Event) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *Event) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *Event) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *Event) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *Event) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *Event) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"kind":`)
+	jw.String(obj.Kind)
+	jw.Raw(`,"payload":`)
+	jw.Value(&obj.Payload)
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *Event) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "kind", "payload":
+		default:
+			switch marshal.FoldName(key) {
+			case "KIND":
+				key = "kind"
+			case "PAYLOAD":
+				key = "payload"
+			}
+		}
+		switch key {
+		case "kind":
+			if err := dec.Decode(&obj.Kind); err != nil {
+				return err
+			}
+		case "payload":
+			if err := dec.Decode(&obj.Payload); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *Event) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *Event) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "kind", "payload":
+		default:
+			switch marshal.FoldName(key) {
+			case "KIND":
+				key = "kind"
+			case "PAYLOAD":
+				key = "payload"
+			}
+		}
+		switch key {
+		case "kind":
+			out := &obj.Kind
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "payload":
+			out := &obj.Payload
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *Extension) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *Extension) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *Extension) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *Extension) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *Extension) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *Extension) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"XMLName":`)
+	jw.Value(&obj.XMLName)
+	jw.Raw(`,"Value":`)
+	jw.String(obj.Value)
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *Extension) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "XMLName", "Value":
+		default:
+			switch marshal.FoldName(key) {
+			case "XMLNAME":
+				key = "XMLName"
+			case "VALUE":
+				key = "Value"
+			}
+		}
+		switch key {
+		case "XMLName":
+			if err := dec.Decode(&obj.XMLName); err != nil {
+				return err
+			}
+		case "Value":
+			if err := dec.Decode(&obj.Value); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *Extension) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *Extension) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "XMLName", "Value":
+		default:
+			switch marshal.FoldName(key) {
+			case "XMLNAME":
+				key = "XMLName"
+			case "VALUE":
+				key = "Value"
+			}
+		}
+		switch key {
+		case "XMLName":
+			out := &obj.XMLName
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Value":
+			out := &obj.Value
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *inner) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *inner) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *inner) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *inner) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *inner) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *inner) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"hidden":`)
+	jw.String(obj.Hidden)
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *inner) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "hidden":
+		default:
+			switch marshal.FoldName(key) {
+			case "HIDDEN":
+				key = "hidden"
+			}
+		}
+		switch key {
+		case "hidden":
+			if err := dec.Decode(&obj.Hidden); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *inner) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *inner) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "hidden":
+		default:
+			switch marshal.FoldName(key) {
+			case "HIDDEN":
+				key = "hidden"
+			}
+		}
+		switch key {
+		case "hidden":
+			out := &obj.Hidden
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *innerPtr) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *innerPtr) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *innerPtr) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *innerPtr) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *innerPtr) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *innerPtr) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"unreachable":`)
+	jw.String(obj.Unreachable)
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *innerPtr) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "unreachable":
+		default:
+			switch marshal.FoldName(key) {
+			case "UNREACHABLE":
+				key = "unreachable"
+			}
+		}
+		switch key {
+		case "unreachable":
+			if err := dec.Decode(&obj.Unreachable); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *innerPtr) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *innerPtr) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "unreachable":
+		default:
+			switch marshal.FoldName(key) {
+			case "UNREACHABLE":
+				key = "unreachable"
+			}
+		}
+		switch key {
+		case "unreachable":
+			out := &obj.Unreachable
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *Left) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *Left) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *Left) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *Left) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *Left) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *Left) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"Dup":`)
+	jw.String(obj.Dup)
+	jw.Raw(`,"Pick":`)
+	jw.String(obj.Pick)
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *Left) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "Dup", "Pick":
+		default:
+			switch marshal.FoldName(key) {
+			case "DUP":
+				key = "Dup"
+			case "PICK":
+				key = "Pick"
+			}
+		}
+		switch key {
+		case "Dup":
+			if err := dec.Decode(&obj.Dup); err != nil {
+				return err
+			}
+		case "Pick":
+			if err := dec.Decode(&obj.Pick); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *Left) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *Left) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "Dup", "Pick":
+		default:
+			switch marshal.FoldName(key) {
+			case "DUP":
+				key = "Dup"
+			case "PICK":
+				key = "Pick"
+			}
+		}
+		switch key {
+		case "Dup":
+			out := &obj.Dup
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Pick":
+			out := &obj.Pick
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *Level) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *Level) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// levelEnumConstants pairs the names of the Level constants with their values.
+var levelEnumConstants = struct {
+	names  []string
+	values []Level
+}{
+	names:  []string{"debug", "info", "err"},
+	values: []Level{LevelDebug, LevelInfo, LevelError},
+}
+
+// ParseLevel returns the Level constant called name.
+func ParseLevel(name string) (Level, error) {
+	for i, n := range levelEnumConstants.names {
+		if n == name {
+			return levelEnumConstants.values[i], nil
+		}
+	}
+	var zero Level
+	return zero, fmt.Errorf("invalid Level %q", name)
+}
+
+// LevelValues returns the values of the Level constants, in declaration
+// order.
+func LevelValues() []Level {
+	return []Level{LevelDebug, LevelInfo, LevelError}
+}
+
+// MarshalText implements encoding.TextMarshaler. It writes the name of the
+// constant obj equals, or the underlying value of obj if there is none, e.g.
+// for a zero value without a constant.
+func (obj Level) MarshalText() ([]byte, error) {
+	for i, v := range levelEnumConstants.values {
+		if v == obj {
+			return []byte(levelEnumConstants.names[i]), nil
+		}
+	}
+	return []byte(string(obj)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It reads the names
+// ParseLevel accepts, and the underlying values MarshalText writes.
+func (obj *Level) UnmarshalText(text []byte) error {
+	if v, err := ParseLevel(string(text)); err == nil {
+		*obj = v
+		return nil
+	}
+	*obj = Level(text)
+	return nil
+}
+
+// String returns the name of the constant obj equals, or the value of obj
+// after the type name if there is none.
+func (obj Level) String() string {
+	for i, v := range levelEnumConstants.values {
+		if v == obj {
+			return levelEnumConstants.names[i]
+		}
+	}
+	return fmt.Sprintf("Level(%q)", string(obj))
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *Level) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *Level) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *Level) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.Text(*obj)
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *Level) ReadJSON(dec *marshal.Decoder) error {
+	return dec.Decode(obj)
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *Listener) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *Listener) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *Listener) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *Listener) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *Listener) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *Listener) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"address":`)
+	jw.String(obj.Address)
+	if obj.TLS {
+		jw.Raw(`,"tls":`)
+		jw.Bool(obj.TLS)
+	}
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *Listener) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "address", "tls":
+		default:
+			switch marshal.FoldName(key) {
+			case "ADDRESS":
+				key = "address"
+			case "TLS":
+				key = "tls"
+			}
+		}
+		switch key {
+		case "address":
+			if err := dec.Decode(&obj.Address); err != nil {
+				return err
+			}
+		case "tls":
+			if err := dec.Decode(&obj.TLS); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *Listener) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *Listener) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "address", "tls":
+		default:
+			switch marshal.FoldName(key) {
+			case "ADDRESS":
+				key = "address"
+			case "TLS":
+				key = "tls"
+			}
+		}
+		switch key {
+		case "address":
+			out := &obj.Address
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "tls":
+			out := &obj.TLS
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalYAML returns the YAML mapping of obj. Its keys follow the json tags
+// of the fields and keep their declaration order, with their doc comments.
+func (obj Listener) MarshalYAML() (interface{}, error) {
+	node := marshal.NewYAMLMapping()
+	if err := marshal.AddYAMLField(node, "address", "Address is a host:port pair.", obj.Address); err != nil {
+		return nil, err
+	}
+	if obj.TLS {
+		if err := marshal.AddYAMLField(node, "tls", "", obj.TLS); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+// UnmarshalYAML decodes the YAML mapping value into obj, matching keys to
+// fields like UnmarshalJSONBinary does. Unknown keys are ignored.
+func (obj *Listener) UnmarshalYAML(value *yamlv3.Node) error {
+	return marshal.DecodeYAMLMapping(value, func(key string, value *yamlv3.Node) error {
+		switch key {
+		case "address", "tls":
+		default:
+			switch marshal.FoldName(key) {
+			case "ADDRESS":
+				key = "address"
+			case "TLS":
+				key = "tls"
+			}
+		}
+		switch key {
+		case "address":
+			return marshal.DecodeYAML(value, &obj.Address)
+		case "tls":
+			return marshal.DecodeYAML(value, &obj.TLS)
+		}
+		return nil
+	})
+}
+
+// MarshalTOML returns the TOML document of obj. Its keys follow the json tags
+// of the fields and keep their declaration order, with their doc comments.
+// TOML has no null: nil fields are left out.
+func (obj Listener) MarshalTOML() ([]byte, error) {
+	t := marshal.NewTOMLTable()
+	if err := obj.WriteTOML(t); err != nil {
+		return nil, err
+	}
+	return t.MarshalTOML()
+}
+
+// WriteTOML adds the fields of obj to t. It is used by MarshalTOML of the
+// types containing this one.
+func (obj Listener) WriteTOML(t *marshal.TOMLTable) error {
+	if err := t.Add("address", "Address is a host:port pair.", obj.Address); err != nil {
+		return err
+	}
+	if obj.TLS {
+		if err := t.Add("tls", "", obj.TLS); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalTOML decodes the value of a TOML table, as given by the TOML
+// library, into obj, matching keys to fields like UnmarshalJSONBinary does.
+func (obj *Listener) UnmarshalTOML(data interface{}) error {
+	return marshal.DecodeTOML(data, obj)
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *Meta) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *Meta) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *Meta) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *Meta) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *Meta) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *Meta) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	wrote := false
+	if len(obj.Labels) != 0 {
+		jw.Raw(`"labels":`)
+		wrote = true
+		if obj.Labels == nil {
+			jw.Null()
+		} else {
+			keys := make([]string, 0, len(obj.Labels))
+			for key := range obj.Labels {
+				keys = append(keys, key)
+			}
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+			jw.RawByte('{')
+			for i, key := range keys {
+				if i > 0 {
+					jw.RawByte(',')
+				}
+				jw.String(key)
+				jw.RawByte(':')
+				val := obj.Labels[key]
+				jw.String(val)
+			}
+			jw.RawByte('}')
+		}
+	}
+	if wrote {
+		jw.RawByte(',')
+	}
+	jw.Raw(`"note":`)
+	if obj.Note == nil {
+		jw.Null()
+	} else {
+		jw.QuotedString(*obj.Note)
+	}
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *Meta) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "labels", "note":
+		default:
+			switch marshal.FoldName(key) {
+			case "LABELS":
+				key = "labels"
+			case "NOTE":
+				key = "note"
+			}
+		}
+		switch key {
+		case "labels":
+			if err := dec.Decode(&obj.Labels); err != nil {
+				return err
+			}
+		case "note":
+			if err := dec.DecodeQuoted(&obj.Note); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *Meta) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *Meta) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "labels", "note":
+		default:
+			switch marshal.FoldName(key) {
+			case "LABELS":
+				key = "labels"
+			case "NOTE":
+				key = "note"
+			}
+		}
+		switch key {
+		case "labels":
+			out := &obj.Labels
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "note":
+			out := &obj.Note
+			if err := marshal.UnmarshalQuoted(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *Named) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *Named) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *Named) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *Named) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *Named) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *Named) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"X":`)
+	jw.Int(int64(obj.X))
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *Named) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "X":
+		default:
+			switch marshal.FoldName(key) {
+			case "X":
+				key = "X"
+			}
+		}
+		switch key {
+		case "X":
+			if err := dec.Decode(&obj.X); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *Named) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *Named) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "X":
+		default:
+			switch marshal.FoldName(key) {
+			case "X":
+				key = "X"
+			}
+		}
+		switch key {
+		case "X":
+			out := &obj.X
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *Offset) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *Offset) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *Offset) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *Offset) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *Offset) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *Offset) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.Int(int64(*obj))
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *Offset) ReadJSON(dec *marshal.Decoder) error {
+	if err := dec.Decode(obj); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *Order) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *Order) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *Order) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *Order) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *Order) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *Order) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"XMLName":`)
+	jw.Value(&obj.XMLName)
+	jw.Raw(`,"ID":`)
+	jw.Int(obj.OrderBase.ID)
+	jw.Raw(`,"Version":`)
+	jw.Uint(uint64(obj.OrderBase.Version))
+	jw.Raw(`,"Status":`)
+	jw.Text(obj.Status)
+	jw.Raw(`,"Kind":`)
+	jw.Text(obj.Kind)
+	jw.Raw(`,"Rush":`)
+	if obj.Rush == nil {
+		jw.Null()
+	} else {
+		jw.Bool(*obj.Rush)
+	}
+	jw.Raw(`,"Currency":`)
+	jw.String(obj.Currency)
+	jw.Raw(`,"Extra":`)
+	if obj.Extra == nil {
+		jw.Null()
+	} else {
+		jw.RawByte('[')
+		for i := range obj.Extra {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			in := &obj.Extra[i]
+			jw.Value(in)
+		}
+		jw.RawByte(']')
+	}
+	jw.Raw(`,"Note":`)
+	jw.String(obj.Note)
+	jw.Raw(`,"Customer":`)
+	obj.Customer.WriteJSON(jw)
+	jw.Raw(`,"Billing":`)
+	obj.Billing.WriteJSON(jw)
+	jw.Raw(`,"Lines":`)
+	if obj.Lines == nil {
+		jw.Null()
+	} else {
+		jw.RawByte('[')
+		for i := range obj.Lines {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			in := &obj.Lines[i]
+			in.WriteJSON(jw)
+		}
+		jw.RawByte(']')
+	}
+	jw.Raw(`,"Coupons":`)
+	if obj.Coupons == nil {
+		jw.Null()
+	} else {
+		jw.RawByte('[')
+		for i := range obj.Coupons {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			in := &obj.Coupons[i]
+			jw.String(*in)
+		}
+		jw.RawByte(']')
+	}
+	jw.Raw(`,"Placed":`)
+	jw.Value(&obj.Placed)
+	jw.Raw(`,"Total":`)
+	jw.Float(obj.Total, 64)
+	jw.Raw(`,"Ref":`)
+	jw.String(obj.Ref)
+	jw.Raw(`,"Colors":`)
+	if obj.Colors == nil {
+		jw.Null()
+	} else {
+		jw.RawByte('[')
+		for i := range obj.Colors {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			in := &obj.Colors[i]
+			jw.Text(*in)
+		}
+		jw.RawByte(']')
+	}
+	jw.Raw(`,"Rest":`)
+	if obj.Rest == nil {
+		jw.Null()
+	} else {
+		jw.RawByte('[')
+		for i := range obj.Rest {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			in := &obj.Rest[i]
+			in.WriteJSON(jw)
+		}
+		jw.RawByte(']')
+	}
+	jw.Raw(`,"Internal":`)
+	jw.String(obj.Internal)
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *Order) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "XMLName", "ID", "Version", "Status", "Kind", "Rush", "Currency", "Extra", "Note", "Customer", "Billing", "Lines", "Coupons", "Placed", "Total", "Ref", "Colors", "Rest", "Internal":
+		default:
+			switch marshal.FoldName(key) {
+			case "XMLNAME":
+				key = "XMLName"
+			case "ID":
+				key = "ID"
+			case "VERSION":
+				key = "Version"
+			case "STATUS":
+				key = "Status"
+			case "KIND":
+				key = "Kind"
+			case "RUSH":
+				key = "Rush"
+			case "CURRENCY":
+				key = "Currency"
+			case "EXTRA":
+				key = "Extra"
+			case "NOTE":
+				key = "Note"
+			case "CUSTOMER":
+				key = "Customer"
+			case "BILLING":
+				key = "Billing"
+			case "LINES":
+				key = "Lines"
+			case "COUPONS":
+				key = "Coupons"
+			case "PLACED":
+				key = "Placed"
+			case "TOTAL":
+				key = "Total"
+			case "REF":
+				key = "Ref"
+			case "COLORS":
+				key = "Colors"
+			case "REST":
+				key = "Rest"
+			case "INTERNAL":
+				key = "Internal"
+			}
+		}
+		switch key {
+		case "XMLName":
+			if err := dec.Decode(&obj.XMLName); err != nil {
+				return err
+			}
+		case "ID":
+			if err := dec.Decode(&obj.OrderBase.ID); err != nil {
+				return err
+			}
+		case "Version":
+			if err := dec.Decode(&obj.OrderBase.Version); err != nil {
+				return err
+			}
+		case "Status":
+			if err := dec.Decode(&obj.Status); err != nil {
+				return err
+			}
+		case "Kind":
+			if err := dec.Decode(&obj.Kind); err != nil {
+				return err
+			}
+		case "Rush":
+			if err := dec.Decode(&obj.Rush); err != nil {
+				return err
+			}
+		case "Currency":
+			if err := dec.Decode(&obj.Currency); err != nil {
+				return err
+			}
+		case "Extra":
+			if err := dec.Decode(&obj.Extra); err != nil {
+				return err
+			}
+		case "Note":
+			if err := dec.Decode(&obj.Note); err != nil {
+				return err
+			}
+		case "Customer":
+			if err := obj.Customer.ReadJSON(dec); err != nil {
+				return err
+			}
+		case "Billing":
+			if null, err := dec.Null(); err != nil {
+				return err
+			} else if null {
+				obj.Billing = nil
+			} else {
+				if obj.Billing == nil {
+					obj.Billing = new(shared.Address)
+				}
+				if err := obj.Billing.ReadJSON(dec); err != nil {
+					return err
+				}
+			}
+		case "Lines":
+			if ok, err := dec.Begin('['); err != nil {
+				return err
+			} else if !ok {
+				obj.Lines = nil
+			} else {
+				if obj.Lines == nil {
+					obj.Lines = []OrderLine{}
+				}
+				obj.Lines = obj.Lines[:0]
+				for dec.More() {
+					var val OrderLine
+					if err := val.ReadJSON(dec); err != nil {
+						return err
+					}
+					obj.Lines = append(obj.Lines, val)
+				}
+				if err := dec.End(']'); err != nil {
+					return err
+				}
+			}
+		case "Coupons":
+			if err := dec.Decode(&obj.Coupons); err != nil {
+				return err
+			}
+		case "Placed":
+			if err := dec.Decode(&obj.Placed); err != nil {
+				return err
+			}
+		case "Total":
+			if err := dec.Decode(&obj.Total); err != nil {
+				return err
+			}
+		case "Ref":
+			if err := dec.Decode(&obj.Ref); err != nil {
+				return err
+			}
+		case "Colors":
+			if err := dec.Decode(&obj.Colors); err != nil {
+				return err
+			}
+		case "Rest":
+			if ok, err := dec.Begin('['); err != nil {
+				return err
+			} else if !ok {
+				obj.Rest = nil
+			} else {
+				if obj.Rest == nil {
+					obj.Rest = []Extension{}
+				}
+				obj.Rest = obj.Rest[:0]
+				for dec.More() {
+					var val Extension
+					if err := val.ReadJSON(dec); err != nil {
+						return err
+					}
+					obj.Rest = append(obj.Rest, val)
+				}
+				if err := dec.End(']'); err != nil {
+					return err
+				}
+			}
+		case "Internal":
+			if err := dec.Decode(&obj.Internal); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *Order) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *Order) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "XMLName", "ID", "Version", "Status", "Kind", "Rush", "Currency", "Extra", "Note", "Customer", "Billing", "Lines", "Coupons", "Placed", "Total", "Ref", "Colors", "Rest", "Internal":
+		default:
+			switch marshal.FoldName(key) {
+			case "XMLNAME":
+				key = "XMLName"
+			case "ID":
+				key = "ID"
+			case "VERSION":
+				key = "Version"
+			case "STATUS":
+				key = "Status"
+			case "KIND":
+				key = "Kind"
+			case "RUSH":
+				key = "Rush"
+			case "CURRENCY":
+				key = "Currency"
+			case "EXTRA":
+				key = "Extra"
+			case "NOTE":
+				key = "Note"
+			case "CUSTOMER":
+				key = "Customer"
+			case "BILLING":
+				key = "Billing"
+			case "LINES":
+				key = "Lines"
+			case "COUPONS":
+				key = "Coupons"
+			case "PLACED":
+				key = "Placed"
+			case "TOTAL":
+				key = "Total"
+			case "REF":
+				key = "Ref"
+			case "COLORS":
+				key = "Colors"
+			case "REST":
+				key = "Rest"
+			case "INTERNAL":
+				key = "Internal"
+			}
+		}
+		switch key {
+		case "XMLName":
+			out := &obj.XMLName
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "ID":
+			out := &obj.OrderBase.ID
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Version":
+			out := &obj.OrderBase.Version
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Status":
+			out := &obj.Status
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Kind":
+			out := &obj.Kind
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Rush":
+			out := &obj.Rush
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Currency":
+			out := &obj.Currency
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Extra":
+			out := &obj.Extra
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Note":
+			out := &obj.Note
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Customer":
+			out := &obj.Customer
+			out.UnmarshalJSONStrict(data, path, errs)
+		case "Billing":
+			out := &obj.Billing
+			if marshal.IsNull(data) {
+				*out = nil
+			} else {
+				if *out == nil {
+					*out = new(shared.Address)
+				}
+				out := *out
+				out.UnmarshalJSONStrict(data, path, errs)
+			}
+		case "Lines":
+			out := &obj.Lines
+			var items []json.RawMessage
+			if err := json.Unmarshal(data, &items); err != nil {
+				errs.Add(path, err)
+			} else if items == nil {
+				*out = nil
+			} else {
+				*out = make([]OrderLine, len(items))
+				for i := range items {
+					data, path, out := items[i], marshal.Index(path, i), &(*out)[i]
+					out.UnmarshalJSONStrict(data, path, errs)
+				}
+			}
+		case "Coupons":
+			out := &obj.Coupons
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Placed":
+			out := &obj.Placed
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Total":
+			out := &obj.Total
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Ref":
+			out := &obj.Ref
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Colors":
+			out := &obj.Colors
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Rest":
+			out := &obj.Rest
+			var items []json.RawMessage
+			if err := json.Unmarshal(data, &items); err != nil {
+				errs.Add(path, err)
+			} else if items == nil {
+				*out = nil
+			} else {
+				*out = make([]Extension, len(items))
+				for i := range items {
+					data, path, out := items[i], marshal.Index(path, i), &(*out)[i]
+					out.UnmarshalJSONStrict(data, path, errs)
+				}
+			}
+		case "Internal":
+			out := &obj.Internal
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalXML writes the XML element of obj, following the xml tags of its
+// fields like encoding/xml does but without reflection.
+func (obj Order) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Space: "urn:example:orders", Local: "order"}
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "id"}, Value: strconv.FormatInt(obj.OrderBase.ID, 10)})
+	if obj.Status != "" {
+		{
+			text, err := obj.Status.MarshalText()
+			if err != nil {
+				return err
+			}
+			start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "status"}, Value: string(text)})
+		}
+	}
+	if obj.Kind != "" {
+		{
+			text, err := obj.Kind.MarshalText()
+			if err != nil {
+				return err
+			}
+			start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "kind"}, Value: string(text)})
+		}
+	}
+	if obj.Rush != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "rush"}, Value: strconv.FormatBool(*obj.Rush)})
+	}
+	if obj.Currency != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Space: "urn:example:money", Local: "currency"}, Value: obj.Currency})
+	}
+	start.Attr = append(start.Attr, obj.Extra...)
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	parents := marshal.NewXMLParents(e)
+	if err := marshal.EncodeXMLText(e, xml.Name{Local: "version"}, strconv.FormatUint(uint64(obj.OrderBase.Version), 10)); err != nil {
+		return err
+	}
+	if err := marshal.EncodeXMLComment(e, obj.Note); err != nil {
+		return err
+	}
+	if err := obj.Customer.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "customer"}}); err != nil {
+		return err
+	}
+	if obj.Billing != nil {
+		if err := e.EncodeElement(*obj.Billing, xml.StartElement{Name: xml.Name{Local: "billing"}}); err != nil {
+			return err
+		}
+	}
+	if err := parents.Push("lines"); err != nil {
+		return err
+	}
+	for _, v := range obj.Lines {
+		if err := v.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "line"}}); err != nil {
+			return err
+		}
+	}
+	if err := parents.Trim("lines"); err != nil {
+		return err
+	}
+	if err := parents.Push("lines"); err != nil {
+		return err
+	}
+	if len(obj.Coupons) != 0 {
+		for _, v := range obj.Coupons {
+			if v != "" {
+				if err := marshal.EncodeXMLText(e, xml.Name{Local: "coupon"}, v); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if err := parents.Trim(); err != nil {
+		return err
+	}
+	{
+		text, err := obj.Placed.MarshalText()
+		if err != nil {
+			return err
+		}
+		if err := marshal.EncodeXMLText(e, xml.Name{Local: "placed"}, string(text)); err != nil {
+			return err
+		}
+	}
+	if err := parents.Trim(); err != nil {
+		return err
+	}
+	if err := marshal.EncodeXMLText(e, xml.Name{Local: "total"}, strconv.FormatFloat(obj.Total, 'g', -1, 64)); err != nil {
+		return err
+	}
+	if err := parents.Trim(); err != nil {
+		return err
+	}
+	if obj.Ref != "" {
+		if err := marshal.EncodeXMLText(e, xml.Name{Space: "urn:example:refs", Local: "ref"}, obj.Ref); err != nil {
+			return err
+		}
+	}
+	if err := parents.Trim(); err != nil {
+		return err
+	}
+	if len(obj.Colors) != 0 {
+		for _, v := range obj.Colors {
+			if v != 0 {
+				{
+					text, err := v.MarshalText()
+					if err != nil {
+						return err
+					}
+					if err := marshal.EncodeXMLText(e, xml.Name{Local: "color"}, string(text)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	if err := parents.Trim(); err != nil {
+		return err
+	}
+	for _, v := range obj.Rest {
+		{
+			start := xml.StartElement{Name: xml.Name{Local: "Rest"}}
+			if v.XMLName.Local != "" {
+				start.Name = v.XMLName
+			}
+			if err := e.EncodeElement(v, start); err != nil {
+				return err
+			}
+		}
+	}
+	if err := parents.Trim(); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// UnmarshalXML decodes the XML element starting with start into obj,
+// following the xml tags of its fields like encoding/xml does. Unknown
+// attributes and elements are ignored.
+func (obj *Order) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	if err := marshal.CheckXMLName(start, "urn:example:orders", "order"); err != nil {
+		return err
+	}
+	obj.XMLName = start.Name
+	for _, attr := range start.Attr {
+		switch {
+		case attr.Name.Local == "id":
+			value, err := marshal.ParseXMLInt(attr.Value, 64)
+			if err != nil {
+				return err
+			}
+			obj.OrderBase.ID = value
+		case attr.Name.Local == "status":
+			if err := obj.Status.UnmarshalText([]byte(attr.Value)); err != nil {
+				return err
+			}
+		case attr.Name.Local == "kind":
+			if err := obj.Kind.UnmarshalText([]byte(attr.Value)); err != nil {
+				return err
+			}
+		case attr.Name.Local == "rush":
+			if obj.Rush == nil {
+				obj.Rush = new(bool)
+			}
+			value, err := marshal.ParseXMLBool(attr.Value)
+			if err != nil {
+				return err
+			}
+			*obj.Rush = value
+		case attr.Name.Local == "currency" && attr.Name.Space == "urn:example:money":
+			obj.Currency = attr.Value
+		default:
+			obj.Extra = append(obj.Extra, attr)
+		}
+	}
+	var comment []byte
+	err := marshal.DecodeXMLElement(d, func(start xml.StartElement) (bool, error) {
+		switch {
+		case start.Name.Local == "version":
+			text, err := marshal.DecodeXMLText(d)
+			if err != nil {
+				return true, err
+			}
+			value, err := marshal.ParseXMLUint(text, 0)
+			if err != nil {
+				return true, err
+			}
+			obj.OrderBase.Version = uint(value)
+			return true, nil
+		case start.Name.Local == "customer":
+			if err := obj.Customer.UnmarshalXML(d, start); err != nil {
+				return true, err
+			}
+			return true, nil
+		case start.Name.Local == "billing":
+			if obj.Billing == nil {
+				obj.Billing = new(shared.Address)
+			}
+			if err := d.DecodeElement(obj.Billing, &start); err != nil {
+				return true, err
+			}
+			return true, nil
+		case start.Name.Local == "lines":
+			return true, marshal.DecodeXMLElement(d, func(start xml.StartElement) (bool, error) {
+				switch {
+				case start.Name.Local == "line":
+					{
+						var item OrderLine
+						if err := item.UnmarshalXML(d, start); err != nil {
+							return true, err
+						}
+						obj.Lines = append(obj.Lines, item)
+					}
+					return true, nil
+				case start.Name.Local == "coupon":
+					{
+						var item string
+						text, err := marshal.DecodeXMLText(d)
+						if err != nil {
+							return true, err
+						}
+						item = text
+						obj.Coupons = append(obj.Coupons, item)
+					}
+					return true, nil
+				}
+				return false, nil
+			}, nil, nil)
+		case start.Name.Local == "placed":
+			text, err := marshal.DecodeXMLText(d)
+			if err != nil {
+				return true, err
+			}
+			if err := obj.Placed.UnmarshalText([]byte(text)); err != nil {
+				return true, err
+			}
+			return true, nil
+		case start.Name.Local == "total":
+			text, err := marshal.DecodeXMLText(d)
+			if err != nil {
+				return true, err
+			}
+			value, err := marshal.ParseXMLFloat(text, 64)
+			if err != nil {
+				return true, err
+			}
+			obj.Total = value
+			return true, nil
+		case start.Name.Local == "ref" && start.Name.Space == "urn:example:refs":
+			text, err := marshal.DecodeXMLText(d)
+			if err != nil {
+				return true, err
+			}
+			obj.Ref = text
+			return true, nil
+		case start.Name.Local == "color":
+			{
+				var item Color
+				text, err := marshal.DecodeXMLText(d)
+				if err != nil {
+					return true, err
+				}
+				if err := item.UnmarshalText([]byte(text)); err != nil {
+					return true, err
+				}
+				obj.Colors = append(obj.Colors, item)
+			}
+			return true, nil
+		case start.Name.Local == "Rest":
+			{
+				var item Extension
+				if err := d.DecodeElement(&item, &start); err != nil {
+					return true, err
+				}
+				obj.Rest = append(obj.Rest, item)
+			}
+			return true, nil
+		default:
+			{
+				var item Extension
+				if err := d.DecodeElement(&item, &start); err != nil {
+					return true, err
+				}
+				obj.Rest = append(obj.Rest, item)
+			}
+			return true, nil
+		}
+	}, nil, &comment)
+	if err != nil {
+		return err
+	}
+	obj.Note = string(comment)
+	return nil
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *OrderBase) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *OrderBase) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *OrderBase) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *OrderBase) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *OrderBase) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *OrderBase) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"ID":`)
+	jw.Int(obj.ID)
+	jw.Raw(`,"Version":`)
+	jw.Uint(uint64(obj.Version))
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *OrderBase) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "ID", "Version":
+		default:
+			switch marshal.FoldName(key) {
+			case "ID":
+				key = "ID"
+			case "VERSION":
+				key = "Version"
+			}
+		}
+		switch key {
+		case "ID":
+			if err := dec.Decode(&obj.ID); err != nil {
+				return err
+			}
+		case "Version":
+			if err := dec.Decode(&obj.Version); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *OrderBase) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *OrderBase) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "ID", "Version":
+		default:
+			switch marshal.FoldName(key) {
+			case "ID":
+				key = "ID"
+			case "VERSION":
+				key = "Version"
+			}
+		}
+		switch key {
+		case "ID":
+			out := &obj.ID
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Version":
+			out := &obj.Version
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *OrderLine) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *OrderLine) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *OrderLine) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *OrderLine) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *OrderLine) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *OrderLine) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"SKU":`)
+	jw.String(obj.SKU)
+	jw.Raw(`,"Quantity":`)
+	jw.Uint(uint64(obj.Quantity))
+	jw.Raw(`,"Price":`)
+	jw.Float(float64(obj.Price), 32)
+	jw.Raw(`,"Gift":`)
+	jw.Bytes(obj.Gift)
+	jw.Raw(`,"Discount":`)
+	jw.Int(int64(obj.Discount))
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *OrderLine) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "SKU", "Quantity", "Price", "Gift", "Discount":
+		default:
+			switch marshal.FoldName(key) {
+			case "SKU":
+				key = "SKU"
+			case "QUANTITY":
+				key = "Quantity"
+			case "PRICE":
+				key = "Price"
+			case "GIFT":
+				key = "Gift"
+			case "DISCOUNT":
+				key = "Discount"
+			}
+		}
+		switch key {
+		case "SKU":
+			if err := dec.Decode(&obj.SKU); err != nil {
+				return err
+			}
+		case "Quantity":
+			if err := dec.Decode(&obj.Quantity); err != nil {
+				return err
+			}
+		case "Price":
+			if err := dec.Decode(&obj.Price); err != nil {
+				return err
+			}
+		case "Gift":
+			if err := dec.Decode(&obj.Gift); err != nil {
+				return err
+			}
+		case "Discount":
+			if err := dec.Decode(&obj.Discount); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *OrderLine) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *OrderLine) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "SKU", "Quantity", "Price", "Gift", "Discount":
+		default:
+			switch marshal.FoldName(key) {
+			case "SKU":
+				key = "SKU"
+			case "QUANTITY":
+				key = "Quantity"
+			case "PRICE":
+				key = "Price"
+			case "GIFT":
+				key = "Gift"
+			case "DISCOUNT":
+				key = "Discount"
+			}
+		}
+		switch key {
+		case "SKU":
+			out := &obj.SKU
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Quantity":
+			out := &obj.Quantity
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Price":
+			out := &obj.Price
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Gift":
+			out := &obj.Gift
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Discount":
+			out := &obj.Discount
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalXML writes the XML element of obj, following the xml tags of its
+// fields like encoding/xml does but without reflection.
+func (obj OrderLine) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "sku"}, Value: obj.SKU})
+	if obj.Discount != 0 {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "discount"}, Value: strconv.FormatInt(int64(obj.Discount), 10)})
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := marshal.EncodeXMLText(e, xml.Name{Local: "qty"}, strconv.FormatUint(uint64(obj.Quantity), 10)); err != nil {
+		return err
+	}
+	if err := marshal.EncodeXMLText(e, xml.Name{Local: "price"}, strconv.FormatFloat(float64(obj.Price), 'g', -1, 32)); err != nil {
+		return err
+	}
+	if len(obj.Gift) != 0 {
+		if err := marshal.EncodeXMLText(e, xml.Name{Local: "gift"}, string(obj.Gift)); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// UnmarshalXML decodes the XML element starting with start into obj,
+// following the xml tags of its fields like encoding/xml does. Unknown
+// attributes and elements are ignored.
+func (obj *OrderLine) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch {
+		case attr.Name.Local == "sku":
+			obj.SKU = attr.Value
+		case attr.Name.Local == "discount":
+			value, err := marshal.ParseXMLInt(attr.Value, 8)
+			if err != nil {
+				return err
+			}
+			obj.Discount = int8(value)
+		}
+	}
+	return marshal.DecodeXMLElement(d, func(start xml.StartElement) (bool, error) {
+		switch {
+		case start.Name.Local == "qty":
+			text, err := marshal.DecodeXMLText(d)
+			if err != nil {
+				return true, err
+			}
+			value, err := marshal.ParseXMLUint(text, 16)
+			if err != nil {
+				return true, err
+			}
+			obj.Quantity = uint16(value)
+			return true, nil
+		case start.Name.Local == "price":
+			text, err := marshal.DecodeXMLText(d)
+			if err != nil {
+				return true, err
+			}
+			value, err := marshal.ParseXMLFloat(text, 32)
+			if err != nil {
+				return true, err
+			}
+			obj.Price = float32(value)
+			return true, nil
+		case start.Name.Local == "gift":
+			text, err := marshal.DecodeXMLText(d)
+			if err != nil {
+				return true, err
+			}
+			obj.Gift = []byte(text)
+			return true, nil
+		}
+		return false, nil
+	}, nil, nil)
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *Right) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *Right) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *Right) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *Right) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *Right) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *Right) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"Dup":`)
+	jw.String(obj.Dup)
+	jw.Raw(`,"Pick":`)
+	jw.String(obj.Pick)
+	jw.Raw(`,"only":`)
+	jw.Uint(uint64(obj.Only))
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *Right) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "Dup", "Pick", "only":
+		default:
+			switch marshal.FoldName(key) {
+			case "DUP":
+				key = "Dup"
+			case "PICK":
+				key = "Pick"
+			case "ONLY":
+				key = "only"
+			}
+		}
+		switch key {
+		case "Dup":
+			if err := dec.Decode(&obj.Dup); err != nil {
+				return err
+			}
+		case "Pick":
+			if err := dec.Decode(&obj.Pick); err != nil {
+				return err
+			}
+		case "only":
+			if err := dec.Decode(&obj.Only); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *Right) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *Right) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "Dup", "Pick", "only":
+		default:
+			switch marshal.FoldName(key) {
+			case "DUP":
+				key = "Dup"
+			case "PICK":
+				key = "Pick"
+			case "ONLY":
+				key = "only"
+			}
+		}
+		switch key {
+		case "Dup":
+			out := &obj.Dup
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Pick":
+			out := &obj.Pick
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "only":
+			out := &obj.Only
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *Session) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *Session) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *Session) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *Session) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *Session) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *Session) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"id":`)
+	jw.String(obj.ID)
+	jw.Raw(`,"visits":`)
+	jw.Int(int64(obj.Visits))
+	jw.Raw(`,"color":`)
+	jw.Text(obj.Color)
+	if obj.Level != nil {
+		jw.Raw(`,"level":`)
+		if obj.Level == nil {
+			jw.Null()
+		} else {
+			jw.Text(*obj.Level)
+		}
+	}
+	jw.Raw(`,"expires":`)
+	jw.Value(&obj.Expires)
+	jw.Raw(`,"items":`)
+	if obj.Items == nil {
+		jw.Null()
+	} else {
+		jw.RawByte('[')
+		for i := range obj.Items {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			in := &obj.Items[i]
+			in.WriteJSON(jw)
+		}
+		jw.RawByte(']')
+	}
+	jw.Raw(`,"by_name":`)
+	if obj.ByName == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.ByName))
+		for key := range obj.ByName {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.ByName[key]
+			val.WriteJSON(jw)
+		}
+		jw.RawByte('}')
+	}
+	jw.Raw(`,"scores":`)
+	jw.RawByte('[')
+	for i := range obj.Scores {
+		if i > 0 {
+			jw.RawByte(',')
+		}
+		in := &obj.Scores[i]
+		jw.Float(*in, 64)
+	}
+	jw.RawByte(']')
+	jw.Raw(`,"matrix":`)
+	if obj.Matrix == nil {
+		jw.Null()
+	} else {
+		jw.RawByte('[')
+		for i := range obj.Matrix {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			in := &obj.Matrix[i]
+			if (*in) == nil {
+				jw.Null()
+			} else {
+				jw.RawByte('[')
+				for i := range *in {
+					if i > 0 {
+						jw.RawByte(',')
+					}
+					in := &(*in)[i]
+					jw.Int(int64(*in))
+				}
+				jw.RawByte(']')
+			}
+		}
+		jw.RawByte(']')
+	}
+	jw.Raw(`,"data":`)
+	jw.Bytes(obj.Data)
+	if obj.Home != nil {
+		jw.Raw(`,"home":`)
+		obj.Home.WriteJSON(jw)
+	}
+	jw.Raw(`,"ratio":`)
+	jw.Float(float64(obj.Ratio), 32)
+	jw.Raw(`,"seen":`)
+	jw.Value(&obj.Seen)
+	jw.Raw(`,"deltas":`)
+	if obj.Deltas == nil {
+		jw.Null()
+	} else {
+		jw.RawByte('[')
+		for i := range obj.Deltas {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			in := &obj.Deltas[i]
+			jw.Int(int64(*in))
+		}
+		jw.RawByte(']')
+	}
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *Session) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "id", "visits", "color", "level", "expires", "items", "by_name", "scores", "matrix", "data", "home", "ratio", "seen", "deltas":
+		default:
+			switch marshal.FoldName(key) {
+			case "ID":
+				key = "id"
+			case "VISITS":
+				key = "visits"
+			case "COLOR":
+				key = "color"
+			case "LEVEL":
+				key = "level"
+			case "EXPIRES":
+				key = "expires"
+			case "ITEMS":
+				key = "items"
+			case "BY_NAME":
+				key = "by_name"
+			case "SCORES":
+				key = "scores"
+			case "MATRIX":
+				key = "matrix"
+			case "DATA":
+				key = "data"
+			case "HOME":
+				key = "home"
+			case "RATIO":
+				key = "ratio"
+			case "SEEN":
+				key = "seen"
+			case "DELTAS":
+				key = "deltas"
+			}
+		}
+		switch key {
+		case "id":
+			if err := dec.Decode(&obj.ID); err != nil {
+				return err
+			}
+		case "visits":
+			if err := dec.Decode(&obj.Visits); err != nil {
+				return err
+			}
+		case "color":
+			if err := dec.Decode(&obj.Color); err != nil {
+				return err
+			}
+		case "level":
+			if err := dec.Decode(&obj.Level); err != nil {
+				return err
+			}
+		case "expires":
+			if err := dec.Decode(&obj.Expires); err != nil {
+				return err
+			}
+		case "items":
+			if ok, err := dec.Begin('['); err != nil {
+				return err
+			} else if !ok {
+				obj.Items = nil
+			} else {
+				if obj.Items == nil {
+					obj.Items = []SessionItem{}
+				}
+				obj.Items = obj.Items[:0]
+				for dec.More() {
+					var val SessionItem
+					if err := val.ReadJSON(dec); err != nil {
+						return err
+					}
+					obj.Items = append(obj.Items, val)
+				}
+				if err := dec.End(']'); err != nil {
+					return err
+				}
+			}
+		case "by_name":
+			if ok, err := dec.Begin('{'); err != nil {
+				return err
+			} else if !ok {
+				obj.ByName = nil
+			} else {
+				if obj.ByName == nil {
+					obj.ByName = make(map[string]*SessionItem)
+				}
+				for dec.More() {
+					key, err := dec.Key()
+					if err != nil {
+						return err
+					}
+					var val *SessionItem
+					if null, err := dec.Null(); err != nil {
+						return err
+					} else if null {
+						val = nil
+					} else {
+						if val == nil {
+							val = new(SessionItem)
+						}
+						if err := val.ReadJSON(dec); err != nil {
+							return err
+						}
+					}
+					obj.ByName[key] = val
+				}
+				if err := dec.End('}'); err != nil {
+					return err
+				}
+			}
+		case "scores":
+			if err := dec.Decode(&obj.Scores); err != nil {
+				return err
+			}
+		case "matrix":
+			if err := dec.Decode(&obj.Matrix); err != nil {
+				return err
+			}
+		case "data":
+			if err := dec.Decode(&obj.Data); err != nil {
+				return err
+			}
+		case "home":
+			if null, err := dec.Null(); err != nil {
+				return err
+			} else if null {
+				obj.Home = nil
+			} else {
+				if obj.Home == nil {
+					obj.Home = new(shared.Address)
+				}
+				if err := obj.Home.ReadJSON(dec); err != nil {
+					return err
+				}
+			}
+		case "ratio":
+			if err := dec.Decode(&obj.Ratio); err != nil {
+				return err
+			}
+		case "seen":
+			if err := dec.Decode(&obj.Seen); err != nil {
+				return err
+			}
+		case "deltas":
+			if err := dec.Decode(&obj.Deltas); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *Session) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *Session) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "id", "visits", "color", "level", "expires", "items", "by_name", "scores", "matrix", "data", "home", "ratio", "seen", "deltas":
+		default:
+			switch marshal.FoldName(key) {
+			case "ID":
+				key = "id"
+			case "VISITS":
+				key = "visits"
+			case "COLOR":
+				key = "color"
+			case "LEVEL":
+				key = "level"
+			case "EXPIRES":
+				key = "expires"
+			case "ITEMS":
+				key = "items"
+			case "BY_NAME":
+				key = "by_name"
+			case "SCORES":
+				key = "scores"
+			case "MATRIX":
+				key = "matrix"
+			case "DATA":
+				key = "data"
+			case "HOME":
+				key = "home"
+			case "RATIO":
+				key = "ratio"
+			case "SEEN":
+				key = "seen"
+			case "DELTAS":
+				key = "deltas"
+			}
+		}
+		switch key {
+		case "id":
+			out := &obj.ID
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "visits":
+			out := &obj.Visits
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "color":
+			out := &obj.Color
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "level":
+			out := &obj.Level
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "expires":
+			out := &obj.Expires
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "items":
+			out := &obj.Items
+			var items []json.RawMessage
+			if err := json.Unmarshal(data, &items); err != nil {
+				errs.Add(path, err)
+			} else if items == nil {
+				*out = nil
+			} else {
+				*out = make([]SessionItem, len(items))
+				for i := range items {
+					data, path, out := items[i], marshal.Index(path, i), &(*out)[i]
+					out.UnmarshalJSONStrict(data, path, errs)
+				}
+			}
+		case "by_name":
+			out := &obj.ByName
+			var items map[string]json.RawMessage
+			if err := json.Unmarshal(data, &items); err != nil {
+				errs.Add(path, err)
+			} else if items == nil {
+				*out = nil
+			} else {
+				*out = make(map[string]*SessionItem, len(items))
+				for _, key := range marshal.SortedKeys(items) {
+					var val *SessionItem
+					{
+						data, path, out := items[key], marshal.Key(path, key), &val
+						if marshal.IsNull(data) {
+							*out = nil
+						} else {
+							if *out == nil {
+								*out = new(SessionItem)
+							}
+							out := *out
+							out.UnmarshalJSONStrict(data, path, errs)
+						}
+					}
+					(*out)[key] = val
+				}
+			}
+		case "scores":
+			out := &obj.Scores
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "matrix":
+			out := &obj.Matrix
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "data":
+			out := &obj.Data
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "home":
+			out := &obj.Home
+			if marshal.IsNull(data) {
+				*out = nil
+			} else {
+				if *out == nil {
+					*out = new(shared.Address)
+				}
+				out := *out
+				out.UnmarshalJSONStrict(data, path, errs)
+			}
+		case "ratio":
+			out := &obj.Ratio
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "seen":
+			out := &obj.Seen
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "deltas":
+			out := &obj.Deltas
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalBinary returns the compact binary encoding of obj: the version of the
+// format, then the fields of obj which are set, tagged by their number.
+func (obj *Session) MarshalBinary() ([]byte, error) {
+	w := marshal.NewBinaryWriter()
+	w.Header()
+	obj.WriteBinary(w)
+	return w.Bytes(), w.Err()
+}
+
+// UnmarshalBinary sets obj to the binary encoding data. Unknown fields are
+// skipped, and fields missing from data are left to their zero value.
+func (obj *Session) UnmarshalBinary(data []byte) error {
+	r := marshal.NewBinaryReader(data)
+	r.Header()
+	obj.ReadBinary(r)
+	return r.Err()
+}
+
+// WriteBinary writes the fields of obj to w, without the version of the format.
+func (obj *Session) WriteBinary(w *marshal.BinaryWriter) {
+	if obj.ID != "" {
+		w.Tag(1, marshal.BinaryBytes)
+		w.String(obj.ID)
+	}
+	if obj.Visits != 0 {
+		w.Tag(2, marshal.BinaryVarint)
+		w.Varint(int64(obj.Visits))
+	}
+	if obj.Color != 0 {
+		w.Tag(4, marshal.BinaryVarint)
+		w.Varint(int64(obj.Color))
+	}
+	if obj.Level != nil {
+		w.Tag(5, marshal.BinaryBytes)
+		w.String(string(*obj.Level))
+	}
+	w.Tag(6, marshal.BinaryBytes)
+	w.Marshaler(&obj.Expires)
+	if obj.Items != nil {
+		w.Tag(7, marshal.BinaryBytes)
+		mark := w.Begin()
+		w.Count(len(obj.Items), obj.Items == nil)
+		for i0 := range obj.Items {
+			w.Struct(&obj.Items[i0])
+		}
+		w.End(mark)
+	}
+	if obj.ByName != nil {
+		w.Tag(8, marshal.BinaryBytes)
+		mark := w.Begin()
+		w.Count(len(obj.ByName), obj.ByName == nil)
+		keys0 := make([]string, 0, len(obj.ByName))
+		for k0 := range obj.ByName {
+			keys0 = append(keys0, k0)
+		}
+		sort.Slice(keys0, func(i, j int) bool {
+			return keys0[i] < keys0[j]
+		})
+		for _, k0 := range keys0 {
+			w.String(k0)
+			v0 := obj.ByName[k0]
+			if v0 == nil {
+				w.Bool(false)
+			} else {
+				w.Bool(true)
+				w.Struct(v0)
+			}
+		}
+		w.End(mark)
+	}
+	{
+		w.Tag(9, marshal.BinaryBytes)
+		mark := w.Begin()
+		for i0 := range obj.Scores {
+			w.Float64(obj.Scores[i0])
+		}
+		w.End(mark)
+	}
+	if obj.Matrix != nil {
+		w.Tag(10, marshal.BinaryBytes)
+		mark := w.Begin()
+		w.Count(len(obj.Matrix), obj.Matrix == nil)
+		for i0 := range obj.Matrix {
+			w.Count(len(obj.Matrix[i0]), obj.Matrix[i0] == nil)
+			for i1 := range obj.Matrix[i0] {
+				w.Varint(int64(obj.Matrix[i0][i1]))
+			}
+		}
+		w.End(mark)
+	}
+	if obj.Data != nil {
+		w.Tag(11, marshal.BinaryBytes)
+		w.ByteSlice(obj.Data)
+	}
+	if obj.Home != nil {
+		w.Tag(12, marshal.BinaryBytes)
+		w.Struct(obj.Home)
+	}
+	if obj.Ratio != 0 {
+		w.Tag(13, marshal.BinaryFixed32)
+		w.Float32(obj.Ratio)
+	}
+	if obj.Seen != nil {
+		w.Tag(14, marshal.BinaryBytes)
+		mark := w.Begin()
+		w.Count(len(obj.Seen), obj.Seen == nil)
+		keys0 := make([]Level, 0, len(obj.Seen))
+		for k0 := range obj.Seen {
+			keys0 = append(keys0, k0)
+		}
+		sort.Slice(keys0, func(i, j int) bool {
+			return keys0[i] < keys0[j]
+		})
+		for _, k0 := range keys0 {
+			w.String(string(k0))
+			v0 := obj.Seen[k0]
+			w.Bool(v0)
+		}
+		w.End(mark)
+	}
+	if obj.Deltas != nil {
+		w.Tag(15, marshal.BinaryBytes)
+		mark := w.Begin()
+		w.Count(len(obj.Deltas), obj.Deltas == nil)
+		for i0 := range obj.Deltas {
+			w.Varint(int64(obj.Deltas[i0]))
+		}
+		w.End(mark)
+	}
+}
+
+// ReadBinary sets obj to the fields read from r, until the end of its data.
+func (obj *Session) ReadBinary(r *marshal.BinaryReader) {
+	var zero Session
+	*obj = zero
+	for r.More() {
+		num, typ := r.Tag()
+		switch num {
+		case 1:
+			if typ != marshal.BinaryBytes {
+				break
+			}
+			obj.ID = r.String()
+			continue
+		case 2:
+			if typ != marshal.BinaryVarint {
+				break
+			}
+			obj.Visits = int(r.Varint())
+			continue
+		case 4:
+			if typ != marshal.BinaryVarint {
+				break
+			}
+			obj.Color = Color(r.Varint())
+			continue
+		case 5:
+			if typ != marshal.BinaryBytes {
+				break
+			}
+			obj.Level = new(Level)
+			*obj.Level = Level(r.String())
+			continue
+		case 6:
+			if typ != marshal.BinaryBytes {
+				break
+			}
+			r.Unmarshaler(&obj.Expires)
+			continue
+		case 7:
+			if typ != marshal.BinaryBytes {
+				break
+			}
+			sub := r.Sub()
+			if n0, ok := sub.Count(); ok {
+				obj.Items = make([]SessionItem, n0)
+				for i0 := range obj.Items {
+					sub.Struct(&obj.Items[i0])
+				}
+			}
+			continue
+		case 8:
+			if typ != marshal.BinaryBytes {
+				break
+			}
+			sub := r.Sub()
+			if n0, ok := sub.Count(); ok {
+				obj.ByName = make(map[string]*SessionItem, n0)
+				for i0 := 0; i0 < n0; i0++ {
+					var k0 string
+					k0 = sub.String()
+					var v0 *SessionItem
+					if sub.Bool() {
+						v0 = new(SessionItem)
+						sub.Struct(v0)
+					}
+					obj.ByName[k0] = v0
+				}
+			}
+			continue
+		case 9:
+			if typ != marshal.BinaryBytes {
+				break
+			}
+			sub := r.Sub()
+			for i0 := range obj.Scores {
+				obj.Scores[i0] = sub.Float64()
+			}
+			continue
+		case 10:
+			if typ != marshal.BinaryBytes {
+				break
+			}
+			sub := r.Sub()
+			if n0, ok := sub.Count(); ok {
+				obj.Matrix = make([][]int16, n0)
+				for i0 := range obj.Matrix {
+					if n1, ok := sub.Count(); ok {
+						obj.Matrix[i0] = make([]int16, n1)
+						for i1 := range obj.Matrix[i0] {
+							obj.Matrix[i0][i1] = int16(sub.Varint())
+						}
+					}
+				}
+			}
+			continue
+		case 11:
+			if typ != marshal.BinaryBytes {
+				break
+			}
+			obj.Data = r.ByteSlice()
+			continue
+		case 12:
+			if typ != marshal.BinaryBytes {
+				break
+			}
+			obj.Home = new(shared.Address)
+			r.Struct(obj.Home)
+			continue
+		case 13:
+			if typ != marshal.BinaryFixed32 {
+				break
+			}
+			obj.Ratio = r.Float32()
+			continue
+		case 14:
+			if typ != marshal.BinaryBytes {
+				break
+			}
+			sub := r.Sub()
+			if n0, ok := sub.Count(); ok {
+				obj.Seen = make(map[Level]bool, n0)
+				for i0 := 0; i0 < n0; i0++ {
+					var k0 Level
+					k0 = Level(sub.String())
+					var v0 bool
+					v0 = sub.Bool()
+					obj.Seen[k0] = v0
+				}
+			}
+			continue
+		case 15:
+			if typ != marshal.BinaryBytes {
+				break
+			}
+			sub := r.Sub()
+			if n0, ok := sub.Count(); ok {
+				obj.Deltas = make([]int8, n0)
+				for i0 := range obj.Deltas {
+					obj.Deltas[i0] = int8(sub.Varint())
+				}
+			}
+			continue
+		}
+		// Skip unknown fields, and fields of an unexpected wire type.
+		r.Skip(typ)
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *SessionItem) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *SessionItem) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *SessionItem) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *SessionItem) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *SessionItem) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *SessionItem) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"name":`)
+	jw.String(obj.Name)
+	jw.Raw(`,"count":`)
+	jw.Uint(uint64(obj.Count))
+	jw.Raw(`,"flags":`)
+	if obj.Flags == nil {
+		jw.Null()
+	} else {
+		jw.RawByte('[')
+		for i := range obj.Flags {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			in := &obj.Flags[i]
+			jw.Bool(*in)
+		}
+		jw.RawByte(']')
+	}
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *SessionItem) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "name", "count", "flags":
+		default:
+			switch marshal.FoldName(key) {
+			case "NAME":
+				key = "name"
+			case "COUNT":
+				key = "count"
+			case "FLAGS":
+				key = "flags"
+			}
+		}
+		switch key {
+		case "name":
+			if err := dec.Decode(&obj.Name); err != nil {
+				return err
+			}
+		case "count":
+			if err := dec.Decode(&obj.Count); err != nil {
+				return err
+			}
+		case "flags":
+			if err := dec.Decode(&obj.Flags); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *SessionItem) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *SessionItem) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "name", "count", "flags":
+		default:
+			switch marshal.FoldName(key) {
+			case "NAME":
+				key = "name"
+			case "COUNT":
+				key = "count"
+			case "FLAGS":
+				key = "flags"
+			}
+		}
+		switch key {
+		case "name":
+			out := &obj.Name
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "count":
+			out := &obj.Count
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "flags":
+			out := &obj.Flags
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalBinary returns the compact binary encoding of obj: the version of the
+// format, then the fields of obj which are set, tagged by their number.
+func (obj *SessionItem) MarshalBinary() ([]byte, error) {
+	w := marshal.NewBinaryWriter()
+	w.Header()
+	obj.WriteBinary(w)
+	return w.Bytes(), w.Err()
+}
+
+// UnmarshalBinary sets obj to the binary encoding data. Unknown fields are
+// skipped, and fields missing from data are left to their zero value.
+func (obj *SessionItem) UnmarshalBinary(data []byte) error {
+	r := marshal.NewBinaryReader(data)
+	r.Header()
+	obj.ReadBinary(r)
+	return r.Err()
+}
+
+// WriteBinary writes the fields of obj to w, without the version of the format.
+func (obj *SessionItem) WriteBinary(w *marshal.BinaryWriter) {
+	if obj.Name != "" {
+		w.Tag(1, marshal.BinaryBytes)
+		w.String(obj.Name)
+	}
+	if obj.Count != 0 {
+		w.Tag(2, marshal.BinaryVarint)
+		w.Uvarint(uint64(obj.Count))
+	}
+	if obj.Flags != nil {
+		w.Tag(3, marshal.BinaryBytes)
+		mark := w.Begin()
+		w.Count(len(obj.Flags), obj.Flags == nil)
+		for i0 := range obj.Flags {
+			w.Bool(obj.Flags[i0])
+		}
+		w.End(mark)
+	}
+}
+
+// ReadBinary sets obj to the fields read from r, until the end of its data.
+func (obj *SessionItem) ReadBinary(r *marshal.BinaryReader) {
+	var zero SessionItem
+	*obj = zero
+	for r.More() {
+		num, typ := r.Tag()
+		switch num {
+		case 1:
+			if typ != marshal.BinaryBytes {
+				break
+			}
+			obj.Name = r.String()
+			continue
+		case 2:
+			if typ != marshal.BinaryVarint {
+				break
+			}
+			obj.Count = uint16(r.Uvarint())
+			continue
+		case 3:
+			if typ != marshal.BinaryBytes {
+				break
+			}
+			sub := r.Sub()
+			if n0, ok := sub.Count(); ok {
+				obj.Flags = make([]bool, n0)
+				for i0 := range obj.Flags {
+					obj.Flags[i0] = sub.Bool()
+				}
+			}
+			continue
+		}
+		// Skip unknown fields, and fields of an unexpected wire type.
+		r.Skip(typ)
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *SessionV1) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *SessionV1) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *SessionV1) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *SessionV1) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *SessionV1) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *SessionV1) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"id":`)
+	jw.String(obj.ID)
+	jw.Raw(`,"visits":`)
+	jw.Int(int64(obj.Visits))
+	jw.Raw(`,"token":`)
+	jw.String(obj.Token)
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *SessionV1) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "id", "visits", "token":
+		default:
+			switch marshal.FoldName(key) {
+			case "ID":
+				key = "id"
+			case "VISITS":
+				key = "visits"
+			case "TOKEN":
+				key = "token"
+			}
+		}
+		switch key {
+		case "id":
+			if err := dec.Decode(&obj.ID); err != nil {
+				return err
+			}
+		case "visits":
+			if err := dec.Decode(&obj.Visits); err != nil {
+				return err
+			}
+		case "token":
+			if err := dec.Decode(&obj.Token); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *SessionV1) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *SessionV1) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "id", "visits", "token":
+		default:
+			switch marshal.FoldName(key) {
+			case "ID":
+				key = "id"
+			case "VISITS":
+				key = "visits"
+			case "TOKEN":
+				key = "token"
+			}
+		}
+		switch key {
+		case "id":
+			out := &obj.ID
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "visits":
+			out := &obj.Visits
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "token":
+			out := &obj.Token
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalBinary returns the compact binary encoding of obj: the version of the
+// format, then the fields of obj which are set, tagged by their number.
+func (obj *SessionV1) MarshalBinary() ([]byte, error) {
+	w := marshal.NewBinaryWriter()
+	w.Header()
+	obj.WriteBinary(w)
+	return w.Bytes(), w.Err()
+}
+
+// UnmarshalBinary sets obj to the binary encoding data. Unknown fields are
+// skipped, and fields missing from data are left to their zero value.
+func (obj *SessionV1) UnmarshalBinary(data []byte) error {
+	r := marshal.NewBinaryReader(data)
+	r.Header()
+	obj.ReadBinary(r)
+	return r.Err()
+}
+
+// WriteBinary writes the fields of obj to w, without the version of the format.
+func (obj *SessionV1) WriteBinary(w *marshal.BinaryWriter) {
+	if obj.ID != "" {
+		w.Tag(1, marshal.BinaryBytes)
+		w.String(obj.ID)
+	}
+	if obj.Visits != 0 {
+		w.Tag(2, marshal.BinaryVarint)
+		w.Varint(int64(obj.Visits))
+	}
+	if obj.Token != "" {
+		w.Tag(3, marshal.BinaryBytes)
+		w.String(obj.Token)
+	}
+}
+
+// ReadBinary sets obj to the fields read from r, until the end of its data.
+func (obj *SessionV1) ReadBinary(r *marshal.BinaryReader) {
+	var zero SessionV1
+	*obj = zero
+	for r.More() {
+		num, typ := r.Tag()
+		switch num {
+		case 1:
+			if typ != marshal.BinaryBytes {
+				break
+			}
+			obj.ID = r.String()
+			continue
+		case 2:
+			if typ != marshal.BinaryVarint {
+				break
+			}
+			obj.Visits = int(r.Varint())
+			continue
+		case 3:
+			if typ != marshal.BinaryBytes {
+				break
+			}
+			obj.Token = r.String()
+			continue
+		}
+		// Skip unknown fields, and fields of an unexpected wire type.
+		r.Skip(typ)
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *T1) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *T1) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *T1) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *T1) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *T1) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *T1) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"Byte":`)
+	jw.Uint(uint64(obj.Byte))
+	jw.Raw(`,"Int8":`)
+	jw.Int(int64(obj.Int8))
+	jw.Raw(`,"Int16":`)
+	jw.Int(int64(obj.Int16))
+	jw.Raw(`,"Int32":`)
+	jw.Int(int64(obj.Int32))
+	jw.Raw(`,"Int64":`)
+	jw.Int(obj.Int64)
+	jw.Raw(`,"Uint8":`)
+	jw.Uint(uint64(obj.Uint8))
+	jw.Raw(`,"Uint16":`)
+	jw.Uint(uint64(obj.Uint16))
+	jw.Raw(`,"Uint32":`)
+	jw.Uint(uint64(obj.Uint32))
+	jw.Raw(`,"Uint64":`)
+	jw.Uint(obj.Uint64)
+	jw.Raw(`,"Float32":`)
+	jw.Float(float64(obj.Float32), 32)
+	jw.Raw(`,"Float64":`)
+	jw.Float(obj.Float64, 64)
+	jw.Raw(`,"Str":`)
+	jw.String(obj.Str)
+	jw.Raw(`,"Offset":`)
+	jw.Int(int64(obj.Offset))
+	jw.Raw(`,"Int8s":`)
+	if obj.Int8s == nil {
+		jw.Null()
+	} else {
+		jw.RawByte('[')
+		for i := range obj.Int8s {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			in := &obj.Int8s[i]
+			jw.Int(int64(*in))
+		}
+		jw.RawByte(']')
+	}
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *T1) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "Byte", "Int8", "Int16", "Int32", "Int64", "Uint8", "Uint16", "Uint32", "Uint64", "Float32", "Float64", "Str", "Offset", "Int8s":
+		default:
+			switch marshal.FoldName(key) {
+			case "BYTE":
+				key = "Byte"
+			case "INT8":
+				key = "Int8"
+			case "INT16":
+				key = "Int16"
+			case "INT32":
+				key = "Int32"
+			case "INT64":
+				key = "Int64"
+			case "UINT8":
+				key = "Uint8"
+			case "UINT16":
+				key = "Uint16"
+			case "UINT32":
+				key = "Uint32"
+			case "UINT64":
+				key = "Uint64"
+			case "FLOAT32":
+				key = "Float32"
+			case "FLOAT64":
+				key = "Float64"
+			case "STR":
+				key = "Str"
+			case "OFFSET":
+				key = "Offset"
+			case "INT8S":
+				key = "Int8s"
+			}
+		}
+		switch key {
+		case "Byte":
+			if err := dec.Decode(&obj.Byte); err != nil {
+				return err
+			}
+		case "Int8":
+			if err := dec.Decode(&obj.Int8); err != nil {
+				return err
+			}
+		case "Int16":
+			if err := dec.Decode(&obj.Int16); err != nil {
+				return err
+			}
+		case "Int32":
+			if err := dec.Decode(&obj.Int32); err != nil {
+				return err
+			}
+		case "Int64":
+			if err := dec.Decode(&obj.Int64); err != nil {
+				return err
+			}
+		case "Uint8":
+			if err := dec.Decode(&obj.Uint8); err != nil {
+				return err
+			}
+		case "Uint16":
+			if err := dec.Decode(&obj.Uint16); err != nil {
+				return err
+			}
+		case "Uint32":
+			if err := dec.Decode(&obj.Uint32); err != nil {
+				return err
+			}
+		case "Uint64":
+			if err := dec.Decode(&obj.Uint64); err != nil {
+				return err
+			}
+		case "Float32":
+			if err := dec.Decode(&obj.Float32); err != nil {
+				return err
+			}
+		case "Float64":
+			if err := dec.Decode(&obj.Float64); err != nil {
+				return err
+			}
+		case "Str":
+			if err := dec.Decode(&obj.Str); err != nil {
+				return err
+			}
+		case "Offset":
+			if err := dec.Decode(&obj.Offset); err != nil {
+				return err
+			}
+		case "Int8s":
+			if err := dec.Decode(&obj.Int8s); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *T1) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *T1) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "Byte", "Int8", "Int16", "Int32", "Int64", "Uint8", "Uint16", "Uint32", "Uint64", "Float32", "Float64", "Str", "Offset", "Int8s":
+		default:
+			switch marshal.FoldName(key) {
+			case "BYTE":
+				key = "Byte"
+			case "INT8":
+				key = "Int8"
+			case "INT16":
+				key = "Int16"
+			case "INT32":
+				key = "Int32"
+			case "INT64":
+				key = "Int64"
+			case "UINT8":
+				key = "Uint8"
+			case "UINT16":
+				key = "Uint16"
+			case "UINT32":
+				key = "Uint32"
+			case "UINT64":
+				key = "Uint64"
+			case "FLOAT32":
+				key = "Float32"
+			case "FLOAT64":
+				key = "Float64"
+			case "STR":
+				key = "Str"
+			case "OFFSET":
+				key = "Offset"
+			case "INT8S":
+				key = "Int8s"
+			}
+		}
+		switch key {
+		case "Byte":
+			out := &obj.Byte
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Int8":
+			out := &obj.Int8
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Int16":
+			out := &obj.Int16
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Int32":
+			out := &obj.Int32
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Int64":
+			out := &obj.Int64
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Uint8":
+			out := &obj.Uint8
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Uint16":
+			out := &obj.Uint16
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Uint32":
+			out := &obj.Uint32
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Uint64":
+			out := &obj.Uint64
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Float32":
+			out := &obj.Float32
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Float64":
+			out := &obj.Float64
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Str":
+			out := &obj.Str
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Offset":
+			out := &obj.Offset
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Int8s":
+			out := &obj.Int8s
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *T2) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *T2) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *T2) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *T2) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *T2) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *T2) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"I":`)
+	if obj.I == nil {
+		jw.Null()
+	} else {
+		jw.RawByte('[')
+		for i := range obj.I {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			in := &obj.I[i]
+			jw.Value(*in)
+		}
+		jw.RawByte(']')
+	}
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *T2) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "I":
+		default:
+			switch marshal.FoldName(key) {
+			case "I":
+				key = "I"
+			}
+		}
+		switch key {
+		case "I":
+			if err := dec.Decode(&obj.I); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *T2) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *T2) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "I":
+		default:
+			switch marshal.FoldName(key) {
+			case "I":
+				key = "I"
+			}
+		}
+		switch key {
+		case "I":
+			out := &obj.I
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *T3) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *T3) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *T3) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *T3) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *T3) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *T3) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"Byte":`)
+	if obj.Byte == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.Byte))
+		for key := range obj.Byte {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.Byte[key]
+			jw.Uint(uint64(val))
+		}
+		jw.RawByte('}')
+	}
+	jw.Raw(`,"Int8":`)
+	if obj.Int8 == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.Int8))
+		for key := range obj.Int8 {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.Int8[key]
+			jw.Int(int64(val))
+		}
+		jw.RawByte('}')
+	}
+	jw.Raw(`,"Int16":`)
+	if obj.Int16 == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.Int16))
+		for key := range obj.Int16 {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.Int16[key]
+			jw.Int(int64(val))
+		}
+		jw.RawByte('}')
+	}
+	jw.Raw(`,"Int32":`)
+	if obj.Int32 == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.Int32))
+		for key := range obj.Int32 {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.Int32[key]
+			jw.Int(int64(val))
+		}
+		jw.RawByte('}')
+	}
+	jw.Raw(`,"Int64":`)
+	if obj.Int64 == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.Int64))
+		for key := range obj.Int64 {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.Int64[key]
+			jw.Int(val)
+		}
+		jw.RawByte('}')
+	}
+	jw.Raw(`,"Uint8":`)
+	if obj.Uint8 == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.Uint8))
+		for key := range obj.Uint8 {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.Uint8[key]
+			jw.Uint(uint64(val))
+		}
+		jw.RawByte('}')
+	}
+	jw.Raw(`,"Uint16":`)
+	if obj.Uint16 == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.Uint16))
+		for key := range obj.Uint16 {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.Uint16[key]
+			jw.Uint(uint64(val))
+		}
+		jw.RawByte('}')
+	}
+	jw.Raw(`,"Uint32":`)
+	if obj.Uint32 == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.Uint32))
+		for key := range obj.Uint32 {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.Uint32[key]
+			jw.Uint(uint64(val))
+		}
+		jw.RawByte('}')
+	}
+	jw.Raw(`,"Uint64":`)
+	if obj.Uint64 == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.Uint64))
+		for key := range obj.Uint64 {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.Uint64[key]
+			jw.Uint(val)
+		}
+		jw.RawByte('}')
+	}
+	jw.Raw(`,"Float32":`)
+	if obj.Float32 == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.Float32))
+		for key := range obj.Float32 {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.Float32[key]
+			jw.Float(float64(val), 32)
+		}
+		jw.RawByte('}')
+	}
+	jw.Raw(`,"Float64":`)
+	if obj.Float64 == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.Float64))
+		for key := range obj.Float64 {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.Float64[key]
+			jw.Float(val, 64)
+		}
+		jw.RawByte('}')
+	}
+	jw.Raw(`,"StringPtr":`)
+	if obj.StringPtr == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.StringPtr))
+		for key := range obj.StringPtr {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.StringPtr[key]
+			if val == nil {
+				jw.Null()
+			} else {
+				jw.String(*val)
+			}
+		}
+		jw.RawByte('}')
+	}
+	jw.Raw(`,"StringPtrPtr":`)
+	if obj.StringPtrPtr == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.StringPtrPtr))
+		for key := range obj.StringPtrPtr {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.StringPtrPtr[key]
+			if val == nil {
+				jw.Null()
+			} else {
+				if (*val) == nil {
+					jw.Null()
+				} else {
+					jw.String(*(*val))
+				}
+			}
+		}
+		jw.RawByte('}')
+	}
+	jw.Raw(`,"Map":`)
+	if obj.Map == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.Map))
+		for key := range obj.Map {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.Map[key]
+			if val == nil {
+				jw.Null()
+			} else {
+				keys := make([]string, 0, len(val))
+				for key := range val {
+					keys = append(keys, key)
+				}
+				sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+				jw.RawByte('{')
+				for i, key := range keys {
+					if i > 0 {
+						jw.RawByte(',')
+					}
+					jw.String(key)
+					jw.RawByte(':')
+					val := val[key]
+					jw.String(val)
+				}
+				jw.RawByte('}')
+			}
+		}
+		jw.RawByte('}')
+	}
+	jw.Raw(`,"MapPtr":`)
+	if obj.MapPtr == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.MapPtr))
+		for key := range obj.MapPtr {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.MapPtr[key]
+			if val == nil {
+				jw.Null()
+			} else {
+				if (*val) == nil {
+					jw.Null()
+				} else {
+					keys := make([]string, 0, len((*val)))
+					for key := range *val {
+						keys = append(keys, key)
+					}
+					sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+					jw.RawByte('{')
+					for i, key := range keys {
+						if i > 0 {
+							jw.RawByte(',')
+						}
+						jw.String(key)
+						jw.RawByte(':')
+						val := (*val)[key]
+						jw.String(val)
+					}
+					jw.RawByte('}')
+				}
+			}
+		}
+		jw.RawByte('}')
+	}
+	jw.Raw(`,"Slice":`)
+	if obj.Slice == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.Slice))
+		for key := range obj.Slice {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.Slice[key]
+			if val == nil {
+				jw.Null()
+			} else {
+				jw.RawByte('[')
+				for i := range val {
+					if i > 0 {
+						jw.RawByte(',')
+					}
+					in := &val[i]
+					jw.String(*in)
+				}
+				jw.RawByte(']')
+			}
+		}
+		jw.RawByte('}')
+	}
+	jw.Raw(`,"SlicePtr":`)
+	if obj.SlicePtr == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.SlicePtr))
+		for key := range obj.SlicePtr {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.SlicePtr[key]
+			if val == nil {
+				jw.Null()
+			} else {
+				if (*val) == nil {
+					jw.Null()
+				} else {
+					jw.RawByte('[')
+					for i := range *val {
+						if i > 0 {
+							jw.RawByte(',')
+						}
+						in := &(*val)[i]
+						jw.String(*in)
+					}
+					jw.RawByte(']')
+				}
+			}
+		}
+		jw.RawByte('}')
+	}
+	jw.Raw(`,"Struct":`)
+	if obj.Struct == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.Struct))
+		for key := range obj.Struct {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.Struct[key]
+			val.WriteJSON(jw)
+		}
+		jw.RawByte('}')
+	}
+	jw.Raw(`,"StructPtr":`)
+	if obj.StructPtr == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.StructPtr))
+		for key := range obj.StructPtr {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.StructPtr[key]
+			val.WriteJSON(jw)
+		}
+		jw.RawByte('}')
+	}
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *T3) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "Byte", "Int8", "Int16", "Int32", "Int64", "Uint8", "Uint16", "Uint32", "Uint64", "Float32", "Float64", "StringPtr", "StringPtrPtr", "Map", "MapPtr", "Slice", "SlicePtr", "Struct", "StructPtr":
+		default:
+			switch marshal.FoldName(key) {
+			case "BYTE":
+				key = "Byte"
+			case "INT8":
+				key = "Int8"
+			case "INT16":
+				key = "Int16"
+			case "INT32":
+				key = "Int32"
+			case "INT64":
+				key = "Int64"
+			case "UINT8":
+				key = "Uint8"
+			case "UINT16":
+				key = "Uint16"
+			case "UINT32":
+				key = "Uint32"
+			case "UINT64":
+				key = "Uint64"
+			case "FLOAT32":
+				key = "Float32"
+			case "FLOAT64":
+				key = "Float64"
+			case "STRINGPTR":
+				key = "StringPtr"
+			case "STRINGPTRPTR":
+				key = "StringPtrPtr"
+			case "MAP":
+				key = "Map"
+			case "MAPPTR":
+				key = "MapPtr"
+			case "SLICE":
+				key = "Slice"
+			case "SLICEPTR":
+				key = "SlicePtr"
+			case "STRUCT":
+				key = "Struct"
+			case "STRUCTPTR":
+				key = "StructPtr"
+			}
+		}
+		switch key {
+		case "Byte":
+			if err := dec.Decode(&obj.Byte); err != nil {
+				return err
+			}
+		case "Int8":
+			if err := dec.Decode(&obj.Int8); err != nil {
+				return err
+			}
+		case "Int16":
+			if err := dec.Decode(&obj.Int16); err != nil {
+				return err
+			}
+		case "Int32":
+			if err := dec.Decode(&obj.Int32); err != nil {
+				return err
+			}
+		case "Int64":
+			if err := dec.Decode(&obj.Int64); err != nil {
+				return err
+			}
+		case "Uint8":
+			if err := dec.Decode(&obj.Uint8); err != nil {
+				return err
+			}
+		case "Uint16":
+			if err := dec.Decode(&obj.Uint16); err != nil {
+				return err
+			}
+		case "Uint32":
+			if err := dec.Decode(&obj.Uint32); err != nil {
+				return err
+			}
+		case "Uint64":
+			if err := dec.Decode(&obj.Uint64); err != nil {
+				return err
+			}
+		case "Float32":
+			if err := dec.Decode(&obj.Float32); err != nil {
+				return err
+			}
+		case "Float64":
+			if err := dec.Decode(&obj.Float64); err != nil {
+				return err
+			}
+		case "StringPtr":
+			if err := dec.Decode(&obj.StringPtr); err != nil {
+				return err
+			}
+		case "StringPtrPtr":
+			if err := dec.Decode(&obj.StringPtrPtr); err != nil {
+				return err
+			}
+		case "Map":
+			if err := dec.Decode(&obj.Map); err != nil {
+				return err
+			}
+		case "MapPtr":
+			if err := dec.Decode(&obj.MapPtr); err != nil {
+				return err
+			}
+		case "Slice":
+			if err := dec.Decode(&obj.Slice); err != nil {
+				return err
+			}
+		case "SlicePtr":
+			if err := dec.Decode(&obj.SlicePtr); err != nil {
+				return err
+			}
+		case "Struct":
+			if ok, err := dec.Begin('{'); err != nil {
+				return err
+			} else if !ok {
+				obj.Struct = nil
+			} else {
+				if obj.Struct == nil {
+					obj.Struct = make(map[string]T1)
+				}
+				for dec.More() {
+					key, err := dec.Key()
+					if err != nil {
+						return err
+					}
+					var val T1
+					if err := val.ReadJSON(dec); err != nil {
+						return err
+					}
+					obj.Struct[key] = val
+				}
+				if err := dec.End('}'); err != nil {
+					return err
+				}
+			}
+		case "StructPtr":
+			if ok, err := dec.Begin('{'); err != nil {
+				return err
+			} else if !ok {
+				obj.StructPtr = nil
+			} else {
+				if obj.StructPtr == nil {
+					obj.StructPtr = make(map[string]*T2)
+				}
+				for dec.More() {
+					key, err := dec.Key()
+					if err != nil {
+						return err
+					}
+					var val *T2
+					if null, err := dec.Null(); err != nil {
+						return err
+					} else if null {
+						val = nil
+					} else {
+						if val == nil {
+							val = new(T2)
+						}
+						if err := val.ReadJSON(dec); err != nil {
+							return err
+						}
+					}
+					obj.StructPtr[key] = val
+				}
+				if err := dec.End('}'); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *T3) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *T3) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "Byte", "Int8", "Int16", "Int32", "Int64", "Uint8", "Uint16", "Uint32", "Uint64", "Float32", "Float64", "StringPtr", "StringPtrPtr", "Map", "MapPtr", "Slice", "SlicePtr", "Struct", "StructPtr":
+		default:
+			switch marshal.FoldName(key) {
+			case "BYTE":
+				key = "Byte"
+			case "INT8":
+				key = "Int8"
+			case "INT16":
+				key = "Int16"
+			case "INT32":
+				key = "Int32"
+			case "INT64":
+				key = "Int64"
+			case "UINT8":
+				key = "Uint8"
+			case "UINT16":
+				key = "Uint16"
+			case "UINT32":
+				key = "Uint32"
+			case "UINT64":
+				key = "Uint64"
+			case "FLOAT32":
+				key = "Float32"
+			case "FLOAT64":
+				key = "Float64"
+			case "STRINGPTR":
+				key = "StringPtr"
+			case "STRINGPTRPTR":
+				key = "StringPtrPtr"
+			case "MAP":
+				key = "Map"
+			case "MAPPTR":
+				key = "MapPtr"
+			case "SLICE":
+				key = "Slice"
+			case "SLICEPTR":
+				key = "SlicePtr"
+			case "STRUCT":
+				key = "Struct"
+			case "STRUCTPTR":
+				key = "StructPtr"
+			}
+		}
+		switch key {
+		case "Byte":
+			out := &obj.Byte
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Int8":
+			out := &obj.Int8
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Int16":
+			out := &obj.Int16
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Int32":
+			out := &obj.Int32
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Int64":
+			out := &obj.Int64
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Uint8":
+			out := &obj.Uint8
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Uint16":
+			out := &obj.Uint16
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Uint32":
+			out := &obj.Uint32
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Uint64":
+			out := &obj.Uint64
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Float32":
+			out := &obj.Float32
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Float64":
+			out := &obj.Float64
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "StringPtr":
+			out := &obj.StringPtr
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "StringPtrPtr":
+			out := &obj.StringPtrPtr
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Map":
+			out := &obj.Map
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "MapPtr":
+			out := &obj.MapPtr
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Slice":
+			out := &obj.Slice
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "SlicePtr":
+			out := &obj.SlicePtr
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Struct":
+			out := &obj.Struct
+			var items map[string]json.RawMessage
+			if err := json.Unmarshal(data, &items); err != nil {
+				errs.Add(path, err)
+			} else if items == nil {
+				*out = nil
+			} else {
+				*out = make(map[string]T1, len(items))
+				for _, key := range marshal.SortedKeys(items) {
+					var val T1
+					{
+						data, path, out := items[key], marshal.Key(path, key), &val
+						out.UnmarshalJSONStrict(data, path, errs)
+					}
+					(*out)[key] = val
+				}
+			}
+		case "StructPtr":
+			out := &obj.StructPtr
+			var items map[string]json.RawMessage
+			if err := json.Unmarshal(data, &items); err != nil {
+				errs.Add(path, err)
+			} else if items == nil {
+				*out = nil
+			} else {
+				*out = make(map[string]*T2, len(items))
+				for _, key := range marshal.SortedKeys(items) {
+					var val *T2
+					{
+						data, path, out := items[key], marshal.Key(path, key), &val
+						if marshal.IsNull(data) {
+							*out = nil
+						} else {
+							if *out == nil {
+								*out = new(T2)
+							}
+							out := *out
+							out.UnmarshalJSONStrict(data, path, errs)
+						}
+					}
+					(*out)[key] = val
+				}
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *T4) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *T4) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *T4) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *T4) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *T4) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *T4) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"name":`)
+	jw.String(obj.Name)
+	if obj.T1 != nil {
+		jw.Raw(`,"t1":`)
+		obj.T1.WriteJSON(jw)
+	}
+	jw.Raw(`,"items":`)
+	if obj.Items == nil {
+		jw.Null()
+	} else {
+		jw.RawByte('[')
+		for i := range obj.Items {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			in := &obj.Items[i]
+			in.WriteJSON(jw)
+		}
+		jw.RawByte(']')
+	}
+	jw.Raw(`,"by_key":`)
+	if obj.ByKey == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.ByKey))
+		for key := range obj.ByKey {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.ByKey[key]
+			val.WriteJSON(jw)
+		}
+		jw.RawByte('}')
+	}
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *T4) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "name", "t1", "items", "by_key":
+		default:
+			switch marshal.FoldName(key) {
+			case "NAME":
+				key = "name"
+			case "T1":
+				key = "t1"
+			case "ITEMS":
+				key = "items"
+			case "BY_KEY":
+				key = "by_key"
+			}
+		}
+		switch key {
+		case "name":
+			if err := dec.Decode(&obj.Name); err != nil {
+				return err
+			}
+		case "t1":
+			if null, err := dec.Null(); err != nil {
+				return err
+			} else if null {
+				obj.T1 = nil
+			} else {
+				if obj.T1 == nil {
+					obj.T1 = new(T1)
+				}
+				if err := obj.T1.ReadJSON(dec); err != nil {
+					return err
+				}
+			}
+		case "items":
+			if ok, err := dec.Begin('['); err != nil {
+				return err
+			} else if !ok {
+				obj.Items = nil
+			} else {
+				if obj.Items == nil {
+					obj.Items = []T1{}
+				}
+				obj.Items = obj.Items[:0]
+				for dec.More() {
+					var val T1
+					if err := val.ReadJSON(dec); err != nil {
+						return err
+					}
+					obj.Items = append(obj.Items, val)
+				}
+				if err := dec.End(']'); err != nil {
+					return err
+				}
+			}
+		case "by_key":
+			if ok, err := dec.Begin('{'); err != nil {
+				return err
+			} else if !ok {
+				obj.ByKey = nil
+			} else {
+				if obj.ByKey == nil {
+					obj.ByKey = make(map[string]*T1)
+				}
+				for dec.More() {
+					key, err := dec.Key()
+					if err != nil {
+						return err
+					}
+					var val *T1
+					if null, err := dec.Null(); err != nil {
+						return err
+					} else if null {
+						val = nil
+					} else {
+						if val == nil {
+							val = new(T1)
+						}
+						if err := val.ReadJSON(dec); err != nil {
+							return err
+						}
+					}
+					obj.ByKey[key] = val
+				}
+				if err := dec.End('}'); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *T4) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *T4) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	found := map[string]bool{}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "name", "t1", "items", "by_key":
+		default:
+			switch marshal.FoldName(key) {
+			case "NAME":
+				key = "name"
+			case "T1":
+				key = "t1"
+			case "ITEMS":
+				key = "items"
+			case "BY_KEY":
+				key = "by_key"
+			}
+		}
+		found[key] = true
+		switch key {
+		case "name":
+			out := &obj.Name
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "t1":
+			out := &obj.T1
+			if marshal.IsNull(data) {
+				*out = nil
+			} else {
+				if *out == nil {
+					*out = new(T1)
+				}
+				out := *out
+				out.UnmarshalJSONStrict(data, path, errs)
+			}
+		case "items":
+			out := &obj.Items
+			var items []json.RawMessage
+			if err := json.Unmarshal(data, &items); err != nil {
+				errs.Add(path, err)
+			} else if items == nil {
+				*out = nil
+			} else {
+				*out = make([]T1, len(items))
+				for i := range items {
+					data, path, out := items[i], marshal.Index(path, i), &(*out)[i]
+					out.UnmarshalJSONStrict(data, path, errs)
+				}
+			}
+		case "by_key":
+			out := &obj.ByKey
+			var items map[string]json.RawMessage
+			if err := json.Unmarshal(data, &items); err != nil {
+				errs.Add(path, err)
+			} else if items == nil {
+				*out = nil
+			} else {
+				*out = make(map[string]*T1, len(items))
+				for _, key := range marshal.SortedKeys(items) {
+					var val *T1
+					{
+						data, path, out := items[key], marshal.Key(path, key), &val
+						if marshal.IsNull(data) {
+							*out = nil
+						} else {
+							if *out == nil {
+								*out = new(T1)
+							}
+							out := *out
+							out.UnmarshalJSONStrict(data, path, errs)
+						}
+					}
+					(*out)[key] = val
+				}
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+	if !found["name"] {
+		errs.Add(marshal.Key(path, "name"), marshal.ErrMissingField)
+	}
+}
+
+// Scan implements the sql.Scanner interface. It decodes the JSON held by a
+// []byte or string column value; a NULL column resets obj to its zero value.
+func (obj *T4) Scan(src interface{}) error {
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		var zero T4
+		*obj = zero
+		return nil
+	case []byte:
+		data = src
+	case string:
+		data = []byte(src)
+	default:
+		return fmt.Errorf("model.T4: cannot scan %T, want []byte, string or nil", src)
+	}
+
+	var value T4
+	if err := value.UnmarshalJSONBinary(data); err != nil {
+		return fmt.Errorf("model.T4: cannot scan JSON column: %v", err)
+	}
+	*obj = value
+	return nil
+}
+
+// Value implements the driver.Valuer interface, storing obj as JSON.
+func (obj T4) Value() (driver.Value, error) {
+	data, err := obj.MarshalJSONBinary()
+	if err != nil {
+		return nil, fmt.Errorf("model.T4: cannot encode JSON column: %v", err)
+	}
+	return data, nil
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *T5) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *T5) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *T5) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *T5) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *T5) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *T5) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"id":`)
+	jw.RawByte('"')
+	jw.Int(obj.Base.ID)
+	jw.RawByte('"')
+	if obj.Base.Kind != "" {
+		jw.Raw(`,"kind":`)
+		jw.String(obj.Base.Kind)
+	}
+	if obj.Meta != nil && len(obj.Meta.Labels) != 0 {
+		jw.Raw(`,"labels":`)
+		if obj.Meta.Labels == nil {
+			jw.Null()
+		} else {
+			keys := make([]string, 0, len(obj.Meta.Labels))
+			for key := range obj.Meta.Labels {
+				keys = append(keys, key)
+			}
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+			jw.RawByte('{')
+			for i, key := range keys {
+				if i > 0 {
+					jw.RawByte(',')
+				}
+				jw.String(key)
+				jw.RawByte(':')
+				val := obj.Meta.Labels[key]
+				jw.String(val)
+			}
+			jw.RawByte('}')
+		}
+	}
+	if obj.Meta != nil {
+		jw.Raw(`,"note":`)
+		if obj.Meta.Note == nil {
+			jw.Null()
+		} else {
+			jw.QuotedString(*obj.Meta.Note)
+		}
+	}
+	jw.Raw(`,"Pick":`)
+	jw.String(obj.Left.Pick)
+	jw.Raw(`,"only":`)
+	jw.Uint(uint64(obj.Right.Only))
+	jw.Raw(`,"hidden":`)
+	jw.String(obj.inner.Hidden)
+	if obj.innerPtr != nil {
+		jw.Raw(`,"unreachable":`)
+		jw.String(obj.innerPtr.Unreachable)
+	}
+	jw.Raw(`,"named":`)
+	obj.Named.WriteJSON(jw)
+	jw.Raw(`,"name":`)
+	jw.String(obj.Name)
+	jw.Raw(`,"-":`)
+	jw.String(obj.Dash)
+	if obj.Count != 0 {
+		jw.Raw(`,"Count":`)
+		jw.Int(int64(obj.Count))
+	}
+	if obj.Ratio != 0 {
+		jw.Raw(`,"ratio":`)
+		jw.Float(obj.Ratio, 64)
+	}
+	jw.Raw(`,"enabled":`)
+	jw.RawByte('"')
+	jw.Bool(obj.Enabled)
+	jw.RawByte('"')
+	if obj.Title != "" {
+		jw.Raw(`,"title":`)
+		jw.QuotedString(obj.Title)
+	}
+	if obj.T1 != nil {
+		jw.Raw(`,"t1":`)
+		obj.T1.WriteJSON(jw)
+	}
+	if len(obj.List) != 0 {
+		jw.Raw(`,"list":`)
+		if obj.List == nil {
+			jw.Null()
+		} else {
+			jw.RawByte('[')
+			for i := range obj.List {
+				if i > 0 {
+					jw.RawByte(',')
+				}
+				in := &obj.List[i]
+				jw.String(*in)
+			}
+			jw.RawByte(']')
+		}
+	}
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *T5) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "id", "kind", "labels", "note", "Pick", "only", "hidden", "unreachable", "named", "name", "-", "Count", "ratio", "enabled", "title", "t1", "list":
+		default:
+			switch marshal.FoldName(key) {
+			case "ID":
+				key = "id"
+			case "KIND":
+				key = "kind"
+			case "LABELS":
+				key = "labels"
+			case "NOTE":
+				key = "note"
+			case "PICK":
+				key = "Pick"
+			case "ONLY":
+				key = "only"
+			case "HIDDEN":
+				key = "hidden"
+			case "UNREACHABLE":
+				key = "unreachable"
+			case "NAMED":
+				key = "named"
+			case "NAME":
+				key = "name"
+			case "-":
+				key = "-"
+			case "COUNT":
+				key = "Count"
+			case "RATIO":
+				key = "ratio"
+			case "ENABLED":
+				key = "enabled"
+			case "TITLE":
+				key = "title"
+			case "T1":
+				key = "t1"
+			case "LIST":
+				key = "list"
+			}
+		}
+		switch key {
+		case "id":
+			if err := dec.DecodeQuoted(&obj.Base.ID); err != nil {
+				return err
+			}
+		case "kind":
+			if err := dec.Decode(&obj.Base.Kind); err != nil {
+				return err
+			}
+		case "labels":
+			if obj.Meta == nil {
+				obj.Meta = new(Meta)
+			}
+			if err := dec.Decode(&obj.Meta.Labels); err != nil {
+				return err
+			}
+		case "note":
+			if obj.Meta == nil {
+				obj.Meta = new(Meta)
+			}
+			if err := dec.DecodeQuoted(&obj.Meta.Note); err != nil {
+				return err
+			}
+		case "Pick":
+			if err := dec.Decode(&obj.Left.Pick); err != nil {
+				return err
+			}
+		case "only":
+			if err := dec.Decode(&obj.Right.Only); err != nil {
+				return err
+			}
+		case "hidden":
+			if err := dec.Decode(&obj.inner.Hidden); err != nil {
+				return err
+			}
+		case "unreachable":
+			if obj.innerPtr == nil {
+				return errors.New("json: cannot set embedded pointer to unexported struct: model.innerPtr")
+			}
+			if err := dec.Decode(&obj.innerPtr.Unreachable); err != nil {
+				return err
+			}
+		case "named":
+			if err := obj.Named.ReadJSON(dec); err != nil {
+				return err
+			}
+		case "name":
+			if err := dec.Decode(&obj.Name); err != nil {
+				return err
+			}
+		case "-":
+			if err := dec.Decode(&obj.Dash); err != nil {
+				return err
+			}
+		case "Count":
+			if err := dec.Decode(&obj.Count); err != nil {
+				return err
+			}
+		case "ratio":
+			if err := dec.Decode(&obj.Ratio); err != nil {
+				return err
+			}
+		case "enabled":
+			if err := dec.DecodeQuoted(&obj.Enabled); err != nil {
+				return err
+			}
+		case "title":
+			if err := dec.DecodeQuoted(&obj.Title); err != nil {
+				return err
+			}
+		case "t1":
+			if null, err := dec.Null(); err != nil {
+				return err
+			} else if null {
+				obj.T1 = nil
+			} else {
+				if obj.T1 == nil {
+					obj.T1 = new(T1)
+				}
+				if err := obj.T1.ReadJSON(dec); err != nil {
+					return err
+				}
+			}
+		case "list":
+			if err := dec.Decode(&obj.List); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *T5) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *T5) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "id", "kind", "labels", "note", "Pick", "only", "hidden", "unreachable", "named", "name", "-", "Count", "ratio", "enabled", "title", "t1", "list":
+		default:
+			switch marshal.FoldName(key) {
+			case "ID":
+				key = "id"
+			case "KIND":
+				key = "kind"
+			case "LABELS":
+				key = "labels"
+			case "NOTE":
+				key = "note"
+			case "PICK":
+				key = "Pick"
+			case "ONLY":
+				key = "only"
+			case "HIDDEN":
+				key = "hidden"
+			case "UNREACHABLE":
+				key = "unreachable"
+			case "NAMED":
+				key = "named"
+			case "NAME":
+				key = "name"
+			case "-":
+				key = "-"
+			case "COUNT":
+				key = "Count"
+			case "RATIO":
+				key = "ratio"
+			case "ENABLED":
+				key = "enabled"
+			case "TITLE":
+				key = "title"
+			case "T1":
+				key = "t1"
+			case "LIST":
+				key = "list"
+			}
+		}
+		switch key {
+		case "id":
+			out := &obj.Base.ID
+			if err := marshal.UnmarshalQuoted(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "kind":
+			out := &obj.Base.Kind
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "labels":
+			if obj.Meta == nil {
+				obj.Meta = new(Meta)
+			}
+			out := &obj.Meta.Labels
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "note":
+			if obj.Meta == nil {
+				obj.Meta = new(Meta)
+			}
+			out := &obj.Meta.Note
+			if err := marshal.UnmarshalQuoted(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Pick":
+			out := &obj.Left.Pick
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "only":
+			out := &obj.Right.Only
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "hidden":
+			out := &obj.inner.Hidden
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "unreachable":
+			if obj.innerPtr == nil {
+				errs.Add(path, errors.New("json: cannot set embedded pointer to unexported struct: model.innerPtr"))
+				break
+			}
+			out := &obj.innerPtr.Unreachable
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "named":
+			out := &obj.Named
+			out.UnmarshalJSONStrict(data, path, errs)
+		case "name":
+			out := &obj.Name
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "-":
+			out := &obj.Dash
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "Count":
+			out := &obj.Count
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "ratio":
+			out := &obj.Ratio
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "enabled":
+			out := &obj.Enabled
+			if err := marshal.UnmarshalQuoted(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "title":
+			out := &obj.Title
+			if err := marshal.UnmarshalQuoted(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "t1":
+			out := &obj.T1
+			if marshal.IsNull(data) {
+				*out = nil
+			} else {
+				if *out == nil {
+					*out = new(T1)
+				}
+				out := *out
+				out.UnmarshalJSONStrict(data, path, errs)
+			}
+		case "list":
+			out := &obj.List
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *T6) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *T6) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *T6) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *T6) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *T6) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *T6) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"color":`)
+	jw.Text(obj.Color)
+	if obj.Level != "" {
+		jw.Raw(`,"level":`)
+		jw.Text(obj.Level)
+	}
+	if len(obj.Palette) != 0 {
+		jw.Raw(`,"palette":`)
+		if obj.Palette == nil {
+			jw.Null()
+		} else {
+			jw.RawByte('[')
+			for i := range obj.Palette {
+				if i > 0 {
+					jw.RawByte(',')
+				}
+				in := &obj.Palette[i]
+				jw.Text(*in)
+			}
+			jw.RawByte(']')
+		}
+	}
+	if len(obj.ByLevel) != 0 {
+		jw.Raw(`,"by_level":`)
+		jw.Value(&obj.ByLevel)
+	}
+	if obj.Ptr != nil {
+		jw.Raw(`,"ptr":`)
+		if obj.Ptr == nil {
+			jw.Null()
+		} else {
+			jw.Text(*obj.Ptr)
+		}
+	}
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *T6) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "color", "level", "palette", "by_level", "ptr":
+		default:
+			switch marshal.FoldName(key) {
+			case "COLOR":
+				key = "color"
+			case "LEVEL":
+				key = "level"
+			case "PALETTE":
+				key = "palette"
+			case "BY_LEVEL":
+				key = "by_level"
+			case "PTR":
+				key = "ptr"
+			}
+		}
+		switch key {
+		case "color":
+			if err := dec.Decode(&obj.Color); err != nil {
+				return err
+			}
+		case "level":
+			if err := dec.Decode(&obj.Level); err != nil {
+				return err
+			}
+		case "palette":
+			if err := dec.Decode(&obj.Palette); err != nil {
+				return err
+			}
+		case "by_level":
+			if err := dec.Decode(&obj.ByLevel); err != nil {
+				return err
+			}
+		case "ptr":
+			if err := dec.Decode(&obj.Ptr); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *T6) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *T6) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "color", "level", "palette", "by_level", "ptr":
+		default:
+			switch marshal.FoldName(key) {
+			case "COLOR":
+				key = "color"
+			case "LEVEL":
+				key = "level"
+			case "PALETTE":
+				key = "palette"
+			case "BY_LEVEL":
+				key = "by_level"
+			case "PTR":
+				key = "ptr"
+			}
+		}
+		switch key {
+		case "color":
+			out := &obj.Color
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "level":
+			out := &obj.Level
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "palette":
+			out := &obj.Palette
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "by_level":
+			out := &obj.ByLevel
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "ptr":
+			out := &obj.Ptr
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *T7) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *T7) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *T7) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *T7) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *T7) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *T7) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"home":`)
+	obj.Home.WriteJSON(jw)
+	if obj.Work != nil {
+		jw.Raw(`,"work":`)
+		obj.Work.WriteJSON(jw)
+	}
+	jw.Raw(`,"contact":`)
+	if obj.Contact == nil {
+		jw.Null()
+	} else {
+		keys := make([]string, 0, len(obj.Contact))
+		for key := range obj.Contact {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		jw.RawByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				jw.RawByte(',')
+			}
+			jw.String(key)
+			jw.RawByte(':')
+			val := obj.Contact[key]
+			jw.Text(val)
+		}
+		jw.RawByte('}')
+	}
+	if obj.Kind != "" {
+		jw.Raw(`,"kind":`)
+		jw.Text(obj.Kind)
+	}
+	if len(obj.Kinds) != 0 {
+		jw.Raw(`,"kinds":`)
+		jw.Value(&obj.Kinds)
+	}
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *T7) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "home", "work", "contact", "kind", "kinds":
+		default:
+			switch marshal.FoldName(key) {
+			case "HOME":
+				key = "home"
+			case "WORK":
+				key = "work"
+			case "CONTACT":
+				key = "contact"
+			case "KIND":
+				key = "kind"
+			case "KINDS":
+				key = "kinds"
+			}
+		}
+		switch key {
+		case "home":
+			if err := obj.Home.ReadJSON(dec); err != nil {
+				return err
+			}
+		case "work":
+			if null, err := dec.Null(); err != nil {
+				return err
+			} else if null {
+				obj.Work = nil
+			} else {
+				if obj.Work == nil {
+					obj.Work = new(shared.Address)
+				}
+				if err := obj.Work.ReadJSON(dec); err != nil {
+					return err
+				}
+			}
+		case "contact":
+			if err := dec.Decode(&obj.Contact); err != nil {
+				return err
+			}
+		case "kind":
+			if err := dec.Decode(&obj.Kind); err != nil {
+				return err
+			}
+		case "kinds":
+			if err := dec.Decode(&obj.Kinds); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *T7) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *T7) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "home", "work", "contact", "kind", "kinds":
+		default:
+			switch marshal.FoldName(key) {
+			case "HOME":
+				key = "home"
+			case "WORK":
+				key = "work"
+			case "CONTACT":
+				key = "contact"
+			case "KIND":
+				key = "kind"
+			case "KINDS":
+				key = "kinds"
+			}
+		}
+		switch key {
+		case "home":
+			out := &obj.Home
+			out.UnmarshalJSONStrict(data, path, errs)
+		case "work":
+			out := &obj.Work
+			if marshal.IsNull(data) {
+				*out = nil
+			} else {
+				if *out == nil {
+					*out = new(shared.Address)
+				}
+				out := *out
+				out.UnmarshalJSONStrict(data, path, errs)
+			}
+		case "contact":
+			out := &obj.Contact
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "kind":
+			out := &obj.Kind
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "kinds":
+			out := &obj.Kinds
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *T8) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *T8) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *T8) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *T8) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *T8) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *T8) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"name":`)
+	jw.String(obj.Name)
+	jw.Raw(`,"color":`)
+	jw.Text(obj.Color)
+	if obj.Level != "" {
+		jw.Raw(`,"level":`)
+		jw.Text(obj.Level)
+	}
+	if obj.Next != nil {
+		jw.Raw(`,"next":`)
+		obj.Next.WriteJSON(jw)
+	}
+	if len(obj.Items) != 0 {
+		jw.Raw(`,"items":`)
+		if obj.Items == nil {
+			jw.Null()
+		} else {
+			jw.RawByte('[')
+			for i := range obj.Items {
+				if i > 0 {
+					jw.RawByte(',')
+				}
+				in := &obj.Items[i]
+				in.WriteJSON(jw)
+			}
+			jw.RawByte(']')
+		}
+	}
+	if len(obj.ByName) != 0 {
+		jw.Raw(`,"by_name":`)
+		if obj.ByName == nil {
+			jw.Null()
+		} else {
+			keys := make([]string, 0, len(obj.ByName))
+			for key := range obj.ByName {
+				keys = append(keys, key)
+			}
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+			jw.RawByte('{')
+			for i, key := range keys {
+				if i > 0 {
+					jw.RawByte(',')
+				}
+				jw.String(key)
+				jw.RawByte(':')
+				val := obj.ByName[key]
+				val.WriteJSON(jw)
+			}
+			jw.RawByte('}')
+		}
+	}
+	if len(obj.Counts) != 0 {
+		jw.Raw(`,"counts":`)
+		if obj.Counts == nil {
+			jw.Null()
+		} else {
+			keys := make([]int32, 0, len(obj.Counts))
+			for key := range obj.Counts {
+				keys = append(keys, key)
+			}
+			sort.Slice(keys, func(i, j int) bool { return marshal.IntKeyLess(int64(keys[i]), int64(keys[j])) })
+			jw.RawByte('{')
+			for i, key := range keys {
+				if i > 0 {
+					jw.RawByte(',')
+				}
+				jw.RawByte('"')
+				jw.Int(int64(key))
+				jw.RawByte('"')
+				jw.RawByte(':')
+				val := obj.Counts[key]
+				jw.Uint(val)
+			}
+			jw.RawByte('}')
+		}
+	}
+	if obj.Nickname != nil {
+		jw.Raw(`,"nickname":`)
+		if obj.Nickname == nil {
+			jw.Null()
+		} else {
+			jw.String(*obj.Nickname)
+		}
+	}
+	if len(obj.Raw) != 0 {
+		jw.Raw(`,"raw":`)
+		jw.Bytes(obj.Raw)
+	}
+	jw.Raw(`,"home":`)
+	obj.Home.WriteJSON(jw)
+	jw.Raw(`,"ratio":`)
+	jw.Float(float64(obj.Ratio), 32)
+	jw.Raw(`,"enabled":`)
+	jw.Bool(obj.Enabled)
+	if len(obj.Scores) != 0 {
+		jw.Raw(`,"scores":`)
+		if obj.Scores == nil {
+			jw.Null()
+		} else {
+			jw.RawByte('[')
+			for i := range obj.Scores {
+				if i > 0 {
+					jw.RawByte(',')
+				}
+				in := &obj.Scores[i]
+				jw.Int(*in)
+			}
+			jw.RawByte(']')
+		}
+	}
+	jw.Raw(`,"offset":`)
+	jw.Int(int64(obj.Offset))
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *T8) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "name", "color", "level", "next", "items", "by_name", "counts", "nickname", "raw", "home", "ratio", "enabled", "scores", "offset":
+		default:
+			switch marshal.FoldName(key) {
+			case "NAME":
+				key = "name"
+			case "COLOR":
+				key = "color"
+			case "LEVEL":
+				key = "level"
+			case "NEXT":
+				key = "next"
+			case "ITEMS":
+				key = "items"
+			case "BY_NAME":
+				key = "by_name"
+			case "COUNTS":
+				key = "counts"
+			case "NICKNAME":
+				key = "nickname"
+			case "RAW":
+				key = "raw"
+			case "HOME":
+				key = "home"
+			case "RATIO":
+				key = "ratio"
+			case "ENABLED":
+				key = "enabled"
+			case "SCORES":
+				key = "scores"
+			case "OFFSET":
+				key = "offset"
+			}
+		}
+		switch key {
+		case "name":
+			if err := dec.Decode(&obj.Name); err != nil {
+				return err
+			}
+		case "color":
+			if err := dec.Decode(&obj.Color); err != nil {
+				return err
+			}
+		case "level":
+			if err := dec.Decode(&obj.Level); err != nil {
+				return err
+			}
+		case "next":
+			if null, err := dec.Null(); err != nil {
+				return err
+			} else if null {
+				obj.Next = nil
+			} else {
+				if obj.Next == nil {
+					obj.Next = new(T8)
+				}
+				if err := obj.Next.ReadJSON(dec); err != nil {
+					return err
+				}
+			}
+		case "items":
+			if ok, err := dec.Begin('['); err != nil {
+				return err
+			} else if !ok {
+				obj.Items = nil
+			} else {
+				if obj.Items == nil {
+					obj.Items = []T9{}
+				}
+				obj.Items = obj.Items[:0]
+				for dec.More() {
+					var val T9
+					if err := val.ReadJSON(dec); err != nil {
+						return err
+					}
+					obj.Items = append(obj.Items, val)
+				}
+				if err := dec.End(']'); err != nil {
+					return err
+				}
+			}
+		case "by_name":
+			if ok, err := dec.Begin('{'); err != nil {
+				return err
+			} else if !ok {
+				obj.ByName = nil
+			} else {
+				if obj.ByName == nil {
+					obj.ByName = make(map[string]*T9)
+				}
+				for dec.More() {
+					key, err := dec.Key()
+					if err != nil {
+						return err
+					}
+					var val *T9
+					if null, err := dec.Null(); err != nil {
+						return err
+					} else if null {
+						val = nil
+					} else {
+						if val == nil {
+							val = new(T9)
+						}
+						if err := val.ReadJSON(dec); err != nil {
+							return err
+						}
+					}
+					obj.ByName[key] = val
+				}
+				if err := dec.End('}'); err != nil {
+					return err
+				}
+			}
+		case "counts":
+			if err := dec.Decode(&obj.Counts); err != nil {
+				return err
+			}
+		case "nickname":
+			if err := dec.Decode(&obj.Nickname); err != nil {
+				return err
+			}
+		case "raw":
+			if err := dec.Decode(&obj.Raw); err != nil {
+				return err
+			}
+		case "home":
+			if err := obj.Home.ReadJSON(dec); err != nil {
+				return err
+			}
+		case "ratio":
+			if err := dec.Decode(&obj.Ratio); err != nil {
+				return err
+			}
+		case "enabled":
+			if err := dec.Decode(&obj.Enabled); err != nil {
+				return err
+			}
+		case "scores":
+			if err := dec.Decode(&obj.Scores); err != nil {
+				return err
+			}
+		case "offset":
+			if err := dec.Decode(&obj.Offset); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *T8) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *T8) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "name", "color", "level", "next", "items", "by_name", "counts", "nickname", "raw", "home", "ratio", "enabled", "scores", "offset":
+		default:
+			switch marshal.FoldName(key) {
+			case "NAME":
+				key = "name"
+			case "COLOR":
+				key = "color"
+			case "LEVEL":
+				key = "level"
+			case "NEXT":
+				key = "next"
+			case "ITEMS":
+				key = "items"
+			case "BY_NAME":
+				key = "by_name"
+			case "COUNTS":
+				key = "counts"
+			case "NICKNAME":
+				key = "nickname"
+			case "RAW":
+				key = "raw"
+			case "HOME":
+				key = "home"
+			case "RATIO":
+				key = "ratio"
+			case "ENABLED":
+				key = "enabled"
+			case "SCORES":
+				key = "scores"
+			case "OFFSET":
+				key = "offset"
+			}
+		}
+		switch key {
+		case "name":
+			out := &obj.Name
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "color":
+			out := &obj.Color
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "level":
+			out := &obj.Level
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "next":
+			out := &obj.Next
+			if marshal.IsNull(data) {
+				*out = nil
+			} else {
+				if *out == nil {
+					*out = new(T8)
+				}
+				out := *out
+				out.UnmarshalJSONStrict(data, path, errs)
+			}
+		case "items":
+			out := &obj.Items
+			var items []json.RawMessage
+			if err := json.Unmarshal(data, &items); err != nil {
+				errs.Add(path, err)
+			} else if items == nil {
+				*out = nil
+			} else {
+				*out = make([]T9, len(items))
+				for i := range items {
+					data, path, out := items[i], marshal.Index(path, i), &(*out)[i]
+					out.UnmarshalJSONStrict(data, path, errs)
+				}
+			}
+		case "by_name":
+			out := &obj.ByName
+			var items map[string]json.RawMessage
+			if err := json.Unmarshal(data, &items); err != nil {
+				errs.Add(path, err)
+			} else if items == nil {
+				*out = nil
+			} else {
+				*out = make(map[string]*T9, len(items))
+				for _, key := range marshal.SortedKeys(items) {
+					var val *T9
+					{
+						data, path, out := items[key], marshal.Key(path, key), &val
+						if marshal.IsNull(data) {
+							*out = nil
+						} else {
+							if *out == nil {
+								*out = new(T9)
+							}
+							out := *out
+							out.UnmarshalJSONStrict(data, path, errs)
+						}
+					}
+					(*out)[key] = val
+				}
+			}
+		case "counts":
+			out := &obj.Counts
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "nickname":
+			out := &obj.Nickname
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "raw":
+			out := &obj.Raw
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "home":
+			out := &obj.Home
+			out.UnmarshalJSONStrict(data, path, errs)
+		case "ratio":
+			out := &obj.Ratio
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "enabled":
+			out := &obj.Enabled
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "scores":
+			out := &obj.Scores
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "offset":
+			out := &obj.Offset
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalProto returns the protobuf wire encoding of obj, following the
+// message T8 declared by the generated .proto file of the package.
+func (obj *T8) MarshalProto() ([]byte, error) {
+	return obj.AppendProto(nil), nil
+}
+
+// AppendProto appends the protobuf wire encoding of obj to b.
+func (obj *T8) AppendProto(b []byte) []byte {
+	if obj.Name != "" {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendString(b, obj.Name)
+	}
+	if int64(obj.Color) != 0 {
+		b = protowire.AppendTag(b, 2, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(int64(obj.Color)))
+	}
+	if string(obj.Level) != "" {
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendString(b, string(obj.Level))
+	}
+	if obj.Next != nil {
+		b = protowire.AppendTag(b, 5, protowire.BytesType)
+		b = protowire.AppendBytes(b, obj.Next.AppendProto(nil))
+	}
+	for i := range obj.Items {
+		b = protowire.AppendTag(b, 6, protowire.BytesType)
+		b = protowire.AppendBytes(b, obj.Items[i].AppendProto(nil))
+	}
+	if len(obj.ByName) != 0 {
+		keys := make([]string, 0, len(obj.ByName))
+		for k := range obj.ByName {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i] < keys[j]
+		})
+		for _, k := range keys {
+			v := obj.ByName[k]
+			var entry []byte
+			entry = protowire.AppendTag(entry, 1, protowire.BytesType)
+			entry = protowire.AppendString(entry, k)
+			entry = protowire.AppendTag(entry, 2, protowire.BytesType)
+			if v == nil {
+				entry = protowire.AppendBytes(entry, nil)
+			} else {
+				entry = protowire.AppendBytes(entry, v.AppendProto(nil))
+			}
+			b = protowire.AppendTag(b, 7, protowire.BytesType)
+			b = protowire.AppendBytes(b, entry)
+		}
+	}
+	if len(obj.Counts) != 0 {
+		keys := make([]int32, 0, len(obj.Counts))
+		for k := range obj.Counts {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i] < keys[j]
+		})
+		for _, k := range keys {
+			v := obj.Counts[k]
+			var entry []byte
+			entry = protowire.AppendTag(entry, 1, protowire.VarintType)
+			entry = protowire.AppendVarint(entry, uint64(k))
+			entry = protowire.AppendTag(entry, 2, protowire.VarintType)
+			entry = protowire.AppendVarint(entry, uint64(v))
+			b = protowire.AppendTag(b, 8, protowire.BytesType)
+			b = protowire.AppendBytes(b, entry)
+		}
+	}
+	if obj.Nickname != nil {
+		b = protowire.AppendTag(b, 9, protowire.BytesType)
+		b = protowire.AppendString(b, (*obj.Nickname))
+	}
+	if len(obj.Raw) != 0 {
+		b = protowire.AppendTag(b, 10, protowire.BytesType)
+		b = protowire.AppendBytes(b, obj.Raw)
+	}
+	{
+		b = protowire.AppendTag(b, 11, protowire.BytesType)
+		b = protowire.AppendBytes(b, obj.Home.AppendProto(nil))
+	}
+	if math.Float32bits(obj.Ratio) != 0 {
+		b = protowire.AppendTag(b, 12, protowire.Fixed32Type)
+		b = protowire.AppendFixed32(b, math.Float32bits(obj.Ratio))
+	}
+	if obj.Enabled {
+		b = protowire.AppendTag(b, 13, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeBool(obj.Enabled))
+	}
+	if len(obj.Scores) != 0 {
+		var packed []byte
+		for _, v := range obj.Scores {
+			packed = protowire.AppendVarint(packed, uint64(v))
+		}
+		b = protowire.AppendTag(b, 14, protowire.BytesType)
+		b = protowire.AppendBytes(b, packed)
+	}
+	if int32(obj.Offset) != 0 {
+		b = protowire.AppendTag(b, 15, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(int32(obj.Offset)))
+	}
+	return b
+}
+
+// UnmarshalProto sets obj to the protobuf message T8 encoded in
+// data. Unknown fields are skipped.
+func (obj *T8) UnmarshalProto(data []byte) error {
+	var zero T8
+	*obj = zero
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		switch num {
+		case 1:
+			if typ != protowire.BytesType {
+				break
+			}
+			x, n := protowire.ConsumeString(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			obj.Name = x
+			continue
+		case 2:
+			if typ != protowire.VarintType {
+				break
+			}
+			x, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			obj.Color = Color(int64(x))
+			continue
+		case 3:
+			if typ != protowire.BytesType {
+				break
+			}
+			x, n := protowire.ConsumeString(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			obj.Level = Level(x)
+			continue
+		case 5:
+			if typ != protowire.BytesType {
+				break
+			}
+			x, n := protowire.ConsumeBytes(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			value := new(T8)
+			if err := value.UnmarshalProto(x); err != nil {
+				return err
+			}
+			obj.Next = value
+			continue
+		case 6:
+			if typ != protowire.BytesType {
+				break
+			}
+			x, n := protowire.ConsumeBytes(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			var value T9
+			if err := value.UnmarshalProto(x); err != nil {
+				return err
+			}
+			obj.Items = append(obj.Items, value)
+			continue
+		case 7:
+			if typ != protowire.BytesType {
+				break
+			}
+			entry, n := protowire.ConsumeBytes(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			var k string
+			v := new(T9)
+			for len(entry) > 0 {
+				num, typ, n := protowire.ConsumeTag(entry)
+				if n < 0 {
+					return protowire.ParseError(n)
+				}
+				entry = entry[n:]
+				switch {
+				case num == 1 && typ == protowire.BytesType:
+					x, n := protowire.ConsumeString(entry)
+					if n < 0 {
+						return protowire.ParseError(n)
+					}
+					entry = entry[n:]
+					k = x
+				case num == 2 && typ == protowire.BytesType:
+					x, n := protowire.ConsumeBytes(entry)
+					if n < 0 {
+						return protowire.ParseError(n)
+					}
+					entry = entry[n:]
+					value := new(T9)
+					if err := value.UnmarshalProto(x); err != nil {
+						return err
+					}
+					v = value
+				default:
+					n := protowire.ConsumeFieldValue(num, typ, entry)
+					if n < 0 {
+						return protowire.ParseError(n)
+					}
+					entry = entry[n:]
+				}
+			}
+			if obj.ByName == nil {
+				obj.ByName = make(map[string]*T9)
+			}
+			obj.ByName[k] = v
+			continue
+		case 8:
+			if typ != protowire.BytesType {
+				break
+			}
+			entry, n := protowire.ConsumeBytes(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			var k int32
+			var v uint64
+			for len(entry) > 0 {
+				num, typ, n := protowire.ConsumeTag(entry)
+				if n < 0 {
+					return protowire.ParseError(n)
+				}
+				entry = entry[n:]
+				switch {
+				case num == 1 && typ == protowire.VarintType:
+					x, n := protowire.ConsumeVarint(entry)
+					if n < 0 {
+						return protowire.ParseError(n)
+					}
+					entry = entry[n:]
+					k = int32(x)
+				case num == 2 && typ == protowire.VarintType:
+					x, n := protowire.ConsumeVarint(entry)
+					if n < 0 {
+						return protowire.ParseError(n)
+					}
+					entry = entry[n:]
+					v = x
+				default:
+					n := protowire.ConsumeFieldValue(num, typ, entry)
+					if n < 0 {
+						return protowire.ParseError(n)
+					}
+					entry = entry[n:]
+				}
+			}
+			if obj.Counts == nil {
+				obj.Counts = make(map[int32]uint64)
+			}
+			obj.Counts[k] = v
+			continue
+		case 9:
+			if typ != protowire.BytesType {
+				break
+			}
+			x, n := protowire.ConsumeString(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			v := x
+			obj.Nickname = &v
+			continue
+		case 10:
+			if typ != protowire.BytesType {
+				break
+			}
+			x, n := protowire.ConsumeBytes(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			obj.Raw = append([]byte(nil), x...)
+			continue
+		case 11:
+			if typ != protowire.BytesType {
+				break
+			}
+			x, n := protowire.ConsumeBytes(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			var value shared.Address
+			if err := value.UnmarshalProto(x); err != nil {
+				return err
+			}
+			obj.Home = value
+			continue
+		case 12:
+			if typ != protowire.Fixed32Type {
+				break
+			}
+			x, n := protowire.ConsumeFixed32(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			obj.Ratio = math.Float32frombits(x)
+			continue
+		case 13:
+			if typ != protowire.VarintType {
+				break
+			}
+			x, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			obj.Enabled = protowire.DecodeBool(x)
+			continue
+		case 14:
+			if typ == protowire.BytesType {
+				packed, n := protowire.ConsumeBytes(data)
+				if n < 0 {
+					return protowire.ParseError(n)
+				}
+				data = data[n:]
+				for len(packed) > 0 {
+					x, n := protowire.ConsumeVarint(packed)
+					if n < 0 {
+						return protowire.ParseError(n)
+					}
+					packed = packed[n:]
+					obj.Scores = append(obj.Scores, int64(x))
+				}
+				continue
+			}
+			if typ != protowire.VarintType {
+				break
+			}
+			x, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			obj.Scores = append(obj.Scores, int64(x))
+			continue
+		case 15:
+			if typ != protowire.VarintType {
+				break
+			}
+			x, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			obj.Offset = int8(int32(x))
+			continue
+		}
+		// Skip unknown fields, and fields of an unexpected wire type.
+		n = protowire.ConsumeFieldValue(num, typ, data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *T9) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *T9) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *T9) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *T9) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *T9) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *T9) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"id":`)
+	jw.Uint(uint64(obj.ID))
+	if len(obj.Tags) != 0 {
+		jw.Raw(`,"tags":`)
+		if obj.Tags == nil {
+			jw.Null()
+		} else {
+			jw.RawByte('[')
+			for i := range obj.Tags {
+				if i > 0 {
+					jw.RawByte(',')
+				}
+				in := &obj.Tags[i]
+				jw.String(*in)
+			}
+			jw.RawByte(']')
+		}
+	}
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *T9) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "id", "tags":
+		default:
+			switch marshal.FoldName(key) {
+			case "ID":
+				key = "id"
+			case "TAGS":
+				key = "tags"
+			}
+		}
+		switch key {
+		case "id":
+			if err := dec.Decode(&obj.ID); err != nil {
+				return err
+			}
+		case "tags":
+			if err := dec.Decode(&obj.Tags); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *T9) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *T9) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "id", "tags":
+		default:
+			switch marshal.FoldName(key) {
+			case "ID":
+				key = "id"
+			case "TAGS":
+				key = "tags"
+			}
+		}
+		switch key {
+		case "id":
+			out := &obj.ID
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "tags":
+			out := &obj.Tags
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+}
+
+// MarshalProto returns the protobuf wire encoding of obj, following the
+// message T9 declared by the generated .proto file of the package.
+func (obj *T9) MarshalProto() ([]byte, error) {
+	return obj.AppendProto(nil), nil
+}
+
+// AppendProto appends the protobuf wire encoding of obj to b.
+func (obj *T9) AppendProto(b []byte) []byte {
+	if obj.ID != 0 {
+		b = protowire.AppendTag(b, 1, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(obj.ID))
+	}
+	for i := range obj.Tags {
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendString(b, obj.Tags[i])
+	}
+	return b
+}
+
+// UnmarshalProto sets obj to the protobuf message T9 encoded in
+// data. Unknown fields are skipped.
+func (obj *T9) UnmarshalProto(data []byte) error {
+	var zero T9
+	*obj = zero
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		switch num {
+		case 1:
+			if typ != protowire.VarintType {
+				break
+			}
+			x, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			obj.ID = uint32(x)
+			continue
+		case 2:
+			if typ != protowire.BytesType {
+				break
+			}
+			x, n := protowire.ConsumeString(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			obj.Tags = append(obj.Tags, x)
+			continue
+		}
+		// Skip unknown fields, and fields of an unexpected wire type.
+		n = protowire.ConsumeFieldValue(num, typ, data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+	}
+	return nil
+}
@@ -0,0 +1,844 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+package model
+
+import (
+	"bytes"
+	json "encoding/json"
+	xml "encoding/xml"
+	"io"
+	"reflect"
+	"testing"
+
+	shared "github.com/zhaolion/gengo/example/marshal-gen/shared"
+)
+
+// generatedMarshaler is implemented by every type with generated marshal
+// methods.
+type generatedMarshaler interface {
+	MarshalJSONBinary() ([]byte, error)
+	UnmarshalJSONBinary(data []byte) error
+	EncodeJSON(w io.Writer) error
+	DecodeJSON(r io.Reader) error
+}
+
+// generatedMarshalCase holds a populated sample value of a type with
+// generated marshal methods.
+type generatedMarshalCase struct {
+	name   string
+	sample generatedMarshaler
+	new    func() generatedMarshaler
+}
+
+func TestGeneratedMarshalRoundTrip(t *testing.T) {
+	for _, tc := range generatedMarshalCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := tc.sample.MarshalJSONBinary()
+			if err != nil {
+				t.Fatalf("MarshalJSONBinary: %v", err)
+			}
+			checkGeneratedMarshalRoundTrip(t, tc, tc.sample, data)
+		})
+	}
+}
+
+// checkGeneratedMarshalRoundTrip checks that EncodeJSON writes obj exactly
+// like MarshalJSONBinary, which wrote data, and that both UnmarshalJSONBinary
+// and DecodeJSON read data back into a value equal to obj, as compared by
+// generatedMarshalEqual.
+func checkGeneratedMarshalRoundTrip(t *testing.T, tc generatedMarshalCase, obj generatedMarshaler, data []byte) {
+	var buf bytes.Buffer
+	if err := obj.EncodeJSON(&buf); err != nil {
+		t.Fatalf("EncodeJSON: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatalf("EncodeJSON wrote %s, MarshalJSONBinary wrote %s", buf.Bytes(), data)
+	}
+
+	out := tc.new()
+	if err := out.UnmarshalJSONBinary(data); err != nil {
+		t.Fatalf("UnmarshalJSONBinary(%s): %v", data, err)
+	}
+	if !generatedMarshalEqual(out, obj) {
+		t.Fatalf("UnmarshalJSONBinary(%s) does not round-trip", data)
+	}
+
+	out = tc.new()
+	if err := out.DecodeJSON(bytes.NewReader(data)); err != nil {
+		t.Fatalf("DecodeJSON(%s): %v", data, err)
+	}
+	if !generatedMarshalEqual(out, obj) {
+		t.Fatalf("DecodeJSON(%s) does not round-trip", data)
+	}
+}
+
+// generatedMarshalEqual reports whether a and b are deeply equal, except for
+// the differences encoding/json documents as lost by a round-trip: nil and
+// empty slices and maps are equal, and so are NaNs. -0 and 0 are equal too,
+// and so are the RawMessages holding the same JSON value.
+func generatedMarshalEqual(a, b interface{}) bool {
+	return generatedMarshalEqualValues(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+var generatedRawMessageType = reflect.TypeOf(json.RawMessage(nil))
+
+func generatedMarshalEqualValues(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+	if a.Type() == generatedRawMessageType {
+		// A RawMessage is written compacted, and a nil one as null,
+		// which is read back as is.
+		x, errA := json.Marshal(json.RawMessage(a.Bytes()))
+		y, errB := json.Marshal(json.RawMessage(b.Bytes()))
+		return errA == nil && errB == nil && bytes.Equal(x, y)
+	}
+	switch a.Kind() {
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !generatedMarshalEqualValues(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for _, key := range a.MapKeys() {
+			if !generatedMarshalEqualValues(a.MapIndex(key), b.MapIndex(key)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return generatedMarshalEqualValues(a.Elem(), b.Elem())
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !generatedMarshalEqualValues(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Float32, reflect.Float64:
+		x, y := a.Float(), b.Float()
+		return x == y || x != x && y != y
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.String:
+		return a.String() == b.String()
+	}
+	// Like reflect.DeepEqual, channels and functions are equal if both are
+	// nil.
+	return a.IsNil() && b.IsNil()
+}
+
+// fuzzGeneratedMarshal checks that every value UnmarshalJSONBinary reads into
+// the type of the case called name round-trips: writing it and reading it
+// back gives the same value, not only after a first round-trip.
+func fuzzGeneratedMarshal(f *testing.F, name string) {
+	var tc generatedMarshalCase
+	for _, c := range generatedMarshalCases {
+		if c.name == name {
+			tc = c
+		}
+	}
+	seed, err := tc.sample.MarshalJSONBinary()
+	if err != nil {
+		f.Fatalf("MarshalJSONBinary: %v", err)
+	}
+	f.Add(seed)
+	f.Add([]byte("null"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		obj := tc.new()
+		if err := obj.UnmarshalJSONBinary(data); err != nil {
+			return
+		}
+		data, err := obj.MarshalJSONBinary()
+		if err != nil {
+			// Not every value read can be written, e.g. values its
+			// MarshalJSON method rejects.
+			return
+		}
+		checkGeneratedMarshalRoundTrip(t, tc, obj, data)
+	})
+}
+
+var generatedMarshalCases = []generatedMarshalCase{
+	{
+		name: "Base",
+		sample: &Base{
+			ID:   -7,
+			Kind: "sample \"<&>\" é",
+			Name: "sample \"<&>\" é",
+		},
+		new: func() generatedMarshaler { return new(Base) },
+	},
+	{
+		name: "Color",
+		sample: func() *Color {
+			var v Color = Red
+			return &v
+		}(),
+		new: func() generatedMarshaler { return new(Color) },
+	},
+	{
+		name: "Config",
+		sample: &Config{
+			ConfigBase: ConfigBase{
+				Version: -7,
+			},
+			Name:  "sample \"<&>\" é",
+			Port:  -7,
+			Debug: true,
+			Level: LevelDebug,
+			Timeout: func() *float64 {
+				var v float64 = -1.5
+				return &v
+			}(),
+			Listeners: []Listener{Listener{
+				Address: "sample \"<&>\" é",
+				TLS:     true,
+			}},
+			Primary: &Listener{
+				Address: "sample \"<&>\" é",
+				TLS:     true,
+			},
+			Labels:  map[string]string{"sample \"<&>\" é": "sample \"<&>\" é"},
+			Palette: []Color{Red},
+		},
+		new: func() generatedMarshaler { return new(Config) },
+	},
+	{
+		name: "ConfigBase",
+		sample: &ConfigBase{
+			Version: -7,
+		},
+		new: func() generatedMarshaler { return new(ConfigBase) },
+	},
+	{
+		name: "Customer",
+		sample: &Customer{
+			Name:  "sample \"<&>\" é",
+			Email: "sample \"<&>\" é",
+			VIP:   true,
+		},
+		new: func() generatedMarshaler { return new(Customer) },
+	},
+	{
+		name: "Event",
+		sample: &Event{
+			Kind: "sample \"<&>\" é",
+		},
+		new: func() generatedMarshaler { return new(Event) },
+	},
+	{
+		name: "Extension",
+		sample: &Extension{
+			XMLName: xml.Name{
+				Space: "sample \"<&>\" é",
+				Local: "sample \"<&>\" é",
+			},
+			Value: "sample \"<&>\" é",
+		},
+		new: func() generatedMarshaler { return new(Extension) },
+	},
+	{
+		name: "inner",
+		sample: &inner{
+			Hidden: "sample \"<&>\" é",
+		},
+		new: func() generatedMarshaler { return new(inner) },
+	},
+	{
+		name: "innerPtr",
+		sample: &innerPtr{
+			Unreachable: "sample \"<&>\" é",
+		},
+		new: func() generatedMarshaler { return new(innerPtr) },
+	},
+	{
+		name: "Left",
+		sample: &Left{
+			Dup:  "sample \"<&>\" é",
+			Pick: "sample \"<&>\" é",
+		},
+		new: func() generatedMarshaler { return new(Left) },
+	},
+	{
+		name: "Level",
+		sample: func() *Level {
+			var v Level = LevelDebug
+			return &v
+		}(),
+		new: func() generatedMarshaler { return new(Level) },
+	},
+	{
+		name: "Listener",
+		sample: &Listener{
+			Address: "sample \"<&>\" é",
+			TLS:     true,
+		},
+		new: func() generatedMarshaler { return new(Listener) },
+	},
+	{
+		name: "Meta",
+		sample: &Meta{
+			Labels: map[string]string{"sample \"<&>\" é": "sample \"<&>\" é"},
+			Note: func() *string {
+				var v string = "sample \"<&>\" é"
+				return &v
+			}(),
+		},
+		new: func() generatedMarshaler { return new(Meta) },
+	},
+	{
+		name: "Named",
+		sample: &Named{
+			X: -7,
+		},
+		new: func() generatedMarshaler { return new(Named) },
+	},
+	{
+		name: "Offset",
+		sample: func() *Offset {
+			var v Offset = -7
+			return &v
+		}(),
+		new: func() generatedMarshaler { return new(Offset) },
+	},
+	{
+		name: "Order",
+		sample: &Order{
+			XMLName: xml.Name{
+				Space: "sample \"<&>\" é",
+				Local: "sample \"<&>\" é",
+			},
+			OrderBase: OrderBase{
+				ID:      -7,
+				Version: 7,
+			},
+			Status: LevelDebug,
+			Rush: func() *bool {
+				var v bool = true
+				return &v
+			}(),
+			Currency: "sample \"<&>\" é",
+			Extra: []xml.Attr{xml.Attr{
+				Name: xml.Name{
+					Space: "sample \"<&>\" é",
+					Local: "sample \"<&>\" é",
+				},
+				Value: "sample \"<&>\" é",
+			}},
+			Note: "sample \"<&>\" é",
+			Customer: Customer{
+				Name:  "sample \"<&>\" é",
+				Email: "sample \"<&>\" é",
+				VIP:   true,
+			},
+			Billing: &shared.Address{
+				Street: "sample \"<&>\" é",
+				City:   "sample \"<&>\" é",
+				Zip:    "sample \"<&>\" é",
+			},
+			Lines: []OrderLine{OrderLine{
+				SKU:      "sample \"<&>\" é",
+				Quantity: 7,
+				Price:    -1.5,
+				Gift:     []byte{7},
+				Discount: -7,
+			}},
+			Coupons: []string{"sample \"<&>\" é"},
+			Total:   -1.5,
+			Ref:     "sample \"<&>\" é",
+			Colors:  []Color{Red},
+			Rest: []Extension{Extension{
+				XMLName: xml.Name{
+					Space: "sample \"<&>\" é",
+					Local: "sample \"<&>\" é",
+				},
+				Value: "sample \"<&>\" é",
+			}},
+			Internal: "sample \"<&>\" é",
+		},
+		new: func() generatedMarshaler { return new(Order) },
+	},
+	{
+		name: "OrderBase",
+		sample: &OrderBase{
+			ID:      -7,
+			Version: 7,
+		},
+		new: func() generatedMarshaler { return new(OrderBase) },
+	},
+	{
+		name: "OrderLine",
+		sample: &OrderLine{
+			SKU:      "sample \"<&>\" é",
+			Quantity: 7,
+			Price:    -1.5,
+			Gift:     []byte{7},
+			Discount: -7,
+		},
+		new: func() generatedMarshaler { return new(OrderLine) },
+	},
+	{
+		name: "Right",
+		sample: &Right{
+			Dup:  "sample \"<&>\" é",
+			Pick: "sample \"<&>\" é",
+			Only: 7,
+		},
+		new: func() generatedMarshaler { return new(Right) },
+	},
+	{
+		name: "Session",
+		sample: &Session{
+			ID:     "sample \"<&>\" é",
+			Visits: -7,
+			Color:  Red,
+			Level: func() *Level {
+				var v Level = LevelDebug
+				return &v
+			}(),
+			Items: []SessionItem{SessionItem{
+				Name:  "sample \"<&>\" é",
+				Count: 7,
+				Flags: []bool{true},
+			}},
+			ByName: map[string]*SessionItem{"sample \"<&>\" é": &SessionItem{
+				Name:  "sample \"<&>\" é",
+				Count: 7,
+				Flags: []bool{true},
+			}},
+			Matrix: [][]int16{[]int16{-7}},
+			Data:   []byte{7},
+			Home: &shared.Address{
+				Street: "sample \"<&>\" é",
+				City:   "sample \"<&>\" é",
+				Zip:    "sample \"<&>\" é",
+			},
+			Ratio:  -1.5,
+			Seen:   map[Level]bool{LevelDebug: true},
+			Deltas: []int8{-7},
+		},
+		new: func() generatedMarshaler { return new(Session) },
+	},
+	{
+		name: "SessionItem",
+		sample: &SessionItem{
+			Name:  "sample \"<&>\" é",
+			Count: 7,
+			Flags: []bool{true},
+		},
+		new: func() generatedMarshaler { return new(SessionItem) },
+	},
+	{
+		name: "SessionV1",
+		sample: &SessionV1{
+			ID:     "sample \"<&>\" é",
+			Visits: -7,
+			Token:  "sample \"<&>\" é",
+		},
+		new: func() generatedMarshaler { return new(SessionV1) },
+	},
+	{
+		name: "T1",
+		sample: &T1{
+			Byte:    7,
+			Int8:    -7,
+			Int16:   -7,
+			Int32:   -7,
+			Int64:   -7,
+			Uint8:   7,
+			Uint16:  7,
+			Uint32:  7,
+			Uint64:  7,
+			Float32: -1.5,
+			Float64: -1.5,
+			Str:     "sample \"<&>\" é",
+			Offset:  -7,
+			Int8s:   []int8{-7},
+		},
+		new: func() generatedMarshaler { return new(T1) },
+	},
+	{
+		name:   "T2",
+		sample: &T2{},
+		new:    func() generatedMarshaler { return new(T2) },
+	},
+	{
+		name: "T3",
+		sample: &T3{
+			Byte:    map[string]byte{"sample \"<&>\" é": 7},
+			Int8:    map[string]int8{"sample \"<&>\" é": -7},
+			Int16:   map[string]int16{"sample \"<&>\" é": -7},
+			Int32:   map[string]int32{"sample \"<&>\" é": -7},
+			Int64:   map[string]int64{"sample \"<&>\" é": -7},
+			Uint8:   map[string]byte{"sample \"<&>\" é": 7},
+			Uint16:  map[string]uint16{"sample \"<&>\" é": 7},
+			Uint32:  map[string]uint32{"sample \"<&>\" é": 7},
+			Uint64:  map[string]uint64{"sample \"<&>\" é": 7},
+			Float32: map[string]float32{"sample \"<&>\" é": -1.5},
+			Float64: map[string]float64{"sample \"<&>\" é": -1.5},
+			StringPtr: map[string]*string{"sample \"<&>\" é": func() *string {
+				var v string = "sample \"<&>\" é"
+				return &v
+			}()},
+			StringPtrPtr: map[string]**string{"sample \"<&>\" é": func() **string {
+				var v *string = func() *string {
+					var v string = "sample \"<&>\" é"
+					return &v
+				}()
+				return &v
+			}()},
+			Map: map[string]map[string]string{"sample \"<&>\" é": map[string]string{"sample \"<&>\" é": "sample \"<&>\" é"}},
+			MapPtr: map[string]*map[string]string{"sample \"<&>\" é": func() *map[string]string {
+				var v map[string]string = map[string]string{"sample \"<&>\" é": "sample \"<&>\" é"}
+				return &v
+			}()},
+			Slice: map[string][]string{"sample \"<&>\" é": []string{"sample \"<&>\" é"}},
+			SlicePtr: map[string]*[]string{"sample \"<&>\" é": func() *[]string {
+				var v []string = []string{"sample \"<&>\" é"}
+				return &v
+			}()},
+			Struct: map[string]T1{"sample \"<&>\" é": T1{
+				Byte:    7,
+				Int8:    -7,
+				Int16:   -7,
+				Int32:   -7,
+				Int64:   -7,
+				Uint8:   7,
+				Uint16:  7,
+				Uint32:  7,
+				Uint64:  7,
+				Float32: -1.5,
+				Float64: -1.5,
+				Str:     "sample \"<&>\" é",
+				Offset:  -7,
+				Int8s:   []int8{-7},
+			}},
+			StructPtr: map[string]*T2{"sample \"<&>\" é": &T2{}},
+		},
+		new: func() generatedMarshaler { return new(T3) },
+	},
+	{
+		name: "T4",
+		sample: &T4{
+			Name: "sample \"<&>\" é",
+			T1: &T1{
+				Byte:    7,
+				Int8:    -7,
+				Int16:   -7,
+				Int32:   -7,
+				Int64:   -7,
+				Uint8:   7,
+				Uint16:  7,
+				Uint32:  7,
+				Uint64:  7,
+				Float32: -1.5,
+				Float64: -1.5,
+				Str:     "sample \"<&>\" é",
+				Offset:  -7,
+				Int8s:   []int8{-7},
+			},
+			Items: []T1{T1{
+				Byte:    7,
+				Int8:    -7,
+				Int16:   -7,
+				Int32:   -7,
+				Int64:   -7,
+				Uint8:   7,
+				Uint16:  7,
+				Uint32:  7,
+				Uint64:  7,
+				Float32: -1.5,
+				Float64: -1.5,
+				Str:     "sample \"<&>\" é",
+				Offset:  -7,
+				Int8s:   []int8{-7},
+			}},
+			ByKey: map[string]*T1{"sample \"<&>\" é": &T1{
+				Byte:    7,
+				Int8:    -7,
+				Int16:   -7,
+				Int32:   -7,
+				Int64:   -7,
+				Uint8:   7,
+				Uint16:  7,
+				Uint32:  7,
+				Uint64:  7,
+				Float32: -1.5,
+				Float64: -1.5,
+				Str:     "sample \"<&>\" é",
+				Offset:  -7,
+				Int8s:   []int8{-7},
+			}},
+		},
+		new: func() generatedMarshaler { return new(T4) },
+	},
+	{
+		name: "T5",
+		sample: &T5{
+			Base: Base{
+				ID:   -7,
+				Kind: "sample \"<&>\" é",
+			},
+			Meta: &Meta{
+				Labels: map[string]string{"sample \"<&>\" é": "sample \"<&>\" é"},
+				Note: func() *string {
+					var v string = "sample \"<&>\" é"
+					return &v
+				}(),
+			},
+			Left: Left{
+				Pick: "sample \"<&>\" é",
+			},
+			Right: Right{
+				Only: 7,
+			},
+			inner: inner{
+				Hidden: "sample \"<&>\" é",
+			},
+			Named: Named{
+				X: -7,
+			},
+			Name:    "sample \"<&>\" é",
+			Dash:    "sample \"<&>\" é",
+			Count:   -7,
+			Ratio:   -1.5,
+			Enabled: true,
+			Title:   "sample \"<&>\" é",
+			T1: &T1{
+				Byte:    7,
+				Int8:    -7,
+				Int16:   -7,
+				Int32:   -7,
+				Int64:   -7,
+				Uint8:   7,
+				Uint16:  7,
+				Uint32:  7,
+				Uint64:  7,
+				Float32: -1.5,
+				Float64: -1.5,
+				Str:     "sample \"<&>\" é",
+				Offset:  -7,
+				Int8s:   []int8{-7},
+			},
+			List: []string{"sample \"<&>\" é"},
+		},
+		new: func() generatedMarshaler { return new(T5) },
+	},
+	{
+		name: "T6",
+		sample: &T6{
+			Color:   Red,
+			Level:   LevelDebug,
+			Palette: []Color{Red},
+			ByLevel: map[Level]int{LevelDebug: -7},
+			Ptr: func() *Color {
+				var v Color = Red
+				return &v
+			}(),
+		},
+		new: func() generatedMarshaler { return new(T6) },
+	},
+	{
+		name: "T7",
+		sample: &T7{
+			Home: shared.Address{
+				Street: "sample \"<&>\" é",
+				City:   "sample \"<&>\" é",
+				Zip:    "sample \"<&>\" é",
+			},
+			Work: &shared.Address{
+				Street: "sample \"<&>\" é",
+				City:   "sample \"<&>\" é",
+				Zip:    "sample \"<&>\" é",
+			},
+			Contact: map[string]Level{"sample \"<&>\" é": LevelDebug},
+		},
+		new: func() generatedMarshaler { return new(T7) },
+	},
+	{
+		name: "T8",
+		sample: &T8{
+			Name:  "sample \"<&>\" é",
+			Color: Red,
+			Level: LevelDebug,
+			Items: []T9{T9{
+				ID:   7,
+				Tags: []string{"sample \"<&>\" é"},
+			}},
+			ByName: map[string]*T9{"sample \"<&>\" é": &T9{
+				ID:   7,
+				Tags: []string{"sample \"<&>\" é"},
+			}},
+			Counts: map[int32]uint64{-7: 7},
+			Nickname: func() *string {
+				var v string = "sample \"<&>\" é"
+				return &v
+			}(),
+			Raw: []byte{7},
+			Home: shared.Address{
+				Street: "sample \"<&>\" é",
+				City:   "sample \"<&>\" é",
+				Zip:    "sample \"<&>\" é",
+			},
+			Ratio:   -1.5,
+			Enabled: true,
+			Scores:  []int64{-7},
+			Offset:  -7,
+		},
+		new: func() generatedMarshaler { return new(T8) },
+	},
+	{
+		name: "T9",
+		sample: &T9{
+			ID:   7,
+			Tags: []string{"sample \"<&>\" é"},
+		},
+		new: func() generatedMarshaler { return new(T9) },
+	},
+}
+
+func FuzzMarshalBase(f *testing.F) {
+	fuzzGeneratedMarshal(f, "Base")
+}
+
+func FuzzMarshalColor(f *testing.F) {
+	fuzzGeneratedMarshal(f, "Color")
+}
+
+func FuzzMarshalConfig(f *testing.F) {
+	fuzzGeneratedMarshal(f, "Config")
+}
+
+func FuzzMarshalConfigBase(f *testing.F) {
+	fuzzGeneratedMarshal(f, "ConfigBase")
+}
+
+func FuzzMarshalCustomer(f *testing.F) {
+	fuzzGeneratedMarshal(f, "Customer")
+}
+
+func FuzzMarshalEvent(f *testing.F) {
+	fuzzGeneratedMarshal(f, "Event")
+}
+
+func FuzzMarshalExtension(f *testing.F) {
+	fuzzGeneratedMarshal(f, "Extension")
+}
+
+func FuzzMarshalInner(f *testing.F) {
+	fuzzGeneratedMarshal(f, "inner")
+}
+
+func FuzzMarshalInnerPtr(f *testing.F) {
+	fuzzGeneratedMarshal(f, "innerPtr")
+}
+
+func FuzzMarshalLeft(f *testing.F) {
+	fuzzGeneratedMarshal(f, "Left")
+}
+
+func FuzzMarshalLevel(f *testing.F) {
+	fuzzGeneratedMarshal(f, "Level")
+}
+
+func FuzzMarshalListener(f *testing.F) {
+	fuzzGeneratedMarshal(f, "Listener")
+}
+
+func FuzzMarshalMeta(f *testing.F) {
+	fuzzGeneratedMarshal(f, "Meta")
+}
+
+func FuzzMarshalNamed(f *testing.F) {
+	fuzzGeneratedMarshal(f, "Named")
+}
+
+func FuzzMarshalOffset(f *testing.F) {
+	fuzzGeneratedMarshal(f, "Offset")
+}
+
+func FuzzMarshalOrder(f *testing.F) {
+	fuzzGeneratedMarshal(f, "Order")
+}
+
+func FuzzMarshalOrderBase(f *testing.F) {
+	fuzzGeneratedMarshal(f, "OrderBase")
+}
+
+func FuzzMarshalOrderLine(f *testing.F) {
+	fuzzGeneratedMarshal(f, "OrderLine")
+}
+
+func FuzzMarshalRight(f *testing.F) {
+	fuzzGeneratedMarshal(f, "Right")
+}
+
+func FuzzMarshalSession(f *testing.F) {
+	fuzzGeneratedMarshal(f, "Session")
+}
+
+func FuzzMarshalSessionItem(f *testing.F) {
+	fuzzGeneratedMarshal(f, "SessionItem")
+}
+
+func FuzzMarshalSessionV1(f *testing.F) {
+	fuzzGeneratedMarshal(f, "SessionV1")
+}
+
+func FuzzMarshalT1(f *testing.F) {
+	fuzzGeneratedMarshal(f, "T1")
+}
+
+func FuzzMarshalT2(f *testing.F) {
+	fuzzGeneratedMarshal(f, "T2")
+}
+
+func FuzzMarshalT3(f *testing.F) {
+	fuzzGeneratedMarshal(f, "T3")
+}
+
+func FuzzMarshalT4(f *testing.F) {
+	fuzzGeneratedMarshal(f, "T4")
+}
+
+func FuzzMarshalT5(f *testing.F) {
+	fuzzGeneratedMarshal(f, "T5")
+}
+
+func FuzzMarshalT6(f *testing.F) {
+	fuzzGeneratedMarshal(f, "T6")
+}
+
+func FuzzMarshalT7(f *testing.F) {
+	fuzzGeneratedMarshal(f, "T7")
+}
+
+func FuzzMarshalT8(f *testing.F) {
+	fuzzGeneratedMarshal(f, "T8")
+}
+
+func FuzzMarshalT9(f *testing.F) {
+	fuzzGeneratedMarshal(f, "T9")
+}
@@ -0,0 +1,30 @@
+//go:generate marshal-gen -i github.com/zhaolion/gengo/example/marshal-gen/shared --openapi --proto --typescript
+
+// Package shared holds types the model package refers to.
+package shared
+
+// Address is a postal address.
+// +gengo:marshal:openapi=true
+// +gengo:marshal:proto=true
+// +gengo:marshal:binary=true
+type Address struct {
+	// +gengo:marshal:proto-field=1
+	// +gengo:marshal:binary-field=1
+	Street string `json:"street"`
+	// +gengo:marshal:proto-field=2
+	// +gengo:marshal:binary-field=2
+	City string `json:"city" marshal:"required"`
+	// Zip is left out when empty.
+	// +gengo:marshal:proto-field=3
+	// +gengo:marshal:binary-field=3
+	Zip string `json:"zip,omitempty"`
+}
+
+// Kind classifies an Address.
+// +gengo:marshal:openapi=true
+type Kind string
+
+const (
+	KindHome Kind = "home"
+	KindWork Kind = "work"
+)
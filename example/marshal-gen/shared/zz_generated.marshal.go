@@ -0,0 +1,429 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	marshal "github.com/zhaolion/gengo/marshal"
+	protowire "google.golang.org/protobuf/encoding/protowire"
+)
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *Address) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *Address) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
+func (obj *Address) String() string {
+	bs, _ := obj.MarshalJSONBinary()
+	return string(bs)
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *Address) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *Address) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *Address) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.RawByte('{')
+	jw.Raw(`"street":`)
+	jw.String(obj.Street)
+	jw.Raw(`,"city":`)
+	jw.String(obj.City)
+	if obj.Zip != "" {
+		jw.Raw(`,"zip":`)
+		jw.String(obj.Zip)
+	}
+	jw.RawByte('}')
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *Address) ReadJSON(dec *marshal.Decoder) error {
+	if ok, err := dec.Begin('{'); err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "street", "city", "zip":
+		default:
+			switch marshal.FoldName(key) {
+			case "STREET":
+				key = "street"
+			case "CITY":
+				key = "city"
+			case "ZIP":
+				key = "zip"
+			}
+		}
+		switch key {
+		case "street":
+			if err := dec.Decode(&obj.Street); err != nil {
+				return err
+			}
+		case "city":
+			if err := dec.Decode(&obj.City); err != nil {
+				return err
+			}
+		case "zip":
+			if err := dec.Decode(&obj.Zip); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return dec.End('}')
+}
+
+// UnmarshalJSONBinaryStrict is like UnmarshalJSONBinary, but rejects unknown
+// fields and reports fields tagged `marshal:"required"` which are missing.
+// The returned *marshal.StrictError lists the JSON path of every problem found
+// in data, not just the first one.
+func (obj *Address) UnmarshalJSONBinaryStrict(data []byte) error {
+	errs := &marshal.StrictError{}
+	obj.UnmarshalJSONStrict(data, marshal.Root, errs)
+	return errs.Err()
+}
+
+// UnmarshalJSONStrict decodes data into obj, recording every problem found
+// below path into errs. Strict decoding of the types containing this one
+// descends into it through this method.
+func (obj *Address) UnmarshalJSONStrict(data []byte, path string, errs *marshal.StrictError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		errs.Add(path, err)
+		return
+	}
+	found := map[string]bool{}
+	for _, key := range marshal.SortedKeys(fields) {
+		data, path := fields[key], marshal.Key(path, key)
+		switch key {
+		case "street", "city", "zip":
+		default:
+			switch marshal.FoldName(key) {
+			case "STREET":
+				key = "street"
+			case "CITY":
+				key = "city"
+			case "ZIP":
+				key = "zip"
+			}
+		}
+		found[key] = true
+		switch key {
+		case "street":
+			out := &obj.Street
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "city":
+			out := &obj.City
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		case "zip":
+			out := &obj.Zip
+			if err := json.Unmarshal(data, out); err != nil {
+				errs.Add(path, err)
+			}
+		default:
+			errs.Add(path, marshal.ErrUnknownField)
+		}
+	}
+	if !found["city"] {
+		errs.Add(marshal.Key(path, "city"), marshal.ErrMissingField)
+	}
+}
+
+// MarshalBinary returns the compact binary encoding of obj: the version of the
+// format, then the fields of obj which are set, tagged by their number.
+func (obj *Address) MarshalBinary() ([]byte, error) {
+	w := marshal.NewBinaryWriter()
+	w.Header()
+	obj.WriteBinary(w)
+	return w.Bytes(), w.Err()
+}
+
+// UnmarshalBinary sets obj to the binary encoding data. Unknown fields are
+// skipped, and fields missing from data are left to their zero value.
+func (obj *Address) UnmarshalBinary(data []byte) error {
+	r := marshal.NewBinaryReader(data)
+	r.Header()
+	obj.ReadBinary(r)
+	return r.Err()
+}
+
+// WriteBinary writes the fields of obj to w, without the version of the format.
+func (obj *Address) WriteBinary(w *marshal.BinaryWriter) {
+	if obj.Street != "" {
+		w.Tag(1, marshal.BinaryBytes)
+		w.String(obj.Street)
+	}
+	if obj.City != "" {
+		w.Tag(2, marshal.BinaryBytes)
+		w.String(obj.City)
+	}
+	if obj.Zip != "" {
+		w.Tag(3, marshal.BinaryBytes)
+		w.String(obj.Zip)
+	}
+}
+
+// ReadBinary sets obj to the fields read from r, until the end of its data.
+func (obj *Address) ReadBinary(r *marshal.BinaryReader) {
+	var zero Address
+	*obj = zero
+	for r.More() {
+		num, typ := r.Tag()
+		switch num {
+		case 1:
+			if typ != marshal.BinaryBytes {
+				break
+			}
+			obj.Street = r.String()
+			continue
+		case 2:
+			if typ != marshal.BinaryBytes {
+				break
+			}
+			obj.City = r.String()
+			continue
+		case 3:
+			if typ != marshal.BinaryBytes {
+				break
+			}
+			obj.Zip = r.String()
+			continue
+		}
+		// Skip unknown fields, and fields of an unexpected wire type.
+		r.Skip(typ)
+	}
+}
+
+// MarshalProto returns the protobuf wire encoding of obj, following the
+// message Address declared by the generated .proto file of the package.
+func (obj *Address) MarshalProto() ([]byte, error) {
+	return obj.AppendProto(nil), nil
+}
+
+// AppendProto appends the protobuf wire encoding of obj to b.
+func (obj *Address) AppendProto(b []byte) []byte {
+	if obj.Street != "" {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendString(b, obj.Street)
+	}
+	if obj.City != "" {
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendString(b, obj.City)
+	}
+	if obj.Zip != "" {
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendString(b, obj.Zip)
+	}
+	return b
+}
+
+// UnmarshalProto sets obj to the protobuf message Address encoded in
+// data. Unknown fields are skipped.
+func (obj *Address) UnmarshalProto(data []byte) error {
+	var zero Address
+	*obj = zero
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		switch num {
+		case 1:
+			if typ != protowire.BytesType {
+				break
+			}
+			x, n := protowire.ConsumeString(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			obj.Street = x
+			continue
+		case 2:
+			if typ != protowire.BytesType {
+				break
+			}
+			x, n := protowire.ConsumeString(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			obj.City = x
+			continue
+		case 3:
+			if typ != protowire.BytesType {
+				break
+			}
+			x, n := protowire.ConsumeString(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			obj.Zip = x
+			continue
+		}
+		// Skip unknown fields, and fields of an unexpected wire type.
+		n = protowire.ConsumeFieldValue(num, typ, data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+// MarshalJSONBinary can marshal themselves into valid JSON.
+func (obj *Kind) MarshalJSONBinary() ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONBinary that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+func (obj *Kind) UnmarshalJSONBinary(data []byte) error {
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// kindEnumConstants pairs the names of the Kind constants with their values.
+var kindEnumConstants = struct {
+	names  []string
+	values []Kind
+}{
+	names:  []string{"home", "work"},
+	values: []Kind{KindHome, KindWork},
+}
+
+// ParseKind returns the Kind constant called name.
+func ParseKind(name string) (Kind, error) {
+	for i, n := range kindEnumConstants.names {
+		if n == name {
+			return kindEnumConstants.values[i], nil
+		}
+	}
+	var zero Kind
+	return zero, fmt.Errorf("invalid Kind %q", name)
+}
+
+// KindValues returns the values of the Kind constants, in declaration
+// order.
+func KindValues() []Kind {
+	return []Kind{KindHome, KindWork}
+}
+
+// MarshalText implements encoding.TextMarshaler. It writes the name of the
+// constant obj equals, or the underlying value of obj if there is none, e.g.
+// for a zero value without a constant.
+func (obj Kind) MarshalText() ([]byte, error) {
+	for i, v := range kindEnumConstants.values {
+		if v == obj {
+			return []byte(kindEnumConstants.names[i]), nil
+		}
+	}
+	return []byte(string(obj)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It reads the names
+// ParseKind accepts, and the underlying values MarshalText writes.
+func (obj *Kind) UnmarshalText(text []byte) error {
+	if v, err := ParseKind(string(text)); err == nil {
+		*obj = v
+		return nil
+	}
+	*obj = Kind(text)
+	return nil
+}
+
+// String returns the name of the constant obj equals, or the value of obj
+// after the type name if there is none.
+func (obj Kind) String() string {
+	for i, v := range kindEnumConstants.values {
+		if v == obj {
+			return kindEnumConstants.names[i]
+		}
+	}
+	return fmt.Sprintf("Kind(%q)", string(obj))
+}
+
+// EncodeJSON writes the JSON encoding of obj to w. Unlike MarshalJSONBinary it
+// does not build the whole document in memory first.
+func (obj *Kind) EncodeJSON(w io.Writer) error {
+	jw := marshal.NewWriter(w)
+	obj.WriteJSON(jw)
+	return jw.Flush()
+}
+
+// DecodeJSON reads the next JSON value from r and stores it in obj. Objects
+// and arrays are consumed token by token instead of being read into memory
+// first. Like a json.Decoder, it may read data from r beyond the JSON value.
+func (obj *Kind) DecodeJSON(r io.Reader) error {
+	return obj.ReadJSON(marshal.NewDecoder(r))
+}
+
+// WriteJSON writes the JSON encoding of obj to jw. It produces the same output
+// as encoding/json and is used by EncodeJSON of the types containing this one.
+func (obj *Kind) WriteJSON(jw *marshal.Writer) {
+	if obj == nil {
+		jw.Null()
+		return
+	}
+	jw.Text(*obj)
+}
+
+// ReadJSON decodes the next value of dec into obj. It is used by DecodeJSON
+// of the types containing this one.
+func (obj *Kind) ReadJSON(dec *marshal.Decoder) error {
+	return dec.Decode(obj)
+}
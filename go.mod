@@ -3,10 +3,14 @@ module github.com/zhaolion/gengo
 go 1.12
 
 require (
+	github.com/BurntSushi/toml v0.3.1
 	github.com/davecgh/go-spew v1.1.1
 	github.com/huandu/xstrings v1.2.0
 	github.com/spf13/pflag v1.0.3
 	golang.org/x/tools v0.0.0-20190807223507-b346f7fd45de // indirect
+	google.golang.org/protobuf v1.28.1
+	gopkg.in/yaml.v2 v2.2.8
+	gopkg.in/yaml.v3 v3.0.1
 	k8s.io/gengo v0.0.0-20190327210449-e17681d19d3a
 	k8s.io/klog v0.4.0
 )
@@ -0,0 +1,360 @@
+package marshal
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// BinaryVersion is the version of the binary format written by BinaryWriter.
+// It is written first by the generated MarshalBinary methods, and readers
+// reject other versions.
+//
+// Schema changes do not change the version: fields are identified by their
+// number, so readers skip the fields they do not know and leave the fields
+// missing from the data to their zero value.
+const BinaryVersion = 1
+
+// BinaryWireType is the encoding of a field value, written along with the
+// field number so that readers can skip unknown fields.
+type BinaryWireType uint8
+
+// Binary wire types.
+const (
+	// BinaryVarint is a varint: integers, booleans and enums.
+	BinaryVarint BinaryWireType = 0
+	// BinaryFixed32 is 4 little-endian bytes: float32.
+	BinaryFixed32 BinaryWireType = 1
+	// BinaryFixed64 is 8 little-endian bytes: float64.
+	BinaryFixed64 BinaryWireType = 2
+	// BinaryBytes is a varint length followed by that many bytes: strings,
+	// byte slices, structs and containers.
+	BinaryBytes BinaryWireType = 3
+)
+
+// binaryWireTypeBits is the number of bits of a field tag holding the wire
+// type.
+const binaryWireTypeBits = 2
+
+// BinaryWriter appends values in the binary format to a buffer. It is used by
+// the generated WriteBinary methods.
+//
+// Errors are sticky: once an error occurred Err returns it. Only Marshaler can
+// fail.
+type BinaryWriter struct {
+	buf []byte
+	err error
+}
+
+// NewBinaryWriter returns an empty BinaryWriter.
+func NewBinaryWriter() *BinaryWriter {
+	return &BinaryWriter{buf: make([]byte, 0, 64)}
+}
+
+// Bytes returns the data written so far.
+func (w *BinaryWriter) Bytes() []byte {
+	return w.buf
+}
+
+// Err returns the first error that occurred while writing, if any.
+func (w *BinaryWriter) Err() error {
+	return w.err
+}
+
+// SetError records err unless an error has been recorded already.
+func (w *BinaryWriter) SetError(err error) {
+	if w.err == nil {
+		w.err = err
+	}
+}
+
+// Header writes the version of the format.
+func (w *BinaryWriter) Header() {
+	w.Uvarint(BinaryVersion)
+}
+
+// Tag writes the number and wire type of the field written next.
+func (w *BinaryWriter) Tag(num uint64, typ BinaryWireType) {
+	w.Uvarint(num<<binaryWireTypeBits | uint64(typ))
+}
+
+// Uvarint writes v as a varint.
+func (w *BinaryWriter) Uvarint(v uint64) {
+	for v >= 0x80 {
+		w.buf = append(w.buf, byte(v)|0x80)
+		v >>= 7
+	}
+	w.buf = append(w.buf, byte(v))
+}
+
+// Varint writes v as a zig-zag encoded varint, so that small negative numbers
+// are short too.
+func (w *BinaryWriter) Varint(v int64) {
+	w.Uvarint(uint64(v<<1) ^ uint64(v>>63))
+}
+
+// Bool writes v as a varint.
+func (w *BinaryWriter) Bool(v bool) {
+	if v {
+		w.buf = append(w.buf, 1)
+	} else {
+		w.buf = append(w.buf, 0)
+	}
+}
+
+// Float32 writes the bits of v as a fixed 32 bits value.
+func (w *BinaryWriter) Float32(v float32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], math.Float32bits(v))
+	w.buf = append(w.buf, b[:]...)
+}
+
+// Float64 writes the bits of v as a fixed 64 bits value.
+func (w *BinaryWriter) Float64(v float64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], math.Float64bits(v))
+	w.buf = append(w.buf, b[:]...)
+}
+
+// String writes the length of s, then s.
+func (w *BinaryWriter) String(s string) {
+	w.Uvarint(uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+// ByteSlice writes the length of b, then b.
+func (w *BinaryWriter) ByteSlice(b []byte) {
+	w.Uvarint(uint64(len(b)))
+	w.buf = append(w.buf, b...)
+}
+
+// Count writes the length n of a slice or map, or that it is nil. It is read
+// by BinaryReader.Count.
+func (w *BinaryWriter) Count(n int, isNil bool) {
+	if isNil {
+		w.Uvarint(0)
+		return
+	}
+	w.Uvarint(uint64(n) + 1)
+}
+
+// Marshaler writes the length of the encoding of m, then the encoding.
+func (w *BinaryWriter) Marshaler(m encoding.BinaryMarshaler) {
+	b, err := m.MarshalBinary()
+	if err != nil {
+		w.SetError(err)
+	}
+	w.ByteSlice(b)
+}
+
+// BinaryStruct is implemented by the structs with generated binary methods.
+type BinaryStruct interface {
+	WriteBinary(w *BinaryWriter)
+	ReadBinary(r *BinaryReader)
+}
+
+// Struct writes the length of the fields of s, then the fields.
+func (w *BinaryWriter) Struct(s BinaryStruct) {
+	mark := w.Begin()
+	s.WriteBinary(w)
+	w.End(mark)
+}
+
+// Begin starts a length-prefixed value, ended by End with the returned mark.
+func (w *BinaryWriter) Begin() int {
+	return len(w.buf)
+}
+
+// End prefixes the value written since the call to Begin returning mark with
+// its length.
+func (w *BinaryWriter) End(mark int) {
+	n := len(w.buf) - mark
+	var prefix [binary.MaxVarintLen64]byte
+	size := binary.PutUvarint(prefix[:], uint64(n))
+	w.buf = append(w.buf, prefix[:size]...)
+	copy(w.buf[mark+size:], w.buf[mark:mark+n])
+	copy(w.buf[mark:], prefix[:size])
+}
+
+// ErrBinaryTruncated is the error of BinaryReader when the data ends in the
+// middle of a value.
+var ErrBinaryTruncated = errors.New("marshal: truncated binary data")
+
+// BinaryReader reads values in the binary format. It is used by the generated
+// ReadBinary methods.
+//
+// Errors are sticky and shared with the readers returned by Sub: once an error
+// occurred every read returns the zero value and Err returns the error.
+type BinaryReader struct {
+	data []byte
+	err  *error
+}
+
+// NewBinaryReader returns a BinaryReader reading data.
+func NewBinaryReader(data []byte) *BinaryReader {
+	return &BinaryReader{data: data, err: new(error)}
+}
+
+// Err returns the first error that occurred while reading, if any.
+func (r *BinaryReader) Err() error {
+	return *r.err
+}
+
+// SetError records err unless an error has been recorded already.
+func (r *BinaryReader) SetError(err error) {
+	if *r.err == nil {
+		*r.err = err
+	}
+	r.data = nil
+}
+
+// More returns true if there is data left to read.
+func (r *BinaryReader) More() bool {
+	return *r.err == nil && len(r.data) > 0
+}
+
+// Header reads the version of the format written by BinaryWriter.Header.
+func (r *BinaryReader) Header() {
+	if v := r.Uvarint(); r.Err() == nil && v != BinaryVersion {
+		r.SetError(fmt.Errorf("marshal: unsupported binary format version %d", v))
+	}
+}
+
+// Tag reads the number and wire type of the next field.
+func (r *BinaryReader) Tag() (num uint64, typ BinaryWireType) {
+	v := r.Uvarint()
+	return v >> binaryWireTypeBits, BinaryWireType(v & (1<<binaryWireTypeBits - 1))
+}
+
+// Skip reads a value of wire type typ and drops it.
+func (r *BinaryReader) Skip(typ BinaryWireType) {
+	switch typ {
+	case BinaryVarint:
+		r.Uvarint()
+	case BinaryFixed32:
+		r.next(4)
+	case BinaryFixed64:
+		r.next(8)
+	case BinaryBytes:
+		r.next(r.length())
+	}
+}
+
+// next returns the next n bytes.
+func (r *BinaryReader) next(n int) []byte {
+	if n > len(r.data) {
+		r.SetError(ErrBinaryTruncated)
+		return nil
+	}
+	b := r.data[:n:n]
+	r.data = r.data[n:]
+	return b
+}
+
+// length reads the length of a value, which must fit in the data left.
+func (r *BinaryReader) length() int {
+	n := r.Uvarint()
+	if n > uint64(len(r.data)) {
+		r.SetError(ErrBinaryTruncated)
+		return 0
+	}
+	return int(n)
+}
+
+// Uvarint reads a varint.
+func (r *BinaryReader) Uvarint() uint64 {
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		if n < 0 {
+			r.SetError(errors.New("marshal: binary varint overflows 64 bits"))
+		} else {
+			r.SetError(ErrBinaryTruncated)
+		}
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+// Varint reads a zig-zag encoded varint.
+func (r *BinaryReader) Varint() int64 {
+	v := r.Uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+// Bool reads a varint as a boolean.
+func (r *BinaryReader) Bool() bool {
+	return r.Uvarint() != 0
+}
+
+// Float32 reads a fixed 32 bits value as the bits of a float32.
+func (r *BinaryReader) Float32() float32 {
+	b := r.next(4)
+	if b == nil {
+		return 0
+	}
+	return math.Float32frombits(binary.LittleEndian.Uint32(b))
+}
+
+// Float64 reads a fixed 64 bits value as the bits of a float64.
+func (r *BinaryReader) Float64() float64 {
+	b := r.next(8)
+	if b == nil {
+		return 0
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(b))
+}
+
+// String reads a length-prefixed string.
+func (r *BinaryReader) String() string {
+	return string(r.next(r.length()))
+}
+
+// ByteSlice reads a length-prefixed byte slice. The returned slice is a copy,
+// never nil unless an error occurred.
+func (r *BinaryReader) ByteSlice() []byte {
+	b := r.next(r.length())
+	if r.Err() != nil {
+		return nil
+	}
+	return append([]byte{}, b...)
+}
+
+// Count reads the length of a slice or map written by BinaryWriter.Count. ok
+// is false if it is nil. As every element takes at least a byte, lengths
+// larger than the data left are rejected.
+func (r *BinaryReader) Count() (n int, ok bool) {
+	v := r.Uvarint()
+	if v == 0 {
+		return 0, false
+	}
+	if v-1 > uint64(len(r.data)) {
+		r.SetError(ErrBinaryTruncated)
+		return 0, false
+	}
+	return int(v - 1), true
+}
+
+// Unmarshaler reads a length-prefixed value into u.
+func (r *BinaryReader) Unmarshaler(u encoding.BinaryUnmarshaler) {
+	b := r.next(r.length())
+	if r.Err() != nil {
+		return
+	}
+	if err := u.UnmarshalBinary(b); err != nil {
+		r.SetError(err)
+	}
+}
+
+// Struct reads the length-prefixed fields of s.
+func (r *BinaryReader) Struct(s BinaryStruct) {
+	s.ReadBinary(r.Sub())
+}
+
+// Sub reads a length-prefixed value and returns a reader of its data, sharing
+// the errors of r.
+func (r *BinaryReader) Sub() *BinaryReader {
+	return &BinaryReader{data: r.next(r.length()), err: r.err}
+}
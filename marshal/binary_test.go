@@ -0,0 +1,130 @@
+package marshal
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	w := NewBinaryWriter()
+	w.Header()
+	w.Tag(1, BinaryVarint)
+	w.Varint(math.MinInt64)
+	w.Varint(-1)
+	w.Uvarint(math.MaxUint64)
+	w.Bool(true)
+	w.Float32(-1.5)
+	w.Float64(math.Inf(1))
+	w.String("é")
+	w.ByteSlice(nil)
+	w.Count(0, true)
+	w.Count(2, false)
+	mark := w.Begin()
+	w.String(string(make([]byte, 200)))
+	w.End(mark)
+
+	r := NewBinaryReader(w.Bytes())
+	r.Header()
+	if num, typ := r.Tag(); num != 1 || typ != BinaryVarint {
+		t.Errorf("unexpected tag %d, %d", num, typ)
+	}
+	if v := r.Varint(); v != math.MinInt64 {
+		t.Errorf("unexpected varint %d", v)
+	}
+	if v := r.Varint(); v != -1 {
+		t.Errorf("unexpected varint %d", v)
+	}
+	if v := r.Uvarint(); v != math.MaxUint64 {
+		t.Errorf("unexpected uvarint %d", v)
+	}
+	if v := r.Bool(); !v {
+		t.Errorf("unexpected bool %v", v)
+	}
+	if v := r.Float32(); v != -1.5 {
+		t.Errorf("unexpected float32 %v", v)
+	}
+	if v := r.Float64(); !math.IsInf(v, 1) {
+		t.Errorf("unexpected float64 %v", v)
+	}
+	if v := r.String(); v != "é" {
+		t.Errorf("unexpected string %q", v)
+	}
+	if v := r.ByteSlice(); v == nil || len(v) != 0 {
+		t.Errorf("unexpected byte slice %#v", v)
+	}
+	if _, ok := r.Count(); ok {
+		t.Error("expected a nil count")
+	}
+	// The count is larger than the data left.
+	if n, ok := r.Count(); !ok || n != 2 {
+		t.Errorf("unexpected count %d, %v", n, ok)
+	}
+	sub := r.Sub()
+	if v := sub.String(); len(v) != 200 {
+		t.Errorf("unexpected length %d", len(v))
+	}
+	if r.More() || sub.More() {
+		t.Error("expected no data left")
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBinaryReaderSkip(t *testing.T) {
+	w := NewBinaryWriter()
+	w.Tag(1, BinaryVarint)
+	w.Uvarint(300)
+	w.Tag(2, BinaryFixed32)
+	w.Float32(1)
+	w.Tag(3, BinaryFixed64)
+	w.Float64(1)
+	w.Tag(4, BinaryBytes)
+	w.String("skipped")
+	w.Tag(5, BinaryVarint)
+	w.Bool(true)
+
+	r := NewBinaryReader(w.Bytes())
+	for r.More() {
+		num, typ := r.Tag()
+		if num == 5 {
+			if !r.Bool() {
+				t.Error("expected true")
+			}
+			continue
+		}
+		r.Skip(typ)
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBinaryReaderErrors(t *testing.T) {
+	testCases := []struct {
+		data []byte
+		read func(r *BinaryReader)
+	}{
+		{[]byte{}, func(r *BinaryReader) { r.Header() }},
+		{[]byte{2}, func(r *BinaryReader) { r.Header() }},
+		{[]byte{0x80}, func(r *BinaryReader) { r.Uvarint() }},
+		{bytes.Repeat([]byte{0xff}, 11), func(r *BinaryReader) { r.Uvarint() }},
+		{[]byte{0, 0, 0}, func(r *BinaryReader) { r.Float32() }},
+		{[]byte{2, 'a'}, func(r *BinaryReader) { _ = r.String() }},
+		{[]byte{3, 0}, func(r *BinaryReader) { r.Count() }},
+		{[]byte{5, 1}, func(r *BinaryReader) { r.Skip(BinaryBytes) }},
+		// Errors of sub-readers are shared.
+		{[]byte{1, 0x80}, func(r *BinaryReader) { r.Sub().Uvarint() }},
+	}
+	for i, tc := range testCases {
+		r := NewBinaryReader(tc.data)
+		tc.read(r)
+		if r.Err() == nil {
+			t.Errorf("case[%d]: expected an error reading %x", i, tc.data)
+		}
+		if r.More() {
+			t.Errorf("case[%d]: expected no more data after an error", i)
+		}
+	}
+}
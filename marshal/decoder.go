@@ -0,0 +1,141 @@
+package marshal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Decoder reads a JSON document token by token. It is used by the generated
+// ReadJSON methods, which consume objects and arrays incrementally instead of
+// buffering the whole document.
+type Decoder struct {
+	dec       *json.Decoder
+	peeked    json.Token
+	hasPeeked bool
+}
+
+// NewDecoder returns a Decoder reading from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{dec: json.NewDecoder(r)}
+}
+
+func (d *Decoder) token() (json.Token, error) {
+	if d.hasPeeked {
+		tok := d.peeked
+		d.peeked, d.hasPeeked = nil, false
+		return tok, nil
+	}
+	return d.dec.Token()
+}
+
+func (d *Decoder) peek() (json.Token, error) {
+	if !d.hasPeeked {
+		tok, err := d.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		d.peeked, d.hasPeeked = tok, true
+	}
+	return d.peeked, nil
+}
+
+// Null consumes the next value and returns true if it is null. Otherwise the
+// value is left in place and false is returned.
+func (d *Decoder) Null() (bool, error) {
+	tok, err := d.peek()
+	if err != nil {
+		return false, err
+	}
+	if tok != nil {
+		return false, nil
+	}
+	d.peeked, d.hasPeeked = nil, false
+	return true, nil
+}
+
+// Begin consumes the start of an object ('{') or an array ('['). It returns
+// false if the value is null instead.
+func (d *Decoder) Begin(delim json.Delim) (bool, error) {
+	tok, err := d.token()
+	if err != nil {
+		return false, err
+	}
+	if tok == nil {
+		return false, nil
+	}
+	if tok != delim {
+		return false, d.unexpected(tok, delim)
+	}
+	return true, nil
+}
+
+// End consumes the end of an object ('}') or an array (']').
+func (d *Decoder) End(delim json.Delim) error {
+	tok, err := d.token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return d.unexpected(tok, delim)
+	}
+	return nil
+}
+
+// More reports whether there is another element in the current object or
+// array.
+func (d *Decoder) More() bool {
+	if d.hasPeeked {
+		delim, ok := d.peeked.(json.Delim)
+		return !ok || (delim != '}' && delim != ']')
+	}
+	return d.dec.More()
+}
+
+// Key reads the next object key.
+func (d *Decoder) Key() (string, error) {
+	tok, err := d.token()
+	if err != nil {
+		return "", err
+	}
+	key, ok := tok.(string)
+	if !ok {
+		return "", d.unexpected(tok, "object key")
+	}
+	return key, nil
+}
+
+// Decode decodes the next value into v using encoding/json.
+func (d *Decoder) Decode(v interface{}) error {
+	if d.hasPeeked {
+		return errors.New("json: Decode called after a token has been peeked")
+	}
+	return d.dec.Decode(v)
+}
+
+// Skip consumes the next value and discards it.
+func (d *Decoder) Skip() error {
+	var discard json.RawMessage
+	return d.Decode(&discard)
+}
+
+func (d *Decoder) unexpected(tok json.Token, expected interface{}) error {
+	return fmt.Errorf("json: expected %v at offset %d, got %v", expected, d.dec.InputOffset(), describe(tok))
+}
+
+func describe(tok json.Token) string {
+	switch v := tok.(type) {
+	case nil:
+		return "null"
+	case json.Delim:
+		return v.String()
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	}
+	return fmt.Sprintf("%T", tok)
+}
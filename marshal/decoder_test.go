@@ -0,0 +1,68 @@
+package marshal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecoder(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(`{"a": null, "b": [1, 2], "c": {"d": "e"}}`))
+	if ok, err := dec.Begin('{'); !ok || err != nil {
+		t.Fatalf("expected an object, got %v, %v", ok, err)
+	}
+
+	var keys []string
+	for dec.More() {
+		key, err := dec.Key()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, key)
+		switch key {
+		case "a":
+			if null, err := dec.Null(); !null || err != nil {
+				t.Fatalf("expected null, got %v, %v", null, err)
+			}
+		case "b":
+			if null, err := dec.Null(); null || err != nil {
+				t.Fatalf("expected no null, got %v, %v", null, err)
+			}
+			if ok, err := dec.Begin('['); !ok || err != nil {
+				t.Fatalf("expected an array, got %v, %v", ok, err)
+			}
+			var sum int
+			for dec.More() {
+				var i int
+				if err := dec.Decode(&i); err != nil {
+					t.Fatal(err)
+				}
+				sum += i
+			}
+			if err := dec.End(']'); err != nil {
+				t.Fatal(err)
+			}
+			if sum != 3 {
+				t.Errorf("expected sum 3, got %d", sum)
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := dec.End('}'); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(keys, ",") != "a,b,c" {
+		t.Errorf("unexpected keys %v", keys)
+	}
+}
+
+func TestDecoderUnexpectedToken(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(`[1]`))
+	if _, err := dec.Begin('{'); err == nil {
+		t.Fatal("expected an error")
+	} else if !strings.Contains(err.Error(), "expected {") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,5 @@
+// Package marshal holds the runtime support used by the code marshal-gen
+// generates.
+//
+// Generated code imports this package; it is not meant to be used directly.
+package marshal
@@ -0,0 +1,40 @@
+package marshal
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// FoldName returns a folded form of the object key name, such that
+// FoldName(x) == FoldName(y) if encoding/json considers the keys x and y to
+// match case-insensitively. The generated decoders fall back to it for keys
+// not matching a field name exactly, like encoding/json does.
+func FoldName(name string) string {
+	out := make([]byte, 0, len(name))
+	for i := 0; i < len(name); {
+		if c := name[i]; c < utf8.RuneSelf {
+			if 'a' <= c && c <= 'z' {
+				c -= 'a' - 'A'
+			}
+			out = append(out, c)
+			i++
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(name[i:])
+		var buf [utf8.UTFMax]byte
+		out = append(out, buf[:utf8.EncodeRune(buf[:], foldRune(r))]...)
+		i += n
+	}
+	return string(out)
+}
+
+// foldRune returns the smallest rune for all runes in the same fold set.
+func foldRune(r rune) rune {
+	for {
+		r2 := unicode.SimpleFold(r)
+		if r2 <= r {
+			return r2
+		}
+		r = r2
+	}
+}
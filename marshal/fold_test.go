@@ -0,0 +1,18 @@
+package marshal
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFoldName(t *testing.T) {
+	names := []string{"name", "NAME", "Name", "n_a-m.e", "ΣIGMA", "σigma", "ςigma", "K", "K", "ſ", "s", "日本"}
+	for _, x := range names {
+		for _, y := range names {
+			expect := bytes.EqualFold([]byte(x), []byte(y))
+			if got := FoldName(x) == FoldName(y); got != expect {
+				t.Errorf("FoldName(%q) == FoldName(%q) is %v, expected %v", x, y, got, expect)
+			}
+		}
+	}
+}
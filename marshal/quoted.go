@@ -0,0 +1,45 @@
+package marshal
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+)
+
+// quotedTypes caches the wrapper struct types used by UnmarshalQuoted, keyed
+// by the type of the value decoded.
+var quotedTypes sync.Map
+
+// UnmarshalQuoted decodes data into v, which must be a pointer, the way
+// encoding/json decodes a struct field tagged with the ",string" option: data
+// is a JSON string holding the JSON encoding of the value, or null.
+func UnmarshalQuoted(data []byte, v interface{}) error {
+	ptr := reflect.ValueOf(v)
+	typ := ptr.Type().Elem()
+
+	wrapper, ok := quotedTypes.Load(typ)
+	if !ok {
+		wrapper, _ = quotedTypes.LoadOrStore(typ, reflect.StructOf([]reflect.StructField{
+			{Name: "V", Type: typ, Tag: `json:"v,string"`},
+		}))
+	}
+
+	// Decode into a copy of the current value, so that it is updated in place
+	// like it would be as a struct field.
+	w := reflect.New(wrapper.(reflect.Type))
+	w.Elem().Field(0).Set(ptr.Elem())
+	doc := make([]byte, 0, len(data)+6)
+	doc = append(append(append(doc, `{"v":`...), data...), '}')
+	err := json.Unmarshal(doc, w.Interface())
+	ptr.Elem().Set(w.Elem().Field(0))
+	return err
+}
+
+// DecodeQuoted decodes the next value into v like UnmarshalQuoted.
+func (d *Decoder) DecodeQuoted(v interface{}) error {
+	var raw json.RawMessage
+	if err := d.Decode(&raw); err != nil {
+		return err
+	}
+	return UnmarshalQuoted(raw, v)
+}
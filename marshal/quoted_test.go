@@ -0,0 +1,48 @@
+package marshal
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestUnmarshalQuoted(t *testing.T) {
+	type quoted struct {
+		Int    int     `json:",string"`
+		Bool   bool    `json:",string"`
+		String string  `json:",string"`
+		Ptr    *string `json:",string"`
+	}
+
+	testCases := []string{
+		`"12"`,
+		`"true"`,
+		`"\"s\""`,
+		`null`,
+		`12`,
+		`"x"`,
+	}
+
+	for i, data := range testCases {
+		expect := quoted{Int: 1, Bool: true, String: "old"}
+		got := expect
+		for _, field := range []struct {
+			name string
+			ptr  interface{}
+		}{
+			{"Int", &got.Int},
+			{"Bool", &got.Bool},
+			{"String", &got.String},
+			{"Ptr", &got.Ptr},
+		} {
+			expectErr := json.Unmarshal([]byte(`{"`+field.name+`":`+data+`}`), &expect)
+			err := UnmarshalQuoted([]byte(data), field.ptr)
+			if (err != nil) != (expectErr != nil) {
+				t.Errorf("case[%d] %s: expected error %v, got %v", i, field.name, expectErr, err)
+			}
+		}
+		if !reflect.DeepEqual(got, expect) {
+			t.Errorf("case[%d]: expected %+v, got %+v", i, expect, got)
+		}
+	}
+}
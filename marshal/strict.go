@@ -0,0 +1,107 @@
+package marshal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Root is the JSON path of the document being decoded.
+const Root = "$"
+
+var (
+	// ErrUnknownField is reported for an object key that does not match any
+	// field of the target struct.
+	ErrUnknownField = errors.New("unknown field")
+	// ErrMissingField is reported for a field tagged `marshal:"required"`
+	// which is absent from the object.
+	ErrMissingField = errors.New("missing required field")
+)
+
+// Problem is a single problem found while strictly decoding a JSON document.
+type Problem struct {
+	// Path is the JSON path of the offending value, e.g. $.items[2].name.
+	Path string
+	// Err describes the problem. It is ErrUnknownField, ErrMissingField or
+	// the error returned by encoding/json for the value.
+	Err error
+}
+
+func (p Problem) String() string {
+	return p.Path + ": " + p.Err.Error()
+}
+
+// StrictError is returned by the generated UnmarshalJSONBinaryStrict methods.
+// It lists every problem found in the document, not just the first one.
+type StrictError struct {
+	Problems []Problem
+}
+
+// Add records a problem found at path.
+func (e *StrictError) Add(path string, err error) {
+	e.Problems = append(e.Problems, Problem{Path: path, Err: err})
+}
+
+// Err returns e if at least one problem has been recorded, nil otherwise.
+func (e *StrictError) Err() error {
+	if len(e.Problems) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *StrictError) Error() string {
+	problems := make([]string, 0, len(e.Problems))
+	for _, p := range e.Problems {
+		problems = append(problems, p.String())
+	}
+	if len(problems) == 1 {
+		return "json: " + problems[0]
+	}
+	return fmt.Sprintf("json: %d problems: %s", len(problems), strings.Join(problems, "; "))
+}
+
+// Key returns the path of the member key of the object at path.
+func Key(path, key string) string {
+	if isIdentifier(key) {
+		return path + "." + key
+	}
+	return path + "[" + strconv.Quote(key) + "]"
+}
+
+// Index returns the path of the i-th element of the array at path.
+func Index(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+// SortedKeys returns the keys of an object in sorted order, so problems are
+// always reported in the same order.
+func SortedKeys(object map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(object))
+	for k := range object {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// IsNull returns true if data is the JSON literal null.
+func IsNull(data json.RawMessage) bool {
+	return strings.TrimSpace(string(data)) == "null"
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
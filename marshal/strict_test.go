@@ -0,0 +1,43 @@
+package marshal
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	testCases := []struct {
+		path, key string
+		expect    string
+	}{
+		{path: Root, key: "name", expect: "$.name"},
+		{path: "$.items[0]", key: "by_key", expect: "$.items[0].by_key"},
+		{path: Root, key: "a.b", expect: `$["a.b"]`},
+		{path: Root, key: "0abc", expect: `$["0abc"]`},
+		{path: Root, key: "", expect: `$[""]`},
+	}
+
+	for i, tc := range testCases {
+		if r := Key(tc.path, tc.key); r != tc.expect {
+			t.Errorf("case[%d]: expected %q, got %q", i, tc.expect, r)
+		}
+	}
+}
+
+func TestStrictError(t *testing.T) {
+	errs := &StrictError{}
+	if errs.Err() != nil {
+		t.Fatalf("expected no error without problems, got %v", errs.Err())
+	}
+
+	errs.Add(Key(Root, "name"), ErrMissingField)
+	if r := errs.Err().Error(); r != "json: $.name: missing required field" {
+		t.Errorf("unexpected error message %q", r)
+	}
+
+	errs.Add(Index(Key(Root, "items"), 1), errors.New("bad value"))
+	expect := "json: 2 problems: $.name: missing required field; $.items[1]: bad value"
+	if r := errs.Err().Error(); r != expect {
+		t.Errorf("expected %q, got %q", expect, r)
+	}
+}
@@ -0,0 +1,350 @@
+package marshal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// TOMLTableWriter is implemented by the types with generated TOML methods,
+// which add their fields to a table in declaration order.
+type TOMLTableWriter interface {
+	WriteTOML(t *TOMLTable) error
+}
+
+// TOMLTable is a TOML table whose keys keep the order they are added in,
+// along with their comments. It is used by the generated MarshalTOML methods.
+type TOMLTable struct {
+	entries []tomlEntry
+}
+
+type tomlEntry struct {
+	key     string
+	comment string
+	// value is a tomlLiteral, a *TOMLTable or a tomlArray.
+	value interface{}
+}
+
+// tomlLiteral is a value written in TOML syntax.
+type tomlLiteral string
+
+type tomlArray []interface{}
+
+// NewTOMLTable returns an empty TOMLTable.
+func NewTOMLTable() *TOMLTable {
+	return &TOMLTable{}
+}
+
+// Add adds the key with the TOML value of v, and the comment written above
+// the key. TOML has no null: nil values are left out. Values implementing
+// TOMLTableWriter become tables of ordered keys, time.Time values datetimes,
+// and the other values are written like their JSON encoding, so that the JSON
+// tags of structs apply.
+func (t *TOMLTable) Add(key, comment string, v interface{}) error {
+	value, err := tomlValue(v)
+	if err != nil {
+		return fmt.Errorf("marshal: TOML key %q: %v", key, err)
+	}
+	if value != nil {
+		t.entries = append(t.entries, tomlEntry{key: key, comment: comment, value: value})
+	}
+	return nil
+}
+
+// AddQuoted is like Add for a field with the ",string" option: the JSON
+// encoding of v is written as a string.
+func (t *TOMLTable) AddQuoted(key, comment string, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() == reflect.Ptr {
+		return nil
+	}
+	data, err := json.Marshal(rv.Interface())
+	if err != nil {
+		return err
+	}
+	t.entries = append(t.entries, tomlEntry{key: key, comment: comment, value: tomlString(string(data))})
+	return nil
+}
+
+// MarshalTOML returns the TOML document of the table. The keys holding
+// tables and arrays of tables are written after the other keys, as TOML
+// requires.
+func (t *TOMLTable) MarshalTOML() ([]byte, error) {
+	var b bytes.Buffer
+	t.write(&b, "")
+	return b.Bytes(), nil
+}
+
+// write writes the table, whose header has been written if path is not
+// empty.
+func (t *TOMLTable) write(b *bytes.Buffer, path string) {
+	for _, e := range t.entries {
+		if isTOMLSection(e.value) {
+			continue
+		}
+		writeTOMLComment(b, e.comment)
+		fmt.Fprintf(b, "%s = %s\n", tomlKey(e.key), tomlInline(e.value))
+	}
+	for _, e := range t.entries {
+		if !isTOMLSection(e.value) {
+			continue
+		}
+		name := tomlKey(e.key)
+		if path != "" {
+			name = path + "." + name
+		}
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		writeTOMLComment(b, e.comment)
+		switch value := e.value.(type) {
+		case *TOMLTable:
+			fmt.Fprintf(b, "[%s]\n", name)
+			value.write(b, name)
+		case tomlArray:
+			for i, elem := range value {
+				if i > 0 {
+					b.WriteByte('\n')
+				}
+				fmt.Fprintf(b, "[[%s]]\n", name)
+				elem.(*TOMLTable).write(b, name)
+			}
+		}
+	}
+}
+
+// isTOMLSection returns true if value is written as a section of the
+// document: a table, or a non-empty array of tables.
+func isTOMLSection(value interface{}) bool {
+	switch value := value.(type) {
+	case *TOMLTable:
+		return true
+	case tomlArray:
+		for _, elem := range value {
+			if _, ok := elem.(*TOMLTable); !ok {
+				return false
+			}
+		}
+		return len(value) > 0
+	}
+	return false
+}
+
+// tomlInline returns value in the inline syntax.
+func tomlInline(value interface{}) string {
+	switch value := value.(type) {
+	case tomlLiteral:
+		return string(value)
+	case *TOMLTable:
+		var entries []string
+		for _, e := range value.entries {
+			entries = append(entries, tomlKey(e.key)+" = "+tomlInline(e.value))
+		}
+		if len(entries) == 0 {
+			return "{}"
+		}
+		return "{ " + strings.Join(entries, ", ") + " }"
+	case tomlArray:
+		var elems []string
+		for _, elem := range value {
+			elems = append(elems, tomlInline(elem))
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	}
+	return ""
+}
+
+func writeTOMLComment(b *bytes.Buffer, comment string) {
+	if comment == "" {
+		return
+	}
+	for _, line := range strings.Split(comment, "\n") {
+		if line == "" {
+			b.WriteString("#\n")
+		} else {
+			b.WriteString("# " + line + "\n")
+		}
+	}
+}
+
+// errTOMLNull is the error of the nil values TOML cannot write, in arrays.
+var errTOMLNull = errors.New("cannot write null in a TOML array")
+
+// tomlValue returns the TOML value of v, or nil if v is nil.
+func tomlValue(v interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return nil, nil
+	}
+	if (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) && rv.IsNil() {
+		return nil, nil
+	}
+	switch v := v.(type) {
+	case TOMLTableWriter:
+		t := NewTOMLTable()
+		if err := v.WriteTOML(t); err != nil {
+			return nil, err
+		}
+		return t, nil
+	case time.Time:
+		return tomlLiteral(v.Format(time.RFC3339Nano)), nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return tomlValue(rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		if isJSONLeaf(rv) {
+			break
+		}
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil, nil
+		}
+		array := tomlArray{}
+		for i := 0; i < rv.Len(); i++ {
+			elem, err := tomlValue(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			if elem == nil {
+				return nil, errTOMLNull
+			}
+			array = append(array, elem)
+		}
+		return array, nil
+	case reflect.Map:
+		if isJSONLeaf(rv) {
+			break
+		}
+		if rv.IsNil() {
+			return nil, nil
+		}
+		keys, err := mapKeys(rv)
+		if err != nil {
+			return nil, err
+		}
+		t := NewTOMLTable()
+		for _, k := range keys {
+			if err := t.Add(k.name, "", rv.MapIndex(k.value).Interface()); err != nil {
+				return nil, err
+			}
+		}
+		return t, nil
+	}
+
+	generic, err := jsonGeneric(v)
+	if err != nil {
+		return nil, err
+	}
+	return tomlGeneric(generic)
+}
+
+// tomlGeneric returns the TOML value of a value decoded by jsonGeneric.
+func tomlGeneric(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		t := NewTOMLTable()
+		for _, k := range sortedKeys(v) {
+			value, err := tomlGeneric(v[k])
+			if err != nil {
+				return nil, err
+			}
+			if value != nil {
+				t.entries = append(t.entries, tomlEntry{key: k, value: value})
+			}
+		}
+		return t, nil
+	case []interface{}:
+		array := tomlArray{}
+		for _, e := range v {
+			elem, err := tomlGeneric(e)
+			if err != nil {
+				return nil, err
+			}
+			if elem == nil {
+				return nil, errTOMLNull
+			}
+			array = append(array, elem)
+		}
+		return array, nil
+	case string:
+		return tomlString(v), nil
+	case bool:
+		return tomlLiteral(strconv.FormatBool(v)), nil
+	case int64:
+		return tomlLiteral(strconv.FormatInt(v, 10)), nil
+	case uint64:
+		return tomlLiteral(strconv.FormatUint(v, 10)), nil
+	case float64:
+		s := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".eE") {
+			s += ".0"
+		}
+		return tomlLiteral(s), nil
+	}
+	return nil, nil
+}
+
+// bareTOMLKey matches the keys which need no quotes.
+var bareTOMLKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func tomlKey(key string) string {
+	if bareTOMLKey.MatchString(key) {
+		return key
+	}
+	return string(tomlString(key))
+}
+
+// tomlString returns s as a TOML basic string.
+func tomlString(s string) tomlLiteral {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f || r == utf8.RuneError {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return tomlLiteral(b.String())
+}
+
+// DecodeTOML decodes the value of a TOML document, as decoded by a TOML
+// library into interface{}, into v, which must be a pointer. It is decoded like
+// the JSON document of the same structure, so that the JSON tags of structs
+// apply. It is used by the generated UnmarshalTOML methods.
+func DecodeTOML(data interface{}, v interface{}) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
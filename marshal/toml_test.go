@@ -0,0 +1,92 @@
+package marshal
+
+import (
+	"testing"
+	"time"
+)
+
+type tomlServer struct {
+	Host string `json:"host"`
+}
+
+func TestTOMLTable(t *testing.T) {
+	s := "s"
+	tbl := NewTOMLTable()
+	for _, e := range []struct {
+		key     string
+		comment string
+		value   interface{}
+	}{
+		{"servers", "Servers are\n\ntried in order.", []tomlServer{{"a"}, {"b"}}},
+		{"str", "", "quote \" back\\slash\ttab\x01"},
+		{"odd key", "", &s},
+		{"nil", "", (*string)(nil)},
+		{"nil_slice", "", []int(nil)},
+		{"float", "", 3.0},
+		{"time", "", time.Date(2020, 1, 2, 3, 4, 5, 600, time.UTC)},
+		{"empty", "", []string{}},
+		{"mixed", "", []interface{}{1, "a", map[string]int{"k": 1}}},
+		{"nested", "", map[string]map[int]bool{"x": {2: true, 10: false}}},
+	} {
+		if err := tbl.Add(e.key, e.comment, e.value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tbl.AddQuoted("quoted", "", &s); err != nil {
+		t.Fatal(err)
+	}
+	data, err := tbl.MarshalTOML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Plain keys come first, then tables and arrays of tables.
+	want := `str = "quote \" back\\slash\ttab\u0001"
+"odd key" = "s"
+float = 3
+time = 2020-01-02T03:04:05.0000006Z
+empty = []
+mixed = [1, "a", { k = 1 }]
+quoted = "\"s\""
+
+# Servers are
+#
+# tried in order.
+[[servers]]
+host = "a"
+
+[[servers]]
+host = "b"
+
+[nested]
+
+[nested.x]
+10 = false
+2 = true
+`
+	if string(data) != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, data)
+	}
+}
+
+func TestTOMLTableErrors(t *testing.T) {
+	for _, v := range []interface{}{[]*int{nil}, []interface{}{nil}, map[bool]int{true: 1}} {
+		if err := NewTOMLTable().Add("key", "", v); err == nil {
+			t.Errorf("expected an error adding %#v", v)
+		}
+	}
+}
+
+func TestDecodeTOML(t *testing.T) {
+	data := map[string]interface{}{
+		"servers": []map[string]interface{}{{"HOST": "a"}},
+	}
+	var got struct {
+		Servers []tomlServer `json:"servers"`
+	}
+	if err := DecodeTOML(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Servers) != 1 || got.Servers[0].Host != "a" {
+		t.Errorf("unexpected %+v", got)
+	}
+}
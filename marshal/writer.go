@@ -0,0 +1,263 @@
+package marshal
+
+import (
+	"bufio"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Writer is a buffered writer with helpers writing JSON values exactly the way
+// encoding/json does. It is used by the generated WriteJSON methods.
+//
+// Errors are sticky: once an error occurred every further call is a no-op and
+// Flush returns the error.
+type Writer struct {
+	w       *bufio.Writer
+	scratch []byte
+	err     error
+}
+
+// NewWriter returns a Writer writing to w.
+func NewWriter(w io.Writer) *Writer {
+	bw, ok := w.(*bufio.Writer)
+	if !ok {
+		bw = bufio.NewWriter(w)
+	}
+	return &Writer{w: bw, scratch: make([]byte, 0, 64)}
+}
+
+// Err returns the first error that occurred while writing, if any.
+func (w *Writer) Err() error {
+	return w.err
+}
+
+// SetError records err unless an error has been recorded already.
+func (w *Writer) SetError(err error) {
+	if w.err == nil {
+		w.err = err
+	}
+}
+
+// Flush writes any buffered data to the underlying io.Writer and returns the
+// first error that occurred while writing.
+func (w *Writer) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	w.err = w.w.Flush()
+	return w.err
+}
+
+// Raw writes s unchanged. s must be valid JSON in the current position.
+func (w *Writer) Raw(s string) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = w.w.WriteString(s)
+}
+
+// RawByte writes c unchanged.
+func (w *Writer) RawByte(c byte) {
+	if w.err != nil {
+		return
+	}
+	w.err = w.w.WriteByte(c)
+}
+
+func (w *Writer) rawBytes(b []byte) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = w.w.Write(b)
+}
+
+// Null writes null.
+func (w *Writer) Null() {
+	w.Raw("null")
+}
+
+// Bool writes true or false.
+func (w *Writer) Bool(v bool) {
+	if v {
+		w.Raw("true")
+	} else {
+		w.Raw("false")
+	}
+}
+
+// Int writes a signed integer.
+func (w *Writer) Int(v int64) {
+	w.rawBytes(strconv.AppendInt(w.scratch[:0], v, 10))
+}
+
+// Uint writes an unsigned integer.
+func (w *Writer) Uint(v uint64) {
+	w.rawBytes(strconv.AppendUint(w.scratch[:0], v, 10))
+}
+
+// Float writes a floating point number of the given bit size (32 or 64),
+// formatted like encoding/json does. NaN and infinities are not valid JSON
+// and are recorded as a *json.UnsupportedValueError.
+func (w *Writer) Float(v float64, bits int) {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		w.SetError(&json.UnsupportedValueError{Str: strconv.FormatFloat(v, 'g', -1, bits)})
+		return
+	}
+
+	// Convert as if by ES6 number to string conversion. This matches most
+	// other JSON generators and encoding/json.
+	abs := math.Abs(v)
+	format := byte('f')
+	if abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	b := strconv.AppendFloat(w.scratch[:0], v, format, -1, bits)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	w.rawBytes(b)
+}
+
+const hex = "0123456789abcdef"
+
+// String writes s as a JSON string, escaping it like encoding/json does
+// (including HTML escaping).
+func (w *Writer) String(s string) {
+	b := appendString(w.scratch[:0], s, true)
+	w.rawBytes(b)
+	w.scratch = b[:0]
+}
+
+// QuotedString writes the JSON encoding of s as a JSON string, which is how
+// encoding/json writes string fields tagged with the ",string" option.
+func (w *Writer) QuotedString(s string) {
+	b := appendString(w.scratch[:0], s, true)
+	quoted := appendString(nil, string(b), false)
+	w.rawBytes(quoted)
+	w.scratch = b[:0]
+}
+
+// appendString appends s encoded as a JSON string to b.
+func appendString(b []byte, s string, escapeHTML bool) []byte {
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && (!escapeHTML || c != '<' && c != '>' && c != '&') {
+				i++
+				continue
+			}
+			b = append(b, s[start:i]...)
+			switch c {
+			case '\\', '"':
+				b = append(b, '\\', c)
+			case '\b':
+				b = append(b, '\\', 'b')
+			case '\f':
+				b = append(b, '\\', 'f')
+			case '\n':
+				b = append(b, '\\', 'n')
+			case '\r':
+				b = append(b, '\\', 'r')
+			case '\t':
+				b = append(b, '\\', 't')
+			default:
+				// This encodes bytes < 0x20 except for \b, \f, \n, \r and \t,
+				// and the HTML characters <, > and &.
+				b = append(b, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b = append(b, s[start:i]...)
+			b = append(b, "\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		// U+2028 is LINE SEPARATOR and U+2029 is PARAGRAPH SEPARATOR. They
+		// are valid JSON but not valid JavaScript, so encoding/json escapes
+		// them.
+		if r == '\u2028' || r == '\u2029' {
+			b = append(b, s[start:i]...)
+			b = append(b, '\\', 'u', '2', '0', '2', hex[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	b = append(b, s[start:]...)
+	return append(b, '"')
+}
+
+// Bytes writes v as a base64 encoded JSON string, or null if v is nil.
+func (w *Writer) Bytes(v []byte) {
+	if v == nil {
+		w.Null()
+		return
+	}
+	w.RawByte('"')
+	if w.err != nil {
+		return
+	}
+	enc := base64.NewEncoder(base64.StdEncoding, w.w)
+	if _, err := enc.Write(v); err != nil {
+		w.SetError(err)
+		return
+	}
+	w.SetError(enc.Close())
+	w.RawByte('"')
+}
+
+// Text writes the result of v.MarshalText as a JSON string.
+func (w *Writer) Text(v encoding.TextMarshaler) {
+	text, err := v.MarshalText()
+	if err != nil {
+		// Let encoding/json report the error, naming the type of v.
+		w.Value(v)
+		return
+	}
+	w.String(string(text))
+}
+
+// Value writes v using encoding/json. It is the fallback for the values the
+// generated code cannot write itself, e.g. interfaces or types implementing
+// json.Marshaler.
+func (w *Writer) Value(v interface{}) {
+	if w.err != nil {
+		return
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		w.SetError(err)
+		return
+	}
+	w.rawBytes(b)
+}
+
+// IntKeyLess reports whether the map key a sorts before b. encoding/json
+// sorts integer keys by their string representation.
+func IntKeyLess(a, b int64) bool {
+	return strconv.FormatInt(a, 10) < strconv.FormatInt(b, 10)
+}
+
+// UintKeyLess is like IntKeyLess for unsigned integer keys.
+func UintKeyLess(a, b uint64) bool {
+	return strconv.FormatUint(a, 10) < strconv.FormatUint(b, 10)
+}
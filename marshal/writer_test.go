@@ -0,0 +1,135 @@
+package marshal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestWriterMatchesEncodingJSON(t *testing.T) {
+	testCases := []struct {
+		value interface{}
+		write func(w *Writer)
+	}{
+		{value: "", write: func(w *Writer) { w.String("") }},
+		{value: "plain", write: func(w *Writer) { w.String("plain") }},
+		{value: "quote\" backslash\\ slash/", write: func(w *Writer) { w.String("quote\" backslash\\ slash/") }},
+		{value: "\b\f\n\r\t\x00\x1f", write: func(w *Writer) { w.String("\b\f\n\r\t\x00\x1f") }},
+		{value: "<html>&amp;", write: func(w *Writer) { w.String("<html>&amp;") }},
+		{value: "invalid \xff utf8", write: func(w *Writer) { w.String("invalid \xff utf8") }},
+		{value: "separators \u2028 \u2029 日本", write: func(w *Writer) { w.String("separators \u2028 \u2029 日本") }},
+		{value: int64(math.MinInt64), write: func(w *Writer) { w.Int(math.MinInt64) }},
+		{value: uint64(math.MaxUint64), write: func(w *Writer) { w.Uint(math.MaxUint64) }},
+		{value: true, write: func(w *Writer) { w.Bool(true) }},
+		{value: 0.0, write: func(w *Writer) { w.Float(0, 64) }},
+		{value: 1.5, write: func(w *Writer) { w.Float(1.5, 64) }},
+		{value: 1e21, write: func(w *Writer) { w.Float(1e21, 64) }},
+		{value: 1e-7, write: func(w *Writer) { w.Float(1e-7, 64) }},
+		{value: -123456789.125, write: func(w *Writer) { w.Float(-123456789.125, 64) }},
+		{value: float32(0.1), write: func(w *Writer) { w.Float(float64(float32(0.1)), 32) }},
+		{value: float32(1e-7), write: func(w *Writer) { w.Float(float64(float32(1e-7)), 32) }},
+		{value: []byte("bytes"), write: func(w *Writer) { w.Bytes([]byte("bytes")) }},
+		{value: []byte{}, write: func(w *Writer) { w.Bytes([]byte{}) }},
+		{value: []byte(nil), write: func(w *Writer) { w.Bytes(nil) }},
+		{value: map[string]int{"b": 1, "a": 2}, write: func(w *Writer) { w.Value(map[string]int{"b": 1, "a": 2}) }},
+	}
+
+	for i, tc := range testCases {
+		expect, err := json.Marshal(tc.value)
+		if err != nil {
+			t.Fatalf("case[%d]: %v", i, err)
+		}
+		buf := &bytes.Buffer{}
+		w := NewWriter(buf)
+		tc.write(w)
+		if err := w.Flush(); err != nil {
+			t.Errorf("case[%d]: unexpected error: %v", i, err)
+			continue
+		}
+		if buf.String() != string(expect) {
+			t.Errorf("case[%d]: expected %s, got %s", i, expect, buf.String())
+		}
+	}
+}
+
+func TestQuotedString(t *testing.T) {
+	type quoted struct {
+		V string `json:"v,string"`
+	}
+	for i, s := range []string{"", "plain", "<a href=\"x\">\\</a>", "\n\xff\u2028"} {
+		expect, err := json.Marshal(quoted{V: s})
+		if err != nil {
+			t.Fatalf("case[%d]: %v", i, err)
+		}
+		buf := &bytes.Buffer{}
+		w := NewWriter(buf)
+		w.Raw(`{"v":`)
+		w.QuotedString(s)
+		w.RawByte('}')
+		if err := w.Flush(); err != nil {
+			t.Fatalf("case[%d]: unexpected error: %v", i, err)
+		}
+		if buf.String() != string(expect) {
+			t.Errorf("case[%d]: expected %s, got %s", i, expect, buf.String())
+		}
+	}
+}
+
+// level is a TextMarshaler failing for negative values.
+type level int
+
+func (l level) MarshalText() ([]byte, error) {
+	if l < 0 {
+		return nil, errors.New("negative level")
+	}
+	return []byte(strings.Repeat("+", int(l))), nil
+}
+
+func TestText(t *testing.T) {
+	for i, l := range []level{0, 3, -1} {
+		expect, expectErr := json.Marshal(l)
+		buf := &bytes.Buffer{}
+		w := NewWriter(buf)
+		w.Text(l)
+		err := w.Flush()
+		if expectErr != nil {
+			if err == nil || err.Error() != expectErr.Error() {
+				t.Errorf("case[%d]: expected error %v, got %v", i, expectErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case[%d]: unexpected error: %v", i, err)
+		}
+		if buf.String() != string(expect) {
+			t.Errorf("case[%d]: expected %s, got %s", i, expect, buf.String())
+		}
+	}
+}
+
+func TestWriterErrorsAreSticky(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := NewWriter(buf)
+	w.RawByte('[')
+	w.Float(math.NaN(), 64)
+	w.RawByte(']')
+	if err := w.Flush(); err == nil {
+		t.Fatal("expected an error for NaN")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing to be written, got %q", buf.String())
+	}
+}
+
+func TestIntKeyLess(t *testing.T) {
+	// encoding/json sorts integer keys by their string representation.
+	if !IntKeyLess(10, 9) || IntKeyLess(9, 10) || !IntKeyLess(-1, 0) {
+		t.Error("integer keys are not sorted by their string representation")
+	}
+	if !UintKeyLess(10, 9) || UintKeyLess(9, 10) {
+		t.Error("unsigned integer keys are not sorted by their string representation")
+	}
+}
@@ -0,0 +1,254 @@
+package marshal
+
+import (
+	"encoding"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EncodeXMLText writes the element name holding the character data text, the
+// way encoding/xml writes a field of a basic type.
+func EncodeXMLText(e *xml.Encoder, name xml.Name, text string) error {
+	start := xml.StartElement{Name: name}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := e.EncodeToken(xml.CharData(text)); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// EncodeXMLComment writes the comment of a field tagged `xml:",comment"`.
+// Nothing is written for an empty comment, like in encoding/xml.
+func EncodeXMLComment(e *xml.Encoder, comment string) error {
+	if comment == "" {
+		return nil
+	}
+	if strings.Contains(comment, "--") {
+		return fmt.Errorf(`xml: comments must not contain "--"`)
+	}
+	if comment[len(comment)-1] == '-' {
+		// "--->" would end the comment wrongly.
+		comment += " "
+	}
+	return e.EncodeToken(xml.Comment(comment))
+}
+
+// XMLParents writes the parent elements of the fields tagged `xml:"a>b"`.
+// Consecutive fields with the same parents share them: like in encoding/xml,
+// the parents are only closed when a field with other parents follows.
+type XMLParents struct {
+	e     *xml.Encoder
+	stack []string
+}
+
+// NewXMLParents returns the XMLParents of the fields of an element written to
+// e, with no parent open.
+func NewXMLParents(e *xml.Encoder) *XMLParents {
+	return &XMLParents{e: e}
+}
+
+// Trim closes the open parents which are not in parents, the parents of the
+// next field.
+func (p *XMLParents) Trim(parents ...string) error {
+	split := 0
+	for split < len(parents) && split < len(p.stack) && parents[split] == p.stack[split] {
+		split++
+	}
+	for i := len(p.stack) - 1; i >= split; i-- {
+		if err := p.e.EncodeToken(xml.EndElement{Name: xml.Name{Local: p.stack[i]}}); err != nil {
+			return err
+		}
+	}
+	p.stack = p.stack[:split]
+	return nil
+}
+
+// Push opens the parents of the next field which are not open yet. It follows
+// a call to Trim with the same parents.
+func (p *XMLParents) Push(parents ...string) error {
+	for _, parent := range parents[len(p.stack):] {
+		if err := p.e.EncodeToken(xml.StartElement{Name: xml.Name{Local: parent}}); err != nil {
+			return err
+		}
+		p.stack = append(p.stack, parent)
+	}
+	return nil
+}
+
+// CheckXMLName returns the error of encoding/xml if the element start decoded
+// into a struct is not the element named by the XMLName field of the struct.
+func CheckXMLName(start xml.StartElement, space, local string) error {
+	if start.Name.Local != local {
+		return xml.UnmarshalError("expected element type <" + local + "> but have <" + start.Name.Local + ">")
+	}
+	if space != "" && start.Name.Space != space {
+		e := "expected element <" + local + "> in name space " + space + " but have "
+		if start.Name.Space == "" {
+			e += "no name space"
+		} else {
+			e += start.Name.Space
+		}
+		return xml.UnmarshalError(e)
+	}
+	return nil
+}
+
+// DecodeXMLElement reads the content of the element whose start was just
+// read from d, up to its end. The child elements are passed to child, which
+// returns whether it decoded them: the others are skipped. The character data
+// and the comments directly inside the element are appended to text and
+// comment, unless they are nil.
+func DecodeXMLElement(d *xml.Decoder, child func(start xml.StartElement) (bool, error), text, comment *[]byte) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			consumed := false
+			if child != nil {
+				if consumed, err = child(tok); err != nil {
+					return err
+				}
+			}
+			if !consumed {
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			return nil
+		case xml.CharData:
+			if text != nil {
+				*text = append(*text, tok...)
+			}
+		case xml.Comment:
+			if comment != nil {
+				*comment = append(*comment, tok...)
+			}
+		}
+	}
+}
+
+// DecodeXMLText reads the content of the element whose start was just read
+// from d and returns its character data. Child elements are skipped.
+func DecodeXMLText(d *xml.Decoder) (string, error) {
+	var text []byte
+	err := DecodeXMLElement(d, nil, &text, nil)
+	return string(text), err
+}
+
+// ParseXMLBool parses the text of a boolean like encoding/xml does: the
+// surrounding space is ignored and the empty text is false.
+func ParseXMLBool(text string) (bool, error) {
+	if text == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(strings.TrimSpace(text))
+}
+
+// ParseXMLInt parses the text of a signed integer of the given bit size like
+// encoding/xml does.
+func ParseXMLInt(text string, bitSize int) (int64, error) {
+	if text == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(strings.TrimSpace(text), 10, bitSize)
+}
+
+// ParseXMLUint parses the text of an unsigned integer of the given bit size
+// like encoding/xml does.
+func ParseXMLUint(text string, bitSize int) (uint64, error) {
+	if text == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(strings.TrimSpace(text), 10, bitSize)
+}
+
+// ParseXMLFloat parses the text of a float of the given bit size like
+// encoding/xml does.
+func ParseXMLFloat(text string, bitSize int) (float64, error) {
+	if text == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(strings.TrimSpace(text), bitSize)
+}
+
+// XMLText returns the text of v, a value of a type of another package, as
+// encoding/xml writes it in an attribute or as character data. The generated
+// code does not know whether such types have a MarshalText method.
+func XMLText(v interface{}) (string, error) {
+	if m, ok := v.(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return string(text), err
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits()), nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return string(rv.Bytes()), nil
+		}
+	}
+	return "", &xml.UnsupportedTypeError{Type: rv.Type()}
+}
+
+// UnmarshalXMLText decodes text into v, a pointer to a value of a type of
+// another package, like encoding/xml decodes an attribute or character data.
+func UnmarshalXMLText(text string, v interface{}) error {
+	if u, ok := v.(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(text))
+	}
+	rv := reflect.ValueOf(v).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(text)
+	case reflect.Bool:
+		b, err := ParseXMLBool(text)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := ParseXMLInt(text, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := ParseXMLUint(text, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := ParseXMLFloat(text, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("cannot unmarshal into %v", rv.Type())
+		}
+		rv.SetBytes([]byte(text))
+	default:
+		return fmt.Errorf("cannot unmarshal into %v", rv.Type())
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package marshal
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestXMLParents(t *testing.T) {
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+	parents := NewXMLParents(e)
+	for _, f := range []struct {
+		parents []string
+		name    string
+	}{
+		{[]string{"a", "b"}, "x"},
+		{[]string{"a", "b"}, "y"},
+		{[]string{"a", "c"}, "z"},
+		{nil, "w"},
+	} {
+		if err := parents.Trim(f.parents...); err != nil {
+			t.Fatal(err)
+		}
+		if err := parents.Push(f.parents...); err != nil {
+			t.Fatal(err)
+		}
+		if err := EncodeXMLText(e, xml.Name{Local: f.name}, "1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := e.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := "<a><b><x>1</x><y>1</y></b><c><z>1</z></c></a><w>1</w>"
+	if got := buf.String(); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestEncodeXMLComment(t *testing.T) {
+	for _, tc := range []struct {
+		comment string
+		want    string
+		err     bool
+	}{
+		{comment: "", want: ""},
+		{comment: "note", want: "<!--note-->"},
+		{comment: "ends with -", want: "<!--ends with - -->"},
+		{comment: "a--b", err: true},
+	} {
+		var buf bytes.Buffer
+		e := xml.NewEncoder(&buf)
+		err := EncodeXMLComment(e, tc.comment)
+		if (err != nil) != tc.err {
+			t.Errorf("%q: unexpected error %v", tc.comment, err)
+			continue
+		}
+		if err := e.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != tc.want {
+			t.Errorf("%q: expected %s, got %s", tc.comment, tc.want, got)
+		}
+	}
+}
+
+func TestDecodeXMLElement(t *testing.T) {
+	d := xml.NewDecoder(strings.NewReader(`<e>a<!--x--><skip><b/></skip>b<keep>c</keep><!--y--></e>`))
+	if _, err := d.Token(); err != nil {
+		t.Fatal(err)
+	}
+	var text, comment []byte
+	var kept []string
+	child := func(start xml.StartElement) (bool, error) {
+		if start.Name.Local != "keep" {
+			return false, nil
+		}
+		s, err := DecodeXMLText(d)
+		kept = append(kept, s)
+		return true, err
+	}
+	if err := DecodeXMLElement(d, child, &text, &comment); err != nil {
+		t.Fatal(err)
+	}
+	if string(text) != "ab" || string(comment) != "xy" || len(kept) != 1 || kept[0] != "c" {
+		t.Errorf("unexpected text %q, comment %q and children %q", text, comment, kept)
+	}
+	if _, err := d.Token(); err == nil {
+		t.Errorf("expected the end of the document")
+	}
+}
+
+func TestCheckXMLName(t *testing.T) {
+	for _, tc := range []struct {
+		start xml.Name
+		space string
+		want  string
+	}{
+		{start: xml.Name{Space: "urn:a", Local: "e"}, space: "urn:a"},
+		{start: xml.Name{Space: "urn:b", Local: "e"}},
+		{start: xml.Name{Local: "f"}, want: "expected element type <e> but have <f>"},
+		{start: xml.Name{Local: "e"}, space: "urn:a", want: "expected element <e> in name space urn:a but have no name space"},
+		{start: xml.Name{Space: "urn:b", Local: "e"}, space: "urn:a", want: "expected element <e> in name space urn:a but have urn:b"},
+	} {
+		err := CheckXMLName(xml.StartElement{Name: tc.start}, tc.space, "e")
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tc.want {
+			t.Errorf("%v: expected error %q, got %q", tc.start, tc.want, got)
+		}
+	}
+}
+
+func TestParseXML(t *testing.T) {
+	if b, err := ParseXMLBool(" true\n"); err != nil || !b {
+		t.Errorf("unexpected %v, %v", b, err)
+	}
+	if i, err := ParseXMLInt("", 8); err != nil || i != 0 {
+		t.Errorf("unexpected %v, %v", i, err)
+	}
+	if _, err := ParseXMLInt("128", 8); err == nil {
+		t.Errorf("expected an out of range error")
+	}
+	if u, err := ParseXMLUint(" 7 ", 16); err != nil || u != 7 {
+		t.Errorf("unexpected %v, %v", u, err)
+	}
+	if f, err := ParseXMLFloat("1e2", 32); err != nil || f != 100 {
+		t.Errorf("unexpected %v, %v", f, err)
+	}
+}
+
+type xmlLevel int8
+
+func TestXMLText(t *testing.T) {
+	when := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, tc := range []struct {
+		v    interface{}
+		want string
+	}{
+		{when, "2020-01-02T03:04:05Z"},
+		{xmlLevel(-3), "-3"},
+		{uint(3), "3"},
+		{float32(0.1), "0.1"},
+		{[]byte("raw"), "raw"},
+		{true, "true"},
+	} {
+		got, err := XMLText(tc.v)
+		if err != nil || got != tc.want {
+			t.Errorf("%v: expected %q, got %q, %v", tc.v, tc.want, got, err)
+		}
+	}
+	if _, err := XMLText(map[string]int{}); err == nil {
+		t.Errorf("expected an error for a map")
+	}
+
+	var decoded time.Time
+	if err := UnmarshalXMLText("2020-01-02T03:04:05Z", &decoded); err != nil || !decoded.Equal(when) {
+		t.Errorf("unexpected %v, %v", decoded, err)
+	}
+	var level xmlLevel
+	if err := UnmarshalXMLText(" -3 ", &level); err != nil || level != -3 {
+		t.Errorf("unexpected %v, %v", level, err)
+	}
+	if err := UnmarshalXMLText("300", &level); err == nil {
+		t.Errorf("expected an out of range error")
+	}
+	var raw []byte
+	if err := UnmarshalXMLText("raw", &raw); err != nil || string(raw) != "raw" {
+		t.Errorf("unexpected %q, %v", raw, err)
+	}
+	var ints []int
+	if err := UnmarshalXMLText("1", &ints); err == nil {
+		t.Errorf("expected an error for []int")
+	}
+}
@@ -0,0 +1,395 @@
+package marshal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NewYAMLMapping returns an empty YAML mapping node, filled by the generated
+// MarshalYAML methods.
+func NewYAMLMapping() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+// AddYAMLField appends the key and the YAML node of v to the mapping node,
+// with the comment written above the key.
+func AddYAMLField(node *yaml.Node, key, comment string, v interface{}) error {
+	value, err := YAMLValue(v)
+	if err != nil {
+		return err
+	}
+	node.Content = append(node.Content, yamlKey(key, comment), value)
+	return nil
+}
+
+// AddYAMLQuoted is like AddYAMLField for a field with the ",string" option:
+// the JSON encoding of v is written as a string.
+func AddYAMLQuoted(node *yaml.Node, key, comment string, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() == reflect.Ptr {
+		return AddYAMLField(node, key, comment, nil)
+	}
+	data, err := json.Marshal(rv.Interface())
+	if err != nil {
+		return err
+	}
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: string(data)}
+	node.Content = append(node.Content, yamlKey(key, comment), value)
+	return nil
+}
+
+func yamlKey(key, comment string) *yaml.Node {
+	if comment != "" {
+		// Blank lines would end the comment, so every line gets a '#'.
+		lines := strings.Split(comment, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight("# "+line, " ")
+		}
+		comment = strings.Join(lines, "\n")
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key, HeadComment: comment}
+}
+
+// YAMLValue returns the YAML node of v. Values with a MarshalYAML method,
+// such as the types with generated YAML methods, write themselves; the other
+// values are written like their JSON encoding, so that the JSON tags of
+// structs apply.
+func YAMLValue(v interface{}) (*yaml.Node, error) {
+	node := &yaml.Node{}
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return node, node.Encode(nil)
+	}
+	if (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) && rv.IsNil() {
+		return node, node.Encode(nil)
+	}
+	if m, ok := v.(yaml.Marshaler); ok {
+		// Node.Encode would drop the comments of the returned node.
+		value, err := m.MarshalYAML()
+		if err != nil {
+			return nil, err
+		}
+		if n, ok := value.(*yaml.Node); ok {
+			return n, nil
+		}
+		return node, node.Encode(value)
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return YAMLValue(rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		if isJSONLeaf(rv) {
+			break
+		}
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return node, node.Encode(nil)
+		}
+		node.Kind, node.Tag = yaml.SequenceNode, "!!seq"
+		for i := 0; i < rv.Len(); i++ {
+			elem, err := YAMLValue(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, elem)
+		}
+		return node, nil
+	case reflect.Map:
+		if isJSONLeaf(rv) {
+			break
+		}
+		if rv.IsNil() {
+			return node, node.Encode(nil)
+		}
+		keys, err := mapKeys(rv)
+		if err != nil {
+			return nil, err
+		}
+		node.Kind, node.Tag = yaml.MappingNode, "!!map"
+		for _, k := range keys {
+			value, err := YAMLValue(rv.MapIndex(k.value).Interface())
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, yamlKey(k.name, ""), value)
+		}
+		return node, nil
+	}
+
+	generic, err := jsonGeneric(v)
+	if err != nil {
+		return nil, err
+	}
+	return genericYAML(generic)
+}
+
+// genericYAML returns the YAML node of a value decoded by jsonGeneric. The
+// keys of objects are kept in the order of encoding/json, which yaml.v3 would
+// sort differently.
+func genericYAML(v interface{}) (*yaml.Node, error) {
+	node := &yaml.Node{}
+	switch v := v.(type) {
+	case map[string]interface{}:
+		node.Kind, node.Tag = yaml.MappingNode, "!!map"
+		for _, k := range sortedKeys(v) {
+			value, err := genericYAML(v[k])
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, yamlKey(k, ""), value)
+		}
+		return node, nil
+	case []interface{}:
+		node.Kind, node.Tag = yaml.SequenceNode, "!!seq"
+		for _, e := range v {
+			elem, err := genericYAML(e)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, elem)
+		}
+		return node, nil
+	}
+	return node, node.Encode(v)
+}
+
+// DecodeYAMLMapping calls field with the key and the value of each entry of
+// the mapping node. A null node is left alone.
+func DecodeYAMLMapping(node *yaml.Node, field func(key string, value *yaml.Node) error) error {
+	node = resolveYAML(node)
+	if isYAMLNull(node) {
+		return nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("marshal: line %d: cannot decode YAML %s into a struct", node.Line, node.ShortTag())
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := resolveYAML(node.Content[i])
+		if key.Kind != yaml.ScalarNode {
+			return fmt.Errorf("marshal: line %d: invalid YAML mapping key", key.Line)
+		}
+		if err := field(key.Value, node.Content[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeYAML decodes the YAML node into v, which must be a pointer. Values
+// with an UnmarshalYAML method decode themselves, the other values are
+// decoded like the JSON document of the same structure.
+func DecodeYAML(node *yaml.Node, v interface{}) error {
+	if _, ok := v.(yaml.Unmarshaler); ok {
+		return node.Decode(v)
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.Elem().Kind() == reflect.Ptr {
+		// Pointers are allocated here so that an UnmarshalYAML method of
+		// the value they point to is called.
+		p := rv.Elem()
+		if isYAMLNull(resolveYAML(node)) {
+			p.Set(reflect.Zero(p.Type()))
+			return nil
+		}
+		if p.IsNil() {
+			p.Set(reflect.New(p.Type().Elem()))
+		}
+		return DecodeYAML(node, p.Interface())
+	}
+	data, err := yamlJSON(node)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// DecodeYAMLQuoted decodes the YAML node into v like DecodeYAML, for a field
+// with the ",string" option.
+func DecodeYAMLQuoted(node *yaml.Node, v interface{}) error {
+	data, err := yamlJSON(node)
+	if err != nil {
+		return err
+	}
+	return UnmarshalQuoted(data, v)
+}
+
+// yamlJSON returns the JSON document of the same structure as the YAML node.
+func yamlJSON(node *yaml.Node) ([]byte, error) {
+	generic, err := yamlGeneric(node)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(generic)
+}
+
+// yamlGeneric returns the value of the YAML node as the types encoding/json
+// decodes into interface{}. Timestamps are kept as strings, and mapping keys
+// are strings.
+func yamlGeneric(node *yaml.Node) (interface{}, error) {
+	node = resolveYAML(node)
+	switch node.Kind {
+	case yaml.MappingNode:
+		m := make(map[string]interface{}, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := resolveYAML(node.Content[i])
+			if key.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("marshal: line %d: invalid YAML mapping key", key.Line)
+			}
+			value, err := yamlGeneric(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			m[key.Value] = value
+		}
+		return m, nil
+	case yaml.SequenceNode:
+		s := make([]interface{}, 0, len(node.Content))
+		for _, n := range node.Content {
+			value, err := yamlGeneric(n)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, value)
+		}
+		return s, nil
+	case yaml.ScalarNode:
+		switch node.ShortTag() {
+		case "!!str", "!!timestamp":
+			return node.Value, nil
+		case "!!binary":
+			// Binary data is base64 encoded, like []byte in JSON.
+			return node.Value, nil
+		}
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+	return nil, nil
+}
+
+// resolveYAML returns the node behind documents and aliases.
+func resolveYAML(node *yaml.Node) *yaml.Node {
+	for {
+		switch {
+		case node.Kind == yaml.DocumentNode && len(node.Content) == 1:
+			node = node.Content[0]
+		case node.Kind == yaml.AliasNode && node.Alias != nil:
+			node = node.Alias
+		default:
+			return node
+		}
+	}
+}
+
+func isYAMLNull(node *yaml.Node) bool {
+	return node.Kind == 0 || node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null"
+}
+
+// isJSONLeaf returns true if encoding/json writes the slice, array or map v
+// itself rather than element by element: byte slices and values with
+// marshal methods.
+func isJSONLeaf(v reflect.Value) bool {
+	switch v.Interface().(type) {
+	case json.Marshaler, interface{ MarshalText() ([]byte, error) }:
+		return true
+	}
+	return v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8
+}
+
+// mapKey is a key of a map along with its name in the JSON encoding.
+type mapKey struct {
+	value reflect.Value
+	name  string
+}
+
+// mapKeys returns the keys of the map v sorted by their JSON name, the way
+// encoding/json writes them.
+func mapKeys(v reflect.Value) ([]mapKey, error) {
+	keys := make([]mapKey, 0, v.Len())
+	for _, k := range v.MapKeys() {
+		var name string
+		if k.Kind() == reflect.String {
+			name = k.String()
+		} else if m, ok := k.Interface().(interface{ MarshalText() ([]byte, error) }); ok {
+			text, err := m.MarshalText()
+			if err != nil {
+				return nil, err
+			}
+			name = string(text)
+		} else {
+			switch k.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				name = strconv.FormatInt(k.Int(), 10)
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				name = strconv.FormatUint(k.Uint(), 10)
+			default:
+				return nil, fmt.Errorf("marshal: unsupported map key type %v", k.Type())
+			}
+		}
+		keys = append(keys, mapKey{value: k, name: name})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].name < keys[j].name
+	})
+	return keys, nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// jsonGeneric returns the JSON encoding of v decoded into interface{}, with
+// numbers decoded as int64, uint64 or float64 to keep their precision.
+func jsonGeneric(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var generic interface{}
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return convertNumbers(generic), nil
+}
+
+// convertNumbers replaces the json.Number values of v.
+func convertNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return i
+		}
+		if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return u
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = convertNumbers(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = convertNumbers(e)
+		}
+	}
+	return v
+}
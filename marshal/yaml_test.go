@@ -0,0 +1,116 @@
+package marshal
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+type yamlValues struct {
+	Int    int                 `json:"int"`
+	Big    uint64              `json:"big"`
+	Float  float32             `json:"float"`
+	Bytes  []byte              `json:"bytes"`
+	Ptr    **string            `json:"ptr"`
+	ByID   map[int]string      `json:"by_id"`
+	Nested []map[string]string `json:"nested,omitempty"`
+}
+
+func TestYAMLValue(t *testing.T) {
+	s := "s"
+	p := &s
+	node, err := YAMLValue(map[string]interface{}{
+		"b": yamlValues{Int: -1, Big: 1 << 63, Float: 0.1, Bytes: []byte("hi"), Ptr: &p, ByID: map[int]string{10: "x", 9: "y"}},
+		"a": []interface{}{nil, "2006-01-02", true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Keys are sorted like in JSON, numbers keep their precision and strings
+	// which look like other values are quoted.
+	want := `a:
+    - null
+    - "2006-01-02"
+    - true
+b:
+    big: 9223372036854775808
+    by_id:
+        "10": x
+        "9": "y"
+    bytes: aGk=
+    float: 0.1
+    int: -1
+    ptr: s
+`
+	if string(data) != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, data)
+	}
+}
+
+func TestDecodeYAML(t *testing.T) {
+	var node yaml.Node
+	data := `
+int: 1
+big: 9223372036854775808
+bytes: !!binary aGk=
+ptr: &s "2006-01-02"
+by_id: {"1": a}
+nested: [{k: *s}]
+`
+	if err := yaml.Unmarshal([]byte(data), &node); err != nil {
+		t.Fatal(err)
+	}
+	var got yamlValues
+	if err := DecodeYAML(&node, &got); err != nil {
+		t.Fatal(err)
+	}
+	s := "2006-01-02"
+	p := &s
+	want := yamlValues{
+		Int:    1,
+		Big:    1 << 63,
+		Bytes:  []byte("hi"),
+		Ptr:    &p,
+		ByID:   map[int]string{1: "a"},
+		Nested: []map[string]string{{"k": s}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestDecodeYAMLMapping(t *testing.T) {
+	testCases := []struct {
+		data string
+		keys []string
+		err  bool
+	}{
+		{data: "", keys: nil},
+		{data: "null", keys: nil},
+		{data: "{b: 1, a: 2}", keys: []string{"b", "a"}},
+		{data: "[1]", err: true},
+		{data: "? [k]\n: v", err: true},
+	}
+	for i, tc := range testCases {
+		var node yaml.Node
+		if err := yaml.Unmarshal([]byte(tc.data), &node); err != nil {
+			t.Fatalf("case[%d]: %v", i, err)
+		}
+		var keys []string
+		err := DecodeYAMLMapping(&node, func(key string, value *yaml.Node) error {
+			keys = append(keys, key)
+			return nil
+		})
+		if (err != nil) != tc.err {
+			t.Errorf("case[%d]: unexpected error %v", i, err)
+		}
+		if !tc.err && !reflect.DeepEqual(keys, tc.keys) {
+			t.Errorf("case[%d]: expected keys %q, got %q", i, tc.keys, keys)
+		}
+	}
+}